@@ -6,11 +6,29 @@ import (
 	"fmt"
 	"mtg-report/config/apicfg"
 	"mtg-report/internal/adapters/handlers/apihandler"
+	"mtg-report/internal/adapters/repositories/alertrepo"
 	"mtg-report/internal/adapters/repositories/cardrepo"
+	"mtg-report/internal/adapters/repositories/collectionrepo"
+	"mtg-report/internal/adapters/repositories/conciliationrepo"
+	"mtg-report/internal/adapters/repositories/quarantinerepo"
+	"mtg-report/internal/adapters/repositories/sharerepo"
+	"mtg-report/internal/adapters/repositories/userrepo"
+	"mtg-report/internal/adapters/repositories/webhookrepo"
+	"mtg-report/internal/adapters/repositories/wishlistrepo"
+	"mtg-report/internal/core/services/alertservice"
 	"mtg-report/internal/core/services/cardservice"
+	"mtg-report/internal/core/services/collectionservice"
+	"mtg-report/internal/core/services/conciliationservice"
+	"mtg-report/internal/core/services/quarantineservice"
+	"mtg-report/internal/core/services/shareservice"
+	"mtg-report/internal/core/services/userservice"
+	"mtg-report/internal/core/services/webhookservice"
+	"mtg-report/internal/core/services/wishlistservice"
 	"mtg-report/internal/core/validate"
 	"mtg-report/internal/sources/databases/mysql"
 	"mtg-report/internal/sources/logger/logrus"
+	"mtg-report/internal/sources/web"
+	"net"
 	"net/http"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -37,7 +55,39 @@ func main() {
 	cardSrv := cardservice.New(cardRepo, cfg.Database.CommitSize, log)
 	cardHand := apihandler.New(requestVal, cardSrv, log)
 
-	router := apihandler.SetupRouter(cardHand)
+	collectionRepo := collectionrepo.New(mysql)
+	collectionSrv := collectionservice.New(collectionRepo, log)
+	collectionHand := apihandler.NewCollectionHandler(requestVal, collectionSrv, log)
+
+	shareRepo := sharerepo.New(mysql)
+	shareSrv := shareservice.New(shareRepo, cardSrv, log)
+	shareHand := apihandler.NewShareHandler(requestVal, shareSrv, log)
+
+	wishlistRepo := wishlistrepo.New(mysql)
+	wishlistSrv := wishlistservice.New(wishlistRepo, log)
+	wishlistHand := apihandler.NewWishlistHandler(requestVal, wishlistSrv, log)
+
+	alertRepo := alertrepo.New(mysql)
+	alertSrv := alertservice.New(alertRepo, log)
+	alertHand := apihandler.NewAlertHandler(requestVal, alertSrv, log)
+
+	webhookRepo := webhookrepo.New(mysql)
+	webhookSrv := webhookservice.New(webhookRepo, web.NewGuard(net.DefaultResolver), log)
+	webhookHand := apihandler.NewWebhookHandler(requestVal, webhookSrv, log)
+
+	conciliationRepo := conciliationrepo.New(mysql)
+	conciliationSrv := conciliationservice.New(conciliationRepo, log)
+	conciliationHand := apihandler.NewConciliationHandler(requestVal, conciliationSrv, log)
+
+	quarantineRepo := quarantinerepo.New(mysql)
+	quarantineSrv := quarantineservice.New(quarantineRepo, log)
+	quarantineHand := apihandler.NewQuarantineHandler(requestVal, quarantineSrv, log)
+
+	userRepo := userrepo.New(mysql)
+	userSrv := userservice.New(userRepo, log)
+	userHand := apihandler.NewUserHandler(requestVal, userSrv, log)
+
+	router := apihandler.SetupRouter(cardHand, collectionHand, shareHand, wishlistHand, alertHand, webhookHand, conciliationHand, quarantineHand, userHand)
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
@@ -0,0 +1,43 @@
+// Command backfillprices fills cards_current_price from the price history,
+// once, after migrations/alter/017_add_cards_current_price.sql. It reads the
+// database settings of the conciliateJob.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/config/cjobcfg"
+	"mtg-report/internal/adapters/repositories/conciliaterepo"
+	"mtg-report/internal/sources/databases/mysql"
+	"mtg-report/internal/sources/logger/logrus"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	cfg, err := cjobcfg.New()
+	if err != nil {
+		panic(err)
+	}
+
+	log := logrus.New(cfg.LogLevel)
+
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Database))
+	if err != nil {
+		log.WithError(err).Fatal("failed in db connection")
+	}
+	defer db.Close()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), cfg.Job.Timeout)
+	defer cancelCtx()
+
+	cardRepo := conciliaterepo.New(mysql.New(db))
+
+	rows, err := cardRepo.BackfillCurrentPrices(ctx)
+	if err != nil {
+		log.WithError(err).Fatal("failed to backfill current prices")
+	}
+
+	log.Info(fmt.Sprintf("current prices backfilled, %d rows affected", rows))
+}
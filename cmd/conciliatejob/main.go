@@ -5,14 +5,25 @@ import (
 	"database/sql"
 	"fmt"
 	"mtg-report/config/cjobcfg"
+	"mtg-report/internal/adapters/email/simplemailtp"
 	"mtg-report/internal/adapters/gateway/cardgateway"
 	"mtg-report/internal/adapters/gateway/exchangegateway"
+	"mtg-report/internal/adapters/gateway/pricelistgateway"
+	"mtg-report/internal/adapters/gateway/sourcegateway"
+	"mtg-report/internal/adapters/gateway/webhookgateway"
 	"mtg-report/internal/adapters/handlers/conciliatehandler"
 	"mtg-report/internal/adapters/repositories/conciliaterepo"
+	"mtg-report/internal/adapters/repositories/webhookrepo"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/ports"
 	"mtg-report/internal/core/services/conciliateservice"
+	"mtg-report/internal/core/services/dispatchservice"
 	"mtg-report/internal/sources/databases/mysql"
 	"mtg-report/internal/sources/logger/logrus"
+	"mtg-report/internal/sources/ratelimit"
+	"mtg-report/internal/sources/timer"
 	"mtg-report/internal/sources/web"
+	"net/smtp"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -25,6 +36,13 @@ func main() {
 
 	log := logrus.New(cfg.LogLevel)
 
+	priceSources, err := domain.NewPriceSources(cfg.Prices.Source, cfg.Prices.Collections, cfg.Prices.Cards)
+	if err != nil {
+		log.WithError(err).Fatal("invalid price sources")
+	}
+
+	auth := smtp.PlainAuth("", cfg.Email.Username, cfg.Email.Password, cfg.Email.Host)
+
 	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Database))
 	if err != nil {
 		log.WithError(err).Fatal("failed in db connection")
@@ -36,11 +54,56 @@ func main() {
 
 	mysql := mysql.New(db)
 	http := web.New()
+	retryHTTP := web.NewRetry(http, web.RetryPolicy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.BaseDelay,
+		MaxDelay:    cfg.Retry.MaxDelay,
+	}, log)
+	timer := timer.New()
+
+	add := cfg.Email.Host + ":" + cfg.Email.Port
+	smtp := simplemailtp.New(auth, timer, cfg.Email.Username, add)
 
 	cardRepo := conciliaterepo.New(mysql)
-	cardGateway := cardgateway.New(http, log)
-	exchangegateway := exchangegateway.New(http, cfg.ExchangeGateway.Url, log)
-	cardSrv := conciliateservice.New(cardRepo, cardGateway, exchangegateway, cfg.Database.CommitSize, log)
+
+	// every Scryfall request waits on the shared limiter, retries included.
+	scryfallLimiter := ratelimit.New(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	scryfallHTTP := web.NewRetry(web.NewLimited(http, scryfallLimiter), web.RetryPolicy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.BaseDelay,
+		MaxDelay:    cfg.Retry.MaxDelay,
+	}, log)
+
+	var bulk *cardgateway.Bulk
+	if cfg.Prices.Mode == cjobcfg.PricesModeBulk {
+		bulk = cardgateway.NewBulk(web.NewWithTimeout(cfg.Prices.BulkTimeout), cfg.Prices.BulkUrl, cfg.Prices.BulkPath, cardRepo, log)
+	}
+	cardGateway := sourcegateway.New(priceSources, map[domain.PriceSource]ports.CardGateway{
+		domain.SourceScryfallUSD: cardgateway.New(scryfallHTTP, domain.SourceScryfallUSD, bulk, log),
+		domain.SourceScryfallEUR: cardgateway.New(scryfallHTTP, domain.SourceScryfallEUR, bulk, log),
+		domain.SourceMTGOTix:     cardgateway.New(scryfallHTTP, domain.SourceMTGOTix, bulk, log),
+		domain.SourcePriceList:   pricelistgateway.New(cfg.Prices.ListPath, cfg.Prices.ListCurrency, log),
+	})
+	exchangeGateway := exchangegateway.New(retryHTTP, cfg.ExchangeGateway.Url, log)
+
+	var secondaryExchangeGateway ports.ExchangeGateway
+	if cfg.ExchangeGateway.SecondaryUrl != "" {
+		secondaryExchangeGateway = exchangegateway.New(retryHTTP, cfg.ExchangeGateway.SecondaryUrl, log)
+	}
+	webhookRepo := webhookrepo.New(mysql)
+	webhookGateway := webhookgateway.New(web.NewGuarded(), log)
+	dispatchSrv := dispatchservice.New(webhookRepo, webhookGateway, cfg.Webhook.MaxAttempts, cfg.Webhook.Backoff, log)
+	staleness := domain.StalenessPolicy{
+		MaxAge:    cfg.Staleness.MaxAge,
+		HighValue: domain.StalenessTier{Price: cfg.Staleness.HighValuePrice, MaxAge: cfg.Staleness.HighValueMaxAge},
+		Bulk:      domain.StalenessTier{Price: cfg.Staleness.BulkPrice, MaxAge: cfg.Staleness.BulkMaxAge},
+	}
+	sanity := domain.SanityRules{
+		MaxChange:  cfg.Sanity.MaxChange,
+		Ceiling:    cfg.Sanity.Ceiling,
+		DropToZero: cfg.Sanity.DropToZero,
+	}
+	cardSrv := conciliateservice.New(cardRepo, cardGateway, exchangeGateway, secondaryExchangeGateway, cfg.ExchangeGateway.MaxRateAge, web.RetryCounters{retryHTTP, scryfallHTTP}, smtp, dispatchSrv, cfg.Database.CommitSize, cfg.Job.Workers, staleness, sanity, cfg.Job.ConditionMultipliers, log)
 	cardHand := conciliatehandler.New(cardSrv, log)
 
 	err = cardHand.Conciliate(ctx)
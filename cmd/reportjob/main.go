@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"mtg-report/config/rjobcfg"
 	"mtg-report/internal/adapters/email/simplemailtp"
+	"mtg-report/internal/adapters/gateway/webhookgateway"
 	"mtg-report/internal/adapters/handlers/reporthandler"
 	"mtg-report/internal/adapters/repositories/reportrepo"
+	"mtg-report/internal/adapters/repositories/webhookrepo"
+	"mtg-report/internal/core/services/dispatchservice"
 	"mtg-report/internal/core/services/reportservice"
 	"mtg-report/internal/sources/databases/mysql"
 	"mtg-report/internal/sources/logger/logrus"
 	"mtg-report/internal/sources/timer"
+	"mtg-report/internal/sources/web"
 	"net/smtp"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -37,12 +41,16 @@ func main() {
 	defer cancelCtx()
 
 	mysql := mysql.New(db)
+	http := web.NewGuarded()
 	timer := timer.New()
 
 	add := cfg.Email.Host + ":" + cfg.Email.Port
-	smtp := simplemailtp.New(auth, timer, cfg.Email.Username, cfg.Email.To, add)
+	smtp := simplemailtp.New(auth, timer, cfg.Email.Username, add)
 	reportRepo := reportrepo.New(mysql)
-	reportSrv := reportservice.New(reportRepo, smtp, log)
+	webhookRepo := webhookrepo.New(mysql)
+	webhookGateway := webhookgateway.New(http, log)
+	dispatchSrv := dispatchservice.New(webhookRepo, webhookGateway, cfg.Webhook.MaxAttempts, cfg.Webhook.Backoff, log)
+	reportSrv := reportservice.New(reportRepo, smtp, dispatchSrv, cfg.Job.Collection, log)
 	reportHand := reporthandler.New(reportSrv, log)
 
 	err = reportHand.ProcessAndSend(ctx)
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/config/retjobcfg"
+	"mtg-report/internal/adapters/handlers/retentionhandler"
+	"mtg-report/internal/adapters/repositories/retentionrepo"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/services/retentionservice"
+	"mtg-report/internal/sources/databases/mysql"
+	"mtg-report/internal/sources/logger/logrus"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	cfg, err := retjobcfg.New()
+	if err != nil {
+		panic(err)
+	}
+
+	log := logrus.New(cfg.LogLevel)
+
+	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Database))
+	if err != nil {
+		log.WithError(err).Fatal("failed in db connection")
+	}
+	defer db.Close()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), cfg.Job.Timeout)
+	defer cancelCtx()
+
+	mysql := mysql.New(db)
+
+	policy := domain.RetentionPolicy{
+		DailyWindow:  cfg.Retention.DailyWindow,
+		WeeklyWindow: cfg.Retention.WeeklyWindow,
+	}
+
+	retentionRepo := retentionrepo.New(mysql)
+	retentionSrv := retentionservice.New(retentionRepo, policy, log)
+	retentionHand := retentionhandler.New(retentionSrv, log)
+
+	err = retentionHand.RollUp(ctx)
+	if err != nil {
+		log.WithError(err).Fatal("failed to roll up price history")
+	}
+}
@@ -3,6 +3,7 @@ package cjobcfg
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,7 +12,14 @@ import (
 type Config struct {
 	Database        Database
 	Job             Job
+	Staleness       Staleness
+	Sanity          Sanity
+	RateLimit       RateLimit
+	Retry           Retry
 	ExchangeGateway ExchangeGateway
+	Email           Email
+	Webhook         Webhook
+	Prices          Prices
 	LogLevel        string
 }
 
@@ -25,11 +33,86 @@ type Database struct {
 }
 
 type Job struct {
-	Timeout time.Duration
+	Timeout              time.Duration
+	Workers              int
+	ConditionMultipliers map[string]float64
 }
 
+// Staleness tells how old the price of a card may get before the job
+// reprices it. Cards worth HighValuePrice BRL or more use HighValueMaxAge and
+// cards worth less than BulkPrice BRL use BulkMaxAge. A zero price disables
+// its tier.
+type Staleness struct {
+	MaxAge          time.Duration
+	HighValuePrice  float64
+	HighValueMaxAge time.Duration
+	BulkPrice       float64
+	BulkMaxAge      time.Duration
+}
+
+// Sanity holds the rules that quarantine a new price instead of storing it.
+// MaxChange is the largest relative change from the old price, Ceiling the
+// largest price in BRL and DropToZero catches priced cards losing their price.
+// A zero MaxChange or Ceiling disables its rule.
+type Sanity struct {
+	MaxChange  float64
+	Ceiling    float64
+	DropToZero bool
+}
+
+// Retry is the policy of the requests to Scryfall and the exchange providers
+// that fail with a network error, a 429 or a 5xx.
+type Retry struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// RateLimit caps the requests made to Scryfall by all the workers together.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// ExchangeGateway holds the primary and secondary rate providers and how old
+// the last stored rates may be to stand in for the primary one.
 type ExchangeGateway struct {
-	Url string
+	Url          string
+	SecondaryUrl string
+	MaxRateAge   time.Duration
+}
+
+type Email struct {
+	Host     string
+	Username string
+	Password string
+	Port     string
+}
+
+const (
+	PricesModeAPI  = "api"
+	PricesModeBulk = "bulk"
+)
+
+// Prices holds the price source names, by collection and card ID, and the
+// local price list used by the price_list source. In bulk mode Scryfall
+// prices are read from a bulk-data dump, at BulkPath or downloaded from
+// BulkUrl, instead of one request per card.
+type Prices struct {
+	Source       string
+	Collections  map[string]string
+	Cards        map[string]string
+	ListPath     string
+	ListCurrency string
+	Mode         string
+	BulkUrl      string
+	BulkPath     string
+	BulkTimeout  time.Duration
+}
+
+type Webhook struct {
+	MaxAttempts int
+	Backoff     time.Duration
 }
 
 func New() (*Config, error) {
@@ -51,9 +134,44 @@ func New() (*Config, error) {
 	viper.SetDefault("conciliatejob.db.commitSize", 1000)
 
 	viper.SetDefault("conciliatejob.timeout", "10s")
+	viper.SetDefault("conciliatejob.workers", 4)
+
+	viper.SetDefault("conciliatejob.staleness.maxAge", "20h")
+	viper.SetDefault("conciliatejob.staleness.highValue.price", 100)
+	viper.SetDefault("conciliatejob.staleness.highValue.maxAge", "0s")
+	viper.SetDefault("conciliatejob.staleness.bulk.price", 1)
+	viper.SetDefault("conciliatejob.staleness.bulk.maxAge", "168h")
+
+	viper.SetDefault("conciliatejob.sanity.maxChange", 4)
+	viper.SetDefault("conciliatejob.sanity.ceiling", 0)
+	viper.SetDefault("conciliatejob.sanity.dropToZero", true)
+
+	viper.SetDefault("conciliatejob.rateLimit.requestsPerSecond", 10)
+	viper.SetDefault("conciliatejob.rateLimit.burst", 1)
+
+	viper.SetDefault("conciliatejob.retry.maxAttempts", 4)
+	viper.SetDefault("conciliatejob.retry.baseDelay", "500ms")
+	viper.SetDefault("conciliatejob.retry.maxDelay", "30s")
 
 	viper.SetDefault("conciliatejob.log.level", "debug")
 
+	viper.SetDefault("conciliatejob.exchange.maxRateAge", "72h")
+
+	viper.SetDefault("conciliatejob.webhook.maxAttempts", 3)
+	viper.SetDefault("conciliatejob.webhook.backoff", "1s")
+
+	viper.SetDefault("conciliatejob.prices.source", "scryfall_usd")
+	viper.SetDefault("conciliatejob.prices.list.currency", "USD")
+	viper.SetDefault("conciliatejob.prices.mode", PricesModeAPI)
+	viper.SetDefault("conciliatejob.prices.bulk.url", "https://api.scryfall.com/bulk-data/default-cards")
+	viper.SetDefault("conciliatejob.prices.bulk.timeout", "10m")
+
+	viper.SetDefault("conciliatejob.conditions.nm", 1.0)
+	viper.SetDefault("conciliatejob.conditions.lp", 0.9)
+	viper.SetDefault("conciliatejob.conditions.mp", 0.75)
+	viper.SetDefault("conciliatejob.conditions.hp", 0.6)
+	viper.SetDefault("conciliatejob.conditions.dmg", 0.4)
+
 	user := viper.GetString("conciliatejob.db.user")
 	password := viper.GetString("conciliatejob.db.password")
 	host := viper.GetString("conciliatejob.db.host")
@@ -62,16 +180,111 @@ func New() (*Config, error) {
 	commitSize := viper.GetInt("conciliatejob.db.commitSize")
 
 	exchangeUrl := viper.GetString("conciliatejob.exchange.url")
+	exchangeSecondaryUrl := viper.GetString("conciliatejob.exchange.secondaryUrl")
+	exchangeMaxRateAgeStr := viper.GetString("conciliatejob.exchange.maxRateAge")
+
+	emailHost := viper.GetString("conciliatejob.email.host")
+	emailUser := viper.GetString("conciliatejob.email.username")
+	emailPassword := viper.GetString("conciliatejob.email.password")
+	emailPort := viper.GetString("conciliatejob.email.port")
 
 	timeoutStr := viper.GetString("conciliatejob.timeout")
+	workers := viper.GetInt("conciliatejob.workers")
+
+	stalenessMaxAgeStr := viper.GetString("conciliatejob.staleness.maxAge")
+	stalenessHighValuePrice := viper.GetFloat64("conciliatejob.staleness.highValue.price")
+	stalenessHighValueMaxAgeStr := viper.GetString("conciliatejob.staleness.highValue.maxAge")
+	stalenessBulkPrice := viper.GetFloat64("conciliatejob.staleness.bulk.price")
+	stalenessBulkMaxAgeStr := viper.GetString("conciliatejob.staleness.bulk.maxAge")
+
+	sanityMaxChange := viper.GetFloat64("conciliatejob.sanity.maxChange")
+	sanityCeiling := viper.GetFloat64("conciliatejob.sanity.ceiling")
+	sanityDropToZero := viper.GetBool("conciliatejob.sanity.dropToZero")
+
+	requestsPerSecond := viper.GetFloat64("conciliatejob.rateLimit.requestsPerSecond")
+	burst := viper.GetInt("conciliatejob.rateLimit.burst")
+
+	retryMaxAttempts := viper.GetInt("conciliatejob.retry.maxAttempts")
+	retryBaseDelayStr := viper.GetString("conciliatejob.retry.baseDelay")
+	retryMaxDelayStr := viper.GetString("conciliatejob.retry.maxDelay")
 
 	logLevel := viper.GetString("conciliatejob.log.level")
 
+	webhookMaxAttempts := viper.GetInt("conciliatejob.webhook.maxAttempts")
+	webhookBackoffStr := viper.GetString("conciliatejob.webhook.backoff")
+
+	priceSource := viper.GetString("conciliatejob.prices.source")
+	priceCollections := viper.GetStringMapString("conciliatejob.prices.collections")
+	priceCards := viper.GetStringMapString("conciliatejob.prices.cards")
+	priceListPath := viper.GetString("conciliatejob.prices.list.path")
+	priceListCurrency := viper.GetString("conciliatejob.prices.list.currency")
+	priceMode := viper.GetString("conciliatejob.prices.mode")
+	priceBulkUrl := viper.GetString("conciliatejob.prices.bulk.url")
+	priceBulkPath := viper.GetString("conciliatejob.prices.bulk.path")
+	priceBulkTimeoutStr := viper.GetString("conciliatejob.prices.bulk.timeout")
+
+	conditionMultipliers := make(map[string]float64)
+	for _, condition := range []string{"NM", "LP", "MP", "HP", "DMG"} {
+		conditionMultipliers[condition] = viper.GetFloat64("conciliatejob.conditions." + strings.ToLower(condition))
+	}
+
 	timeout, err := time.ParseDuration(timeoutStr)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing duration, %w", err)
 	}
 
+	stalenessMaxAge, err := time.ParseDuration(stalenessMaxAgeStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	stalenessHighValueMaxAge, err := time.ParseDuration(stalenessHighValueMaxAgeStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	stalenessBulkMaxAge, err := time.ParseDuration(stalenessBulkMaxAgeStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	webhookBackoff, err := time.ParseDuration(webhookBackoffStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	exchangeMaxRateAge, err := time.ParseDuration(exchangeMaxRateAgeStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	priceBulkTimeout, err := time.ParseDuration(priceBulkTimeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	retryBaseDelay, err := time.ParseDuration(retryBaseDelayStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	retryMaxDelay, err := time.ParseDuration(retryMaxDelayStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	if priceMode != PricesModeAPI && priceMode != PricesModeBulk {
+		return nil, fmt.Errorf("invalid prices mode %q, must be %s or %s", priceMode, PricesModeAPI, PricesModeBulk)
+	}
+
+	if stalenessHighValuePrice > 0 && stalenessBulkPrice >= stalenessHighValuePrice {
+		return nil, fmt.Errorf("invalid staleness prices, bulk price %.2f must be below high value price %.2f", stalenessBulkPrice, stalenessHighValuePrice)
+	}
+
+	if sanityMaxChange < 0 || sanityCeiling < 0 {
+		return nil, fmt.Errorf("invalid sanity rules, max change %.2f and ceiling %.2f must not be negative", sanityMaxChange, sanityCeiling)
+	}
+
 	return &Config{
 		Database: Database{
 			User:       user,
@@ -82,10 +295,56 @@ func New() (*Config, error) {
 			CommitSize: commitSize,
 		},
 		Job: Job{
-			Timeout: timeout,
+			Timeout:              timeout,
+			Workers:              workers,
+			ConditionMultipliers: conditionMultipliers,
+		},
+		Staleness: Staleness{
+			MaxAge:          stalenessMaxAge,
+			HighValuePrice:  stalenessHighValuePrice,
+			HighValueMaxAge: stalenessHighValueMaxAge,
+			BulkPrice:       stalenessBulkPrice,
+			BulkMaxAge:      stalenessBulkMaxAge,
+		},
+		Sanity: Sanity{
+			MaxChange:  sanityMaxChange,
+			Ceiling:    sanityCeiling,
+			DropToZero: sanityDropToZero,
+		},
+		RateLimit: RateLimit{
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             burst,
+		},
+		Retry: Retry{
+			MaxAttempts: retryMaxAttempts,
+			BaseDelay:   retryBaseDelay,
+			MaxDelay:    retryMaxDelay,
 		},
 		ExchangeGateway: ExchangeGateway{
-			Url: exchangeUrl,
+			Url:          exchangeUrl,
+			SecondaryUrl: exchangeSecondaryUrl,
+			MaxRateAge:   exchangeMaxRateAge,
+		},
+		Email: Email{
+			Host:     emailHost,
+			Username: emailUser,
+			Password: emailPassword,
+			Port:     emailPort,
+		},
+		Webhook: Webhook{
+			MaxAttempts: webhookMaxAttempts,
+			Backoff:     webhookBackoff,
+		},
+		Prices: Prices{
+			Source:       priceSource,
+			Collections:  priceCollections,
+			Cards:        priceCards,
+			ListPath:     priceListPath,
+			ListCurrency: priceListCurrency,
+			Mode:         priceMode,
+			BulkUrl:      priceBulkUrl,
+			BulkPath:     priceBulkPath,
+			BulkTimeout:  priceBulkTimeout,
 		},
 		LogLevel: logLevel,
 	}, nil
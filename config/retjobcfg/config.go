@@ -0,0 +1,115 @@
+package retjobcfg
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Database  Database
+	Job       Job
+	Retention Retention
+	LogLevel  string
+}
+
+type Database struct {
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Database string
+}
+
+type Job struct {
+	Timeout time.Duration
+}
+
+// Retention tells how long the price history is kept daily, before it is
+// rolled up into weekly aggregates, and weekly, before those are rolled up
+// into monthly ones.
+type Retention struct {
+	DailyWindow  time.Duration
+	WeeklyWindow time.Duration
+}
+
+func New() (*Config, error) {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	viper.SetDefault("retentionjob.db.user", "root")
+	viper.SetDefault("retentionjob.db.password", "root")
+	viper.SetDefault("retentionjob.db.host", "localhost")
+	viper.SetDefault("retentionjob.db.port", "3306")
+	viper.SetDefault("retentionjob.db.database", "mydatabase")
+
+	viper.SetDefault("retentionjob.timeout", "1h")
+
+	viper.SetDefault("retentionjob.log.level", "debug")
+
+	viper.SetDefault("retentionjob.retention.dailyWindow", "2160h")
+	viper.SetDefault("retentionjob.retention.weeklyWindow", "8760h")
+
+	user := viper.GetString("retentionjob.db.user")
+	password := viper.GetString("retentionjob.db.password")
+	host := viper.GetString("retentionjob.db.host")
+	dbPort := viper.GetString("retentionjob.db.port")
+	database := viper.GetString("retentionjob.db.database")
+
+	timeoutStr := viper.GetString("retentionjob.timeout")
+
+	logLevel := viper.GetString("retentionjob.log.level")
+
+	dailyWindowStr := viper.GetString("retentionjob.retention.dailyWindow")
+	weeklyWindowStr := viper.GetString("retentionjob.retention.weeklyWindow")
+
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	dailyWindow, err := time.ParseDuration(dailyWindowStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	weeklyWindow, err := time.ParseDuration(weeklyWindowStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
+	if dailyWindow <= 0 {
+		return nil, fmt.Errorf("invalid retention, daily window %s must be positive", dailyWindow)
+	}
+
+	if weeklyWindow < dailyWindow {
+		return nil, fmt.Errorf("invalid retention, weekly window %s must not be shorter than daily window %s", weeklyWindow, dailyWindow)
+	}
+
+	return &Config{
+		Database: Database{
+			User:     user,
+			Password: password,
+			Host:     host,
+			Port:     dbPort,
+			Database: database,
+		},
+		Job: Job{
+			Timeout: timeout,
+		},
+		Retention: Retention{
+			DailyWindow:  dailyWindow,
+			WeeklyWindow: weeklyWindow,
+		},
+		LogLevel: logLevel,
+	}, nil
+}
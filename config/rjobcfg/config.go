@@ -12,6 +12,7 @@ type Config struct {
 	Database Database
 	Job      Job
 	Email    Email
+	Webhook  Webhook
 	LogLevel string
 }
 
@@ -24,17 +25,22 @@ type Database struct {
 }
 
 type Job struct {
-	Timeout time.Duration
+	Timeout    time.Duration
+	Collection int64
 }
 
 type Email struct {
 	Host     string
 	Username string
 	Password string
-	To       string
 	Port     string
 }
 
+type Webhook struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
 func New() (*Config, error) {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
@@ -53,9 +59,13 @@ func New() (*Config, error) {
 	viper.SetDefault("reportjob.db.database", "mydatabase")
 
 	viper.SetDefault("reportjob.timeout", "10s")
+	viper.SetDefault("reportjob.collection", 0)
 
 	viper.SetDefault("reportjob.log.level", "debug")
 
+	viper.SetDefault("reportjob.webhook.maxAttempts", 3)
+	viper.SetDefault("reportjob.webhook.backoff", "1s")
+
 	user := viper.GetString("reportjob.db.user")
 	password := viper.GetString("reportjob.db.password")
 	host := viper.GetString("reportjob.db.host")
@@ -65,18 +75,26 @@ func New() (*Config, error) {
 	emailHost := viper.GetString("reportjob.email.host")
 	emailUser := viper.GetString("reportjob.email.username")
 	emailPassword := viper.GetString("reportjob.email.password")
-	emailTo := viper.GetString("reportjob.email.to")
 	emailPort := viper.GetString("reportjob.email.port")
 
 	timeoutStr := viper.GetString("reportjob.timeout")
+	collection := viper.GetInt64("reportjob.collection")
 
 	logLevel := viper.GetString("reportjob.log.level")
 
+	webhookMaxAttempts := viper.GetInt("reportjob.webhook.maxAttempts")
+	webhookBackoffStr := viper.GetString("reportjob.webhook.backoff")
+
 	timeout, err := time.ParseDuration(timeoutStr)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing duration, %w", err)
 	}
 
+	webhookBackoff, err := time.ParseDuration(webhookBackoffStr)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing duration, %w", err)
+	}
+
 	return &Config{
 		Database: Database{
 			User:     user,
@@ -86,15 +104,19 @@ func New() (*Config, error) {
 			Database: database,
 		},
 		Job: Job{
-			Timeout: timeout,
+			Timeout:    timeout,
+			Collection: collection,
 		},
 		LogLevel: logLevel,
 		Email: Email{
 			Host:     emailHost,
 			Username: emailUser,
 			Password: emailPassword,
-			To:       emailTo,
 			Port:     emailPort,
 		},
+		Webhook: Webhook{
+			MaxAttempts: webhookMaxAttempts,
+			Backoff:     webhookBackoff,
+		},
 	}, nil
 }
@@ -10,34 +10,43 @@ type email struct {
 	auth   smtp.Auth
 	timer  timer.Timer
 	from   string
-	to     string
 	adress string
 }
 
-func New(auth smtp.Auth, timer timer.Timer, from string, to string, adress string) *email {
+func New(auth smtp.Auth, timer timer.Timer, from string, adress string) *email {
 	return &email{
 		auth:   auth,
 		timer:  timer,
 		from:   from,
-		to:     to,
 		adress: adress,
 	}
 }
 
-func (e *email) SendEmail(cardsTable, cardsPrice string) error {
-	to := []string{e.to}
+func (e *email) SendEmail(recipient, cardsTable, cardsPrice, wishlistTable string) error {
+	cardPrice := "<p>" + cardsPrice + "</p>"
+
+	return e.send(recipient, "Daily MTG Investment Report", cardPrice+cardsTable+wishlistTable)
+}
+
+func (e *email) SendAlerts(recipient, alertsTable string) error {
+	intro := "<p>The following cards crossed the thresholds of your price alerts.</p>"
+
+	return e.send(recipient, "MTG Price Alerts", intro+alertsTable)
+}
+
+func (e *email) send(recipient, title, content string) error {
+	to := []string{recipient}
 
 	timestamp := e.timer.Now()
-	subject := "Subject: Daily MTG Investment Report\r\n"
+	subject := "Subject: " + title + "\r\n"
 	mime := "MIME-Version: 1.0\r\n"
 	contentType := "Content-Type: text/html; charset=UTF-8\r\n"
 	htmlOpening := "<html><head><style>body { font-family: Arial, sans-serif; }</style></head><body>"
-	title := "<h1>Daily MTG Investment Report</h1>"
+	heading := "<h1>" + title + "</h1>"
 	date := "<p><strong>Report Date: </strong>" + timestamp + "</p>"
-	cardPrice := "<p>" + cardsPrice + "</p>"
 	htmlClosing := "</body></html>\r\n"
 
-	msg := []byte(subject + mime + contentType + "\r\n" + htmlOpening + title + date + cardPrice + cardsTable + htmlClosing)
+	msg := []byte(subject + mime + contentType + "\r\n" + htmlOpening + heading + date + content + htmlClosing)
 
 	err := smtp.SendMail(e.adress, e.auth, e.from, to, msg)
 
@@ -13,16 +13,14 @@ func TestNew(t *testing.T) {
 	mockTimer := mocks.NewTimerMock()
 
 	from := "test@example.com"
-	to := "user@example.com"
 	address := "smtp.example.com:587"
 
-	emailService := New(mockAuth, mockTimer, from, to, address)
+	emailService := New(mockAuth, mockTimer, from, address)
 
 	assert.NotNil(t, emailService)
 	assert.Equal(t, mockAuth, emailService.auth)
 	assert.Equal(t, mockTimer, emailService.timer)
 	assert.Equal(t, from, emailService.from)
-	assert.Equal(t, to, emailService.to)
 	assert.Equal(t, address, emailService.adress)
 }
 
@@ -38,7 +36,7 @@ func TestSendEmail_MessageConstruction(t *testing.T) {
 	to := "user@example.com"
 	address := "invalid-address" // Using invalid address to prevent actual sending
 
-	emailService := New(mockAuth, mockTimer, from, to, address)
+	emailService := New(mockAuth, mockTimer, from, address)
 
 	cardsTable := "<table><tr><td>Test Card</td></tr></table>"
 	cardsPrice := "Total price increased from $100 to $150"
@@ -47,7 +45,7 @@ func TestSendEmail_MessageConstruction(t *testing.T) {
 
 	// This will fail because of invalid address, but we can verify
 	// the service was constructed properly and timer was called
-	err := emailService.SendEmail(cardsTable, cardsPrice)
+	err := emailService.SendEmail(to, cardsTable, cardsPrice, "")
 
 	// We expect an error because of invalid address
 	assert.Error(t, err)
@@ -56,3 +54,18 @@ func TestSendEmail_MessageConstruction(t *testing.T) {
 	// Verify timer was called
 	mockTimer.AssertExpectations(t)
 }
+
+func TestSendAlerts_MessageConstruction(t *testing.T) {
+	mockAuth := mocks.NewSMTPAuthMock()
+	mockTimer := mocks.NewTimerMock()
+
+	emailService := New(mockAuth, mockTimer, "test@example.com", "invalid-address")
+
+	mockTimer.On("Now").Return("2023-12-01 10:00:00")
+
+	err := emailService.SendAlerts("user@example.com", "<table><tr><td>Test Card</td></tr></table>")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send email")
+	mockTimer.AssertExpectations(t)
+}
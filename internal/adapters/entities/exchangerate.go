@@ -1,9 +1,7 @@
 package entities
 
-type ConversionRates struct {
-	BRL *float64 `json:"BRL"`
-}
-
 type ExchangeRate struct {
-	ConversionRates ConversionRates `json:"conversion_rates"`
+	BaseCode           string              `json:"base_code"`
+	TimeLastUpdateUnix int64               `json:"time_last_update_unix"`
+	ConversionRates    map[string]*float64 `json:"conversion_rates"`
 }
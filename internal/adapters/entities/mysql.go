@@ -3,12 +3,16 @@ package entities
 import "time"
 
 type MysqlCardInfo struct {
-	ID              int64    `db:"id"`
-	Name            string   `db:"name"`
-	SetName         string   `db:"set_name"`
-	CollectorNumber string   `db:"collector_number"`
-	LastPrice       *float64 `db:"last_price"`
-	Foil            bool     `db:"foil"`
+	ID              int64      `db:"id"`
+	Name            string     `db:"name"`
+	SetName         string     `db:"set_name"`
+	CollectorNumber string     `db:"collector_number"`
+	LastPrice       *float64   `db:"last_price"`
+	Finish          string     `db:"finish"`
+	Condition       string     `db:"card_condition"`
+	Language        string     `db:"language"`
+	CollectionID    int64      `db:"collection_id"`
+	LastUpdate      *time.Time `db:"last_update"`
 }
 
 type MysqlCardPriceHistory struct {
@@ -20,5 +24,21 @@ type MysqlCardPriceHistory struct {
 	LastPrice       float64    `db:"last_price"`
 	PriceChange     float64    `db:"price_change"`
 	LastUpdate      *time.Time `db:"last_update"`
-	Foil            bool       `db:"foil"`
+	Finish          string     `db:"finish"`
+	Condition       string     `db:"card_condition"`
+	Language        string     `db:"language"`
+	Quantity        int64      `db:"quantity"`
+}
+
+type MysqlPricePoint struct {
+	Resolution  string    `db:"resolution"`
+	PeriodStart time.Time `db:"period_start"`
+	OldPrice    float64   `db:"old_price"`
+	OpenPrice   float64   `db:"open_price"`
+	ClosePrice  float64   `db:"close_price"`
+	MinPrice    float64   `db:"min_price"`
+	MaxPrice    float64   `db:"max_price"`
+	OpenedAt    time.Time `db:"opened_at"`
+	ClosedAt    time.Time `db:"closed_at"`
+	Samples     int64     `db:"samples"`
 }
@@ -1,10 +1,26 @@
 package entities
 
 type Price struct {
-	USD     *string `json:"usd"`
-	USDFoil *string `json:"usd_foil"`
+	USD       *string `json:"usd"`
+	USDFoil   *string `json:"usd_foil"`
+	USDEtched *string `json:"usd_etched"`
+	EUR       *string `json:"eur"`
+	EURFoil   *string `json:"eur_foil"`
+	Tix       *string `json:"tix"`
 }
 
 type ScryfallCard struct {
 	Prices Price `json:"prices"`
 }
+
+// ScryfallBulkCard is a card of a Scryfall bulk-data dump, reduced to what is
+// needed to price it.
+type ScryfallBulkCard struct {
+	Set             string `json:"set"`
+	CollectorNumber string `json:"collector_number"`
+	Prices          Price  `json:"prices"`
+}
+
+type ScryfallBulkData struct {
+	DownloadURI string `json:"download_uri"`
+}
@@ -21,9 +21,13 @@ func CardsInfoToCardsDomain(cards []entities.MysqlCardInfo) []domain.Cards {
 			SetName:         card.SetName,
 			CollectorNumber: card.CollectorNumber,
 			CardsDetails: domain.CardsDetails{
-				LastPrice: lastPrice,
+				LastPrice:  lastPrice,
+				LastUpdate: card.LastUpdate,
 			},
-			Foil: card.Foil,
+			Finish:       domain.Finish(card.Finish),
+			Condition:    card.Condition,
+			Language:     card.Language,
+			CollectionID: card.CollectionID,
 		})
 	}
 
@@ -50,9 +54,33 @@ func CardPriceHistoryToCardsDomain(cards []entities.MysqlCardPriceHistory) []dom
 				PriceChange: card.PriceChange,
 				LastUpdate:  &lastUpdate,
 			},
-			Foil: card.Foil,
+			Finish:    domain.Finish(card.Finish),
+			Condition: card.Condition,
+			Language:  card.Language,
+			Quantity:  card.Quantity,
 		})
 	}
 
 	return domainCards
 }
+
+func PricePointsToDomain(points []entities.MysqlPricePoint) []domain.PricePoint {
+	domainPoints := make([]domain.PricePoint, 0, len(points))
+
+	for _, point := range points {
+		domainPoints = append(domainPoints, domain.PricePoint{
+			Resolution:  domain.PriceResolution(point.Resolution),
+			PeriodStart: point.PeriodStart,
+			OldPrice:    point.OldPrice,
+			Open:        point.OpenPrice,
+			Close:       point.ClosePrice,
+			Min:         point.MinPrice,
+			Max:         point.MaxPrice,
+			OpenedAt:    point.OpenedAt,
+			ClosedAt:    point.ClosedAt,
+			Samples:     point.Samples,
+		})
+	}
+
+	return domainPoints
+}
@@ -28,7 +28,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       nil,
 				},
 			},
@@ -38,7 +38,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice: 0,
 					},
@@ -53,7 +53,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       floatPtr(15.50),
 				},
 			},
@@ -63,7 +63,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice: 15.50,
 					},
@@ -78,7 +78,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       floatPtr(15.50),
 				},
 				{
@@ -86,7 +86,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Counterspell",
 					SetName:         "M21",
 					CollectorNumber: "456",
-					Foil:            false,
+					Finish:          "nonfoil",
 					LastPrice:       nil,
 				},
 				{
@@ -94,7 +94,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Dark Ritual",
 					SetName:         "M21",
 					CollectorNumber: "789",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       floatPtr(25.00),
 				},
 			},
@@ -104,7 +104,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice: 15.50,
 					},
@@ -114,7 +114,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Counterspell",
 					SetName:         "M21",
 					CollectorNumber: "456",
-					Foil:            false,
+					Finish:          domain.FinishNonfoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice: 0,
 					},
@@ -124,7 +124,7 @@ func TestCardsInfoToCardsDomain(t *testing.T) {
 					Name:            "Dark Ritual",
 					SetName:         "M21",
 					CollectorNumber: "789",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice: 25.00,
 					},
@@ -163,7 +163,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       15.50,
 					OldPrice:        12.00,
 					PriceChange:     3.50,
@@ -176,7 +176,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   15.50,
 						OldPrice:    12.00,
@@ -194,7 +194,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       15.50,
 					OldPrice:        12.00,
 					PriceChange:     3.50,
@@ -207,7 +207,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   15.50,
 						OldPrice:    12.00,
@@ -225,7 +225,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       15.50,
 					OldPrice:        12.00,
 					PriceChange:     3.50,
@@ -236,7 +236,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Counterspell",
 					SetName:         "M21",
 					CollectorNumber: "456",
-					Foil:            false,
+					Finish:          "nonfoil",
 					LastPrice:       8.00,
 					OldPrice:        10.00,
 					PriceChange:     -2.00,
@@ -247,7 +247,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Dark Ritual",
 					SetName:         "M21",
 					CollectorNumber: "789",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       25.00,
 					OldPrice:        20.00,
 					PriceChange:     5.00,
@@ -260,7 +260,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   15.50,
 						OldPrice:    12.00,
@@ -273,7 +273,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Counterspell",
 					SetName:         "M21",
 					CollectorNumber: "456",
-					Foil:            false,
+					Finish:          domain.FinishNonfoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   8.00,
 						OldPrice:    10.00,
@@ -286,7 +286,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "Dark Ritual",
 					SetName:         "M21",
 					CollectorNumber: "789",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   25.00,
 						OldPrice:    20.00,
@@ -304,7 +304,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "",
 					SetName:         "",
 					CollectorNumber: "",
-					Foil:            false,
+					Finish:          "nonfoil",
 					LastPrice:       0.0,
 					OldPrice:        0.0,
 					PriceChange:     0.0,
@@ -317,7 +317,7 @@ func TestCardPriceHistoryToCardsDomain(t *testing.T) {
 					Name:            "",
 					SetName:         "",
 					CollectorNumber: "",
-					Foil:            false,
+					Finish:          domain.FinishNonfoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   0.0,
 						OldPrice:    0.0,
@@ -347,7 +347,7 @@ func TestCardsInfoToCardsDomain_Capacity(t *testing.T) {
 			Name:            "Card",
 			SetName:         "SET",
 			CollectorNumber: "123",
-			Foil:            i%2 == 0,
+			Finish:          "nonfoil",
 			LastPrice:       floatPtr(float64(i + 1)),
 		}
 	}
@@ -367,7 +367,7 @@ func TestCardPriceHistoryToCardsDomain_Capacity(t *testing.T) {
 			Name:            "Card",
 			SetName:         "SET",
 			CollectorNumber: "123",
-			Foil:            i%2 == 0,
+			Finish:          "nonfoil",
 			LastPrice:       float64(i + 1),
 			OldPrice:        float64(i),
 			PriceChange:     1.0,
@@ -0,0 +1,180 @@
+package cardgateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mtg-report/internal/adapters/entities"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/sources/logger/logrus"
+	"mtg-report/internal/sources/web"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PricedCards lists the printings a job prices, the only ones kept from a
+// bulk-data dump.
+type PricedCards interface {
+	GetPricedCards(ctx context.Context) ([]domain.Cards, error)
+}
+
+// Bulk holds the prices of a Scryfall bulk-data dump, such as "default
+// cards", by set and collector number, for the printings listed by cards. It
+// is loaded on the first lookup, from path when set or else downloaded from
+// the bulk-data url, and can be shared by the gateways of every Scryfall
+// source.
+type Bulk struct {
+	web    web.HTTP
+	url    string
+	path   string
+	cards  PricedCards
+	log    logrus.Logger
+	mu     sync.RWMutex
+	loaded bool
+	prices map[string]entities.Price
+}
+
+func NewBulk(web web.HTTP, url, path string, cards PricedCards, log logrus.Logger) *Bulk {
+	return &Bulk{
+		web:   web,
+		url:   url,
+		path:  path,
+		cards: cards,
+		log:   log,
+	}
+}
+
+// Prices returns the prices of the card in the dump. When the dump cannot be
+// loaded every card is reported missing, so they are all requested one by one.
+// Once loaded the prices are never written again, so lookups only share a
+// read lock.
+func (b *Bulk) Prices(ctx context.Context, card domain.Cards) (entities.Price, bool) {
+	b.mu.RLock()
+	loaded := b.loaded
+	b.mu.RUnlock()
+
+	if !loaded {
+		b.ensureLoaded(ctx)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	prices, ok := b.prices[bulkKey(card.SetName, card.CollectorNumber)]
+	return prices, ok
+}
+
+// ensureLoaded loads the dump once. A load cut short by the context of the
+// lookup that started it is tried again by the next lookup, while any other
+// failure is kept, so the dump is not fetched again for every card.
+func (b *Bulk) ensureLoaded(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.loaded {
+		return
+	}
+
+	prices, err := b.load(ctx)
+	if err != nil {
+		b.log.Warn(fmt.Errorf("card gateway failed to load bulk data, cards will be requested one by one: %w", err))
+		b.loaded = ctx.Err() == nil
+		return
+	}
+
+	b.log.Info(fmt.Sprintf("%d cards loaded from bulk data", len(prices)))
+	b.prices = prices
+	b.loaded = true
+}
+
+func (b *Bulk) load(ctx context.Context) (map[string]entities.Price, error) {
+	cards, err := b.cards.GetPricedCards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("card gateway failed to get priced cards: %w", err)
+	}
+
+	wanted := make(map[string]struct{}, len(cards))
+	for _, card := range cards {
+		wanted[bulkKey(card.SetName, card.CollectorNumber)] = struct{}{}
+	}
+
+	if b.path != "" {
+		file, err := os.Open(b.path)
+		if err != nil {
+			return nil, fmt.Errorf("card gateway failed to open bulk file: %w", err)
+		}
+		defer file.Close()
+
+		return decodeBulk(file, wanted)
+	}
+
+	var bulkData entities.ScryfallBulkData
+	err = b.get(ctx, b.url, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(&bulkData)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("card gateway failed to get bulk data: %w", err)
+	}
+
+	var prices map[string]entities.Price
+	err = b.get(ctx, bulkData.DownloadURI, func(body io.Reader) error {
+		prices, err = decodeBulk(body, wanted)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("card gateway failed to download bulk file: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (b *Bulk) get(ctx context.Context, url string, read func(body io.Reader) error) error {
+	req, err := b.web.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := b.web.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body().Close()
+
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("http status %d", resp.StatusCode())
+	}
+
+	return read(resp.Body())
+}
+
+// decodeBulk streams the JSON array of the dump card by card and keeps the
+// prices of the wanted printings only, so neither the file nor the prices of
+// the cards nobody owns are held in memory.
+func decodeBulk(r io.Reader, wanted map[string]struct{}) (map[string]entities.Price, error) {
+	decoder := json.NewDecoder(r)
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("card gateway failed to read bulk array: %w", err)
+	}
+
+	prices := make(map[string]entities.Price)
+	for decoder.More() {
+		var card entities.ScryfallBulkCard
+		if err := decoder.Decode(&card); err != nil {
+			return nil, fmt.Errorf("card gateway failed to decode bulk card: %w", err)
+		}
+		key := bulkKey(card.Set, card.CollectorNumber)
+		if _, ok := wanted[key]; ok {
+			prices[key] = card.Prices
+		}
+	}
+
+	return prices, nil
+}
+
+func bulkKey(set, collectorNumber string) string {
+	return strings.ToLower(set) + "/" + collectorNumber
+}
@@ -0,0 +1,212 @@
+package cardgateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testBulkBody = `[
+	{"object": "card", "name": "Black Lotus", "set": "lea", "collector_number": "232", "prices": {"usd": "25000.00", "usd_foil": null, "eur": "20000.00", "tix": null}},
+	{"object": "card", "name": "Counterspell", "set": "mh2", "collector_number": "267", "prices": {"usd": "1.20", "usd_foil": "3.40", "eur": "1.00", "eur_foil": "2.90", "tix": "0.05"}}
+]`
+
+func writeBulkFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "default-cards.json")
+	err := os.WriteFile(path, []byte(testBulkBody), 0o600)
+	assert.NoError(t, err)
+
+	return path
+}
+
+func pricedCards(cards ...domain.Cards) *mocks.ConciliateRepositoryMock {
+	repoMock := mocks.NewConciliateRepositoryMock()
+	repoMock.On("GetPricedCards", mock.Anything).Return(cards, nil)
+
+	return repoMock
+}
+
+var ownedCards = []domain.Cards{{SetName: "lea", CollectorNumber: "232"}, {SetName: "mh2", CollectorNumber: "267"}}
+
+func TestBulk_PricesFromPath(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	mockLogger.On("Info", mock.Anything).Once()
+
+	bulk := NewBulk(mockWeb, "", writeBulkFile(t), pricedCards(ownedCards...), mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, bulk, mockLogger)
+
+	price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "MH2", CollectorNumber: "267", Finish: domain.FinishFoil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3.40, price.Value)
+	assert.Equal(t, "usd_foil", price.Field)
+	assert.Len(t, price.Raw, 5)
+
+	price, err = gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "lea", CollectorNumber: "232", Finish: domain.FinishNonfoil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25000.00, price.Value)
+	mockWeb.AssertNotCalled(t, "Do", mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestBulk_ConcurrentLookupsLoadOnce(t *testing.T) {
+	mockLogger := mocks.NewLogMock()
+	mockLogger.On("Info", mock.Anything).Once()
+	cards := pricedCards(ownedCards...)
+
+	bulk := NewBulk(mocks.NewHTTPMock(), "", writeBulkFile(t), cards, mockLogger)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			price, ok := bulk.Prices(context.Background(), domain.Cards{SetName: "mh2", CollectorNumber: "267"})
+			assert.True(t, ok)
+			assert.Equal(t, "1.20", *price.USD)
+		}()
+	}
+	wg.Wait()
+
+	cards.AssertNumberOfCalls(t, "GetPricedCards", 1)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestBulk_PricesFromDownload(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	metadataRequest := mocks.NewRequestMock()
+	metadataResponse := mocks.NewResponseMock()
+	fileRequest := mocks.NewRequestMock()
+	fileResponse := mocks.NewResponseMock()
+
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/bulk-data/default-cards", mock.Anything).Return(metadataRequest, nil)
+	mockWeb.On("Do", metadataRequest).Return(metadataResponse, nil).Once()
+	metadataResponse.On("StatusCode").Return(http.StatusOK)
+	metadataResponse.On("Body").Return(io.NopCloser(strings.NewReader(`{"type": "default_cards", "download_uri": "https://data.scryfall.io/default-cards/default-cards.json"}`)))
+
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://data.scryfall.io/default-cards/default-cards.json", mock.Anything).Return(fileRequest, nil)
+	mockWeb.On("Do", fileRequest).Return(fileResponse, nil).Once()
+	fileResponse.On("StatusCode").Return(http.StatusOK)
+	fileResponse.On("Body").Return(io.NopCloser(strings.NewReader(testBulkBody)))
+
+	mockLogger.On("Info", mock.Anything).Once()
+
+	bulk := NewBulk(mockWeb, "https://api.scryfall.com/bulk-data/default-cards", "", pricedCards(ownedCards...), mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallEUR, bulk, mockLogger)
+
+	price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "mh2", CollectorNumber: "267", Finish: domain.FinishNonfoil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Price{Value: 1.00, Currency: domain.CurrencyEUR, Source: domain.SourceScryfallEUR, Field: "eur",
+		Raw: []domain.RawPrice{
+			{Field: "usd", Currency: domain.CurrencyUSD, Value: 1.20},
+			{Field: "usd_foil", Currency: domain.CurrencyUSD, Value: 3.40},
+			{Field: "eur", Currency: domain.CurrencyEUR, Value: 1.00},
+			{Field: "eur_foil", Currency: domain.CurrencyEUR, Value: 2.90},
+			{Field: "tix", Currency: domain.CurrencyTIX, Value: 0.05},
+		}}, price)
+	mockWeb.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestBulk_MissingCardIsRequested(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	mockRequest := mocks.NewRequestMock()
+	mockResponse := mocks.NewResponseMock()
+
+	mockLogger.On("Info", mock.Anything).Once()
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/one/1", mock.Anything).Return(mockRequest, nil)
+	mockWeb.On("Do", mockRequest).Return(mockResponse, nil)
+	mockResponse.On("StatusCode").Return(http.StatusOK)
+	mockResponse.On("Body").Return(io.NopCloser(strings.NewReader(`{"prices": {"usd": "2.00"}}`)))
+
+	bulk := NewBulk(mockWeb, "", writeBulkFile(t), pricedCards(ownedCards...), mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, bulk, mockLogger)
+
+	price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "one", CollectorNumber: "1", Finish: domain.FinishNonfoil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2.00, price.Value)
+	mockWeb.AssertExpectations(t)
+}
+
+func TestBulk_LoadErrorRequestsEveryCard(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	mockRequest := mocks.NewRequestMock()
+	mockResponse := mocks.NewResponseMock()
+
+	mockLogger.On("Warn", mock.Anything).Once()
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/mh2/267", mock.Anything).Return(mockRequest, nil)
+	mockWeb.On("Do", mockRequest).Return(mockResponse, nil)
+	mockResponse.On("StatusCode").Return(http.StatusOK)
+
+	repoMock := pricedCards(ownedCards...)
+	bulk := NewBulk(mockWeb, "", filepath.Join(t.TempDir(), "missing.json"), repoMock, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, bulk, mockLogger)
+
+	for i := 0; i < 2; i++ {
+		mockResponse.On("Body").Return(io.NopCloser(strings.NewReader(`{"prices": {"usd": "1.20"}}`))).Twice()
+
+		price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "mh2", CollectorNumber: "267", Finish: domain.FinishNonfoil})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1.20, price.Value)
+	}
+	mockLogger.AssertExpectations(t)
+	repoMock.AssertNumberOfCalls(t, "GetPricedCards", 1)
+}
+
+func TestBulk_CancelledLoadIsRetried(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	repoMock := mocks.NewConciliateRepositoryMock()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockLogger.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Once()
+	repoMock.On("GetPricedCards", cancelled).Return([]domain.Cards(nil), context.Canceled).Once()
+	repoMock.On("GetPricedCards", context.Background()).Return(ownedCards, nil).Once()
+
+	bulk := NewBulk(mockWeb, "", writeBulkFile(t), repoMock, mockLogger)
+
+	_, ok := bulk.Prices(cancelled, domain.Cards{SetName: "mh2", CollectorNumber: "267"})
+	assert.False(t, ok)
+
+	prices, ok := bulk.Prices(context.Background(), domain.Cards{SetName: "mh2", CollectorNumber: "267"})
+	assert.True(t, ok)
+	assert.Equal(t, "1.20", *prices.USD)
+	mockLogger.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDecodeBulk_KeepsWantedCardsOnly(t *testing.T) {
+	prices, err := decodeBulk(strings.NewReader(testBulkBody), map[string]struct{}{bulkKey("MH2", "267"): {}})
+
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	assert.Contains(t, prices, "mh2/267")
+}
+
+func TestDecodeBulk_InvalidJSON(t *testing.T) {
+	_, err := decodeBulk(strings.NewReader(`[{"set": "mh2", "collector_number": 267}]`), map[string]struct{}{})
+
+	assert.ErrorContains(t, err, "card gateway failed to decode bulk card")
+}
@@ -13,7 +13,7 @@ func (e ErrFailedToGetCardRequest) Error() string {
 type ErrPriceIsZero struct{}
 
 func (e ErrPriceIsZero) Error() string {
-	return "price is zero - card could be foil or non-foil, check register"
+	return "price is zero - check the finish of the card"
 }
 
 type ErrCardNotFound struct{}
@@ -19,7 +19,7 @@ func TestErrFailedToGetCardRequest_Interface(t *testing.T) {
 
 func TestErrPriceIsZero_Error(t *testing.T) {
 	err := ErrPriceIsZero{}
-	expected := "price is zero - card could be foil or non-foil, check register"
+	expected := "price is zero - check the finish of the card"
 	assert.Equal(t, expected, err.Error())
 }
 
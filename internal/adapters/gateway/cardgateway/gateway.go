@@ -14,66 +14,140 @@ import (
 )
 
 type cardGateway struct {
-	web web.HTTP
-	log logrus.Logger
+	web    web.HTTP
+	source domain.PriceSource
+	bulk   *Bulk
+	log    logrus.Logger
 }
 
-func New(web web.HTTP, log logrus.Logger) *cardGateway {
+// New returns a Scryfall gateway that reads the price of the given source:
+// scryfall_usd, scryfall_eur or mtgo_tix. With a bulk dump, cards are priced
+// from it and only the ones missing from it are requested one by one. The
+// rate of the requests is left to web, which should be limited by a limiter
+// shared by every gateway calling Scryfall.
+func New(web web.HTTP, source domain.PriceSource, bulk *Bulk, log logrus.Logger) *cardGateway {
 	return &cardGateway{
-		web: web,
-		log: log,
+		web:    web,
+		source: source,
+		bulk:   bulk,
+		log:    log,
 	}
 }
 
-func (cg *cardGateway) GetCardPrice(ctx context.Context, card domain.Cards) (float64, error) {
+func (cg *cardGateway) GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error) {
+	if cg.bulk != nil {
+		if prices, ok := cg.bulk.Prices(ctx, card); ok {
+			return cg.price(card, prices)
+		}
+	}
+
 	url := fmt.Sprintf("https://api.scryfall.com/cards/%s/%s", card.SetName, card.CollectorNumber)
 	req, err := cg.web.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return 0, fmt.Errorf("card gateway failed to get card: %w", err)
+		return domain.Price{}, fmt.Errorf("card gateway failed to get card: %w", err)
 	}
 
 	resp, err := cg.web.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("card gateway failed to get response: %w", err)
+		return domain.Price{}, fmt.Errorf("card gateway failed to get response: %w", err)
 	}
 	defer resp.Body().Close()
 
 	if resp.StatusCode() == http.StatusNotFound {
-		return 0, ErrCardNotFound{}
+		return domain.Price{}, ErrCardNotFound{}
 	}
 
 	body, err := ioutil.ReadAll(resp.Body())
 	if err != nil {
-		return 0, fmt.Errorf("card gateway failed to read body: %w", err)
+		return domain.Price{}, fmt.Errorf("card gateway failed to read body: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return 0, fmt.Errorf("card gateway failed to get card: http status %d, response: %s", resp.StatusCode(), string(body))
+		return domain.Price{}, fmt.Errorf("card gateway failed to get card: http status %d, response: %s", resp.StatusCode(), string(body))
 	}
 
 	var cardRequest entities.ScryfallCard
 	err = json.Unmarshal(body, &cardRequest)
 	if err != nil {
-		return 0, fmt.Errorf("card gateway failed to unmarshal body: %w", err)
+		return domain.Price{}, fmt.Errorf("card gateway failed to unmarshal body: %w", err)
 	}
 
-	if card.Foil {
-		if cardRequest.Prices.USDFoil != nil {
-			usdFoil, err := strconv.ParseFloat(*cardRequest.Prices.USDFoil, 64)
-			if err != nil {
-				return 0, fmt.Errorf("card gateway failed to parse float for usd foil: %w", err)
-			}
-			return usdFoil, nil
+	return cg.price(card, cardRequest.Prices)
+}
+
+// price reads the quote of the source for the finish of the card among the
+// prices Scryfall returned for it. A missing quote fails cards never priced,
+// whose finish is most likely wrong, and prices the others at zero.
+func (cg *cardGateway) price(card domain.Cards, prices entities.Price) (domain.Price, error) {
+	field, currency := cg.selectField(card.Finish)
+	price := domain.Price{Currency: currency, Source: cg.source, Field: field}
+
+	found := false
+	for _, quote := range rawQuotes(prices) {
+		if quote.value == nil {
+			continue
 		}
-	} else {
-		if cardRequest.Prices.USD != nil {
-			usd, err := strconv.ParseFloat(*cardRequest.Prices.USD, 64)
-			if err != nil {
-				return 0, fmt.Errorf("card gateway failed to parse float for usd: %w", err)
+
+		value, err := strconv.ParseFloat(*quote.value, 64)
+		if err != nil {
+			if quote.field == field {
+				return domain.Price{}, fmt.Errorf("card gateway failed to parse float for %s: %w", field, err)
 			}
-			return usd, nil
+			continue
+		}
+
+		price.Raw = append(price.Raw, domain.RawPrice{Field: quote.field, Currency: quote.currency, Value: value})
+		if quote.field == field {
+			price.Value = value
+			found = true
+		}
+	}
+
+	// A card that had a price and lost its quote dropped to zero, which is left
+	// to the sanity rules of conciliation instead of failing the card.
+	if !found && card.LastPrice <= 0 {
+		return domain.Price{}, ErrPriceIsZero{}
+	}
+
+	return price, nil
+}
+
+// selectField picks the Scryfall field of the source for the finish. Scryfall
+// only quotes etched foils apart in USD, Cardmarket prices every other foil
+// finish as a foil and MTGO has no foil price, so tix are used for all.
+func (cg *cardGateway) selectField(finish domain.Finish) (string, string) {
+	switch cg.source {
+	case domain.SourceScryfallEUR:
+		if finish.IsFoil() {
+			return "eur_foil", domain.CurrencyEUR
 		}
+		return "eur", domain.CurrencyEUR
+	case domain.SourceMTGOTix:
+		return "tix", domain.CurrencyTIX
+	default:
+		switch {
+		case finish == domain.FinishEtched:
+			return "usd_etched", domain.CurrencyUSD
+		case finish.IsFoil():
+			return "usd_foil", domain.CurrencyUSD
+		}
+		return "usd", domain.CurrencyUSD
 	}
+}
 
-	return 0, ErrPriceIsZero{}
+type rawQuote struct {
+	field    string
+	value    *string
+	currency string
+}
+
+func rawQuotes(prices entities.Price) []rawQuote {
+	return []rawQuote{
+		{"usd", prices.USD, domain.CurrencyUSD},
+		{"usd_foil", prices.USDFoil, domain.CurrencyUSD},
+		{"usd_etched", prices.USDEtched, domain.CurrencyUSD},
+		{"eur", prices.EUR, domain.CurrencyEUR},
+		{"eur_foil", prices.EURFoil, domain.CurrencyEUR},
+		{"tix", prices.Tix, domain.CurrencyTIX},
+	}
 }
@@ -19,10 +19,12 @@ func TestNew(t *testing.T) {
 	mockWeb := mocks.NewHTTPMock()
 	mockLogger := mocks.NewLogMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	assert.NotNil(t, gateway)
 	assert.Equal(t, mockWeb, gateway.web)
+	assert.Equal(t, domain.SourceScryfallUSD, gateway.source)
+	assert.Nil(t, gateway.bulk)
 	assert.Equal(t, mockLogger, gateway.log)
 }
 
@@ -32,12 +34,12 @@ func TestGetCardPrice_Success_NonFoil(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	responseBody := `{
@@ -55,7 +57,8 @@ func TestGetCardPrice_Success_NonFoil(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 10.50, price)
+	assert.Equal(t, domain.Price{Value: 10.50, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD, Field: "usd",
+		Raw: []domain.RawPrice{{Field: "usd", Currency: domain.CurrencyUSD, Value: 10.50}, {Field: "usd_foil", Currency: domain.CurrencyUSD, Value: 25.00}}}, price)
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
 }
@@ -66,12 +69,12 @@ func TestGetCardPrice_Success_Foil(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            true,
+		Finish:          domain.FinishFoil,
 	}
 
 	responseBody := `{
@@ -89,7 +92,8 @@ func TestGetCardPrice_Success_Foil(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 25.00, price)
+	assert.Equal(t, domain.Price{Value: 25.00, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD, Field: "usd_foil",
+		Raw: []domain.RawPrice{{Field: "usd", Currency: domain.CurrencyUSD, Value: 10.50}, {Field: "usd_foil", Currency: domain.CurrencyUSD, Value: 25.00}}}, price)
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
 }
@@ -98,12 +102,12 @@ func TestGetCardPrice_RequestError(t *testing.T) {
 	mockWeb := mocks.NewHTTPMock()
 	mockLogger := mocks.NewLogMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/161", mock.Anything).Return(nil, fmt.Errorf("request error"))
@@ -111,7 +115,7 @@ func TestGetCardPrice_RequestError(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.Contains(t, err.Error(), "card gateway failed to get card")
 	mockWeb.AssertExpectations(t)
 }
@@ -121,12 +125,12 @@ func TestGetCardPrice_DoRequestError(t *testing.T) {
 	mockLogger := mocks.NewLogMock()
 	mockRequest := mocks.NewRequestMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/161", mock.Anything).Return(mockRequest, nil)
@@ -135,7 +139,7 @@ func TestGetCardPrice_DoRequestError(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.Contains(t, err.Error(), "card gateway failed to get response")
 	mockWeb.AssertExpectations(t)
 }
@@ -146,12 +150,12 @@ func TestGetCardPrice_CardNotFound(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "999",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/999", mock.Anything).Return(mockRequest, nil)
@@ -162,7 +166,7 @@ func TestGetCardPrice_CardNotFound(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.IsType(t, ErrCardNotFound{}, err)
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
@@ -174,12 +178,12 @@ func TestGetCardPrice_NonOKStatus(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	responseBody := "internal server error"
@@ -192,7 +196,7 @@ func TestGetCardPrice_NonOKStatus(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.Contains(t, err.Error(), "card gateway failed to get card: http status 500")
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
@@ -204,12 +208,12 @@ func TestGetCardPrice_InvalidJSON(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	responseBody := `{"invalid": json}`
@@ -222,7 +226,7 @@ func TestGetCardPrice_InvalidJSON(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.Contains(t, err.Error(), "card gateway failed to unmarshal body")
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
@@ -234,12 +238,12 @@ func TestGetCardPrice_PriceIsZero_NonFoil(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	responseBody := `{
@@ -257,24 +261,65 @@ func TestGetCardPrice_PriceIsZero_NonFoil(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.IsType(t, ErrPriceIsZero{}, err)
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
 }
 
+func TestGetCardPrice_PricedCardLosesItsQuote(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockLogger := mocks.NewLogMock()
+	mockRequest := mocks.NewRequestMock()
+	mockResponse := mocks.NewResponseMock()
+
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
+
+	card := domain.Cards{
+		SetName:         "alpha",
+		CollectorNumber: "161",
+		Finish:          domain.FinishNonfoil,
+		CardsDetails:    domain.CardsDetails{LastPrice: 50},
+	}
+
+	responseBody := `{
+		"prices": {
+			"usd": null,
+			"usd_foil": "25.00"
+		}
+	}`
+
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/161", mock.Anything).Return(mockRequest, nil)
+	mockWeb.On("Do", mockRequest).Return(mockResponse, nil)
+	mockResponse.On("StatusCode").Return(http.StatusOK)
+	mockResponse.On("Body").Return(io.NopCloser(strings.NewReader(responseBody)))
+
+	price, err := gateway.GetCardPrice(context.Background(), card)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Price{
+		Value:    0,
+		Currency: domain.CurrencyUSD,
+		Source:   domain.SourceScryfallUSD,
+		Field:    "usd",
+		Raw:      []domain.RawPrice{{Field: "usd_foil", Currency: domain.CurrencyUSD, Value: 25}},
+	}, price)
+	mockWeb.AssertExpectations(t)
+	mockResponse.AssertExpectations(t)
+}
+
 func TestGetCardPrice_PriceIsZero_Foil(t *testing.T) {
 	mockWeb := mocks.NewHTTPMock()
 	mockLogger := mocks.NewLogMock()
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            true,
+		Finish:          domain.FinishFoil,
 	}
 
 	responseBody := `{
@@ -292,7 +337,7 @@ func TestGetCardPrice_PriceIsZero_Foil(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.IsType(t, ErrPriceIsZero{}, err)
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
@@ -304,12 +349,12 @@ func TestGetCardPrice_InvalidFloatParsing(t *testing.T) {
 	mockRequest := mocks.NewRequestMock()
 	mockResponse := mocks.NewResponseMock()
 
-	gateway := New(mockWeb, mockLogger)
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mockLogger)
 
 	card := domain.Cards{
 		SetName:         "alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	responseBody := `{
@@ -327,8 +372,90 @@ func TestGetCardPrice_InvalidFloatParsing(t *testing.T) {
 	price, err := gateway.GetCardPrice(context.Background(), card)
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, price)
+	assert.Equal(t, domain.Price{}, price)
 	assert.Contains(t, err.Error(), "card gateway failed to parse float for usd")
 	mockWeb.AssertExpectations(t)
 	mockResponse.AssertExpectations(t)
 }
+
+func TestGetCardPrice_Sources(t *testing.T) {
+	responseBody := `{
+		"prices": {
+			"usd": "10.50",
+			"usd_foil": "25.00",
+			"usd_etched": "30.00",
+			"eur": "9.00",
+			"eur_foil": "20.00",
+			"tix": "0.03"
+		}
+	}`
+
+	tests := []struct {
+		name   string
+		source domain.PriceSource
+		finish domain.Finish
+		want   domain.Price
+	}{
+		{name: "should read usd etched", source: domain.SourceScryfallUSD, finish: domain.FinishEtched, want: domain.Price{Value: 30, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD, Field: "usd_etched"}},
+		{name: "should read usd foil for other foil finishes", source: domain.SourceScryfallUSD, finish: domain.FinishSurge, want: domain.Price{Value: 25, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD, Field: "usd_foil"}},
+		{name: "should read eur", source: domain.SourceScryfallEUR, finish: domain.FinishNonfoil, want: domain.Price{Value: 9, Currency: domain.CurrencyEUR, Source: domain.SourceScryfallEUR, Field: "eur"}},
+		{name: "should read eur foil for etched", source: domain.SourceScryfallEUR, finish: domain.FinishEtched, want: domain.Price{Value: 20, Currency: domain.CurrencyEUR, Source: domain.SourceScryfallEUR, Field: "eur_foil"}},
+		{name: "should read tix for foils too", source: domain.SourceMTGOTix, finish: domain.FinishFoil, want: domain.Price{Value: 0.03, Currency: domain.CurrencyTIX, Source: domain.SourceMTGOTix, Field: "tix"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeb := mocks.NewHTTPMock()
+			mockRequest := mocks.NewRequestMock()
+			mockResponse := mocks.NewResponseMock()
+
+			gateway := New(mockWeb, tt.source, nil, mocks.NewLogMock())
+
+			mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/161", mock.Anything).Return(mockRequest, nil)
+			mockWeb.On("Do", mockRequest).Return(mockResponse, nil)
+			mockResponse.On("StatusCode").Return(http.StatusOK)
+			mockResponse.On("Body").Return(io.NopCloser(strings.NewReader(responseBody)))
+
+			price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "alpha", CollectorNumber: "161", Finish: tt.finish})
+
+			assert.NoError(t, err)
+			assert.Len(t, price.Raw, 6)
+			price.Raw = nil
+			assert.Equal(t, tt.want, price)
+		})
+	}
+}
+
+func TestGetCardPrice_KeepsEveryRawPrice(t *testing.T) {
+	mockWeb := mocks.NewHTTPMock()
+	mockRequest := mocks.NewRequestMock()
+	mockResponse := mocks.NewResponseMock()
+
+	gateway := New(mockWeb, domain.SourceScryfallUSD, nil, mocks.NewLogMock())
+
+	responseBody := `{
+		"prices": {
+			"usd": "10.50",
+			"usd_foil": null,
+			"usd_etched": "30.00",
+			"eur": "not-a-number",
+			"eur_foil": "20.00",
+			"tix": "0.03"
+		}
+	}`
+
+	mockWeb.On("NewRequestWithContext", mock.Anything, "GET", "https://api.scryfall.com/cards/alpha/161", mock.Anything).Return(mockRequest, nil)
+	mockWeb.On("Do", mockRequest).Return(mockResponse, nil)
+	mockResponse.On("StatusCode").Return(http.StatusOK)
+	mockResponse.On("Body").Return(io.NopCloser(strings.NewReader(responseBody)))
+
+	price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "alpha", CollectorNumber: "161", Finish: domain.FinishNonfoil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RawPrice{
+		{Field: "usd", Currency: domain.CurrencyUSD, Value: 10.50},
+		{Field: "usd_etched", Currency: domain.CurrencyUSD, Value: 30.00},
+		{Field: "eur_foil", Currency: domain.CurrencyEUR, Value: 20.00},
+		{Field: "tix", Currency: domain.CurrencyTIX, Value: 0.03},
+	}, price.Raw)
+}
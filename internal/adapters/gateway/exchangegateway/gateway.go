@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"mtg-report/internal/adapters/entities"
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/sources/logger/logrus"
 	"mtg-report/internal/sources/web"
 	"net/http"
+	"time"
 )
 
 type exchangeGateway struct {
@@ -25,37 +27,68 @@ func New(web web.HTTP, url string, log logrus.Logger) *exchangeGateway {
 	}
 }
 
-func (eg *exchangeGateway) GetUSD(ctx context.Context) (float64, error) {
+// GetRates returns every conversion rate of the day. BRL is required, as all
+// prices are stored in it.
+func (eg *exchangeGateway) GetRates(ctx context.Context) (domain.ExchangeRates, error) {
+	exchange, err := eg.getRates(ctx)
+	if err != nil {
+		return domain.ExchangeRates{}, err
+	}
+
+	if exchange.ConversionRates[domain.CurrencyBRL] == nil {
+		return domain.ExchangeRates{}, ErrExchangeRequestNillValue{}
+	}
+
+	rates := domain.ExchangeRates{
+		Date:  time.Now().UTC(),
+		Base:  exchange.BaseCode,
+		Rates: make(map[string]float64, len(exchange.ConversionRates)),
+	}
+	if exchange.TimeLastUpdateUnix > 0 {
+		rates.Date = time.Unix(exchange.TimeLastUpdateUnix, 0).UTC()
+	}
+	rates.Date = time.Date(rates.Date.Year(), rates.Date.Month(), rates.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	if len(rates.Base) == 0 {
+		rates.Base = domain.CurrencyUSD
+	}
+
+	for currency, rate := range exchange.ConversionRates {
+		if rate != nil {
+			rates.Rates[currency] = *rate
+		}
+	}
+
+	return rates, nil
+}
+
+func (eg *exchangeGateway) getRates(ctx context.Context) (entities.ExchangeRate, error) {
 	url := fmt.Sprintf(eg.url)
 	req, err := eg.web.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return 0, fmt.Errorf("exchange gateway failed to create request: %w", err)
+		return entities.ExchangeRate{}, fmt.Errorf("exchange gateway failed to create request: %w", err)
 	}
 
 	resp, err := eg.web.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("exchange gateway failed to get response: %w", err)
+		return entities.ExchangeRate{}, fmt.Errorf("exchange gateway failed to get response: %w", err)
 	}
 	defer resp.Body().Close()
 
 	if resp.StatusCode() != http.StatusOK {
-		return 0, ErrFailedToGetExchangeRequest{}
+		return entities.ExchangeRate{}, ErrFailedToGetExchangeRequest{}
 	}
 
 	body, err := ioutil.ReadAll(resp.Body())
 	if err != nil {
-		return 0, fmt.Errorf("exchange gateway failed to read body: %w", err)
+		return entities.ExchangeRate{}, fmt.Errorf("exchange gateway failed to read body: %w", err)
 	}
 
 	var exchange entities.ExchangeRate
 	err = json.Unmarshal(body, &exchange)
 	if err != nil {
-		return 0, fmt.Errorf("exchange gateway failed to unmarshal body: %w", err)
-	}
-
-	if exchange.ConversionRates.BRL == nil {
-		return 0, ErrExchangeRequestNillValue{}
+		return entities.ExchangeRate{}, fmt.Errorf("exchange gateway failed to unmarshal body: %w", err)
 	}
 
-	return *exchange.ConversionRates.BRL, nil
+	return exchange, nil
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"io"
+	"mtg-report/internal/core/domain"
 	"mtg-report/mocks"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,13 +26,13 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, url, gateway.url)
 }
 
-func TestExchangeGateway_GetUSD_Success(t *testing.T) {
+func TestExchangeGateway_GetRates_Success(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	respMock := mocks.NewResponseMock()
 	reqMock := mocks.NewRequestMock()
 	logMock := mocks.NewLogMock()
 
-	responseBody := `{"conversion_rates":{"BRL":5.25}}`
+	responseBody := `{"base_code":"USD","time_last_update_unix":1768521601,"conversion_rates":{"USD":1,"BRL":5.25,"EUR":null}}`
 	bodyReader := io.NopCloser(strings.NewReader(responseBody))
 
 	webMock.On("NewRequestWithContext", mock.Anything, "GET", "https://api.test.com", nil).Return(reqMock, nil)
@@ -39,16 +41,20 @@ func TestExchangeGateway_GetUSD_Success(t *testing.T) {
 	respMock.On("Body").Return(bodyReader)
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.NoError(t, err)
-	assert.Equal(t, 5.25, got)
+	assert.Equal(t, domain.ExchangeRates{
+		Date:  time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC),
+		Base:  domain.CurrencyUSD,
+		Rates: map[string]float64{"USD": 1, "BRL": 5.25},
+	}, got)
 
 	webMock.AssertExpectations(t)
 	respMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_RequestCreationError(t *testing.T) {
+func TestExchangeGateway_GetRates_RequestCreationError(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	logMock := mocks.NewLogMock()
 
@@ -56,16 +62,16 @@ func TestExchangeGateway_GetUSD_RequestCreationError(t *testing.T) {
 		Return(nil, errors.New("request creation failed"))
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.Contains(t, err.Error(), "exchange gateway failed to create request")
 
 	webMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_RequestExecutionError(t *testing.T) {
+func TestExchangeGateway_GetRates_RequestExecutionError(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	reqMock := mocks.NewRequestMock()
 	logMock := mocks.NewLogMock()
@@ -74,16 +80,16 @@ func TestExchangeGateway_GetUSD_RequestExecutionError(t *testing.T) {
 	webMock.On("Do", reqMock).Return(nil, errors.New("request execution failed"))
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.Contains(t, err.Error(), "exchange gateway failed to get response")
 
 	webMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_NonOKStatusCode(t *testing.T) {
+func TestExchangeGateway_GetRates_NonOKStatusCode(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	respMock := mocks.NewResponseMock()
 	reqMock := mocks.NewRequestMock()
@@ -97,17 +103,17 @@ func TestExchangeGateway_GetUSD_NonOKStatusCode(t *testing.T) {
 	respMock.On("Body").Return(bodyReader)
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.IsType(t, ErrFailedToGetExchangeRequest{}, err)
 
 	webMock.AssertExpectations(t)
 	respMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_InvalidJSON(t *testing.T) {
+func TestExchangeGateway_GetRates_InvalidJSON(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	respMock := mocks.NewResponseMock()
 	reqMock := mocks.NewRequestMock()
@@ -122,17 +128,17 @@ func TestExchangeGateway_GetUSD_InvalidJSON(t *testing.T) {
 	respMock.On("Body").Return(bodyReader)
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.Contains(t, err.Error(), "exchange gateway failed to unmarshal body")
 
 	webMock.AssertExpectations(t)
 	respMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_NilBRLRate(t *testing.T) {
+func TestExchangeGateway_GetRates_NilBRLRate(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	respMock := mocks.NewResponseMock()
 	reqMock := mocks.NewRequestMock()
@@ -147,17 +153,17 @@ func TestExchangeGateway_GetUSD_NilBRLRate(t *testing.T) {
 	respMock.On("Body").Return(bodyReader)
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.IsType(t, ErrExchangeRequestNillValue{}, err)
 
 	webMock.AssertExpectations(t)
 	respMock.AssertExpectations(t)
 }
 
-func TestExchangeGateway_GetUSD_MissingBRLRate(t *testing.T) {
+func TestExchangeGateway_GetRates_MissingBRLRate(t *testing.T) {
 	webMock := mocks.NewHTTPMock()
 	respMock := mocks.NewResponseMock()
 	reqMock := mocks.NewRequestMock()
@@ -172,12 +178,35 @@ func TestExchangeGateway_GetUSD_MissingBRLRate(t *testing.T) {
 	respMock.On("Body").Return(bodyReader)
 
 	gateway := New(webMock, "https://api.test.com", logMock)
-	got, err := gateway.GetUSD(context.Background())
+	got, err := gateway.GetRates(context.Background())
 
 	assert.Error(t, err)
-	assert.Equal(t, float64(0), got)
+	assert.Equal(t, domain.ExchangeRates{}, got)
 	assert.IsType(t, ErrExchangeRequestNillValue{}, err)
 
 	webMock.AssertExpectations(t)
 	respMock.AssertExpectations(t)
 }
+
+func TestExchangeGateway_GetRates_DefaultsBaseAndDate(t *testing.T) {
+	webMock := mocks.NewHTTPMock()
+	respMock := mocks.NewResponseMock()
+	reqMock := mocks.NewRequestMock()
+	logMock := mocks.NewLogMock()
+
+	webMock.On("NewRequestWithContext", mock.Anything, "GET", "https://api.test.com", nil).Return(reqMock, nil)
+	webMock.On("Do", reqMock).Return(respMock, nil)
+	respMock.On("StatusCode").Return(http.StatusOK)
+	respMock.On("Body").Return(io.NopCloser(strings.NewReader(`{"conversion_rates":{"BRL":5.5,"EUR":0.5}}`)))
+
+	gateway := New(webMock, "https://api.test.com", logMock)
+	got, err := gateway.GetRates(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CurrencyUSD, got.Base)
+	assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour), got.Date)
+
+	eur, ok := got.BRL(domain.CurrencyEUR)
+	assert.True(t, ok)
+	assert.Equal(t, 11.0, eur)
+}
@@ -0,0 +1,7 @@
+package pricelistgateway
+
+type ErrCardNotFound struct{}
+
+func (e ErrCardNotFound) Error() string {
+	return "card not found in price list"
+}
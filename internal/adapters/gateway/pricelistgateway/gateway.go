@@ -0,0 +1,108 @@
+package pricelistgateway
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/sources/logger/logrus"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// priceField names the quote of the price list in the raw prices.
+const priceField = "price_list"
+
+type priceListGateway struct {
+	path     string
+	currency string
+	log      logrus.Logger
+
+	once   sync.Once
+	prices map[string]float64
+	err    error
+}
+
+// New returns a gateway that prices cards from a local CSV file with the
+// columns set_name, collector_number, finish and price, quoted in currency. The
+// file is read on the first price asked.
+func New(path, currency string, log logrus.Logger) *priceListGateway {
+	return &priceListGateway{
+		path:     path,
+		currency: strings.ToUpper(currency),
+		log:      log,
+	}
+}
+
+func (pg *priceListGateway) GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error) {
+	pg.once.Do(func() {
+		pg.prices, pg.err = pg.load()
+	})
+	if pg.err != nil {
+		return domain.Price{}, pg.err
+	}
+
+	price, ok := pg.prices[priceKey(card.SetName, card.CollectorNumber, card.Finish)]
+	if !ok {
+		return domain.Price{}, ErrCardNotFound{}
+	}
+
+	return domain.Price{
+		Value:    price,
+		Currency: pg.currency,
+		Source:   domain.SourcePriceList,
+		Field:    priceField,
+		Raw:      []domain.RawPrice{{Field: priceField, Currency: pg.currency, Value: price}},
+	}, nil
+}
+
+func (pg *priceListGateway) load() (map[string]float64, error) {
+	file, err := os.Open(pg.path)
+	if err != nil {
+		return nil, fmt.Errorf("price list gateway failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	prices := make(map[string]float64)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("price list gateway failed to read file: %w", err)
+		}
+
+		if line == 1 && record[0] == "set_name" {
+			continue
+		}
+
+		finish := domain.NormalizeFinish(record[2])
+		if !domain.ValidFinish(finish) {
+			return nil, fmt.Errorf("price list gateway failed to parse finish %q in line %d", record[2], line)
+		}
+
+		price, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("price list gateway failed to parse price in line %d: %w", line, err)
+		}
+
+		prices[priceKey(record[0], record[1], finish)] = price
+	}
+
+	pg.log.Info(fmt.Sprintf("%d prices loaded from %s", len(prices), pg.path))
+
+	return prices, nil
+}
+
+func priceKey(setName, collectorNumber string, finish domain.Finish) string {
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(setName), strings.ToLower(collectorNumber), finish)
+}
@@ -0,0 +1,90 @@
+package pricelistgateway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func writePriceList(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestNew(t *testing.T) {
+	mockLogger := mocks.NewLogMock()
+
+	gateway := New("prices.csv", "eur", mockLogger)
+
+	assert.NotNil(t, gateway)
+	assert.Equal(t, "prices.csv", gateway.path)
+	assert.Equal(t, domain.CurrencyEUR, gateway.currency)
+}
+
+func TestGetCardPrice_Success(t *testing.T) {
+	mockLogger := mocks.NewLogMock()
+	mockLogger.On("Info", mock.Anything).Once()
+
+	path := writePriceList(t, "set_name,collector_number,finish,price\nLEA,161,nonfoil,10.50\nlea,161,foil,25\n")
+	gateway := New(path, "USD", mockLogger)
+
+	price, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "lea", CollectorNumber: "161", Finish: domain.FinishNonfoil})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Price{
+		Value:    10.50,
+		Currency: domain.CurrencyUSD,
+		Source:   domain.SourcePriceList,
+		Field:    "price_list",
+		Raw:      []domain.RawPrice{{Field: "price_list", Currency: domain.CurrencyUSD, Value: 10.50}},
+	}, price)
+
+	price, err = gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "LEA", CollectorNumber: "161", Finish: domain.FinishFoil})
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, price.Value)
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetCardPrice_CardNotFound(t *testing.T) {
+	mockLogger := mocks.NewLogMock()
+	mockLogger.On("Info", mock.Anything).Once()
+
+	path := writePriceList(t, "lea,161,nonfoil,10.50\n")
+	gateway := New(path, "USD", mockLogger)
+
+	_, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "lea", CollectorNumber: "232"})
+
+	assert.IsType(t, ErrCardNotFound{}, err)
+}
+
+func TestGetCardPrice_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "should fail when the file is missing", path: filepath.Join(t.TempDir(), "missing.csv"), wantErr: "price list gateway failed to open file"},
+		{name: "should fail on an invalid price", path: writePriceList(t, "lea,161,nonfoil,abc\n"), wantErr: "price list gateway failed to parse price in line 1"},
+		{name: "should fail on an invalid finish", path: writePriceList(t, "lea,161,maybe,1\n"), wantErr: "price list gateway failed to parse finish \"maybe\" in line 1"},
+		{name: "should fail on missing columns", path: writePriceList(t, "lea,161,1\n"), wantErr: "price list gateway failed to read file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := New(tt.path, "USD", mocks.NewLogMock())
+
+			_, err := gateway.GetCardPrice(context.Background(), domain.Cards{SetName: "lea", CollectorNumber: "161"})
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package sourcegateway
+
+import (
+	"fmt"
+	"mtg-report/internal/core/domain"
+)
+
+type ErrSourceNotConfigured struct {
+	source domain.PriceSource
+}
+
+func (e ErrSourceNotConfigured) Error() string {
+	return fmt.Sprintf("price source %s is not configured", e.source)
+}
@@ -0,0 +1,32 @@
+package sourcegateway
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/ports"
+)
+
+type sourceGateway struct {
+	sources  domain.PriceSources
+	gateways map[domain.PriceSource]ports.CardGateway
+}
+
+// New returns a gateway that prices each card with the gateway of the source
+// configured for it.
+func New(sources domain.PriceSources, gateways map[domain.PriceSource]ports.CardGateway) *sourceGateway {
+	return &sourceGateway{
+		sources:  sources,
+		gateways: gateways,
+	}
+}
+
+func (sg *sourceGateway) GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error) {
+	source := sg.sources.For(card)
+
+	gateway, ok := sg.gateways[source]
+	if !ok {
+		return domain.Price{}, ErrSourceNotConfigured{source: source}
+	}
+
+	return gateway.GetCardPrice(ctx, card)
+}
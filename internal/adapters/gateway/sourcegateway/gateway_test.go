@@ -0,0 +1,46 @@
+package sourcegateway
+
+import (
+	"context"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/ports"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCardPrice(t *testing.T) {
+	usdMock := mocks.NewCardGatewayMock()
+	eurMock := mocks.NewCardGatewayMock()
+
+	gateway := New(domain.PriceSources{
+		Default:     domain.SourceScryfallUSD,
+		Collections: map[int64]domain.PriceSource{2: domain.SourceScryfallEUR},
+		Cards:       map[int64]domain.PriceSource{9: domain.SourceMTGOTix},
+	}, map[domain.PriceSource]ports.CardGateway{
+		domain.SourceScryfallUSD: usdMock,
+		domain.SourceScryfallEUR: eurMock,
+	})
+
+	usdCard := domain.Cards{ID: 1, CollectionID: 1}
+	eurCard := domain.Cards{ID: 2, CollectionID: 2}
+
+	usdMock.On("GetCardPrice", context.Background(), usdCard).Return(domain.Price{Value: 1, Currency: domain.CurrencyUSD}, nil).Once()
+	eurMock.On("GetCardPrice", context.Background(), eurCard).Return(domain.Price{Value: 2, Currency: domain.CurrencyEUR}, nil).Once()
+
+	price, err := gateway.GetCardPrice(context.Background(), usdCard)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, price.Value)
+
+	price, err = gateway.GetCardPrice(context.Background(), eurCard)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, price.Value)
+
+	_, err = gateway.GetCardPrice(context.Background(), domain.Cards{ID: 9, CollectionID: 2})
+	assert.EqualError(t, err, "price source mtgo_tix is not configured")
+
+	usdMock.AssertExpectations(t)
+	eurMock.AssertExpectations(t)
+}
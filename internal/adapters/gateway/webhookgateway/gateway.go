@@ -0,0 +1,43 @@
+package webhookgateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mtg-report/internal/sources/logger/logrus"
+	"mtg-report/internal/sources/web"
+	"net/http"
+)
+
+type webhookGateway struct {
+	web web.HTTP
+	log logrus.Logger
+}
+
+func New(web web.HTTP, log logrus.Logger) *webhookGateway {
+	return &webhookGateway{
+		web: web,
+		log: log,
+	}
+}
+
+// Post sends body to url and returns the status code of the response. Any
+// status is returned without error, the caller decides what is a success.
+func (wg *webhookGateway) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := wg.web.NewRequestWithHeaders(ctx, http.MethodPost, url, bytes.NewReader(body), headers)
+	if err != nil {
+		return 0, fmt.Errorf("webhook gateway failed to create request: %w", err)
+	}
+
+	resp, err := wg.web.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook gateway failed to get response: %w", err)
+	}
+	defer resp.Body().Close()
+
+	// drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body())
+
+	return resp.StatusCode(), nil
+}
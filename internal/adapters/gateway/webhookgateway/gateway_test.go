@@ -0,0 +1,60 @@
+package webhookgateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mtg-report/mocks"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew(t *testing.T) {
+	gateway := New(mocks.NewHTTPMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, gateway)
+}
+
+func TestWebhookGateway_Post_Success(t *testing.T) {
+	webMock := mocks.NewHTTPMock()
+	respMock := mocks.NewResponseMock()
+	reqMock := mocks.NewRequestMock()
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	body := []byte(`{"event":"report.generated"}`)
+
+	webMock.On("NewRequestWithHeaders", mock.Anything, http.MethodPost, "https://hooks.test.com", bytes.NewReader(body), headers).
+		Return(reqMock, nil)
+	webMock.On("Do", reqMock).Return(respMock, nil)
+	respMock.On("StatusCode").Return(http.StatusAccepted)
+	respMock.On("Body").Return(io.NopCloser(strings.NewReader("ok")))
+
+	gateway := New(webMock, mocks.NewLogMock())
+	got, err := gateway.Post(context.Background(), "https://hooks.test.com", headers, body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, got)
+	webMock.AssertExpectations(t)
+	respMock.AssertExpectations(t)
+}
+
+func TestWebhookGateway_Post_RequestExecutionError(t *testing.T) {
+	webMock := mocks.NewHTTPMock()
+	reqMock := mocks.NewRequestMock()
+
+	webMock.On("NewRequestWithHeaders", mock.Anything, http.MethodPost, "https://hooks.test.com", mock.Anything, mock.Anything).
+		Return(reqMock, nil)
+	webMock.On("Do", reqMock).Return(nil, errors.New("connection refused"))
+
+	gateway := New(webMock, mocks.NewLogMock())
+	got, err := gateway.Post(context.Background(), "https://hooks.test.com", nil, nil)
+
+	assert.ErrorContains(t, err, "webhook gateway failed to get response")
+	assert.Equal(t, 0, got)
+	webMock.AssertExpectations(t)
+}
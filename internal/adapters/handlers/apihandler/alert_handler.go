@@ -0,0 +1,125 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type alertHandler struct {
+	validator    validate
+	AlertService ports.AlertService
+	log          logrus.Logger
+}
+
+func NewAlertHandler(v validate, as ports.AlertService, log logrus.Logger) *alertHandler {
+	return &alertHandler{
+		validator:    v,
+		AlertService: as,
+		log:          log,
+	}
+}
+
+func (h *alertHandler) InsertAlert(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert alert")
+
+	alert := dtos.RequestAlert{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert alert")
+		http.Error(w, "failed to insert alert", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &alert)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert alert")
+		http.Error(w, "failed to insert alert, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.Alert(alert)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert alert")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.AlertService.InsertAlert(r.Context(), alert)
+	if errors.Is(err, domain.ErrCardNotFound{}) {
+		h.log.WithError(err).Warn("failed to insert alert")
+		http.Error(w, domain.ErrCardNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert alert")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("alert inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *alertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get alerts")
+
+	response, err := h.AlertService.GetAlerts(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get alerts")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("alerts retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *alertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete alert")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete alert")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.AlertService.DeleteAlert(r.Context(), id)
+	if errors.Is(err, domain.ErrAlertNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete alert")
+		http.Error(w, domain.ErrAlertNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete alert")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("alert deleted")
+	}
+}
+
+func (h *alertHandler) GetAlertHistory(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get alert history")
+
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.AlertService.GetAlertHistory(r.Context(), page, limit)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get alert history")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("alert history retrieved")
+		encondeResponse(w, response)
+	}
+}
@@ -0,0 +1,205 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewAlertHandler(t *testing.T) {
+	h := NewAlertHandler(mocks.NewValidateMock(), mocks.NewAlertServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_InsertAlert(t *testing.T) {
+	cardID := int64(3)
+	threshold := 10.0
+	request := dtos.RequestAlert{CardID: &cardID, Kind: "percentage", Direction: "up", Threshold: &threshold}
+
+	tests := []struct {
+		name        string
+		reqBody     []byte
+		validateErr error
+		callService bool
+		serviceErr  error
+		wantCode    int
+	}{
+		{name: "should return StatusBadRequest when unable to unmarshal request body",
+			reqBody: []byte("{invalid json}"), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when validation fails",
+			reqBody:     []byte(`{"card_id": 3, "kind": "percentage", "direction": "up", "threshold": 10}`),
+			validateErr: errors.New("either card_id or set_name is required"), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when card is not found",
+			reqBody:     []byte(`{"card_id": 3, "kind": "percentage", "direction": "up", "threshold": 10}`),
+			callService: true, serviceErr: fmt.Errorf("service failed to insert alert: %w", domain.ErrCardNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			reqBody:     []byte(`{"card_id": 3, "kind": "percentage", "direction": "up", "threshold": 10}`),
+			callService: true, serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+		{name: "should return StatusOK when alert is inserted",
+			reqBody:     []byte(`{"card_id": 3, "kind": "percentage", "direction": "up", "threshold": 10}`),
+			callService: true, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewAlertServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("Alert", request).Return(tt.validateErr).Maybe()
+			if tt.callService {
+				sMock.On("InsertAlert", mock.Anything, request).
+					Return(dtos.ResponseAlert{ID: 1, CardID: &cardID, Kind: "percentage", Direction: "up", Threshold: 10}, tt.serviceErr)
+			}
+
+			h := NewAlertHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/alerts", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertAlert(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetAlerts(t *testing.T) {
+	sMock := mocks.NewAlertServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	lMock.On("Info", mock.Anything).Twice()
+	sMock.On("GetAlerts", mock.Anything).Return([]dtos.ResponseAlert{
+		{ID: 1, SetName: "Alpha", Kind: "absolute", Direction: "down", Threshold: 100, CreatedAt: createdAt},
+	}, nil)
+
+	h := NewAlertHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/alerts", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetAlerts(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"id": 1, "set_name": "Alpha", "kind": "absolute", "direction": "down", "threshold": 100,
+		"created_at": "2024-05-01T10:00:00Z"}]`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_DeleteAlert(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when alert is deleted", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when alert is not found",
+			serviceErr: fmt.Errorf("service failed to delete alert: %w", domain.ErrAlertNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewAlertServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "alerts", "3"}).Return("3", nil)
+			sMock.On("DeleteAlert", mock.Anything, "3").Return(tt.serviceErr)
+
+			h := NewAlertHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/alerts/3", nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteAlert(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetAlertHistory(t *testing.T) {
+	t.Run("should return StatusBadRequest when pagination is invalid", func(t *testing.T) {
+		sMock := mocks.NewAlertServiceMock()
+		vMock := mocks.NewValidateMock()
+		lMock := mocks.NewLogMock()
+		cMock := mocks.NewCustomMock()
+
+		lMock.On("Info", mock.Anything).Once()
+		lMock.On("WithError", mock.Anything).Return(cMock).Once()
+		cMock.On("Warn", mock.Anything).Once()
+		vMock.On("Pagination", "0", "").Return(0, 0, errors.New("page must be greater than 0"))
+
+		h := NewAlertHandler(vMock, sMock, lMock)
+
+		req, _ := http.NewRequest(http.MethodGet, "/alerts/history?page=0", nil)
+		resp := httptest.NewRecorder()
+
+		h.GetAlertHistory(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		vMock.AssertExpectations(t)
+		lMock.AssertExpectations(t)
+		cMock.AssertExpectations(t)
+	})
+
+	t.Run("should return StatusOK with the triggered alerts", func(t *testing.T) {
+		sMock := mocks.NewAlertServiceMock()
+		vMock := mocks.NewValidateMock()
+		lMock := mocks.NewLogMock()
+
+		triggeredAt := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+		lMock.On("Info", mock.Anything).Twice()
+		vMock.On("Pagination", "2", "5").Return(2, 5, nil)
+		sMock.On("GetAlertHistory", mock.Anything, 2, 5).Return(dtos.ResponsePaginatedAlertEvents{
+			Events: []dtos.ResponseAlertEvent{
+				{ID: 9, AlertID: 1, CardID: 3, Name: "Lightning Bolt", Set: "Alpha", Kind: "percentage", Direction: "up",
+					Threshold: 10, OldPrice: 100, NewPrice: 120, TriggeredAt: triggeredAt},
+			},
+			Page: 2, Limit: 5, Total: 6, TotalPages: 2,
+		}, nil)
+
+		h := NewAlertHandler(vMock, sMock, lMock)
+
+		req, _ := http.NewRequest(http.MethodGet, "/alerts/history?page=2&limit=5", nil)
+		resp := httptest.NewRecorder()
+
+		h.GetAlertHistory(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"triggered_at":"2024-05-02T03:00:00Z"`)
+		sMock.AssertExpectations(t)
+		vMock.AssertExpectations(t)
+		lMock.AssertExpectations(t)
+	})
+}
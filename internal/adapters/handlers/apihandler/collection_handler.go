@@ -0,0 +1,363 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type collectionHandler struct {
+	validator         validate
+	CollectionService ports.CollectionService
+	log               logrus.Logger
+}
+
+func NewCollectionHandler(v validate, cs ports.CollectionService, log logrus.Logger) *collectionHandler {
+	return &collectionHandler{
+		validator:         v,
+		CollectionService: cs,
+		log:               log,
+	}
+}
+
+func (h *collectionHandler) InsertCollection(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert collection")
+
+	collection := dtos.RequestCollection{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert collection")
+		http.Error(w, "failed to insert collection", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &collection)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert collection")
+		http.Error(w, "failed to insert collection, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.Collection(collection)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert collection")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CollectionService.InsertCollection(r.Context(), collection)
+	if errors.Is(err, domain.ErrCollectionAlreadyExists{}) {
+		h.log.WithError(err).Warn("failed to insert collection")
+		http.Error(w, domain.ErrCollectionAlreadyExists{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert collection")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("collection inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get collections")
+
+	response, err := h.CollectionService.GetCollections(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get collections")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("collections retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) GetCollectionByID(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get collection by id")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get collection by id")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CollectionService.GetCollectionByID(r.Context(), id)
+	if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to get collection by id")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to get collection by id")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("collection retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler update collection")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to update collection")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collection := dtos.RequestCollection{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on update collection")
+		http.Error(w, "failed to update collection", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &collection)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on update collection")
+		http.Error(w, "failed to update collection, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.UpdateCollection(collection)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to update collection")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collection.ID = id
+
+	response, err := h.CollectionService.UpdateCollection(r.Context(), collection)
+	if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to update collection")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCollectionAlreadyExists{}) {
+		h.log.WithError(err).Warn("failed to update collection")
+		http.Error(w, domain.ErrCollectionAlreadyExists{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to update collection")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("collection updated")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete collection")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete collection")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.CollectionService.DeleteCollection(r.Context(), id)
+	if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete collection")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCollectionNotEmpty{}) {
+		h.log.WithError(err).Warn("failed to delete collection")
+		http.Error(w, domain.ErrCollectionNotEmpty{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrDefaultCollection{}) {
+		h.log.WithError(err).Warn("failed to delete collection")
+		http.Error(w, domain.ErrDefaultCollection{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete collection")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("collection deleted")
+	}
+}
+
+// RequireCollectionRole only calls next when the user has at least the given
+// role on the collection of a /collection/{id}/... route.
+func (h *collectionHandler) RequireCollectionRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) > 3 {
+			parts = parts[:3]
+		}
+
+		id, err := h.validator.CardID(parts)
+		if err != nil {
+			h.log.WithError(err).Warn("failed to check collection role")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		collectionID, err := h.validator.CollectionID(id)
+		if err != nil {
+			h.log.WithError(err).Warn("failed to check collection role")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		userRole, err := h.CollectionService.GetRole(r.Context(), collectionID)
+		h.requireRole(w, r, role, userRole, err, next)
+	}
+}
+
+// RequireCardRole only calls next when the user has at least the given role on
+// the collection that holds the card of a /card/{id} route.
+func (h *collectionHandler) RequireCardRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/sell"), "/")
+		id, err := h.validator.CardID(parts)
+		if err != nil {
+			h.log.WithError(err).Warn("failed to check card role")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		userRole, err := h.CollectionService.GetCardRole(r.Context(), id)
+		h.requireRole(w, r, role, userRole, err, next)
+	}
+}
+
+// RequireImportRole only calls next when the user has at least the given role
+// on the collection named by the collection query parameter, which defaults to
+// the user's default collection.
+func (h *collectionHandler) RequireImportRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		collectionID, err := h.validator.CollectionID(r.URL.Query().Get("collection"))
+		if err != nil {
+			h.log.WithError(err).Warn("failed to validate collection parameter")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		userRole, err := h.CollectionService.GetRole(r.Context(), collectionID)
+		h.requireRole(w, r, role, userRole, err, next)
+	}
+}
+
+func (h *collectionHandler) requireRole(w http.ResponseWriter, r *http.Request, required, role domain.Role, err error, next http.HandlerFunc) {
+	if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to check role")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCardNotFound{}) {
+		h.log.WithError(err).Warn("failed to check role")
+		http.Error(w, domain.ErrCardNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to check role")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else if !role.Includes(required) {
+		h.log.WithError(domain.ErrForbidden{}).Warn("failed to check role")
+		encodeForbidden(w, required)
+	} else {
+		next(w, r)
+	}
+}
+
+func (h *collectionHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get members")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/members"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get members")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CollectionService.GetMembers(r.Context(), id)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get members")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("members retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) InsertMember(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert member")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/members"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert member")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	member := dtos.RequestMember{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert member")
+		http.Error(w, "failed to insert member", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &member)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert member")
+		http.Error(w, "failed to insert member, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.Member(member)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert member")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	member.CollectionID = id
+
+	response, err := h.CollectionService.InsertMember(r.Context(), member)
+	if errors.Is(err, domain.ErrUserNotFound{}) {
+		h.log.WithError(err).Warn("failed to insert member")
+		http.Error(w, domain.ErrUserNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCollectionOwner{}) {
+		h.log.WithError(err).Warn("failed to insert member")
+		http.Error(w, domain.ErrCollectionOwner{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert member")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("member inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *collectionHandler) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete member")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, userID, err := h.validator.MemberID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete member")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.CollectionService.DeleteMember(r.Context(), id, userID)
+	if errors.Is(err, domain.ErrMemberNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete member")
+		http.Error(w, domain.ErrMemberNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCollectionOwner{}) {
+		h.log.WithError(err).Warn("failed to delete member")
+		http.Error(w, domain.ErrCollectionOwner{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete member")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("member deleted")
+	}
+}
@@ -0,0 +1,565 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewCollectionHandler(t *testing.T) {
+	sMock := mocks.NewCollectionServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	h := NewCollectionHandler(vMock, sMock, lMock)
+
+	assert.NotNil(t, h)
+}
+
+func Test_InsertCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.CollectionServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when unable to unmarshal request body",
+			reqBody: []byte("{invalid json}"),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when validation fails",
+			reqBody: []byte(`{"description": "no name"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("Collection", mock.Anything).Return(errors.New("name is required"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when collection already exists",
+			reqBody: []byte(`{"name": "Trade Binder"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("Collection", mock.Anything).Return(nil)
+				sMock.On("InsertCollection", mock.Anything, mock.Anything).
+					Return(dtos.ResponseCollection{}, fmt.Errorf("service failed to insert collection: %w", domain.ErrCollectionAlreadyExists{}))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when insert is successful",
+			reqBody: []byte(`{"name": "Trade Binder"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("Collection", mock.Anything).Return(nil)
+				sMock.On("InsertCollection", mock.Anything, dtos.RequestCollection{Name: "Trade Binder"}).
+					Return(dtos.ResponseCollection{ID: 2, Name: "Trade Binder"}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/collection", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertCollection(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetCollections(t *testing.T) {
+	sMock := mocks.NewCollectionServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lMock.On("Info", mock.Anything).Twice()
+	sMock.On("GetCollections", mock.Anything).Return([]dtos.ResponseCollection{{ID: 1, Name: "Default", IsDefault: true, Role: "owner", TotalCards: 4}}, nil)
+
+	h := NewCollectionHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/collections", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetCollections(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"id": 1, "name": "Default", "description": "", "is_default": true, "role": "owner", "total_cards": 4}]`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_UpdateCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.CollectionServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when validation fails",
+			url:     "/collection/2",
+			reqBody: []byte(`{}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("2", nil)
+				vMock.On("UpdateCollection", mock.Anything).Return(errors.New("name or description is required"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when collection is not found",
+			url:     "/collection/9",
+			reqBody: []byte(`{"name": "Commander"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("9", nil)
+				vMock.On("UpdateCollection", mock.Anything).Return(nil)
+				sMock.On("UpdateCollection", mock.Anything, dtos.RequestCollection{ID: "9", Name: "Commander"}).
+					Return(dtos.ResponseCollection{}, domain.ErrCollectionNotFound{})
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when update is successful",
+			url:     "/collection/2",
+			reqBody: []byte(`{"name": "Commander"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("CardID", mock.Anything).Return("2", nil)
+				vMock.On("UpdateCollection", mock.Anything).Return(nil)
+				sMock.On("UpdateCollection", mock.Anything, dtos.RequestCollection{ID: "2", Name: "Commander"}).
+					Return(dtos.ResponseCollection{ID: 2, Name: "Commander"}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPatch, tt.url, bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.UpdateCollection(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeleteCollection(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when collection is deleted", url: "/collection/2", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when collection still has cards", url: "/collection/2",
+			serviceErr: fmt.Errorf("service failed to delete collection: %w", domain.ErrCollectionNotEmpty{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when deleting the default collection", url: "/collection/1",
+			serviceErr: domain.ErrDefaultCollection{}, wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails", url: "/collection/2",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			id := tt.url[len("/collection/"):]
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock)
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", mock.Anything).Return(id, nil)
+			sMock.On("DeleteCollection", mock.Anything, id).Return(tt.serviceErr)
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, tt.url, nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteCollection(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_RequireCollectionRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		required   domain.Role
+		role       domain.Role
+		serviceErr error
+		wantCode   int
+		wantBody   string
+	}{
+		{name: "should call next when the role is enough", required: domain.RoleReader, role: domain.RoleEditor, wantCode: http.StatusNoContent},
+		{name: "should return StatusForbidden with the required role", required: domain.RoleOwner, role: domain.RoleEditor,
+			wantCode: http.StatusForbidden, wantBody: `{"error": "not enough permissions on the collection", "required_role": "owner"}`},
+		{name: "should return StatusBadRequest when the user is not a member", required: domain.RoleReader,
+			serviceErr: fmt.Errorf("service failed to get role: %w", domain.ErrCollectionNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails", required: domain.RoleReader,
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			vMock.On("CardID", []string{"", "collection", "2"}).Return("2", nil)
+			vMock.On("CollectionID", "2").Return(int64(2), nil)
+			sMock.On("GetRole", mock.Anything, int64(2)).Return(tt.role, tt.serviceErr)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			next := func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/collection/2/members", nil)
+			resp := httptest.NewRecorder()
+
+			h.RequireCollectionRole(tt.required, next)(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, resp.Body.String())
+			}
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_RequireCardRole(t *testing.T) {
+	sMock := mocks.NewCollectionServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+	cMock := mocks.NewCustomMock()
+
+	vMock.On("CardID", []string{"", "card", "9"}).Return("9", nil)
+	sMock.On("GetCardRole", mock.Anything, "9").Return(domain.RoleReader, nil)
+	lMock.On("WithError", mock.Anything).Return(cMock).Once()
+	cMock.On("Warn", mock.Anything).Once()
+
+	h := NewCollectionHandler(vMock, sMock, lMock)
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next should not be called")
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "/card/9/sell", nil)
+	resp := httptest.NewRecorder()
+
+	h.RequireCardRole(domain.RoleEditor, next)(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.JSONEq(t, `{"error": "not enough permissions on the collection", "required_role": "editor"}`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	vMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+	cMock.AssertExpectations(t)
+}
+
+func Test_RequireImportRole(t *testing.T) {
+	sMock := mocks.NewCollectionServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	vMock.On("CollectionID", "3").Return(int64(3), nil)
+	sMock.On("GetRole", mock.Anything, int64(3)).Return(domain.RoleEditor, nil)
+
+	h := NewCollectionHandler(vMock, sMock, lMock)
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "/cards?collection=3", nil)
+	resp := httptest.NewRecorder()
+
+	h.RequireImportRole(domain.RoleEditor, next)(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	sMock.AssertExpectations(t)
+	vMock.AssertExpectations(t)
+}
+
+func Test_GetMembers(t *testing.T) {
+	sMock := mocks.NewCollectionServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lMock.On("Info", mock.Anything).Twice()
+	vMock.On("CardID", []string{"", "collection", "2"}).Return("2", nil)
+	sMock.On("GetMembers", mock.Anything, "2").Return([]dtos.ResponseMember{
+		{UserID: 7, Name: "Jace", Email: "jace@example.com", Role: "owner"},
+		{UserID: 8, Name: "Chandra", Email: "chandra@example.com", Role: "reader"},
+	}, nil)
+
+	h := NewCollectionHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/collection/2/members", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetMembers(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[
+		{"user_id": 7, "name": "Jace", "email": "jace@example.com", "role": "owner"},
+		{"user_id": 8, "name": "Chandra", "email": "chandra@example.com", "role": "reader"}
+	]`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	vMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_InsertMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.CollectionServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when validation fails",
+			reqBody: []byte(`{"email": "chandra@example.com", "role": "owner"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("2", nil)
+				vMock.On("Member", mock.Anything).Return(errors.New("role must be reader or editor"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when user is not found",
+			reqBody: []byte(`{"email": "nobody@example.com", "role": "reader"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("2", nil)
+				vMock.On("Member", mock.Anything).Return(nil)
+				sMock.On("InsertMember", mock.Anything, mock.Anything).
+					Return(dtos.ResponseMember{}, fmt.Errorf("service failed to insert member: %w", domain.ErrUserNotFound{}))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when member is inserted",
+			reqBody: []byte(`{"email": "chandra@example.com", "role": "editor"}`),
+			mockSetup: func(
+				sMock *mocks.CollectionServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("CardID", mock.Anything).Return("2", nil)
+				vMock.On("Member", mock.Anything).Return(nil)
+				sMock.On("InsertMember", mock.Anything, dtos.RequestMember{CollectionID: "2", Email: "chandra@example.com", Role: "editor"}).
+					Return(dtos.ResponseMember{UserID: 8, Name: "Chandra", Email: "chandra@example.com", Role: "editor"}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/collection/2/members", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertMember(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeleteMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when member is removed", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when removing the owner",
+			serviceErr: fmt.Errorf("service failed to delete member: %w", domain.ErrCollectionOwner{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when member is not found",
+			serviceErr: domain.ErrMemberNotFound{}, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCollectionServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			vMock.On("MemberID", mock.Anything).Return("2", "8", nil)
+			sMock.On("DeleteMember", mock.Anything, "2", "8").Return(tt.serviceErr)
+
+			h := NewCollectionHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/collection/2/members/8", nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteMember(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
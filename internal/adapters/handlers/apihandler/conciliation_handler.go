@@ -0,0 +1,81 @@
+package apihandler
+
+import (
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type conciliationHandler struct {
+	validator           validate
+	ConciliationService ports.ConciliationService
+	log                 logrus.Logger
+}
+
+func NewConciliationHandler(v validate, cs ports.ConciliationService, log logrus.Logger) *conciliationHandler {
+	return &conciliationHandler{
+		validator:           v,
+		ConciliationService: cs,
+		log:                 log,
+	}
+}
+
+func (h *conciliationHandler) GetConciliations(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get conciliations")
+
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.ConciliationService.GetConciliations(r.Context(), page, limit)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get conciliations")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("conciliations retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *conciliationHandler) GetConciliationFailures(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get conciliation failures")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/failures"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get conciliation failures")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.ConciliationService.GetConciliationFailures(r.Context(), id, page, limit)
+	if errors.Is(err, domain.ErrConciliationRunNotFound{}) {
+		h.log.WithError(err).Warn("failed to get conciliation failures")
+		http.Error(w, domain.ErrConciliationRunNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to get conciliation failures")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("conciliation failures retrieved")
+		encondeResponse(w, response)
+	}
+}
@@ -0,0 +1,119 @@
+package apihandler
+
+import (
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewConciliationHandler(t *testing.T) {
+	h := NewConciliationHandler(mocks.NewValidateMock(), mocks.NewConciliationServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_GetConciliations(t *testing.T) {
+	sMock := mocks.NewConciliationServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	startedAt := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(time.Hour)
+	lMock.On("Info", mock.Anything).Twice()
+	vMock.On("Pagination", "", "").Return(1, 20, nil)
+	sMock.On("GetConciliations", mock.Anything, 1, 20).Return(dtos.ResponsePaginatedConciliations{
+		Conciliations: []dtos.ResponseConciliation{{ID: 3, StartedAt: startedAt, FinishedAt: &finishedAt, Status: "finished",
+			Processed: 10, Failed: 1, ErrorSummary: "timeout: 1"}},
+		Page: 1, Limit: 20, Total: 1, TotalPages: 1,
+	}, nil)
+
+	h := NewConciliationHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/conciliations", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetConciliations(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"conciliations": [{"id": 3, "started_at": "2024-05-01T03:00:00Z", "finished_at": "2024-05-01T04:00:00Z",
+		"status": "finished", "exchange_rate": null, "processed": 10, "failed": 1, "skipped": 0, "retries": 0,
+		"error_summary": "timeout: 1"}], "page": 1, "limit": 20, "total": 1, "total_pages": 1}`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_GetConciliationFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK with the failures", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when run is not found",
+			serviceErr: fmt.Errorf("service failed to get conciliation run: %w", domain.ErrConciliationRunNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewConciliationServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "conciliations", "3"}).Return("3", nil)
+			vMock.On("Pagination", "1", "20").Return(1, 20, nil)
+			sMock.On("GetConciliationFailures", mock.Anything, "3", 1, 20).Return(dtos.ResponsePaginatedConciliationFailures{
+				Failures: []dtos.ResponseConciliationFailure{{ID: 5, CardID: 12, Name: "Black Lotus", Reason: "timeout"}},
+				Page:     1, Limit: 20, Total: 1, TotalPages: 1,
+			}, tt.serviceErr)
+
+			h := NewConciliationHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodGet, "/conciliations/3/failures?page=1&limit=20", nil)
+			resp := httptest.NewRecorder()
+
+			h.GetConciliationFailures(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetConciliationFailures_InvalidID(t *testing.T) {
+	sMock := mocks.NewConciliationServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+	cMock := mocks.NewCustomMock()
+
+	lMock.On("Info", mock.Anything)
+	lMock.On("WithError", mock.Anything).Return(cMock)
+	cMock.On("Warn", mock.Anything)
+	vMock.On("CardID", []string{"", "conciliations", "abc"}).Return("", errors.New("invalid id"))
+
+	h := NewConciliationHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/conciliations/abc/failures", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetConciliationFailures(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	sMock.AssertNotCalled(t, "GetConciliationFailures", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
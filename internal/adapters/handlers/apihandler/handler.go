@@ -1,23 +1,44 @@
 package apihandler
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
 	"mtg-report/internal/core/ports"
 	"mtg-report/internal/sources/logger/logrus"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
 type validate interface {
 	Card(dtos.RequestInsertCard) error
 	CardID(parts []string) (string, error)
-	Filters(setName, name, collector_number string) map[string]string
-	CardName(card dtos.RequestUpdateCard) error
+	Filters(setName, name, collector_number, collection string) map[string]string
+	UpdateCard(card dtos.RequestUpdateCard) error
 	Pagination(pageStr, limitStr string) (int, int, error)
+	Sale(sale dtos.RequestSellCard) error
+	Year(yearStr string) (int, error)
+	Currency(currency string) (string, error)
+	Resolution(resolution string) (domain.PriceResolution, error)
+	QuarantineStatus(status string) (domain.QuarantineStatus, error)
+	CollectionID(collection string) (int64, error)
+	Collection(collection dtos.RequestCollection) error
+	UpdateCollection(collection dtos.RequestCollection) error
+	User(user dtos.RequestUser) error
+	Member(member dtos.RequestMember) error
+	MemberID(parts []string) (string, string, error)
+	ShareID(parts []string) (string, string, error)
+	ShareToken(parts []string) (string, error)
+	WishlistItem(item dtos.RequestWishlistItem) error
+	UpdateWishlistItem(item dtos.RequestWishlistItem) error
+	Alert(alert dtos.RequestAlert) error
+	Webhook(webhook dtos.RequestWebhook) error
 }
 
 type apiHandler struct {
@@ -61,12 +82,15 @@ func (h *apiHandler) InsertCard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response, err := h.CardService.InsertCard(r.Context(), card)
-	if errors.Is(err, domain.ErrCardAlreadyExists{}) {
-		h.log.WithError(err).Warn("failed to insert card")
-		http.Error(w, domain.ErrCardAlreadyExists{}.Error(), http.StatusBadRequest)
-	} else if errors.Is(err, domain.ErrInvalidSetName{}) {
+	if errors.Is(err, domain.ErrInvalidSetName{}) {
 		h.log.WithError(err).Warn("failed to insert card")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+	} else if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to insert card")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrForbidden{}) {
+		h.log.WithError(err).Warn("failed to insert card")
+		encodeForbidden(w, domain.RoleEditor)
 	} else if err != nil {
 		h.log.WithError(err).Error("failed to insert card")
 		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
@@ -88,7 +112,14 @@ func (h *apiHandler) InsertCards(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	inserted, failed := h.CardService.InsertCards(r.Context(), file)
+	collectionID, err := h.validator.CollectionID(r.URL.Query().Get("collection"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate collection parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inserted, failed := h.CardService.InsertCards(r.Context(), file, collectionID)
 
 	response := dtos.ResponseConciliateJob{
 		Processed:    inserted,
@@ -129,10 +160,11 @@ func (h *apiHandler) GetCards(w http.ResponseWriter, r *http.Request) {
 	setName := r.URL.Query().Get("set_name")
 	name := r.URL.Query().Get("name")
 	collector_number := r.URL.Query().Get("collector_number")
+	collection := r.URL.Query().Get("collection")
 	pageStr := r.URL.Query().Get("page")
 	limitStr := r.URL.Query().Get("limit")
 
-	filters := h.validator.Filters(setName, name, collector_number)
+	filters := h.validator.Filters(setName, name, collector_number, collection)
 
 	page, limit, err := h.validator.Pagination(pageStr, limitStr)
 	if err != nil {
@@ -141,8 +173,18 @@ func (h *apiHandler) GetCards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.CardService.GetCardsPaginated(r.Context(), filters, page, limit)
+	currency, err := h.validator.Currency(r.URL.Query().Get("currency"))
 	if err != nil {
+		h.log.WithError(err).Warn("failed to validate currency parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CardService.GetCardsPaginated(r.Context(), filters, currency, page, limit)
+	if errors.Is(err, domain.ErrExchangeRateNotFound{}) {
+		h.log.WithError(err).Warn("failed to get cards paginated")
+		http.Error(w, domain.ErrExchangeRateNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
 		h.log.WithError(err).Error("failed to get cards paginated")
 		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
 	} else {
@@ -192,10 +234,27 @@ func (h *apiHandler) GetCardHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.CardService.GetCardHistoryPaginated(r.Context(), id, page, limit)
+	currency, err := h.validator.Currency(r.URL.Query().Get("currency"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate currency parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resolution, err := h.validator.Resolution(r.URL.Query().Get("resolution"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate resolution parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CardService.GetCardHistoryPaginated(r.Context(), id, currency, resolution, page, limit)
 	if errors.Is(err, domain.ErrCardNotFound{}) {
 		h.log.WithError(err).Warn("failed to get card history")
 		http.Error(w, domain.ErrCardNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrExchangeRateNotFound{}) {
+		h.log.WithError(err).Warn("failed to get card history")
+		http.Error(w, domain.ErrExchangeRateNotFound{}.Error(), http.StatusBadRequest)
 	} else if err != nil {
 		h.log.WithError(err).Error("failed to get card history")
 		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
@@ -232,7 +291,7 @@ func (h *apiHandler) UpdateCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.validator.CardName(card)
+	err = h.validator.UpdateCard(card)
 	if err != nil {
 		h.log.WithError(err).Warn("failed to update card")
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -245,6 +304,15 @@ func (h *apiHandler) UpdateCard(w http.ResponseWriter, r *http.Request) {
 	if errors.Is(err, domain.ErrCardNotFound{}) {
 		h.log.WithError(err).Warn("failed to update card")
 		http.Error(w, domain.ErrCardNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCardAlreadyExists{}) {
+		h.log.WithError(err).Warn("failed to update card")
+		http.Error(w, "card already exists in the target collection", http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrCollectionNotFound{}) {
+		h.log.WithError(err).Warn("failed to update card")
+		http.Error(w, domain.ErrCollectionNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrForbidden{}) {
+		h.log.WithError(err).Warn("failed to update card")
+		encodeForbidden(w, domain.RoleEditor)
 	} else if err != nil {
 		h.log.WithError(err).Error("failed to update card")
 		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
@@ -257,8 +325,25 @@ func (h *apiHandler) UpdateCard(w http.ResponseWriter, r *http.Request) {
 func (h *apiHandler) GetCollectionStats(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("handler get collection stats")
 
-	response, err := h.CardService.GetCollectionStats(r.Context())
+	collectionID, err := h.validator.CollectionID(r.URL.Query().Get("collection"))
 	if err != nil {
+		h.log.WithError(err).Warn("failed to validate collection parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	currency, err := h.validator.Currency(r.URL.Query().Get("currency"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate currency parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CardService.GetCollectionStats(r.Context(), collectionID, currency)
+	if errors.Is(err, domain.ErrExchangeRateNotFound{}) {
+		h.log.WithError(err).Warn("failed to get collection stats")
+		http.Error(w, domain.ErrExchangeRateNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
 		h.log.WithError(err).Error("failed to get collection stats")
 		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
 	} else {
@@ -267,6 +352,128 @@ func (h *apiHandler) GetCollectionStats(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (h *apiHandler) SellCard(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler sell card")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/sell"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to sell card")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sale := dtos.RequestSellCard{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on sell card")
+		http.Error(w, "failed to sell card", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &sale)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on sell card")
+		http.Error(w, "failed to sell card, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.Sale(sale)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to sell card")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sale.ID = id
+
+	response, err := h.CardService.SellCard(r.Context(), sale)
+	if errors.Is(err, domain.ErrCardNotFound{}) {
+		h.log.WithError(err).Warn("failed to sell card")
+		http.Error(w, domain.ErrCardNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrNotEnoughCopies{}) {
+		h.log.WithError(err).Warn("failed to sell card")
+		http.Error(w, domain.ErrNotEnoughCopies{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to sell card")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("card sold")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *apiHandler) GetRealizedGains(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get realized gains")
+
+	year, err := h.validator.Year(r.URL.Query().Get("year"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate year parameter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.CardService.GetRealizedGains(r.Context(), year)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get realized gains")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Info("realized gains retrieved")
+
+	if r.URL.Query().Get("format") == "csv" {
+		encodeRealizedGainsCSV(w, response)
+		return
+	}
+
+	encondeResponse(w, response)
+}
+
+func encodeRealizedGainsCSV(w http.ResponseWriter, response dtos.ResponseRealizedGains) {
+	records := [][]string{{"month", "copies_sold", "gross_proceeds", "fees", "cost_basis", "realized_gain", "unknown_cost_copies", "unknown_cost_proceeds"}}
+
+	for _, month := range response.Months {
+		records = append(records, []string{
+			month.Month,
+			strconv.FormatInt(month.CopiesSold, 10),
+			formatAmount(month.GrossProceeds),
+			formatAmount(month.Fees),
+			formatAmount(month.CostBasis),
+			formatAmount(month.RealizedGain),
+			strconv.FormatInt(month.UnknownCostCopies, 10),
+			formatAmount(month.UnknownCostProceeds),
+		})
+	}
+
+	records = append(records, []string{
+		"total",
+		"",
+		formatAmount(response.TotalGrossProceeds),
+		formatAmount(response.TotalFees),
+		formatAmount(response.TotalCostBasis),
+		formatAmount(response.TotalRealizedGain),
+		strconv.FormatInt(response.TotalUnknownCostCopies, 10),
+		formatAmount(response.TotalUnknownCostProceeds),
+	})
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=realized-gains-%d.csv", response.Year))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
 func encondeResponse(w http.ResponseWriter, response interface{}) {
 	jsonResponse, err := json.Marshal(response)
 	if err != nil {
@@ -278,3 +485,20 @@ func encondeResponse(w http.ResponseWriter, response interface{}) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResponse)
 }
+
+// encodeForbidden answers with the role the user would need, so clients can
+// tell a missing permission apart from a missing resource.
+func encodeForbidden(w http.ResponseWriter, required domain.Role) {
+	jsonResponse, err := json.Marshal(dtos.ResponseError{
+		Error:        domain.ErrForbidden{}.Error(),
+		RequiredRole: string(required),
+	})
+	if err != nil {
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	w.Write(jsonResponse)
+}
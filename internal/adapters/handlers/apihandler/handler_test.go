@@ -98,29 +98,10 @@ func Test_InsertCard(t *testing.T) {
 			wantErr:  true,
 			wantCode: http.StatusBadRequest,
 		},
-		{
-			name:      "should return StatusBadRequest when card already exists",
-			reqMethod: http.MethodPost,
-			reqBody:   []byte(`{"name": "Card1", "set_name": "M21", "collector_number": "123", "foil": true}`),
-			mockSetup: func(
-				sMock *mocks.CardServiceMock,
-				vMock *mocks.ValidateMock,
-				lMock *mocks.LogMock,
-				cMock *mocks.CustomMock,
-			) {
-				lMock.On("Info", mock.Anything).Once()
-				lMock.On("WithError", mock.Anything).Return(cMock).Once()
-				cMock.On("Warn", mock.Anything).Once()
-				vMock.On("Card", mock.Anything).Return(nil)
-				sMock.On("InsertCard", mock.Anything, mock.Anything).Return(dtos.ResponseInsertCard{}, domain.ErrCardAlreadyExists{})
-			},
-			wantErr:  true,
-			wantCode: http.StatusBadRequest,
-		},
 		{
 			name:      "should return StatusOK when insert is successful",
 			reqMethod: http.MethodPost,
-			reqBody:   []byte(`{"name": "Card1", "set_name": "M21", "collector_number": "123", "foil": true}`),
+			reqBody:   []byte(`{"name": "Card1", "set_name": "M21", "collector_number": "123", "finish": "foil"}`),
 			mockSetup: func(
 				sMock *mocks.CardServiceMock,
 				vMock *mocks.ValidateMock,
@@ -200,7 +181,7 @@ func Test_InsertCards(t *testing.T) {
 				cMock *mocks.CustomMock,
 			) {
 				lMock.On("Info", mock.Anything).Twice()
-				sMock.On("InsertCards", mock.Anything, mock.Anything).Return(int64(1), int64(0))
+				sMock.On("InsertCards", mock.Anything, mock.Anything, int64(0)).Return(int64(1), int64(0))
 			},
 			wantCode: http.StatusOK,
 		},
@@ -230,6 +211,7 @@ func Test_InsertCards(t *testing.T) {
 			cMock := mocks.NewCustomMock()
 
 			tt.mockSetup(sMock, lMock, cMock)
+			vMock.On("CollectionID", "").Return(int64(0), nil)
 
 			h := New(vMock, sMock, lMock)
 
@@ -361,7 +343,7 @@ func Test_GetCards(t *testing.T) {
 				lMock.On("Info", mock.Anything).Once()
 				lMock.On("WithError", mock.Anything).Return(cMock).Once()
 				cMock.On("Warn", mock.Anything).Once()
-				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
+				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
 				vMock.On("Pagination", "invalid", "10").Return(0, 0, errors.New("invalid page"))
 			},
 			wantCode: http.StatusBadRequest,
@@ -376,12 +358,50 @@ func Test_GetCards(t *testing.T) {
 				cMock *mocks.CustomMock,
 			) {
 				lMock.On("Info", mock.Anything).Twice()
-				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
+				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
 				vMock.On("Pagination", "1", "10").Return(1, 10, nil)
-				sMock.On("GetCardsPaginated", mock.Anything, mock.Anything, 1, 10).Return(dtos.ResponsePaginatedCards{}, nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				sMock.On("GetCardsPaginated", mock.Anything, mock.Anything, "BRL", 1, 10).Return(dtos.ResponsePaginatedCards{}, nil)
 			},
 			wantCode: http.StatusOK,
 		},
+		{
+			name: "should return StatusBadRequest when currency is invalid",
+			url:  "/cards?currency=JPY",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
+				vMock.On("Pagination", "", "").Return(1, 10, nil)
+				vMock.On("Currency", "JPY").Return("", errors.New("currency must be one of BRL, USD or EUR"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return StatusBadRequest when no rate is stored for the currency",
+			url:  "/cards?currency=EUR",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("Filters", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{})
+				vMock.On("Pagination", "", "").Return(1, 10, nil)
+				vMock.On("Currency", "EUR").Return("EUR", nil)
+				sMock.On("GetCardsPaginated", mock.Anything, mock.Anything, "EUR", 1, 10).Return(dtos.ResponsePaginatedCards{}, domain.ErrExchangeRateNotFound{})
+			},
+			wantCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -538,7 +558,46 @@ func Test_GetCardHistory(t *testing.T) {
 				lMock.On("Info", mock.Anything).Twice()
 				vMock.On("CardID", mock.Anything).Return("1", nil)
 				vMock.On("Pagination", "1", "10").Return(1, 10, nil)
-				sMock.On("GetCardHistoryPaginated", mock.Anything, "1", 1, 10).Return(dtos.ResponsePaginatedCards{}, nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				vMock.On("Resolution", "").Return(domain.PriceResolutionDaily, nil)
+				sMock.On("GetCardHistoryPaginated", mock.Anything, "1", "BRL", domain.PriceResolutionDaily, 1, 10).Return(dtos.ResponsePaginatedCards{}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "should return StatusBadRequest when resolution validation fails",
+			url:  "/card/1/history?resolution=yearly",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("1", nil)
+				vMock.On("Pagination", "", "").Return(1, 10, nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				vMock.On("Resolution", "yearly").Return(domain.PriceResolution(""), errors.New("resolution must be one of daily, weekly or monthly"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return StatusOK when history is retrieved at a resolution",
+			url:  "/card/1/history?resolution=monthly",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("CardID", mock.Anything).Return("1", nil)
+				vMock.On("Pagination", "", "").Return(1, 10, nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				vMock.On("Resolution", "monthly").Return(domain.PriceResolutionMonthly, nil)
+				sMock.On("GetCardHistoryPaginated", mock.Anything, "1", "BRL", domain.PriceResolutionMonthly, 1, 10).Return(dtos.ResponsePaginatedCards{}, nil)
 			},
 			wantCode: http.StatusOK,
 		},
@@ -590,7 +649,9 @@ func Test_GetCollectionStats(t *testing.T) {
 				cMock *mocks.CustomMock,
 			) {
 				lMock.On("Info", mock.Anything).Twice()
-				sMock.On("GetCollectionStats", mock.Anything).Return(dtos.ResponseCollectionStats{
+				vMock.On("CollectionID", "").Return(int64(0), nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				sMock.On("GetCollectionStats", mock.Anything, int64(0), "BRL").Return(dtos.ResponseCollectionStats{
 					TotalCards: 100,
 					FoilCards:  25,
 					UniqueSets: 10,
@@ -610,10 +671,43 @@ func Test_GetCollectionStats(t *testing.T) {
 				lMock.On("Info", mock.Anything).Once()
 				lMock.On("WithError", mock.Anything).Return(cMock).Once()
 				cMock.On("Error", mock.Anything).Once()
-				sMock.On("GetCollectionStats", mock.Anything).Return(dtos.ResponseCollectionStats{}, errors.New("service error"))
+				vMock.On("CollectionID", "").Return(int64(0), nil)
+				vMock.On("Currency", "").Return("BRL", nil)
+				sMock.On("GetCollectionStats", mock.Anything, int64(0), "BRL").Return(dtos.ResponseCollectionStats{}, errors.New("service error"))
 			},
 			wantCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should return StatusBadRequest when currency is invalid",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CollectionID", "").Return(int64(0), nil)
+				vMock.On("Currency", "").Return("", errors.New("currency must be one of BRL, USD or EUR"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return StatusBadRequest when collection is invalid",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CollectionID", "").Return(int64(0), errors.New("invalid collection parameter"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -701,7 +795,7 @@ func Test_UpdateCard(t *testing.T) {
 			) {
 				lMock.On("Info", mock.Anything).Twice()
 				vMock.On("CardID", mock.Anything).Return("1", nil)
-				vMock.On("CardName", mock.Anything).Return(nil)
+				vMock.On("UpdateCard", mock.Anything).Return(nil)
 				sMock.On("UpdateCard", mock.Anything, mock.Anything).Return(dtos.ResponseInsertCard{ID: 1, Name: "Updated Card"}, nil)
 			},
 			wantCode: http.StatusOK,
@@ -744,6 +838,227 @@ func Test_UpdateCard(t *testing.T) {
 	}
 }
 
+func Test_SellCard(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.CardServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when sale validation fails",
+			url:     "/card/1/sell",
+			reqBody: []byte(`{"quantity": 1}`),
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", []string{"", "card", "1"}).Return("1", nil)
+				vMock.On("Sale", mock.Anything).Return(errors.New("sale_price is required"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when there are not enough copies",
+			url:     "/card/1/sell",
+			reqBody: []byte(`{"quantity": 5, "sale_price": 10}`),
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("CardID", mock.Anything).Return("1", nil)
+				vMock.On("Sale", mock.Anything).Return(nil)
+				sMock.On("SellCard", mock.Anything, mock.Anything).Return(dtos.ResponseSale{}, domain.ErrNotEnoughCopies{})
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when card is sold",
+			url:     "/card/1/sell",
+			reqBody: []byte(`{"quantity": 1, "sale_price": 10, "fees": 1.5, "sale_date": "2026-03-10"}`),
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("CardID", mock.Anything).Return("1", nil)
+				vMock.On("Sale", mock.Anything).Return(nil)
+				sMock.On("SellCard", mock.Anything, mock.MatchedBy(func(sale dtos.RequestSellCard) bool {
+					return sale.ID == "1" && *sale.SalePrice == 10 && sale.Fees == 1.5
+				})).Return(dtos.ResponseSale{ID: 1, CardID: 1, Quantity: 1}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCardServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := New(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.SellCard(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetRealizedGains(t *testing.T) {
+	gains := dtos.ResponseRealizedGains{
+		Year: 2026,
+		Months: []dtos.ResponseMonthlyRealizedGain{
+			{Month: "2026-01", CopiesSold: 2, GrossProceeds: 20, Fees: 2, CostBasis: 10, RealizedGain: 8},
+		},
+		TotalGrossProceeds: 20,
+		TotalFees:          2,
+		TotalCostBasis:     10,
+		TotalRealizedGain:  8,
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		mockSetup func(
+			sMock *mocks.CardServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "should return StatusBadRequest when year is invalid",
+			url:  "/reports/realized-gains?year=abc",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("Year", "abc").Return(0, errors.New("invalid year parameter"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return StatusInternalServerError when service fails",
+			url:  "/reports/realized-gains?year=2026",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Error", mock.Anything).Once()
+				vMock.On("Year", "2026").Return(2026, nil)
+				sMock.On("GetRealizedGains", mock.Anything, 2026).Return(dtos.ResponseRealizedGains{}, errors.New("service error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should return json when format is not set",
+			url:  "/reports/realized-gains?year=2026",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("Year", "2026").Return(2026, nil)
+				sMock.On("GetRealizedGains", mock.Anything, 2026).Return(gains, nil)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `"total_realized_gain":8`,
+		},
+		{
+			name: "should return csv when format is csv",
+			url:  "/reports/realized-gains?year=2026&format=csv",
+			mockSetup: func(
+				sMock *mocks.CardServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("Year", "2026").Return(2026, nil)
+				sMock.On("GetRealizedGains", mock.Anything, 2026).Return(gains, nil)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody:        "2026-01,2,20.00,2.00,10.00,8.00,0,0.00\ntotal,,20.00,2.00,10.00,8.00,0,0.00\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewCardServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := New(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp := httptest.NewRecorder()
+
+			h.GetRealizedGains(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, resp.Header().Get("Content-Type"))
+				assert.Contains(t, resp.Body.String(), tt.wantBody)
+			}
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
 func Test_encondeResponse(t *testing.T) {
 	tests := []struct {
 		name     string
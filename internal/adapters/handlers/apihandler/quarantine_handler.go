@@ -0,0 +1,98 @@
+package apihandler
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type quarantineHandler struct {
+	validator         validate
+	QuarantineService ports.QuarantineService
+	log               logrus.Logger
+}
+
+func NewQuarantineHandler(v validate, qs ports.QuarantineService, log logrus.Logger) *quarantineHandler {
+	return &quarantineHandler{
+		validator:         v,
+		QuarantineService: qs,
+		log:               log,
+	}
+}
+
+func (h *quarantineHandler) GetQuarantinedPrices(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get quarantined prices")
+
+	status, err := h.validator.QuarantineStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate quarantine status")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.QuarantineService.GetQuarantinedPrices(r.Context(), status, page, limit)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get quarantined prices")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("quarantined prices retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *quarantineHandler) AcceptQuarantinedPrice(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler accept quarantined price")
+
+	h.review(w, r, "accept", h.QuarantineService.AcceptQuarantinedPrice)
+}
+
+func (h *quarantineHandler) RejectQuarantinedPrice(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler reject quarantined price")
+
+	h.review(w, r, "reject", h.QuarantineService.RejectQuarantinedPrice)
+}
+
+// review handles both decisions on a quarantined price, which only differ by
+// the last segment of the path and the service call.
+func (h *quarantineHandler) review(w http.ResponseWriter, r *http.Request, decision string, settle func(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error)) {
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"+decision), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to " + decision + " quarantined price")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := settle(r.Context(), id)
+	if errors.Is(err, domain.ErrQuarantinedPriceNotFound{}) {
+		h.log.WithError(err).Warn("failed to " + decision + " quarantined price")
+		http.Error(w, domain.ErrQuarantinedPriceNotFound{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrQuarantinedPriceReviewed{}) {
+		h.log.WithError(err).Warn("failed to " + decision + " quarantined price")
+		http.Error(w, domain.ErrQuarantinedPriceReviewed{}.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, domain.ErrForbidden{}) {
+		h.log.WithError(err).Warn("failed to " + decision + " quarantined price")
+		encodeForbidden(w, domain.RoleEditor)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to " + decision + " quarantined price")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("quarantined price " + decision + "ed")
+		encondeResponse(w, response)
+	}
+}
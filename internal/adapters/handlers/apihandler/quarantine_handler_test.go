@@ -0,0 +1,163 @@
+package apihandler
+
+import (
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewQuarantineHandler(t *testing.T) {
+	h := NewQuarantineHandler(mocks.NewValidateMock(), mocks.NewQuarantineServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_GetQuarantinedPrices(t *testing.T) {
+	sMock := mocks.NewQuarantineServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	quotedAt := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	lMock.On("Info", mock.Anything).Twice()
+	vMock.On("QuarantineStatus", "").Return(domain.QuarantinePending, nil)
+	vMock.On("Pagination", "", "").Return(1, 20, nil)
+	sMock.On("GetQuarantinedPrices", mock.Anything, domain.QuarantinePending, 1, 20).Return(dtos.ResponsePaginatedQuarantinedPrices{
+		Prices: []dtos.ResponseQuarantinedPrice{{ID: 5, RunID: 3, CardID: 12, Name: "Black Lotus", SetName: "lea", CollectorNumber: "232",
+			LastPrice: 500, OldPrice: 10, PriceChange: 490, PriceSource: "scryfall_usd", QuotedAt: &quotedAt, Rule: "max_change", Status: "pending"}},
+		Page: 1, Limit: 20, Total: 1, TotalPages: 1,
+	}, nil)
+
+	h := NewQuarantineHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/quarantine", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetQuarantinedPrices(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"prices": [{"id": 5, "run_id": 3, "card_id": 12, "name": "Black Lotus", "set_name": "lea",
+		"collector_number": "232", "last_price": 500, "old_price": 10, "price_change": 490, "price_source": "scryfall_usd",
+		"quoted_at": "2024-05-01T03:00:00Z", "rule": "max_change", "status": "pending"}],
+		"page": 1, "limit": 20, "total": 1, "total_pages": 1}`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_GetQuarantinedPrices_InvalidStatus(t *testing.T) {
+	sMock := mocks.NewQuarantineServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+	cMock := mocks.NewCustomMock()
+
+	lMock.On("Info", mock.Anything)
+	lMock.On("WithError", mock.Anything).Return(cMock)
+	cMock.On("Warn", mock.Anything)
+	vMock.On("QuarantineStatus", "deleted").Return(domain.QuarantineStatus(""), errors.New("status must be one of pending, accepted, rejected or superseded"))
+
+	h := NewQuarantineHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/quarantine?status=deleted", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetQuarantinedPrices(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	sMock.AssertNotCalled(t, "GetQuarantinedPrices", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_AcceptQuarantinedPrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK with the accepted price", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when price is not found",
+			serviceErr: fmt.Errorf("service failed to get quarantined price: %w", domain.ErrQuarantinedPriceNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when price was already reviewed",
+			serviceErr: domain.ErrQuarantinedPriceReviewed{}, wantCode: http.StatusBadRequest},
+		{name: "should return StatusForbidden when user is not an editor",
+			serviceErr: domain.ErrForbidden{}, wantCode: http.StatusForbidden},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewQuarantineServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "quarantine", "5"}).Return("5", nil)
+			sMock.On("AcceptQuarantinedPrice", mock.Anything, "5").Return(dtos.ResponseQuarantinedPrice{ID: 5, Status: "accepted"}, tt.serviceErr)
+
+			h := NewQuarantineHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/quarantine/5/accept", nil)
+			resp := httptest.NewRecorder()
+
+			h.AcceptQuarantinedPrice(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_RejectQuarantinedPrice(t *testing.T) {
+	sMock := mocks.NewQuarantineServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lMock.On("Info", mock.Anything)
+	vMock.On("CardID", []string{"", "quarantine", "5"}).Return("5", nil)
+	sMock.On("RejectQuarantinedPrice", mock.Anything, "5").Return(dtos.ResponseQuarantinedPrice{ID: 5, Status: "rejected"}, nil)
+
+	h := NewQuarantineHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodPost, "/quarantine/5/reject", nil)
+	resp := httptest.NewRecorder()
+
+	h.RejectQuarantinedPrice(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	sMock.AssertExpectations(t)
+	vMock.AssertExpectations(t)
+}
+
+func Test_RejectQuarantinedPrice_InvalidID(t *testing.T) {
+	sMock := mocks.NewQuarantineServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+	cMock := mocks.NewCustomMock()
+
+	lMock.On("Info", mock.Anything)
+	lMock.On("WithError", mock.Anything).Return(cMock)
+	cMock.On("Warn", mock.Anything)
+	vMock.On("CardID", []string{"", "quarantine", "abc"}).Return("", errors.New("invalid id"))
+
+	h := NewQuarantineHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodPost, "/quarantine/abc/reject", nil)
+	resp := httptest.NewRecorder()
+
+	h.RejectQuarantinedPrice(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	sMock.AssertNotCalled(t, "RejectQuarantinedPrice", mock.Anything, mock.Anything)
+}
@@ -1,6 +1,10 @@
 package apihandler
 
-import "net/http"
+import (
+	"mtg-report/internal/core/domain"
+	"net/http"
+	"strings"
+)
 
 type cards interface {
 	InsertCard(http.ResponseWriter, *http.Request)
@@ -11,9 +15,72 @@ type cards interface {
 	GetCardHistory(w http.ResponseWriter, r *http.Request)
 	UpdateCard(w http.ResponseWriter, r *http.Request)
 	GetCollectionStats(w http.ResponseWriter, r *http.Request)
+	SellCard(w http.ResponseWriter, r *http.Request)
+	GetRealizedGains(w http.ResponseWriter, r *http.Request)
 }
 
-func SetupRouter(c cards) http.Handler {
+type collections interface {
+	InsertCollection(w http.ResponseWriter, r *http.Request)
+	GetCollections(w http.ResponseWriter, r *http.Request)
+	GetCollectionByID(w http.ResponseWriter, r *http.Request)
+	UpdateCollection(w http.ResponseWriter, r *http.Request)
+	DeleteCollection(w http.ResponseWriter, r *http.Request)
+	GetMembers(w http.ResponseWriter, r *http.Request)
+	InsertMember(w http.ResponseWriter, r *http.Request)
+	DeleteMember(w http.ResponseWriter, r *http.Request)
+	RequireCollectionRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc
+	RequireCardRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc
+	RequireImportRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc
+}
+
+type shares interface {
+	InsertShare(w http.ResponseWriter, r *http.Request)
+	GetShares(w http.ResponseWriter, r *http.Request)
+	DeleteShare(w http.ResponseWriter, r *http.Request)
+	GetSharedCards(w http.ResponseWriter, r *http.Request)
+	GetSharedPage(w http.ResponseWriter, r *http.Request)
+}
+
+type wishlist interface {
+	InsertWishlistItem(w http.ResponseWriter, r *http.Request)
+	GetWishlist(w http.ResponseWriter, r *http.Request)
+	UpdateWishlistItem(w http.ResponseWriter, r *http.Request)
+	DeleteWishlistItem(w http.ResponseWriter, r *http.Request)
+}
+
+type alerts interface {
+	InsertAlert(w http.ResponseWriter, r *http.Request)
+	GetAlerts(w http.ResponseWriter, r *http.Request)
+	DeleteAlert(w http.ResponseWriter, r *http.Request)
+	GetAlertHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type webhooks interface {
+	InsertWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+}
+
+type conciliations interface {
+	GetConciliations(w http.ResponseWriter, r *http.Request)
+	GetConciliationFailures(w http.ResponseWriter, r *http.Request)
+}
+
+type quarantine interface {
+	GetQuarantinedPrices(w http.ResponseWriter, r *http.Request)
+	AcceptQuarantinedPrice(w http.ResponseWriter, r *http.Request)
+	RejectQuarantinedPrice(w http.ResponseWriter, r *http.Request)
+}
+
+type users interface {
+	AuthMiddleware(next http.Handler) http.Handler
+	InsertUser(w http.ResponseWriter, r *http.Request)
+	GetUser(w http.ResponseWriter, r *http.Request)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request)
+}
+
+func SetupRouter(c cards, cl collections, s shares, wl wishlist, a alerts, wh webhooks, cn conciliations, q quarantine, u users) http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/card", func(w http.ResponseWriter, r *http.Request) {
@@ -26,13 +93,23 @@ func SetupRouter(c cards) http.Handler {
 	})
 
 	mux.HandleFunc("/card/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sell") {
+			switch r.Method {
+			case http.MethodPost:
+				cl.RequireCardRole(domain.RoleEditor, c.SellCard)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			c.GetCardbyID(w, r)
 		case http.MethodPatch:
-			c.UpdateCard(w, r)
+			cl.RequireCardRole(domain.RoleEditor, c.UpdateCard)(w, r)
 		case http.MethodDelete:
-			c.DeleteCard(w, r)
+			cl.RequireCardRole(domain.RoleEditor, c.DeleteCard)(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -41,7 +118,7 @@ func SetupRouter(c cards) http.Handler {
 	mux.HandleFunc("/cards", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPost:
-			c.InsertCards(w, r)
+			cl.RequireImportRole(domain.RoleEditor, c.InsertCards)(w, r)
 		case http.MethodGet:
 			c.GetCards(w, r)
 		default:
@@ -60,5 +137,275 @@ func SetupRouter(c cards) http.Handler {
 		}
 	})
 
-	return CORSMiddleware(mux)
+	mux.HandleFunc("/collection", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			cl.InsertCollection(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/collection/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/members") {
+			switch r.Method {
+			case http.MethodGet:
+				cl.RequireCollectionRole(domain.RoleReader, cl.GetMembers)(w, r)
+			case http.MethodPost:
+				cl.RequireCollectionRole(domain.RoleOwner, cl.InsertMember)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/shares") {
+			switch r.Method {
+			case http.MethodGet:
+				cl.RequireCollectionRole(domain.RoleOwner, s.GetShares)(w, r)
+			case http.MethodPost:
+				cl.RequireCollectionRole(domain.RoleOwner, s.InsertShare)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "/shares/") {
+			switch r.Method {
+			case http.MethodDelete:
+				cl.RequireCollectionRole(domain.RoleOwner, s.DeleteShare)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "/members/") {
+			switch r.Method {
+			case http.MethodDelete:
+				cl.RequireCollectionRole(domain.RoleOwner, cl.DeleteMember)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			cl.GetCollectionByID(w, r)
+		case http.MethodPatch:
+			cl.RequireCollectionRole(domain.RoleOwner, cl.UpdateCollection)(w, r)
+		case http.MethodDelete:
+			cl.RequireCollectionRole(domain.RoleOwner, cl.DeleteCollection)(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			cl.GetCollections(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/reports/realized-gains", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			c.GetRealizedGains(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/wishlist", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			wl.InsertWishlistItem(w, r)
+		case http.MethodGet:
+			wl.GetWishlist(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/wishlist/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			wl.UpdateWishlistItem(w, r)
+		case http.MethodDelete:
+			wl.DeleteWishlistItem(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/alerts", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			a.InsertAlert(w, r)
+		case http.MethodGet:
+			a.GetAlerts(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/alerts/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts/history" {
+			switch r.Method {
+			case http.MethodGet:
+				a.GetAlertHistory(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodDelete:
+			a.DeleteAlert(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			wh.InsertWebhook(w, r)
+		case http.MethodGet:
+			wh.GetWebhooks(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/webhooks/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/deliveries") {
+			switch r.Method {
+			case http.MethodGet:
+				wh.GetWebhookDeliveries(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodDelete:
+			wh.DeleteWebhook(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/conciliations", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			cn.GetConciliations(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/conciliations/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/failures") {
+			switch r.Method {
+			case http.MethodGet:
+				cn.GetConciliationFailures(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		http.NotFound(w, r)
+	})
+
+	mux.HandleFunc("/quarantine", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			q.GetQuarantinedPrices(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/quarantine/", func(w http.ResponseWriter, r *http.Request) {
+		var review http.HandlerFunc
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accept"):
+			review = q.AcceptQuarantinedPrice
+		case strings.HasSuffix(r.URL.Path, "/reject"):
+			review = q.RejectQuarantinedPrice
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			review(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			u.GetUser(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/user/api-key", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			u.RotateAPIKey(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// signing up and share links are the only routes that do not need an api
+	// key.
+	public := http.NewServeMux()
+
+	public.HandleFunc("/shared/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cards") {
+			switch r.Method {
+			case http.MethodGet:
+				s.GetSharedCards(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.GetSharedPage(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	public.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			u.InsertUser(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	public.Handle("/", u.AuthMiddleware(mux))
+
+	return CORSMiddleware(public)
 }
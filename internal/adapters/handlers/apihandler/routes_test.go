@@ -1,6 +1,7 @@
 package apihandler
 
 import (
+	"mtg-report/internal/core/domain"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -54,9 +55,241 @@ func (m *mockCardsHandler) GetCollectionStats(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusOK)
 }
 
+func (m *mockCardsHandler) SellCard(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCardsHandler) GetRealizedGains(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockCollectionsHandler struct {
+	mock.Mock
+}
+
+func (m *mockCollectionsHandler) InsertCollection(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) GetCollectionByID(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) InsertMember(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockCollectionsHandler) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+// the role checks pass straight through; they are covered by the collection
+// handler tests.
+func (m *mockCollectionsHandler) RequireCollectionRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return next
+}
+
+func (m *mockCollectionsHandler) RequireCardRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return next
+}
+
+func (m *mockCollectionsHandler) RequireImportRole(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return next
+}
+
+type mockSharesHandler struct {
+	mock.Mock
+}
+
+func (m *mockSharesHandler) InsertShare(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockSharesHandler) GetShares(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockSharesHandler) DeleteShare(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockSharesHandler) GetSharedCards(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockSharesHandler) GetSharedPage(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockWishlistHandler struct {
+	mock.Mock
+}
+
+func (m *mockWishlistHandler) InsertWishlistItem(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWishlistHandler) UpdateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWishlistHandler) DeleteWishlistItem(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockAlertsHandler struct {
+	mock.Mock
+}
+
+func (m *mockAlertsHandler) InsertAlert(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockAlertsHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockAlertsHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockAlertsHandler) GetAlertHistory(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockWebhooksHandler struct {
+	mock.Mock
+}
+
+func (m *mockWebhooksHandler) InsertWebhook(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWebhooksHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockWebhooksHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockConciliationsHandler struct {
+	mock.Mock
+}
+
+func (m *mockConciliationsHandler) GetConciliations(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockConciliationsHandler) GetConciliationFailures(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockQuarantineHandler struct {
+	mock.Mock
+}
+
+func (m *mockQuarantineHandler) GetQuarantinedPrices(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockQuarantineHandler) AcceptQuarantinedPrice(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockQuarantineHandler) RejectQuarantinedPrice(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+type mockUsersHandler struct {
+	mock.Mock
+	unauthorized bool
+}
+
+func (m *mockUsersHandler) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.unauthorized {
+			http.Error(w, "invalid or missing api key", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *mockUsersHandler) InsertUser(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockUsersHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockUsersHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	m.Called(w, r)
+	w.WriteHeader(http.StatusOK)
+}
+
 func TestSetupRouter_CardPOST(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodPost, "/card", strings.NewReader("{}"))
 	resp := httptest.NewRecorder()
@@ -71,7 +304,7 @@ func TestSetupRouter_CardPOST(t *testing.T) {
 
 func TestSetupRouter_CardMethodNotAllowed(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodGet, "/card", nil)
 	resp := httptest.NewRecorder()
@@ -84,7 +317,7 @@ func TestSetupRouter_CardMethodNotAllowed(t *testing.T) {
 
 func TestSetupRouter_CardWithIDGET(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodGet, "/card/123", nil)
 	resp := httptest.NewRecorder()
@@ -99,7 +332,7 @@ func TestSetupRouter_CardWithIDGET(t *testing.T) {
 
 func TestSetupRouter_CardWithIDPATCH(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodPatch, "/card/123", strings.NewReader("{}"))
 	resp := httptest.NewRecorder()
@@ -114,7 +347,7 @@ func TestSetupRouter_CardWithIDPATCH(t *testing.T) {
 
 func TestSetupRouter_CardWithIDDELETE(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodDelete, "/card/123", nil)
 	resp := httptest.NewRecorder()
@@ -129,7 +362,7 @@ func TestSetupRouter_CardWithIDDELETE(t *testing.T) {
 
 func TestSetupRouter_CardWithIDMethodNotAllowed(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodHead, "/card/123", nil)
 	resp := httptest.NewRecorder()
@@ -142,7 +375,7 @@ func TestSetupRouter_CardWithIDMethodNotAllowed(t *testing.T) {
 
 func TestSetupRouter_CardsPOST(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodPost, "/cards", strings.NewReader("{}"))
 	resp := httptest.NewRecorder()
@@ -157,7 +390,7 @@ func TestSetupRouter_CardsPOST(t *testing.T) {
 
 func TestSetupRouter_CardsGET(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodGet, "/cards", nil)
 	resp := httptest.NewRecorder()
@@ -172,7 +405,7 @@ func TestSetupRouter_CardsGET(t *testing.T) {
 
 func TestSetupRouter_CardsMethodNotAllowed(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodDelete, "/cards", nil)
 	resp := httptest.NewRecorder()
@@ -185,7 +418,7 @@ func TestSetupRouter_CardsMethodNotAllowed(t *testing.T) {
 
 func TestSetupRouter_CardHistory(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodGet, "/card-history/123", nil)
 	resp := httptest.NewRecorder()
@@ -200,7 +433,7 @@ func TestSetupRouter_CardHistory(t *testing.T) {
 
 func TestSetupRouter_CollectionStatsGET(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodGet, "/collection-stats", nil)
 	resp := httptest.NewRecorder()
@@ -215,7 +448,7 @@ func TestSetupRouter_CollectionStatsGET(t *testing.T) {
 
 func TestSetupRouter_CollectionStatsMethodNotAllowed(t *testing.T) {
 	mockHandler := &mockCardsHandler{}
-	router := SetupRouter(mockHandler)
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
 
 	req := httptest.NewRequest(http.MethodPost, "/collection-stats", nil)
 	resp := httptest.NewRecorder()
@@ -225,3 +458,383 @@ func TestSetupRouter_CollectionStatsMethodNotAllowed(t *testing.T) {
 	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
 	assert.Contains(t, resp.Body.String(), "Method not allowed")
 }
+
+func TestSetupRouter_SellCardPOST(t *testing.T) {
+	mockHandler := &mockCardsHandler{}
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+	req := httptest.NewRequest(http.MethodPost, "/card/123/sell", strings.NewReader("{}"))
+	resp := httptest.NewRecorder()
+
+	mockHandler.On("SellCard", resp, req)
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSetupRouter_SellCardMethodNotAllowed(t *testing.T) {
+	mockHandler := &mockCardsHandler{}
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+	req := httptest.NewRequest(http.MethodGet, "/card/123/sell", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Method not allowed")
+}
+
+func TestSetupRouter_RealizedGainsGET(t *testing.T) {
+	mockHandler := &mockCardsHandler{}
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/realized-gains?year=2026", nil)
+	resp := httptest.NewRecorder()
+
+	mockHandler.On("GetRealizedGains", resp, req)
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	mockHandler.AssertExpectations(t)
+}
+
+func TestSetupRouter_Collections(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route collection insert", method: http.MethodPost, path: "/collection", mockMethod: "InsertCollection"},
+		{name: "should route collections list", method: http.MethodGet, path: "/collections", mockMethod: "GetCollections"},
+		{name: "should route collection get", method: http.MethodGet, path: "/collection/2", mockMethod: "GetCollectionByID"},
+		{name: "should route collection update", method: http.MethodPatch, path: "/collection/2", mockMethod: "UpdateCollection"},
+		{name: "should route collection delete", method: http.MethodDelete, path: "/collection/2", mockMethod: "DeleteCollection"},
+		{name: "should route members get", method: http.MethodGet, path: "/collection/2/members", mockMethod: "GetMembers"},
+		{name: "should route member insert", method: http.MethodPost, path: "/collection/2/members", mockMethod: "InsertMember"},
+		{name: "should route member delete", method: http.MethodDelete, path: "/collection/2/members/5", mockMethod: "DeleteMember"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCollections := &mockCollectionsHandler{}
+			router := SetupRouter(&mockCardsHandler{}, mockCollections, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockCollections.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockCollections.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_Users(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route user sign up", method: http.MethodPost, path: "/users", mockMethod: "InsertUser"},
+		{name: "should route current user", method: http.MethodGet, path: "/user", mockMethod: "GetUser"},
+		{name: "should route api key rotation", method: http.MethodPost, path: "/user/api-key", mockMethod: "RotateAPIKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsers := &mockUsersHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, mockUsers)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockUsers.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockUsers.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_RequiresAuthentication(t *testing.T) {
+	mockHandler := &mockCardsHandler{}
+	mockUsers := &mockUsersHandler{unauthorized: true}
+	router := SetupRouter(mockHandler, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, mockUsers)
+
+	req := httptest.NewRequest(http.MethodGet, "/cards", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	mockHandler.AssertNotCalled(t, "GetCards", mock.Anything, mock.Anything)
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
+	resp = httptest.NewRecorder()
+
+	mockUsers.On("InsertUser", resp, req)
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestSetupRouter_Shares(t *testing.T) {
+	token := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route share insert", method: http.MethodPost, path: "/collection/2/shares", mockMethod: "InsertShare"},
+		{name: "should route shares get", method: http.MethodGet, path: "/collection/2/shares", mockMethod: "GetShares"},
+		{name: "should route share delete", method: http.MethodDelete, path: "/collection/2/shares/3", mockMethod: "DeleteShare"},
+		{name: "should route shared cards", method: http.MethodGet, path: "/shared/" + token + "/cards", mockMethod: "GetSharedCards"},
+		{name: "should route shared page", method: http.MethodGet, path: "/shared/" + token, mockMethod: "GetSharedPage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockShares := &mockSharesHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, mockShares, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockShares.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockShares.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_Wishlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route wishlist insert", method: http.MethodPost, path: "/wishlist", mockMethod: "InsertWishlistItem"},
+		{name: "should route wishlist get", method: http.MethodGet, path: "/wishlist", mockMethod: "GetWishlist"},
+		{name: "should route wishlist item update", method: http.MethodPatch, path: "/wishlist/3", mockMethod: "UpdateWishlistItem"},
+		{name: "should route wishlist item delete", method: http.MethodDelete, path: "/wishlist/3", mockMethod: "DeleteWishlistItem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWishlist := &mockWishlistHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, mockWishlist, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockWishlist.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockWishlist.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_Alerts(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route alert insert", method: http.MethodPost, path: "/alerts", mockMethod: "InsertAlert"},
+		{name: "should route alerts get", method: http.MethodGet, path: "/alerts", mockMethod: "GetAlerts"},
+		{name: "should route alert delete", method: http.MethodDelete, path: "/alerts/3", mockMethod: "DeleteAlert"},
+		{name: "should route alert history", method: http.MethodGet, path: "/alerts/history", mockMethod: "GetAlertHistory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAlerts := &mockAlertsHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{}, mockAlerts, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockAlerts.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockAlerts.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_Webhooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route webhook insert", method: http.MethodPost, path: "/webhooks", mockMethod: "InsertWebhook"},
+		{name: "should route webhooks get", method: http.MethodGet, path: "/webhooks", mockMethod: "GetWebhooks"},
+		{name: "should route webhook delete", method: http.MethodDelete, path: "/webhooks/4", mockMethod: "DeleteWebhook"},
+		{name: "should route webhook deliveries", method: http.MethodGet, path: "/webhooks/4/deliveries", mockMethod: "GetWebhookDeliveries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhooks := &mockWebhooksHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{},
+				&mockAlertsHandler{}, mockWebhooks, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			resp := httptest.NewRecorder()
+
+			mockWebhooks.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockWebhooks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_Conciliations(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route conciliations get", method: http.MethodGet, path: "/conciliations", mockMethod: "GetConciliations"},
+		{name: "should route conciliation failures", method: http.MethodGet, path: "/conciliations/3/failures", mockMethod: "GetConciliationFailures"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConciliations := &mockConciliationsHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{},
+				&mockAlertsHandler{}, &mockWebhooksHandler{}, mockConciliations, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			mockConciliations.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockConciliations.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_ConciliationsMethodNotAllowed(t *testing.T) {
+	router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{},
+		&mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+	for _, path := range []string{"/conciliations", "/conciliations/3/failures"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	}
+}
+
+func TestSetupRouter_Quarantine(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		mockMethod string
+	}{
+		{name: "should route quarantined prices get", method: http.MethodGet, path: "/quarantine", mockMethod: "GetQuarantinedPrices"},
+		{name: "should route quarantined price accept", method: http.MethodPost, path: "/quarantine/3/accept", mockMethod: "AcceptQuarantinedPrice"},
+		{name: "should route quarantined price reject", method: http.MethodPost, path: "/quarantine/3/reject", mockMethod: "RejectQuarantinedPrice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuarantine := &mockQuarantineHandler{}
+			router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{},
+				&mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, mockQuarantine, &mockUsersHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			mockQuarantine.On(tt.mockMethod, resp, req)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			mockQuarantine.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetupRouter_QuarantineMethodNotAllowed(t *testing.T) {
+	router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, &mockSharesHandler{}, &mockWishlistHandler{},
+		&mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{})
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodPost, path: "/quarantine"},
+		{method: http.MethodGet, path: "/quarantine/3/accept"},
+		{method: http.MethodDelete, path: "/quarantine/3/reject"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	}
+}
+
+func TestSetupRouter_SharedLinksArePublic(t *testing.T) {
+	mockShares := &mockSharesHandler{}
+	router := SetupRouter(&mockCardsHandler{}, &mockCollectionsHandler{}, mockShares, &mockWishlistHandler{}, &mockAlertsHandler{}, &mockWebhooksHandler{}, &mockConciliationsHandler{}, &mockQuarantineHandler{}, &mockUsersHandler{unauthorized: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/shared/"+strings.Repeat("ab", 32)+"/cards", nil)
+	resp := httptest.NewRecorder()
+
+	mockShares.On("GetSharedCards", resp, req)
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	mockShares.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/collection/2/shares", nil)
+	resp = httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
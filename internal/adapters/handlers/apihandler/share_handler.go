@@ -0,0 +1,232 @@
+package apihandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// sharedPageLimit is the number of cards on each page of the HTML view.
+const sharedPageLimit = 100
+
+var sharedPageTemplate = template.Must(template.New("shared").Funcs(template.FuncMap{
+	"amount": formatAmount,
+	"value": func(card dtos.ResponseCard) string {
+		return formatAmount(card.LastPrice * float64(card.Quantity))
+	},
+	"prev": func(page int) int { return page - 1 },
+	"next": func(page int) int { return page + 1 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>{{.Name}}</title>
+<style>
+body { font-family: Arial, sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+td.number { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p><strong>Total value: </strong>R$ {{amount .TotalValue}} in {{.Cards.Total}} cards</p>
+<table>
+<tr><th>Name</th><th>Set</th><th>Number</th><th>Finish</th><th>Condition</th><th>Language</th><th>Quantity</th><th>Price</th><th>Value</th></tr>
+{{range .Cards.Cards}}<tr><td>{{.Name}}</td><td>{{.Set}}</td><td>{{.CollectorNumber}}</td><td>{{.Finish}}</td><td>{{.Condition}}</td><td>{{.Language}}</td><td class="number">{{.Quantity}}</td><td class="number">{{amount .LastPrice}}</td><td class="number">{{value .}}</td></tr>
+{{end}}</table>
+<p>{{if gt .Cards.Page 1}}<a href="?page={{.Cards.Page | prev}}">previous</a> {{end}}page {{.Cards.Page}} of {{.Cards.TotalPages}}{{if lt .Cards.Page .Cards.TotalPages}} <a href="?page={{.Cards.Page | next}}">next</a>{{end}}</p>
+</body>
+</html>
+`))
+
+type shareHandler struct {
+	validator    validate
+	ShareService ports.ShareService
+	log          logrus.Logger
+}
+
+func NewShareHandler(v validate, ss ports.ShareService, log logrus.Logger) *shareHandler {
+	return &shareHandler{
+		validator:    v,
+		ShareService: ss,
+		log:          log,
+	}
+}
+
+func (h *shareHandler) InsertShare(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert share")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/shares"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert share")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	share := dtos.RequestShare{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert share")
+		http.Error(w, "failed to insert share", http.StatusInternalServerError)
+		return
+	}
+
+	// the body is optional, a share shows the cost basis by default.
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &share)
+		if err != nil {
+			h.log.WithError(err).Warn("error to read body on insert share")
+			http.Error(w, "failed to insert share, check body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	share.CollectionID = id
+
+	response, err := h.ShareService.InsertShare(r.Context(), share)
+	if err != nil {
+		h.log.WithError(err).Error("failed to insert share")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("share inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *shareHandler) GetShares(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get shares")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/shares"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get shares")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.ShareService.GetShares(r.Context(), id)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get shares")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("shares retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *shareHandler) DeleteShare(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete share")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, shareID, err := h.validator.ShareID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete share")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.ShareService.DeleteShare(r.Context(), id, shareID)
+	if errors.Is(err, domain.ErrShareNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete share")
+		http.Error(w, domain.ErrShareNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete share")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("share deleted")
+	}
+}
+
+func (h *shareHandler) GetSharedCards(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get shared cards")
+
+	parts := strings.Split(r.URL.Path, "/")
+	token, err := h.validator.ShareToken(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get shared cards")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	setName := r.URL.Query().Get("set_name")
+	name := r.URL.Query().Get("name")
+	collector_number := r.URL.Query().Get("collector_number")
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	filters := h.validator.Filters(setName, name, collector_number, "")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.ShareService.GetSharedCards(r.Context(), token, filters, page, limit)
+	if errors.Is(err, domain.ErrShareNotFound{}) {
+		h.log.WithError(err).Warn("failed to get shared cards")
+		http.Error(w, domain.ErrShareNotFound{}.Error(), http.StatusNotFound)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to get shared cards")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("shared cards retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *shareHandler) GetSharedPage(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get shared page")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	token, err := h.validator.ShareToken(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get shared page")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, limit, err := h.validator.Pagination(r.URL.Query().Get("page"), strconv.Itoa(sharedPageLimit))
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.ShareService.GetSharedCollection(r.Context(), token, page, limit)
+	if errors.Is(err, domain.ErrShareNotFound{}) {
+		h.log.WithError(err).Warn("failed to get shared page")
+		http.Error(w, domain.ErrShareNotFound{}.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to get shared page")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := sharedPageTemplate.Execute(&buf, response); err != nil {
+		h.log.WithError(err).Error("failed to render shared page")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.log.Info("shared page retrieved")
+
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,183 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testShareToken = strings.Repeat("ab", 32)
+
+func Test_NewShareHandler(t *testing.T) {
+	h := NewShareHandler(mocks.NewValidateMock(), mocks.NewShareServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_InsertShare(t *testing.T) {
+	tests := []struct {
+		name        string
+		reqBody     []byte
+		wantRequest dtos.RequestShare
+	}{
+		{name: "should show the cost basis without a body", reqBody: nil,
+			wantRequest: dtos.RequestShare{CollectionID: "2"}},
+		{name: "should hide the cost basis when asked", reqBody: []byte(`{"hide_cost_basis": true}`),
+			wantRequest: dtos.RequestShare{CollectionID: "2", HideCostBasis: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewShareServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+
+			lMock.On("Info", mock.Anything).Twice()
+			vMock.On("CardID", []string{"", "collection", "2"}).Return("2", nil)
+			sMock.On("InsertShare", mock.Anything, tt.wantRequest).
+				Return(dtos.ResponseShare{ID: 3, CollectionID: 2, Token: testShareToken, URL: "/shared/" + testShareToken}, nil)
+
+			h := NewShareHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/collection/2/shares", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertShare(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), `"token":"`+testShareToken+`"`)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeleteShare(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when share is revoked", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when share is not found",
+			serviceErr: fmt.Errorf("service failed to delete share: %w", domain.ErrShareNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewShareServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("ShareID", mock.Anything).Return("2", "3", nil)
+			sMock.On("DeleteShare", mock.Anything, "2", "3").Return(tt.serviceErr)
+
+			h := NewShareHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/collection/2/shares/3", nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteShare(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetSharedCards(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK with the cards", wantCode: http.StatusOK},
+		{name: "should return StatusNotFound when the token is unknown or revoked",
+			serviceErr: fmt.Errorf("service failed to get share: %w", domain.ErrShareNotFound{}), wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewShareServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			vMock.On("ShareToken", []string{"", "shared", testShareToken, "cards"}).Return(testShareToken, nil)
+			vMock.On("Filters", "", "Bolt", "", "").Return(map[string]string{"name": "Bolt"})
+			vMock.On("Pagination", "", "").Return(1, 20, nil)
+			sMock.On("GetSharedCards", mock.Anything, testShareToken, map[string]string{"name": "Bolt"}, 1, 20).
+				Return(dtos.ResponsePaginatedCards{Cards: []dtos.ResponseCard{}, Page: 1, Limit: 20}, tt.serviceErr)
+
+			h := NewShareHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodGet, "/shared/"+testShareToken+"/cards?name=Bolt", nil)
+			resp := httptest.NewRecorder()
+
+			h.GetSharedCards(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetSharedPage(t *testing.T) {
+	sMock := mocks.NewShareServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lMock.On("Info", mock.Anything).Twice()
+	vMock.On("ShareToken", []string{"", "shared", testShareToken}).Return(testShareToken, nil)
+	vMock.On("Pagination", "", "100").Return(1, 100, nil)
+	sMock.On("GetSharedCollection", mock.Anything, testShareToken, 1, 100).Return(dtos.ResponseSharedCollection{
+		Name:       "Trade <Binder>",
+		TotalValue: 30,
+		Cards: dtos.ResponsePaginatedCards{
+			Cards: []dtos.ResponseCard{{Name: "Lightning Bolt", Set: "lea", CollectorNumber: "161", Quantity: 3, LastPrice: 10}},
+			Page:  1, Limit: 100, Total: 1, TotalPages: 1,
+		},
+	}, nil)
+
+	h := NewShareHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/shared/"+testShareToken, nil)
+	resp := httptest.NewRecorder()
+
+	h.GetSharedPage(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/html; charset=UTF-8", resp.Header().Get("Content-Type"))
+	body := resp.Body.String()
+	assert.Contains(t, body, "<h1>Trade &lt;Binder&gt;</h1>")
+	assert.Contains(t, body, "R$ 30.00")
+	assert.Contains(t, body, "<td>Lightning Bolt</td>")
+	assert.Contains(t, body, "page 1 of 1")
+	assert.NotContains(t, body, "next</a>")
+	sMock.AssertExpectations(t)
+	vMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type userHandler struct {
+	validator   validate
+	UserService ports.UserService
+	log         logrus.Logger
+}
+
+func NewUserHandler(v validate, us ports.UserService, log logrus.Logger) *userHandler {
+	return &userHandler{
+		validator:   v,
+		UserService: us,
+		log:         log,
+	}
+}
+
+// AuthMiddleware authenticates the request with the API key sent as
+// "Authorization: Bearer <key>" and stores the user in the request context.
+func (h *userHandler) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		user, err := h.UserService.Authenticate(r.Context(), apiKey)
+		if errors.Is(err, domain.ErrUnauthorized{}) {
+			h.log.WithError(err).Warn("failed to authenticate")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, domain.ErrUnauthorized{}.Error(), http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			h.log.WithError(err).Error("failed to authenticate")
+			http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(domain.WithUser(r.Context(), user)))
+	})
+}
+
+func (h *userHandler) InsertUser(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert user")
+
+	user := dtos.RequestUser{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert user")
+		http.Error(w, "failed to insert user", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &user)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert user")
+		http.Error(w, "failed to insert user, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.User(user)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert user")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.UserService.InsertUser(r.Context(), user)
+	if errors.Is(err, domain.ErrUserAlreadyExists{}) {
+		h.log.WithError(err).Warn("failed to insert user")
+		http.Error(w, domain.ErrUserAlreadyExists{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert user")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("user inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *userHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get user")
+
+	user := domain.UserFromContext(r.Context())
+
+	encondeResponse(w, dtos.ResponseUser{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+	})
+}
+
+func (h *userHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler rotate api key")
+
+	response, err := h.UserService.RotateAPIKey(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to rotate api key")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("api key rotated")
+		encondeResponse(w, response)
+	}
+}
@@ -0,0 +1,288 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewUserHandler(t *testing.T) {
+	sMock := mocks.NewUserServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	h := NewUserHandler(vMock, sMock, lMock)
+
+	assert.NotNil(t, h)
+}
+
+func Test_AuthMiddleware(t *testing.T) {
+	user := domain.User{ID: 2, Name: "Jace", Email: "jace@example.com"}
+
+	tests := []struct {
+		name      string
+		header    string
+		mockSetup func(
+			sMock *mocks.UserServiceMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:   "should return StatusUnauthorized when api key is unknown",
+			header: "Bearer unknown",
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				sMock.On("Authenticate", mock.Anything, "unknown").
+					Return(domain.User{}, fmt.Errorf("service failed to authenticate: %w", domain.ErrUnauthorized{}))
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "should return StatusInternalServerError when authentication fails",
+			header: "Bearer secret",
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Error", mock.Anything).Once()
+				sMock.On("Authenticate", mock.Anything, "secret").Return(domain.User{}, errors.New("database error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "should call next with the user in the context",
+			header: "Bearer secret",
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				sMock.On("Authenticate", mock.Anything, "secret").Return(user, nil)
+			},
+			wantCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewUserServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, lMock, cMock)
+
+			h := NewUserHandler(vMock, sMock, lMock)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, user, domain.UserFromContext(r.Context()))
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/cards", nil)
+			req.Header.Set("Authorization", tt.header)
+			resp := httptest.NewRecorder()
+
+			h.AuthMiddleware(next).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", resp.Header().Get("WWW-Authenticate"))
+			}
+
+			sMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_InsertUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.UserServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when unable to unmarshal request body",
+			reqBody: []byte("{invalid json}"),
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when validation fails",
+			reqBody: []byte(`{"name": "Jace"}`),
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("User", mock.Anything).Return(errors.New("email is required"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when user already exists",
+			reqBody: []byte(`{"name": "Jace", "email": "jace@example.com"}`),
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("User", mock.Anything).Return(nil)
+				sMock.On("InsertUser", mock.Anything, mock.Anything).
+					Return(dtos.ResponseUser{}, fmt.Errorf("service failed to insert user: %w", domain.ErrUserAlreadyExists{}))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when insert is successful",
+			reqBody: []byte(`{"name": "Jace", "email": "jace@example.com"}`),
+			mockSetup: func(
+				sMock *mocks.UserServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("User", mock.Anything).Return(nil)
+				sMock.On("InsertUser", mock.Anything, dtos.RequestUser{Name: "Jace", Email: "jace@example.com"}).
+					Return(dtos.ResponseUser{ID: 2, Name: "Jace", Email: "jace@example.com", APIKey: "secret"}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewUserServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := NewUserHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertUser(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetUser(t *testing.T) {
+	sMock := mocks.NewUserServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lMock.On("Info", mock.Anything).Once()
+
+	h := NewUserHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/user", nil)
+	req = req.WithContext(domain.WithUser(req.Context(), domain.User{ID: 2, Name: "Jace", Email: "jace@example.com"}))
+	resp := httptest.NewRecorder()
+
+	h.GetUser(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"id": 2, "name": "Jace", "email": "jace@example.com"}`, resp.Body.String())
+	lMock.AssertExpectations(t)
+}
+
+func Test_RotateAPIKey(t *testing.T) {
+	t.Run("should return StatusOK with the new api key", func(t *testing.T) {
+		sMock := mocks.NewUserServiceMock()
+		vMock := mocks.NewValidateMock()
+		lMock := mocks.NewLogMock()
+
+		lMock.On("Info", mock.Anything).Twice()
+		sMock.On("RotateAPIKey", mock.Anything).Return(dtos.ResponseUser{ID: 2, Name: "Jace", Email: "jace@example.com", APIKey: "new"}, nil)
+
+		h := NewUserHandler(vMock, sMock, lMock)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-key", nil)
+		resp := httptest.NewRecorder()
+
+		h.RotateAPIKey(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"id": 2, "name": "Jace", "email": "jace@example.com", "api_key": "new"}`, resp.Body.String())
+		sMock.AssertExpectations(t)
+		lMock.AssertExpectations(t)
+	})
+
+	t.Run("should return StatusInternalServerError when service fails", func(t *testing.T) {
+		sMock := mocks.NewUserServiceMock()
+		vMock := mocks.NewValidateMock()
+		lMock := mocks.NewLogMock()
+		cMock := mocks.NewCustomMock()
+
+		lMock.On("Info", mock.Anything).Once()
+		lMock.On("WithError", mock.Anything).Return(cMock).Once()
+		cMock.On("Error", mock.Anything).Once()
+		sMock.On("RotateAPIKey", mock.Anything).Return(dtos.ResponseUser{}, errors.New("service error"))
+
+		h := NewUserHandler(vMock, sMock, lMock)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-key", nil)
+		resp := httptest.NewRecorder()
+
+		h.RotateAPIKey(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		sMock.AssertExpectations(t)
+		lMock.AssertExpectations(t)
+		cMock.AssertExpectations(t)
+	})
+}
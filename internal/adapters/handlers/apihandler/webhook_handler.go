@@ -0,0 +1,136 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type webhookHandler struct {
+	validator      validate
+	WebhookService ports.WebhookService
+	log            logrus.Logger
+}
+
+func NewWebhookHandler(v validate, ws ports.WebhookService, log logrus.Logger) *webhookHandler {
+	return &webhookHandler{
+		validator:      v,
+		WebhookService: ws,
+		log:            log,
+	}
+}
+
+func (h *webhookHandler) InsertWebhook(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert webhook")
+
+	webhook := dtos.RequestWebhook{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert webhook")
+		http.Error(w, "failed to insert webhook", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &webhook)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert webhook")
+		http.Error(w, "failed to insert webhook, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.Webhook(webhook)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert webhook")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.WebhookService.InsertWebhook(r.Context(), webhook)
+	if errors.Is(err, domain.ErrWebhookURLNotAllowed{}) {
+		h.log.WithError(err).Warn("failed to insert webhook")
+		http.Error(w, domain.ErrWebhookURLNotAllowed{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert webhook")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("webhook inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *webhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get webhooks")
+
+	response, err := h.WebhookService.GetWebhooks(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get webhooks")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("webhooks retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *webhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete webhook")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete webhook")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.WebhookService.DeleteWebhook(r.Context(), id)
+	if errors.Is(err, domain.ErrWebhookNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete webhook")
+		http.Error(w, domain.ErrWebhookNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete webhook")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("webhook deleted")
+	}
+}
+
+func (h *webhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get webhook deliveries")
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/deliveries"), "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get webhook deliveries")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit, err := h.validator.Pagination(pageStr, limitStr)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to validate pagination parameters")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.WebhookService.GetWebhookDeliveries(r.Context(), id, page, limit)
+	if errors.Is(err, domain.ErrWebhookNotFound{}) {
+		h.log.WithError(err).Warn("failed to get webhook deliveries")
+		http.Error(w, domain.ErrWebhookNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to get webhook deliveries")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("webhook deliveries retrieved")
+		encondeResponse(w, response)
+	}
+}
@@ -0,0 +1,193 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewWebhookHandler(t *testing.T) {
+	h := NewWebhookHandler(mocks.NewValidateMock(), mocks.NewWebhookServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_InsertWebhook(t *testing.T) {
+	request := dtos.RequestWebhook{URL: "https://hooks.test.com", Events: []string{"report.generated"}}
+	body := []byte(`{"url": "https://hooks.test.com", "events": ["report.generated"]}`)
+
+	tests := []struct {
+		name        string
+		reqBody     []byte
+		validateErr error
+		callService bool
+		serviceErr  error
+		wantCode    int
+	}{
+		{name: "should return StatusBadRequest when unable to unmarshal request body",
+			reqBody: []byte("{invalid json}"), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when validation fails",
+			reqBody: body, validateErr: errors.New("events is required"), wantCode: http.StatusBadRequest},
+		{name: "should return StatusBadRequest when url is not allowed",
+			reqBody: body, callService: true, serviceErr: fmt.Errorf("service failed to check webhook url: %w", domain.ErrWebhookURLNotAllowed{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			reqBody: body, callService: true, serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+		{name: "should return StatusOK when webhook is inserted",
+			reqBody: body, callService: true, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWebhookServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("Webhook", request).Return(tt.validateErr).Maybe()
+			if tt.callService {
+				sMock.On("InsertWebhook", mock.Anything, request).
+					Return(dtos.ResponseWebhook{ID: 4, URL: "https://hooks.test.com", Events: []string{"report.generated"}, Secret: "secret"}, tt.serviceErr)
+			}
+
+			h := NewWebhookHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertWebhook(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantCode == http.StatusOK {
+				assert.Contains(t, resp.Body.String(), `"secret":"secret"`)
+			}
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetWebhooks(t *testing.T) {
+	sMock := mocks.NewWebhookServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	lMock.On("Info", mock.Anything).Twice()
+	sMock.On("GetWebhooks", mock.Anything).Return([]dtos.ResponseWebhook{
+		{ID: 4, URL: "https://hooks.test.com", Events: []string{"conciliation.finished"}, CreatedAt: createdAt},
+	}, nil)
+
+	h := NewWebhookHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetWebhooks(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"id": 4, "url": "https://hooks.test.com", "events": ["conciliation.finished"],
+		"created_at": "2024-05-01T10:00:00Z"}]`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_DeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when webhook is deleted", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when webhook is not found",
+			serviceErr: fmt.Errorf("service failed to delete webhook: %w", domain.ErrWebhookNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWebhookServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "webhooks", "4"}).Return("4", nil)
+			sMock.On("DeleteWebhook", mock.Anything, "4").Return(tt.serviceErr)
+
+			h := NewWebhookHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/webhooks/4", nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteWebhook(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetWebhookDeliveries(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK with the deliveries", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when webhook is not found",
+			serviceErr: fmt.Errorf("service failed to get webhook: %w", domain.ErrWebhookNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWebhookServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "webhooks", "4"}).Return("4", nil)
+			vMock.On("Pagination", "1", "20").Return(1, 20, nil)
+			sMock.On("GetWebhookDeliveries", mock.Anything, "4", 1, 20).Return(dtos.ResponsePaginatedWebhookDeliveries{
+				Deliveries: []dtos.ResponseWebhookDelivery{{ID: 9, DeliveryID: "abc", Event: "report.generated", Attempt: 1,
+					StatusCode: 200, Success: true}},
+				Page: 1, Limit: 20, Total: 1, TotalPages: 1,
+			}, tt.serviceErr)
+
+			h := NewWebhookHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodGet, "/webhooks/4/deliveries?page=1&limit=20", nil)
+			resp := httptest.NewRecorder()
+
+			h.GetWebhookDeliveries(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
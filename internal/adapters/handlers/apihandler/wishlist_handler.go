@@ -0,0 +1,151 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strings"
+)
+
+type wishlistHandler struct {
+	validator       validate
+	WishlistService ports.WishlistService
+	log             logrus.Logger
+}
+
+func NewWishlistHandler(v validate, ws ports.WishlistService, log logrus.Logger) *wishlistHandler {
+	return &wishlistHandler{
+		validator:       v,
+		WishlistService: ws,
+		log:             log,
+	}
+}
+
+func (h *wishlistHandler) InsertWishlistItem(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler insert wishlist item")
+
+	item := dtos.RequestWishlistItem{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert wishlist item")
+		http.Error(w, "failed to insert wishlist item", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &item)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on insert wishlist item")
+		http.Error(w, "failed to insert wishlist item, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.WishlistItem(item)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to insert wishlist item")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.WishlistService.InsertWishlistItem(r.Context(), item)
+	if errors.Is(err, domain.ErrWishlistItemAlreadyExists{}) {
+		h.log.WithError(err).Warn("failed to insert wishlist item")
+		http.Error(w, domain.ErrWishlistItemAlreadyExists{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to insert wishlist item")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("wishlist item inserted")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *wishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler get wishlist")
+
+	response, err := h.WishlistService.GetWishlist(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get wishlist")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("wishlist retrieved")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *wishlistHandler) UpdateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler update wishlist item")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to update wishlist item")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item := dtos.RequestWishlistItem{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on update wishlist item")
+		http.Error(w, "failed to update wishlist item", http.StatusInternalServerError)
+		return
+	}
+
+	err = json.Unmarshal(body, &item)
+	if err != nil {
+		h.log.WithError(err).Warn("error to read body on update wishlist item")
+		http.Error(w, "failed to update wishlist item, check body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.validator.UpdateWishlistItem(item)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to update wishlist item")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item.ID = id
+
+	response, err := h.WishlistService.UpdateWishlistItem(r.Context(), item)
+	if errors.Is(err, domain.ErrWishlistItemNotFound{}) {
+		h.log.WithError(err).Warn("failed to update wishlist item")
+		http.Error(w, domain.ErrWishlistItemNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to update wishlist item")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("wishlist item updated")
+		encondeResponse(w, response)
+	}
+}
+
+func (h *wishlistHandler) DeleteWishlistItem(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("handler delete wishlist item")
+
+	parts := strings.Split(r.URL.Path, "/")
+	id, err := h.validator.CardID(parts)
+	if err != nil {
+		h.log.WithError(err).Warn("failed to delete wishlist item")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.WishlistService.DeleteWishlistItem(r.Context(), id)
+	if errors.Is(err, domain.ErrWishlistItemNotFound{}) {
+		h.log.WithError(err).Warn("failed to delete wishlist item")
+		http.Error(w, domain.ErrWishlistItemNotFound{}.Error(), http.StatusBadRequest)
+	} else if err != nil {
+		h.log.WithError(err).Error("failed to delete wishlist item")
+		http.Error(w, ErrInternalErr{}.Error(), http.StatusInternalServerError)
+	} else {
+		h.log.Info("wishlist item deleted")
+	}
+}
@@ -0,0 +1,238 @@
+package apihandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_NewWishlistHandler(t *testing.T) {
+	h := NewWishlistHandler(mocks.NewValidateMock(), mocks.NewWishlistServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, h)
+}
+
+func Test_InsertWishlistItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		reqBody   []byte
+		mockSetup func(
+			sMock *mocks.WishlistServiceMock,
+			vMock *mocks.ValidateMock,
+			lMock *mocks.LogMock,
+			cMock *mocks.CustomMock,
+		)
+		wantCode int
+	}{
+		{
+			name:    "should return StatusBadRequest when unable to unmarshal request body",
+			reqBody: []byte("{invalid json}"),
+			mockSetup: func(
+				sMock *mocks.WishlistServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when validation fails",
+			reqBody: []byte(`{"name": "Lightning Bolt"}`),
+			mockSetup: func(
+				sMock *mocks.WishlistServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("WishlistItem", mock.Anything).Return(errors.New("max_price is required"))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusBadRequest when item already exists",
+			reqBody: []byte(`{"name": "Lightning Bolt", "set_name": "Alpha", "collector_number": "161", "finish": "nonfoil", "max_price": 50}`),
+			mockSetup: func(
+				sMock *mocks.WishlistServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Once()
+				lMock.On("WithError", mock.Anything).Return(cMock).Once()
+				cMock.On("Warn", mock.Anything).Once()
+				vMock.On("WishlistItem", mock.Anything).Return(nil)
+				sMock.On("InsertWishlistItem", mock.Anything, mock.Anything).
+					Return(dtos.ResponseWishlistItem{}, fmt.Errorf("service failed to insert wishlist item: %w", domain.ErrWishlistItemAlreadyExists{}))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "should return StatusOK when insert is successful",
+			reqBody: []byte(`{"name": "Lightning Bolt", "set_name": "Alpha", "collector_number": "161", "finish": "nonfoil", "max_price": 50}`),
+			mockSetup: func(
+				sMock *mocks.WishlistServiceMock,
+				vMock *mocks.ValidateMock,
+				lMock *mocks.LogMock,
+				cMock *mocks.CustomMock,
+			) {
+				lMock.On("Info", mock.Anything).Twice()
+				vMock.On("WishlistItem", mock.Anything).Return(nil)
+				sMock.On("InsertWishlistItem", mock.Anything, mock.Anything).
+					Return(dtos.ResponseWishlistItem{ID: 3, Name: "Lightning Bolt", MaxPrice: 50}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWishlistServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			tt.mockSetup(sMock, vMock, lMock, cMock)
+
+			h := NewWishlistHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPost, "/wishlist", bytes.NewBuffer(tt.reqBody))
+			resp := httptest.NewRecorder()
+
+			h.InsertWishlistItem(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+			lMock.AssertExpectations(t)
+			cMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetWishlist(t *testing.T) {
+	sMock := mocks.NewWishlistServiceMock()
+	vMock := mocks.NewValidateMock()
+	lMock := mocks.NewLogMock()
+
+	lastPrice := 45.0
+	lMock.On("Info", mock.Anything).Twice()
+	sMock.On("GetWishlist", mock.Anything).Return([]dtos.ResponseWishlistItem{
+		{ID: 3, Name: "Lightning Bolt", Set: "Alpha", CollectorNumber: "161", Finish: "nonfoil", MaxPrice: 50, LastPrice: &lastPrice, Affordable: true},
+	}, nil)
+
+	h := NewWishlistHandler(vMock, sMock, lMock)
+
+	req, _ := http.NewRequest(http.MethodGet, "/wishlist", nil)
+	resp := httptest.NewRecorder()
+
+	h.GetWishlist(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"id": 3, "name": "Lightning Bolt", "set": "Alpha", "collector_number": "161", "finish": "nonfoil",
+		"max_price": 50, "last_price": 45, "affordable": true}]`, resp.Body.String())
+	sMock.AssertExpectations(t)
+	lMock.AssertExpectations(t)
+}
+
+func Test_UpdateWishlistItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when target price is updated", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when item is not found",
+			serviceErr: fmt.Errorf("service failed to update wishlist item: %w", domain.ErrWishlistItemNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWishlistServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			maxPrice := 40.0
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", []string{"", "wishlist", "3"}).Return("3", nil)
+			vMock.On("UpdateWishlistItem", dtos.RequestWishlistItem{MaxPrice: &maxPrice}).Return(nil)
+			sMock.On("UpdateWishlistItem", mock.Anything, dtos.RequestWishlistItem{ID: "3", MaxPrice: &maxPrice}).
+				Return(dtos.ResponseWishlistItem{ID: 3, MaxPrice: 40}, tt.serviceErr)
+
+			h := NewWishlistHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodPatch, "/wishlist/3", bytes.NewBufferString(`{"max_price": 40}`))
+			resp := httptest.NewRecorder()
+
+			h.UpdateWishlistItem(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeleteWishlistItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "should return StatusOK when item is deleted", wantCode: http.StatusOK},
+		{name: "should return StatusBadRequest when item is not found",
+			serviceErr: fmt.Errorf("service failed to delete wishlist item: %w", domain.ErrWishlistItemNotFound{}), wantCode: http.StatusBadRequest},
+		{name: "should return StatusInternalServerError when service fails",
+			serviceErr: errors.New("service error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sMock := mocks.NewWishlistServiceMock()
+			vMock := mocks.NewValidateMock()
+			lMock := mocks.NewLogMock()
+			cMock := mocks.NewCustomMock()
+
+			lMock.On("Info", mock.Anything)
+			lMock.On("WithError", mock.Anything).Return(cMock).Maybe()
+			cMock.On("Warn", mock.Anything).Maybe()
+			cMock.On("Error", mock.Anything).Maybe()
+			vMock.On("CardID", mock.Anything).Return("3", nil)
+			sMock.On("DeleteWishlistItem", mock.Anything, "3").Return(tt.serviceErr)
+
+			h := NewWishlistHandler(vMock, sMock, lMock)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/wishlist/3", nil)
+			resp := httptest.NewRecorder()
+
+			h.DeleteWishlistItem(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			sMock.AssertExpectations(t)
+			vMock.AssertExpectations(t)
+		})
+	}
+}
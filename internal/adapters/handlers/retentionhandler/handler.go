@@ -0,0 +1,32 @@
+package retentionhandler
+
+import (
+	"context"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+)
+
+type handler struct {
+	RetentionService ports.RetentionService
+	log              logrus.Logger
+}
+
+func New(rs ports.RetentionService, log logrus.Logger) *handler {
+	return &handler{
+		RetentionService: rs,
+		log:              log,
+	}
+}
+
+func (h *handler) RollUp(ctx context.Context) error {
+	h.log.Info("roll up price history")
+
+	err := h.RetentionService.RollUp(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.log.Info("price history rolled up")
+
+	return nil
+}
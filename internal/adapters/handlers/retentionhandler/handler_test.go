@@ -0,0 +1,58 @@
+package retentionhandler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew(t *testing.T) {
+	mockRetentionService := mocks.NewRetentionServiceMock()
+	mockLogger := mocks.NewLogMock()
+
+	handler := New(mockRetentionService, mockLogger)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockRetentionService, handler.RetentionService)
+	assert.Equal(t, mockLogger, handler.log)
+}
+
+func TestRollUp_Success(t *testing.T) {
+	mockRetentionService := mocks.NewRetentionServiceMock()
+	mockLogger := mocks.NewLogMock()
+
+	handler := New(mockRetentionService, mockLogger)
+
+	mockLogger.On("Info", mock.Anything).Twice()
+	mockRetentionService.On("RollUp", mock.Anything).Return(nil)
+
+	err := handler.RollUp(context.Background())
+
+	assert.NoError(t, err)
+	mockRetentionService.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestRollUp_ServiceError(t *testing.T) {
+	mockRetentionService := mocks.NewRetentionServiceMock()
+	mockLogger := mocks.NewLogMock()
+
+	handler := New(mockRetentionService, mockLogger)
+
+	expectedError := fmt.Errorf("service error")
+
+	mockLogger.On("Info", mock.Anything).Once()
+	mockRetentionService.On("RollUp", mock.Anything).Return(expectedError)
+
+	err := handler.RollUp(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockRetentionService.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
@@ -0,0 +1,189 @@
+package alertrepo
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) InsertAlert(ctx context.Context, userID int64, alert domain.Alert) (domain.Alert, error) {
+	// a card alert is only stored when the user can see the card.
+	insertAlertQuery := `
+	INSERT INTO alerts 
+		(user_id, card_id, set_name, kind, direction, threshold, created_at) 
+	SELECT 
+		?, ?, ?, ?, ?, ?, ? 
+	FROM DUAL 
+	WHERE 
+		? IS NULL OR EXISTS (
+			SELECT 1 
+			FROM cards c 
+			WHERE c.id = ? AND c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?)
+		);`
+
+	res, err := r.db.ExecContext(ctx, insertAlertQuery, userID, alert.CardID, alert.SetName, alert.Kind, alert.Direction,
+		alert.Threshold, alert.CreatedAt, alert.CardID, alert.CardID, userID)
+	if err != nil {
+		return domain.Alert{}, fmt.Errorf("repository failed to exec insert query in insert alert: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return domain.Alert{}, fmt.Errorf("repository failed to get rows affected in insert alert: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.Alert{}, domain.ErrCardNotFound{}
+	}
+
+	alert.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.Alert{}, fmt.Errorf("repository failed to get last inserted id in insert alert: %w", err)
+	}
+
+	alert.UserID = userID
+
+	return alert, nil
+}
+
+func (r *repository) GetAlerts(ctx context.Context, userID int64) ([]domain.Alert, error) {
+	getAlertsQuery := `
+	SELECT 
+		id,
+		user_id,
+		card_id,
+		set_name,
+		kind,
+		direction,
+		threshold,
+		created_at
+	FROM 
+		alerts 
+	WHERE 
+		user_id = ?
+	ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, getAlertsQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []domain.Alert
+
+	for rows.Next() {
+		var alert domain.Alert
+		err := rows.Scan(&alert.ID, &alert.UserID, &alert.CardID, &alert.SetName, &alert.Kind, &alert.Direction,
+			&alert.Threshold, &alert.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get alerts: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *repository) DeleteAlert(ctx context.Context, userID int64, id string) error {
+	deleteAlertQuery := `
+	DELETE FROM alerts 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	res, err := r.db.ExecContext(ctx, deleteAlertQuery, id, userID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec delete query in delete alert: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected in delete alert: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrAlertNotFound{}
+	}
+
+	return nil
+}
+
+func (r *repository) GetAlertEvents(ctx context.Context, userID int64, offset, limit int) ([]domain.AlertEvent, error) {
+	// alert and card ids are cleared when they are deleted, the event keeps
+	// its own copy of everything it shows.
+	getEventsQuery := `
+	SELECT 
+		id,
+		COALESCE(alert_id, 0),
+		user_id,
+		COALESCE(card_id, 0),
+		card_name,
+		set_name,
+		kind,
+		direction,
+		threshold,
+		old_price,
+		new_price,
+		triggered_at
+	FROM 
+		alert_events 
+	WHERE 
+		user_id = ?
+	ORDER BY triggered_at DESC, id DESC
+	LIMIT ?, ?;`
+
+	rows, err := r.db.QueryContext(ctx, getEventsQuery, userID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get alert events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.AlertEvent
+
+	for rows.Next() {
+		var event domain.AlertEvent
+		err := rows.Scan(&event.ID, &event.Alert.ID, &event.Alert.UserID, &event.CardID, &event.CardName, &event.CardSetName,
+			&event.Alert.Kind, &event.Alert.Direction, &event.Alert.Threshold, &event.OldPrice, &event.NewPrice, &event.TriggeredAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get alert events: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get alert events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *repository) GetAlertEventsCount(ctx context.Context, userID int64) (int64, error) {
+	countQuery := `
+	SELECT COUNT(*)
+	FROM 
+		alert_events 
+	WHERE 
+		user_id = ?;`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan count in get alert events count: %w", err)
+	}
+
+	return count, nil
+}
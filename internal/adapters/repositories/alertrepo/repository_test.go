@@ -0,0 +1,161 @@
+package alertrepo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestInsertAlert_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	cardID := int64(3)
+	createdAt := time.Now()
+	alert := domain.Alert{CardID: &cardID, Kind: domain.AlertPercentage, Direction: domain.AlertUp, Threshold: 10, CreatedAt: createdAt}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, &cardID, "", domain.AlertPercentage,
+		domain.AlertUp, 10.0, createdAt, &cardID, &cardID, testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockResult.On("LastInsertId").Return(int64(2), nil)
+
+	got, err := repo.InsertAlert(context.Background(), testUserID, alert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got.ID)
+	assert.Equal(t, testUserID, got.UserID)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertAlert_CardNotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	cardID := int64(3)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+
+	_, err := repo.InsertAlert(context.Background(), testUserID, domain.Alert{CardID: &cardID})
+
+	assert.IsType(t, domain.ErrCardNotFound{}, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertNotCalled(t, "LastInsertId")
+}
+
+func TestInsertAlert_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	_, err := repo.InsertAlert(context.Background(), testUserID, domain.Alert{SetName: "Alpha"})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert alert")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetAlerts_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowsScanner, nil)
+
+	alerts, err := repo.GetAlerts(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestDeleteAlert_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+
+	err := repo.DeleteAlert(context.Background(), testUserID, "2")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestDeleteAlert_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+
+	err := repo.DeleteAlert(context.Background(), testUserID, "2")
+
+	assert.IsType(t, domain.ErrAlertNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetAlertEvents_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, 10, 5}).Return(mockRowsScanner, nil)
+
+	events, err := repo.GetAlertEvents(context.Background(), testUserID, 10, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetAlertEventsCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowScanner)
+
+	_, err := repo.GetAlertEventsCount(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
@@ -10,8 +10,64 @@ import (
 	database "mtg-report/internal/sources/databases/mysql"
 	"mtg-report/internal/sources/logger/logrus"
 	"strings"
+	"time"
+)
+
+// upsertCardQuery increments the quantity of an existing entry. The acquisition
+// price becomes the weighted average of both lots, after converting the new
+// lot into the currency of the entry through the BRL value of each lot, and
+// the rate is weighted so that the cost basis in BRL of both lots is kept. A
+// new lot whose value cannot be converted is counted at the entry price.
+// MySQL applies the assignments from left to right, so currency must come
+// first, rate before price, and quantity last.
+const upsertCardQuery = `
+	ON DUPLICATE KEY UPDATE 
+		acquisition_currency = IF(acquisition_price IS NULL AND VALUES(acquisition_price) IS NOT NULL, VALUES(acquisition_currency), acquisition_currency),
+		acquisition_rate = CASE
+			WHEN VALUES(acquisition_price) IS NULL THEN acquisition_rate
+			WHEN acquisition_price IS NULL THEN VALUES(acquisition_rate)
+			WHEN acquisition_currency <> VALUES(acquisition_currency) THEN acquisition_rate
+			WHEN acquisition_price * quantity + VALUES(acquisition_price) * VALUES(quantity) = 0 THEN VALUES(acquisition_rate)
+			ELSE (acquisition_price * acquisition_rate * quantity + VALUES(acquisition_price) * VALUES(acquisition_rate) * VALUES(quantity)) /
+				(acquisition_price * quantity + VALUES(acquisition_price) * VALUES(quantity))
+		END,
+		acquisition_price = CASE
+			WHEN VALUES(acquisition_price) IS NULL THEN acquisition_price
+			WHEN acquisition_price IS NULL THEN VALUES(acquisition_price)
+			WHEN acquisition_currency = VALUES(acquisition_currency) THEN
+				(acquisition_price * quantity + VALUES(acquisition_price) * VALUES(quantity)) / (quantity + VALUES(quantity))
+			ELSE (acquisition_price * quantity +
+				COALESCE(VALUES(acquisition_price) * VALUES(acquisition_rate) / NULLIF(acquisition_rate, 0), acquisition_price) * VALUES(quantity)) /
+				(quantity + VALUES(quantity))
+		END,
+		acquisition_date = COALESCE(acquisition_date, VALUES(acquisition_date)),
+		quantity = quantity + VALUES(quantity)`
+
+// acquisitionRate is the value in BRL of one unit of an acquisition currency
+// on an acquisition date, from the stored exchange rates: the last rate on or
+// before that day, or the oldest one when the date is older than the history.
+// A NULL date takes the latest rate. It takes the currency twice and the date
+// twice as arguments, and is NULL when no rate was ever stored.
+func acquisitionRate(currency, date string) string {
+	return fmt.Sprintf(`CASE %[1]s WHEN 'BRL' THEN 1 ELSE (
+		SELECT 
+			brl.rate / cur.rate 
+		FROM 
+			exchange_rates brl 
+		JOIN 
+			exchange_rates cur ON cur.rate_date = brl.rate_date AND cur.currency = %[1]s 
+		WHERE 
+			brl.currency = 'BRL' AND cur.rate > 0 
+		ORDER BY 
+			brl.rate_date <= COALESCE(%[2]s, CURRENT_DATE) DESC, ABS(DATEDIFF(brl.rate_date, COALESCE(%[2]s, CURRENT_DATE))) 
+		LIMIT 1) END`, currency, date)
+}
 
-	"github.com/go-sql-driver/mysql"
+// memberCards restricts a query on cards c to the collections a user is a
+// member of, editableCards to the ones the user may change.
+const (
+	memberCards   = "c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?)"
+	editableCards = "c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ? AND role IN ('editor', 'owner'))"
 )
 
 type repository struct {
@@ -26,20 +82,25 @@ func New(db database.Client, log logrus.Logger) *repository {
 	}
 }
 
-func (r *repository) InsertCard(ctx context.Context, card domain.Cards) (domain.Cards, error) {
+func (r *repository) InsertCard(ctx context.Context, userID int64, card domain.Cards) (domain.Cards, error) {
+	collectionID, err := collectionOf(ctx, r.db, userID, card.CollectionID)
+	if err != nil {
+		return domain.Cards{}, fmt.Errorf("repository failed to get collection in insert card: %w", err)
+	}
+	card.CollectionID = collectionID
+
 	insertCardQuery := `
 	INSERT INTO cards 
-		(name, set_name, collector_number, foil) 
+		(name, set_name, collector_number, finish, card_condition, language, quantity,
+		acquisition_price, acquisition_currency, acquisition_rate, acquisition_date, collection_id) 
 	VALUES 
-		(?, ?, ?, ?);`
+		(?, ?, ?, ?, ?, ?, ?, ?, ?, ` + acquisitionRate("?", "?") + `, ?, ?)` + upsertCardQuery + `,
+		id = LAST_INSERT_ID(id);`
 
-	res, err := r.db.ExecContext(ctx, insertCardQuery, card.Name, card.SetName, card.CollectorNumber, card.Foil)
+	res, err := r.db.ExecContext(ctx, insertCardQuery, card.Name, card.SetName, card.CollectorNumber, card.Finish, card.Condition,
+		card.Language, card.Quantity, card.AcquisitionPrice, card.AcquisitionCurrency, card.AcquisitionCurrency, card.AcquisitionCurrency,
+		card.AcquisitionDate, card.AcquisitionDate, card.AcquisitionDate, card.CollectionID)
 	if err != nil {
-		if driverErr, ok := err.(*mysql.MySQLError); ok {
-			if driverErr.Number == 1062 {
-				return domain.Cards{}, domain.ErrCardAlreadyExists{}
-			}
-		}
 		return domain.Cards{}, fmt.Errorf("repository failed to exec insert query in insert card: %w", err)
 	}
 
@@ -48,19 +109,32 @@ func (r *repository) InsertCard(ctx context.Context, card domain.Cards) (domain.
 		return domain.Cards{}, fmt.Errorf("repository failed to get last inserted id in insert card: %w", err)
 	}
 
+	getQuantityQuery := `
+	SELECT 
+		quantity 
+	FROM 
+		cards 
+	WHERE 
+		id = ?;`
+
+	err = r.db.QueryRowContext(ctx, getQuantityQuery, id).Scan(&card.Quantity)
+	if err != nil {
+		return domain.Cards{}, fmt.Errorf("repository failed to scan quantity in insert card: %w", err)
+	}
+
 	card.ID = id
 
 	return card, nil
 }
 
-func (r *repository) DeleteCard(ctx context.Context, id string) error {
+func (r *repository) DeleteCard(ctx context.Context, userID int64, id string) error {
 	DeleteCardQuery := `
-	DELETE FROM 
-		cards 
+	DELETE c FROM 
+		cards c 
 	WHERE
-		id = ?`
+		c.id = ? AND ` + editableCards
 
-	_, err := r.db.ExecContext(ctx, DeleteCardQuery, id)
+	_, err := r.db.ExecContext(ctx, DeleteCardQuery, id, userID)
 	if err != nil {
 		return fmt.Errorf("repository failed to exec delete query in delete card: %w", err)
 	}
@@ -68,37 +142,41 @@ func (r *repository) DeleteCard(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *repository) GetCardbyID(ctx context.Context, id string) (domain.Cards, error) {
+func (r *repository) GetCardbyID(ctx context.Context, userID int64, id string) (domain.Cards, error) {
 	getCardQuery := `
 	SELECT 
 		c.id,
 		name,
 		set_name,
 		collector_number,
-		foil,
+		finish,
+		card_condition,
+		language,
+		quantity,
+		collection_id,
 		COALESCE(cd.last_price, 0) as last_price,
 		COALESCE(cd.old_price, 0) as old_price,
 		COALESCE(cd.price_change, 0) as price_change,
-		cd.last_update
+		cd.last_update,
+		c.acquisition_price,
+		c.acquisition_currency,
+		c.acquisition_rate,
+		c.acquisition_date
 	FROM 
 		cards c
 	LEFT JOIN 
-	(
-		SELECT *,
-			ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-		FROM 
-			cards_details
-	) cd
+		cards_current_price cd
 	ON 
-		c.id = cd.card_id AND cd.rn = 1
+		c.id = cd.card_id
 	WHERE 
-		c.id = ?;`
+		c.id = ? AND ` + memberCards + `;`
 
-	row := r.db.QueryRowContext(ctx, getCardQuery, id)
+	row := r.db.QueryRowContext(ctx, getCardQuery, id, userID)
 
 	var cardDomain domain.Cards
 	err := row.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber,
-		&cardDomain.Foil, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate)
+		&cardDomain.Finish, &cardDomain.Condition, &cardDomain.Language, &cardDomain.Quantity, &cardDomain.CollectionID, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate,
+		&cardDomain.AcquisitionPrice, &cardDomain.AcquisitionCurrency, &cardDomain.AcquisitionRate, &cardDomain.AcquisitionDate)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.Cards{}, domain.ErrCardNotFound{}
@@ -110,44 +188,36 @@ func (r *repository) GetCardbyID(ctx context.Context, id string) (domain.Cards,
 	return cardDomain, nil
 }
 
-func (r *repository) GetCards(ctx context.Context, filters map[string]string) ([]domain.Cards, error) {
+func (r *repository) GetCards(ctx context.Context, userID int64, filters map[string]string) ([]domain.Cards, error) {
 	getCardsQuery := `
     SELECT 
         c.id,
         name,
         set_name,
         collector_number,
-        foil,
+        finish,
+        card_condition,
+        language,
+        quantity,
+        collection_id,
         COALESCE(cd.last_price, 0) as last_price,
         COALESCE(cd.old_price, 0) as old_price,
         COALESCE(cd.price_change, 0) as price_change,
-        last_update
+        last_update,
+        c.acquisition_price,
+        c.acquisition_currency,
+        c.acquisition_rate,
+        c.acquisition_date
     FROM 
         cards c
     LEFT JOIN 
-    (
-        SELECT *,
-            ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-        FROM 
-            cards_details
-    ) cd
+        cards_current_price cd
     ON 
-        c.id = cd.card_id AND cd.rn = 1
+        c.id = cd.card_id
     `
 
-	var first bool = true
-	var values []interface{}
-
-	for key, value := range filters {
-		if !first {
-			getCardsQuery += " AND "
-		} else {
-			getCardsQuery += " WHERE "
-		}
-		getCardsQuery += fmt.Sprintf("%s = ?", key)
-		values = append(values, value)
-		first = false
-	}
+	where, values := filtersClause(userID, filters)
+	getCardsQuery += where
 
 	getCardsQuery += " ORDER BY last_price DESC"
 
@@ -161,7 +231,8 @@ func (r *repository) GetCards(ctx context.Context, filters map[string]string) ([
 	for rows.Next() {
 		var cardDomain domain.Cards
 		err := rows.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber,
-			&cardDomain.Foil, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate)
+			&cardDomain.Finish, &cardDomain.Condition, &cardDomain.Language, &cardDomain.Quantity, &cardDomain.CollectionID, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate,
+			&cardDomain.AcquisitionPrice, &cardDomain.AcquisitionCurrency, &cardDomain.AcquisitionRate, &cardDomain.AcquisitionDate)
 		if err != nil {
 			return nil, fmt.Errorf("repository failed to scan row in get cards: %w", err)
 		}
@@ -175,25 +246,46 @@ func (r *repository) GetCards(ctx context.Context, filters map[string]string) ([
 	return cardsDomain, nil
 }
 
-func (r *repository) InsertCards(ctx context.Context, cards []domain.Cards) error {
+func (r *repository) InsertCards(ctx context.Context, userID int64, cards []domain.Cards) error {
+	collections := make(map[int64]int64)
+	for i := range cards {
+		collectionID, ok := collections[cards[i].CollectionID]
+		if !ok {
+			var err error
+			collectionID, err = collectionOf(ctx, r.db, userID, cards[i].CollectionID)
+			if err != nil {
+				return fmt.Errorf("repository failed to get collection in insert cards: %w", err)
+			}
+			collections[cards[i].CollectionID] = collectionID
+		}
+		cards[i].CollectionID = collectionID
+	}
+
 	valueStrings := make([]string, 0, len(cards))
-	valueArgs := make([]interface{}, 0, len(cards)*6)
+	valueArgs := make([]interface{}, 0, len(cards)*15)
 	for _, card := range cards {
-		valueStrings = append(valueStrings, "(?, ?, ?, ?)")
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, "+acquisitionRate("?", "?")+", ?, ?)")
 		valueArgs = append(valueArgs, card.Name)
 		valueArgs = append(valueArgs, card.SetName)
 		valueArgs = append(valueArgs, card.CollectorNumber)
-		valueArgs = append(valueArgs, card.Foil)
+		valueArgs = append(valueArgs, card.Finish)
+		valueArgs = append(valueArgs, card.Condition)
+		valueArgs = append(valueArgs, card.Language)
+		valueArgs = append(valueArgs, card.Quantity)
+		valueArgs = append(valueArgs, card.AcquisitionPrice)
+		valueArgs = append(valueArgs, card.AcquisitionCurrency)
+		valueArgs = append(valueArgs, card.AcquisitionCurrency, card.AcquisitionCurrency, card.AcquisitionDate, card.AcquisitionDate)
+		valueArgs = append(valueArgs, card.AcquisitionDate)
+		valueArgs = append(valueArgs, card.CollectionID)
 	}
 
 	stmt := fmt.Sprintf(`
 	INSERT INTO cards 
-		(name, set_name, collector_number, foil) 
+		(name, set_name, collector_number, finish, card_condition, language, quantity,
+		acquisition_price, acquisition_currency, acquisition_rate, acquisition_date, collection_id) 
 	VALUES 
-		%s 
-	ON DUPLICATE KEY UPDATE 
-		name = name;`,
-		strings.Join(valueStrings, ","))
+		%s %s;`,
+		strings.Join(valueStrings, ","), upsertCardQuery)
 
 	_, err := r.db.ExecContext(ctx, stmt, valueArgs...)
 	if err != nil {
@@ -203,7 +295,7 @@ func (r *repository) InsertCards(ctx context.Context, cards []domain.Cards) erro
 	return nil
 }
 
-func (r *repository) GetCardHistory(ctx context.Context, id string) ([]domain.Cards, error) {
+func (r *repository) GetCardHistory(ctx context.Context, userID int64, id string) ([]domain.Cards, error) {
 	cards := []entities.MysqlCardPriceHistory{}
 
 	getQuery := `
@@ -212,7 +304,10 @@ func (r *repository) GetCardHistory(ctx context.Context, id string) ([]domain.Ca
 		c.name,
 		c.set_name,
 		c.collector_number,
-		c.foil,
+		c.finish,
+		c.card_condition,
+		c.language,
+		c.quantity,
 		COALESCE(cd.last_price, 0),
 		COALESCE(cd.old_price, 0),
 		COALESCE(cd.price_change, 0),
@@ -224,11 +319,11 @@ func (r *repository) GetCardHistory(ctx context.Context, id string) ([]domain.Ca
 	ON 
 		c.id = cd.card_id
 	WHERE 
-		c.id = ?
+		c.id = ? AND ` + memberCards + `
 	ORDER BY 
 		last_update DESC;
 	`
-	rows, err := r.db.QueryContext(ctx, getQuery, id)
+	rows, err := r.db.QueryContext(ctx, getQuery, id, userID)
 	if err != nil {
 		return nil, fmt.Errorf("repository failed to query in get cards history: %w", err)
 	}
@@ -236,7 +331,7 @@ func (r *repository) GetCardHistory(ctx context.Context, id string) ([]domain.Ca
 
 	for rows.Next() {
 		var card entities.MysqlCardPriceHistory
-		err = rows.Scan(&card.ID, &card.Name, &card.SetName, &card.CollectorNumber, &card.Foil, &card.LastPrice, &card.OldPrice, &card.PriceChange, &card.LastUpdate)
+		err = rows.Scan(&card.ID, &card.Name, &card.SetName, &card.CollectorNumber, &card.Finish, &card.Condition, &card.Language, &card.Quantity, &card.LastPrice, &card.OldPrice, &card.PriceChange, &card.LastUpdate)
 		if err != nil {
 			return nil, fmt.Errorf("repository failed to scan rows in get cards history: %w", err)
 		}
@@ -254,7 +349,7 @@ func (r *repository) GetCardHistory(ctx context.Context, id string) ([]domain.Ca
 	return factories.CardPriceHistoryToCardsDomain(cards), nil
 }
 
-func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (domain.Cards, error) {
+func (r *repository) UpdateCard(ctx context.Context, userID int64, card domain.UpdateCard) (domain.Cards, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return domain.Cards{}, fmt.Errorf("repository failed to begin transaction in update card: %w", err)
@@ -265,12 +360,12 @@ func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (do
 	SELECT 
 		COUNT(*) 
 	FROM 
-		cards 
+		cards c 
 	WHERE 
-		id = ?;`
+		c.id = ? AND ` + editableCards + `;`
 
 	var count int
-	err = tx.QueryRowContext(ctx, checkCardQuery, card.ID).Scan(&count)
+	err = tx.QueryRowContext(ctx, checkCardQuery, card.ID, userID).Scan(&count)
 	if err != nil {
 		return domain.Cards{}, fmt.Errorf("repository failed to query card count in update card: %w", err)
 	}
@@ -279,15 +374,60 @@ func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (do
 		return domain.Cards{}, domain.ErrCardNotFound{}
 	}
 
-	updateCardQuery := `
+	if card.CollectionID != 0 {
+		_, err = collectionOf(ctx, tx, userID, card.CollectionID)
+		if err != nil {
+			return domain.Cards{}, fmt.Errorf("repository failed to get collection in update card: %w", err)
+		}
+	}
+
+	setClauses := make([]string, 0, 6)
+	values := make([]interface{}, 0, 7)
+
+	if len(card.Name) != 0 {
+		setClauses = append(setClauses, "name = ?")
+		values = append(values, card.Name)
+	}
+
+	if card.Quantity != 0 {
+		setClauses = append(setClauses, "quantity = ?")
+		values = append(values, card.Quantity)
+	}
+
+	if card.CollectionID != 0 {
+		setClauses = append(setClauses, "collection_id = ?")
+		values = append(values, card.CollectionID)
+	}
+
+	if card.AcquisitionPrice != nil {
+		setClauses = append(setClauses, "acquisition_price = ?")
+		values = append(values, *card.AcquisitionPrice)
+	}
+
+	if len(card.AcquisitionCurrency) != 0 {
+		setClauses = append(setClauses, "acquisition_currency = ?")
+		values = append(values, card.AcquisitionCurrency)
+	}
+
+	if card.AcquisitionDate != nil {
+		setClauses = append(setClauses, "acquisition_date = ?")
+		values = append(values, *card.AcquisitionDate)
+	}
+
+	values = append(values, card.ID)
+
+	updateCardQuery := fmt.Sprintf(`
 	UPDATE cards 
 	SET 
-		name = ? 
+		%s 
 	WHERE
-		id = ?;`
+		id = ?;`, strings.Join(setClauses, ", "))
 
-	result, err := tx.ExecContext(ctx, updateCardQuery, card.Name, card.ID)
+	result, err := tx.ExecContext(ctx, updateCardQuery, values...)
 	if err != nil {
+		if database.IsError(err, database.ErrDuplicateEntry) {
+			return domain.Cards{}, domain.ErrCardAlreadyExists{}
+		}
 		return domain.Cards{}, fmt.Errorf("repository failed to exec update query in update card: %w", err)
 	}
 
@@ -296,13 +436,37 @@ func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (do
 		return domain.Cards{}, fmt.Errorf("repository failed to get rows affected in update card: %w", err)
 	}
 
+	// the rate follows the acquisition, so it is resolved again from the
+	// currency and date stored after the update.
+	if card.AcquisitionPrice != nil || len(card.AcquisitionCurrency) != 0 || card.AcquisitionDate != nil {
+		updateRateQuery := `
+	UPDATE cards 
+	SET 
+		acquisition_rate = ` + acquisitionRate("cards.acquisition_currency", "cards.acquisition_date") + `
+	WHERE
+		id = ?;`
+
+		_, err = tx.ExecContext(ctx, updateRateQuery, card.ID)
+		if err != nil {
+			return domain.Cards{}, fmt.Errorf("repository failed to exec update rate query in update card: %w", err)
+		}
+	}
+
 	getCardQuery := `
 	SELECT 
 		c.id,
 		name,
 		set_name,
 		collector_number,
-		foil
+		finish,
+		card_condition,
+		language,
+		quantity,
+		collection_id,
+		acquisition_price,
+		acquisition_currency,
+		acquisition_rate,
+		acquisition_date
 	FROM 
 		cards c
 	WHERE 
@@ -314,7 +478,8 @@ func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (do
 	}
 
 	var cardDomain domain.Cards
-	err = row.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber, &cardDomain.Foil)
+	err = row.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber, &cardDomain.Finish, &cardDomain.Condition, &cardDomain.Language, &cardDomain.Quantity, &cardDomain.CollectionID,
+		&cardDomain.AcquisitionPrice, &cardDomain.AcquisitionCurrency, &cardDomain.AcquisitionRate, &cardDomain.AcquisitionDate)
 	if err != nil {
 		return domain.Cards{}, fmt.Errorf("repository failed to scan row in update card: %w", err)
 	}
@@ -327,44 +492,36 @@ func (r *repository) UpdateCard(ctx context.Context, card domain.UpdateCard) (do
 	return cardDomain, nil
 }
 
-func (r *repository) GetCardsPaginated(ctx context.Context, filters map[string]string, offset, limit int) ([]domain.Cards, error) {
+func (r *repository) GetCardsPaginated(ctx context.Context, userID int64, filters map[string]string, offset, limit int) ([]domain.Cards, error) {
 	getCardsQuery := `
     SELECT 
         c.id,
         name,
         set_name,
         collector_number,
-        foil,
+        finish,
+        card_condition,
+        language,
+        quantity,
+        collection_id,
         COALESCE(cd.last_price, 0) as last_price,
         COALESCE(cd.old_price, 0) as old_price,
         COALESCE(cd.price_change, 0) as price_change,
-        last_update
+        last_update,
+        c.acquisition_price,
+        c.acquisition_currency,
+        c.acquisition_rate,
+        c.acquisition_date
     FROM 
         cards c
     LEFT JOIN 
-    (
-        SELECT *,
-            ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-        FROM 
-            cards_details
-    ) cd
+        cards_current_price cd
     ON 
-        c.id = cd.card_id AND cd.rn = 1
+        c.id = cd.card_id
     `
 
-	var first bool = true
-	var values []interface{}
-
-	for key, value := range filters {
-		if !first {
-			getCardsQuery += " AND "
-		} else {
-			getCardsQuery += " WHERE "
-		}
-		getCardsQuery += fmt.Sprintf("%s = ?", key)
-		values = append(values, value)
-		first = false
-	}
+	where, values := filtersClause(userID, filters)
+	getCardsQuery += where
 
 	getCardsQuery += " ORDER BY last_price DESC LIMIT ? OFFSET ?"
 	values = append(values, limit, offset)
@@ -380,7 +537,8 @@ func (r *repository) GetCardsPaginated(ctx context.Context, filters map[string]s
 	for rows.Next() {
 		var cardDomain domain.Cards
 		err := rows.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber,
-			&cardDomain.Foil, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate)
+			&cardDomain.Finish, &cardDomain.Condition, &cardDomain.Language, &cardDomain.Quantity, &cardDomain.CollectionID, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate,
+			&cardDomain.AcquisitionPrice, &cardDomain.AcquisitionCurrency, &cardDomain.AcquisitionRate, &cardDomain.AcquisitionDate)
 		if err != nil {
 			return nil, fmt.Errorf("repository failed to scan row in get cards paginated: %w", err)
 		}
@@ -390,25 +548,14 @@ func (r *repository) GetCardsPaginated(ctx context.Context, filters map[string]s
 	return cardsDomain, nil
 }
 
-func (r *repository) GetCardsCount(ctx context.Context, filters map[string]string) (int64, error) {
+func (r *repository) GetCardsCount(ctx context.Context, userID int64, filters map[string]string) (int64, error) {
 	countQuery := `
     SELECT COUNT(*)
     FROM cards c
     `
 
-	var first bool = true
-	var values []interface{}
-
-	for key, value := range filters {
-		if !first {
-			countQuery += " AND "
-		} else {
-			countQuery += " WHERE "
-		}
-		countQuery += fmt.Sprintf("%s = ?", key)
-		values = append(values, value)
-		first = false
-	}
+	where, values := filtersClause(userID, filters)
+	countQuery += where
 
 	row := r.db.QueryRowContext(ctx, countQuery, values...)
 
@@ -421,105 +568,424 @@ func (r *repository) GetCardsCount(ctx context.Context, filters map[string]strin
 	return count, nil
 }
 
-func (r *repository) GetCardHistoryPaginated(ctx context.Context, id string, offset, limit int) ([]domain.Cards, error) {
-	cards := []entities.MysqlCardPriceHistory{}
-
-	getQuery := `
+// priceHistory groups the price history of a card into the periods of
+// resolution. The history is made of the daily snapshots of the card and the
+// weekly and monthly aggregates the retention job rolled older snapshots up
+// into. Points finer than resolution are merged into the period their own
+// period starts in, as the retention job does, while coarser ones are kept as
+// they are, since the snapshots behind them are gone. bucket_id keeps apart
+// the points that share a period but are not merged.
+func priceHistory(resolution domain.PriceResolution) string {
+	var buckets string
+	switch resolution {
+	case domain.PriceResolutionWeekly:
+		buckets = `
+				IF(resolution = 'daily', 'weekly', resolution) AS bucket_resolution,
+				IF(resolution = 'daily', DATE_SUB(period_start, INTERVAL WEEKDAY(period_start) DAY), period_start) AS bucket_start,
+				IF(resolution = 'daily', 0, 1) AS bucket_id,`
+	case domain.PriceResolutionMonthly:
+		buckets = `
+				'monthly' AS bucket_resolution,
+				IF(resolution = 'monthly', period_start, CAST(DATE_FORMAT(period_start, '%Y-%m-01') AS DATE)) AS bucket_start,
+				IF(resolution = 'monthly', 1, 0) AS bucket_id,`
+	default:
+		buckets = `
+				resolution AS bucket_resolution,
+				period_start AS bucket_start,
+				snapshot_id AS bucket_id,`
+	}
+
+	return `
 	SELECT 
-		c.id,
-		c.name,
-		c.set_name,
-		c.collector_number,
-		c.foil,
-		COALESCE(cd.last_price, 0),
-		COALESCE(cd.old_price, 0),
-		COALESCE(cd.price_change, 0),
-		cd.last_update
-	FROM 
-		cards c 
-	LEFT JOIN 
-		cards_details cd 
-	ON 
-		c.id = cd.card_id
-	WHERE 
-		c.id = ?
-	ORDER BY 
-		last_update DESC
-	LIMIT ? OFFSET ?;
-	`
-	rows, err := r.db.QueryContext(ctx, getQuery, id, limit, offset)
+		bucket_resolution,
+		bucket_start,
+		MAX(CASE WHEN first_rn = 1 THEN old_price END),
+		MAX(CASE WHEN first_rn = 1 THEN open_price END),
+		MAX(CASE WHEN last_rn = 1 THEN close_price END),
+		MIN(min_price),
+		MAX(max_price),
+		MIN(opened_at),
+		MAX(closed_at) AS closed_at,
+		SUM(samples)
+	FROM
+	(
+		SELECT 
+			points.*,
+			ROW_NUMBER() OVER(PARTITION BY bucket_resolution, bucket_start, bucket_id ORDER BY opened_at) AS first_rn,
+			ROW_NUMBER() OVER(PARTITION BY bucket_resolution, bucket_start, bucket_id ORDER BY closed_at DESC) AS last_rn
+		FROM
+		(
+			SELECT ` + buckets + `
+				old_price,
+				open_price,
+				close_price,
+				min_price,
+				max_price,
+				opened_at,
+				closed_at,
+				samples
+			FROM
+			(
+				SELECT 
+					'daily' AS resolution,
+					DATE(cd.last_update) AS period_start,
+					cd.id AS snapshot_id,
+					cd.old_price,
+					cd.last_price AS open_price,
+					cd.last_price AS close_price,
+					cd.last_price AS min_price,
+					cd.last_price AS max_price,
+					cd.last_update AS opened_at,
+					cd.last_update AS closed_at,
+					1 AS samples
+				FROM 
+					cards c 
+				JOIN 
+					cards_details cd 
+				ON 
+					c.id = cd.card_id
+				WHERE 
+					c.id = ? AND ` + memberCards + `
+				UNION ALL
+				SELECT 
+					pa.resolution,
+					pa.period_start,
+					0,
+					pa.old_price,
+					pa.open_price,
+					pa.close_price,
+					pa.min_price,
+					pa.max_price,
+					pa.opened_at,
+					pa.closed_at,
+					pa.samples
+				FROM 
+					cards c 
+				JOIN 
+					cards_price_aggregates pa 
+				ON 
+					c.id = pa.card_id
+				WHERE 
+					c.id = ? AND ` + memberCards + `
+			) history
+		) points
+	) ranked
+	GROUP BY 
+		bucket_resolution, bucket_start, bucket_id`
+}
+
+// GetCardPriceHistoryPaginated returns a page of the price history of a card
+// at resolution, newest first.
+func (r *repository) GetCardPriceHistoryPaginated(ctx context.Context, userID int64, id string, resolution domain.PriceResolution, offset, limit int) ([]domain.PricePoint, error) {
+	points := []entities.MysqlPricePoint{}
+
+	getQuery := priceHistory(resolution) + `
+	ORDER BY closed_at DESC, bucket_start DESC
+	LIMIT ? OFFSET ?;`
+
+	rows, err := r.db.QueryContext(ctx, getQuery, id, userID, id, userID, limit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("repository failed to query in get cards history paginated: %w", err)
+		return nil, fmt.Errorf("repository failed to query in get card price history paginated: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var card entities.MysqlCardPriceHistory
-		err = rows.Scan(&card.ID, &card.Name, &card.SetName, &card.CollectorNumber, &card.Foil, &card.LastPrice, &card.OldPrice, &card.PriceChange, &card.LastUpdate)
+		var point entities.MysqlPricePoint
+		err = rows.Scan(&point.Resolution, &point.PeriodStart, &point.OldPrice, &point.OpenPrice, &point.ClosePrice, &point.MinPrice, &point.MaxPrice, &point.OpenedAt, &point.ClosedAt, &point.Samples)
 		if err != nil {
-			return nil, fmt.Errorf("repository failed to scan rows in get cards history paginated: %w", err)
+			return nil, fmt.Errorf("repository failed to scan rows in get card price history paginated: %w", err)
 		}
-		cards = append(cards, card)
+		points = append(points, point)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("repository failed after iterating rows in get cards history paginated: %w", err)
+		return nil, fmt.Errorf("repository failed after iterating rows in get card price history paginated: %w", err)
 	}
 
-	return factories.CardPriceHistoryToCardsDomain(cards), nil
+	return factories.PricePointsToDomain(points), nil
 }
 
-func (r *repository) GetCardHistoryCount(ctx context.Context, id string) (int64, error) {
-	countQuery := `
-	SELECT COUNT(*)
-	FROM 
-		cards c 
-	LEFT JOIN 
-		cards_details cd 
-	ON 
-		c.id = cd.card_id
-	WHERE 
-		c.id = ?;
-	`
-
-	row := r.db.QueryRowContext(ctx, countQuery, id)
+// GetCardPriceHistoryCount counts the points of the price history of a card
+// at resolution.
+func (r *repository) GetCardPriceHistoryCount(ctx context.Context, userID int64, id string, resolution domain.PriceResolution) (int64, error) {
+	countQuery := `SELECT COUNT(*) FROM (` + priceHistory(resolution) + `
+	) history_points;`
 
 	var count int64
-	err := row.Scan(&count)
+	err := r.db.QueryRowContext(ctx, countQuery, id, userID, id, userID).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("repository failed to scan count in get card history count: %w", err)
+		return 0, fmt.Errorf("repository failed to scan row in get card price history count: %w", err)
 	}
 
 	return count, nil
 }
 
-func (r *repository) GetCollectionStats(ctx context.Context) (domain.CollectionStats, error) {
+func (r *repository) GetCollectionStats(ctx context.Context, userID, collectionID int64) (domain.CollectionStats, error) {
+	// cards without an acquisition price (or USD ones bought before any stored
+	// exchange rate) have a NULL cost basis, so they are left out of both cost
+	// and gain sums.
 	statsQuery := `
 	SELECT 
-		COUNT(*) as total_cards,
-		SUM(CASE WHEN foil = true THEN 1 ELSE 0 END) as foil_cards,
+		COALESCE(SUM(c.quantity), 0) as total_cards,
+		COALESCE(SUM(CASE WHEN finish <> 'nonfoil' THEN c.quantity ELSE 0 END), 0) as foil_cards,
 		COUNT(DISTINCT set_name) as unique_sets,
-		COALESCE(SUM(cd.last_price), 0) as total_value
+		COALESCE(SUM(cd.last_price * c.quantity), 0) as total_value,
+		COALESCE(SUM(c.acquisition_price * c.acquisition_rate * c.quantity), 0) as total_cost_basis,
+		COALESCE(SUM(COALESCE(cd.last_price, 0) * c.quantity - 
+			c.acquisition_price * c.acquisition_rate * c.quantity), 0) as unrealized_gain
 	FROM 
 		cards c
 	LEFT JOIN 
-	(
-		SELECT *,
-			ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-		FROM 
-			cards_details
-	) cd
+		cards_current_price cd
 	ON 
-		c.id = cd.card_id AND cd.rn = 1;
-	`
+		c.id = cd.card_id
+	WHERE 
+		c.quantity > 0 AND ` + memberCards
+
+	values := []interface{}{userID}
+	if collectionID != 0 {
+		statsQuery += " AND c.collection_id = ?"
+		values = append(values, collectionID)
+	}
 
-	row := r.db.QueryRowContext(ctx, statsQuery)
+	row := r.db.QueryRowContext(ctx, statsQuery, values...)
 
 	var stats domain.CollectionStats
-	err := row.Scan(&stats.TotalCards, &stats.FoilCards, &stats.UniqueSets, &stats.TotalValue)
+	err := row.Scan(&stats.TotalCards, &stats.FoilCards, &stats.UniqueSets, &stats.TotalValue,
+		&stats.TotalCostBasis, &stats.UnrealizedGain)
 	if err != nil {
 		return domain.CollectionStats{}, fmt.Errorf("repository failed to scan collection stats: %w", err)
 	}
 
 	return stats, nil
 }
+
+func (r *repository) SellCard(ctx context.Context, userID int64, sale domain.Sale) (domain.Sale, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Sale{}, fmt.Errorf("repository failed to begin transaction in sell card: %w", err)
+	}
+	defer tx.Rollback()
+
+	getCardQuery := `
+	SELECT 
+		c.quantity,
+		c.acquisition_price,
+		c.acquisition_currency,
+		c.acquisition_rate
+	FROM 
+		cards c
+	WHERE 
+		c.id = ? AND ` + editableCards + `
+	FOR UPDATE;`
+
+	var card domain.Cards
+	err = tx.QueryRowContext(ctx, getCardQuery, sale.CardID, userID).Scan(&card.Quantity, &card.AcquisitionPrice,
+		&card.AcquisitionCurrency, &card.AcquisitionRate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Sale{}, domain.ErrCardNotFound{}
+		}
+		return domain.Sale{}, fmt.Errorf("repository failed to scan card in sell card: %w", err)
+	}
+
+	if card.Quantity < sale.Quantity {
+		return domain.Sale{}, domain.ErrNotEnoughCopies{}
+	}
+
+	sale.RemainingQuantity = card.Quantity - sale.Quantity
+
+	// the cost basis is frozen at sale time, later edits to the card must not
+	// change gains already realized.
+	card.Quantity = sale.Quantity
+	if costBasis, ok := card.CostBasis(); ok {
+		sale.CostBasis = &costBasis
+	}
+
+	updateQuantityQuery := `
+	UPDATE cards 
+	SET 
+		quantity = ? 
+	WHERE 
+		id = ?;`
+
+	_, err = tx.ExecContext(ctx, updateQuantityQuery, sale.RemainingQuantity, sale.CardID)
+	if err != nil {
+		return domain.Sale{}, fmt.Errorf("repository failed to exec update query in sell card: %w", err)
+	}
+
+	insertSaleQuery := `
+	INSERT INTO sales 
+		(user_id, card_id, quantity, sale_price, fees, sale_date, cost_basis) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?, ?);`
+
+	res, err := tx.ExecContext(ctx, insertSaleQuery, userID, sale.CardID, sale.Quantity, sale.SalePrice, sale.Fees,
+		sale.SaleDate, sale.CostBasis)
+	if err != nil {
+		return domain.Sale{}, fmt.Errorf("repository failed to exec insert query in sell card: %w", err)
+	}
+
+	sale.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.Sale{}, fmt.Errorf("repository failed to get last insert id in sell card: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Sale{}, fmt.Errorf("repository failed to commit transaction in sell card: %w", err)
+	}
+
+	return sale, nil
+}
+
+func (r *repository) GetRealizedGains(ctx context.Context, userID int64, year int) ([]domain.MonthlyRealizedGain, error) {
+	getGainsQuery := `
+	SELECT 
+		MONTH(sale_date) as month,
+		SUM(quantity) as copies_sold,
+		SUM(sale_price * quantity) as gross_proceeds,
+		SUM(fees) as fees,
+		COALESCE(SUM(cost_basis), 0) as cost_basis,
+		SUM(CASE WHEN cost_basis IS NULL THEN quantity ELSE 0 END) as unknown_cost_copies,
+		COALESCE(SUM(CASE WHEN cost_basis IS NULL THEN sale_price * quantity - fees END), 0) as unknown_cost_proceeds
+	FROM 
+		sales
+	WHERE 
+		user_id = ? AND sale_date >= ? AND sale_date < ?
+	GROUP BY 
+		MONTH(sale_date)
+	ORDER BY month;`
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	rows, err := r.db.QueryContext(ctx, getGainsQuery, userID, start, start.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get realized gains: %w", err)
+	}
+	defer rows.Close()
+
+	var gains []domain.MonthlyRealizedGain
+
+	for rows.Next() {
+		var gain domain.MonthlyRealizedGain
+		err := rows.Scan(&gain.Month, &gain.CopiesSold, &gain.GrossProceeds, &gain.Fees, &gain.CostBasis, &gain.UnknownCostCopies, &gain.UnknownCostProceeds)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get realized gains: %w", err)
+		}
+		gains = append(gains, gain)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get realized gains: %w", err)
+	}
+
+	return gains, nil
+}
+
+// GetExchangeRates returns the daily value in BRL of one unit of currency,
+// oldest first, derived from the rates of both against the base of each day.
+func (r *repository) GetExchangeRates(ctx context.Context, currency string) (domain.ExchangeRateHistory, error) {
+	getRatesQuery := `
+	SELECT 
+		brl.rate_date,
+		brl.rate / cur.rate as rate
+	FROM 
+		exchange_rates brl
+	JOIN 
+		exchange_rates cur ON cur.rate_date = brl.rate_date AND cur.currency = ?
+	WHERE 
+		brl.currency = 'BRL' AND cur.rate > 0
+	ORDER BY brl.rate_date;`
+
+	rows, err := r.db.QueryContext(ctx, getRatesQuery, currency)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var history domain.ExchangeRateHistory
+
+	for rows.Next() {
+		var rate domain.ExchangeRate
+		err := rows.Scan(&rate.Date, &rate.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get exchange rates: %w", err)
+		}
+		history = append(history, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get exchange rates: %w", err)
+	}
+
+	return history, nil
+}
+
+// filtersClause builds the WHERE clause of the card listings of a user. Cards
+// whose copies were all sold are kept for their price history but not listed.
+func filtersClause(userID int64, filters map[string]string) (string, []interface{}) {
+	clause := " WHERE c.quantity > 0 AND " + memberCards
+	values := make([]interface{}, 0, len(filters)+1)
+	values = append(values, userID)
+
+	for key, value := range filters {
+		clause += fmt.Sprintf(" AND %s = ?", key)
+		values = append(values, value)
+	}
+
+	return clause, values
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) database.RowScanner
+}
+
+// collectionOf resolves the collection a user writes cards to: the default
+// collection of the user when collectionID is 0, otherwise a collection the
+// user is an editor or the owner of.
+func collectionOf(ctx context.Context, db queryRower, userID, collectionID int64) (int64, error) {
+	if collectionID == 0 {
+		getDefaultQuery := `
+	SELECT 
+		id 
+	FROM 
+		collections 
+	WHERE 
+		user_id = ? AND is_default = 1;`
+
+		var id int64
+		err := db.QueryRowContext(ctx, getDefaultQuery, userID).Scan(&id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, domain.ErrCollectionNotFound{}
+			}
+			return 0, fmt.Errorf("repository failed to scan default collection: %w", err)
+		}
+
+		return id, nil
+	}
+
+	getRoleQuery := `
+	SELECT 
+		role 
+	FROM 
+		collection_members 
+	WHERE 
+		user_id = ? AND collection_id = ?;`
+
+	var role domain.Role
+	err := db.QueryRowContext(ctx, getRoleQuery, userID, collectionID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrCollectionNotFound{}
+		}
+		return 0, fmt.Errorf("repository failed to scan collection role: %w", err)
+	}
+
+	if !role.Includes(domain.RoleEditor) {
+		return 0, domain.ErrForbidden{}
+	}
+
+	return collectionID, nil
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"mtg-report/internal/core/domain"
 	"mtg-report/mocks"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+const testUserID int64 = 7
+
 func TestInsertCard_Success(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockLogger := mocks.NewLogMock()
 	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
 
 	repo := New(mockDB, mockLogger)
 
@@ -25,44 +29,114 @@ func TestInsertCard_Success(t *testing.T) {
 		Name:            "Lightning Bolt",
 		SetName:         "Alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
+		Quantity:        2,
 	}
 
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
 	mockResult.On("LastInsertId").Return(int64(1), nil)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"),
-		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Foil}).Return(mockResult, nil)
+		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Finish, card.Condition, card.Language, card.Quantity,
+			card.AcquisitionPrice, card.AcquisitionCurrency, card.AcquisitionCurrency, card.AcquisitionCurrency,
+			card.AcquisitionDate, card.AcquisitionDate, card.AcquisitionDate, card.CollectionID}).Return(mockResult, nil)
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1)}).Return(mockRowScanner)
 
-	result, err := repo.InsertCard(context.Background(), card)
+	result, err := repo.InsertCard(context.Background(), testUserID, card)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), result.ID)
 	assert.Equal(t, card.Name, result.Name)
 	mockDB.AssertExpectations(t)
 	mockResult.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
 }
 
-func TestInsertCard_DuplicateCard(t *testing.T) {
+func TestInsertCard_ConvertsLotInOtherCurrency(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockLogger := mocks.NewLogMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
 
 	repo := New(mockDB, mockLogger)
 
+	acquisitionPrice := 50.0
+	acquisitionDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
 	card := domain.Cards{
 		Name:            "Lightning Bolt",
 		SetName:         "Alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
+		Quantity:        1,
+		Acquisition: domain.Acquisition{
+			AcquisitionPrice:    &acquisitionPrice,
+			AcquisitionCurrency: domain.CurrencyBRL,
+			AcquisitionDate:     &acquisitionDate,
+		},
+	}
+
+	// an entry of 4 copies at USD 10 must keep its currency and cost basis: the
+	// BRL lot is converted into USD through the rates of both lots, and the
+	// currency is only taken from the new lot when the entry had no price.
+	convertsLot := func(query string) bool {
+		currency := strings.Index(query, "acquisition_currency = IF(acquisition_price IS NULL AND VALUES(acquisition_price) IS NOT NULL")
+		rate := strings.Index(query, "acquisition_rate = CASE")
+		price := strings.Index(query, "acquisition_price = CASE")
+		return currency >= 0 && currency < rate && rate < price &&
+			strings.Contains(query, "WHEN acquisition_currency <> VALUES(acquisition_currency) THEN acquisition_rate") &&
+			strings.Contains(query, "COALESCE(VALUES(acquisition_price) * VALUES(acquisition_rate) / NULLIF(acquisition_rate, 0), acquisition_price)")
 	}
 
-	mysqlErr := &mysql.MySQLError{Number: 1062}
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
+	mockResult.On("LastInsertId").Return(int64(1), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(convertsLot),
+		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Finish, card.Condition, card.Language, card.Quantity,
+			&acquisitionPrice, domain.CurrencyBRL, domain.CurrencyBRL, domain.CurrencyBRL,
+			&acquisitionDate, &acquisitionDate, &acquisitionDate, card.CollectionID}).Return(mockResult, nil)
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1)}).Return(mockRowScanner)
+
+	_, err := repo.InsertCard(context.Background(), testUserID, card)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertCard_QuantityScanError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
 	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	card := domain.Cards{
+		Name:            "Lightning Bolt",
+		SetName:         "Alpha",
+		CollectorNumber: "161",
+		Finish:          domain.FinishNonfoil,
+		Quantity:        1,
+	}
+
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
+	mockResult.On("LastInsertId").Return(int64(1), nil)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"),
-		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Foil}).Return(mockResult, mysqlErr)
+		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Finish, card.Condition, card.Language, card.Quantity,
+			card.AcquisitionPrice, card.AcquisitionCurrency, card.AcquisitionCurrency, card.AcquisitionCurrency,
+			card.AcquisitionDate, card.AcquisitionDate, card.AcquisitionDate, card.CollectionID}).Return(mockResult, nil)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1)}).Return(mockRowScanner)
 
-	_, err := repo.InsertCard(context.Background(), card)
+	_, err := repo.InsertCard(context.Background(), testUserID, card)
 
 	assert.Error(t, err)
-	assert.IsType(t, domain.ErrCardAlreadyExists{}, err)
+	assert.Contains(t, err.Error(), "repository failed to scan quantity in insert card")
 	mockDB.AssertExpectations(t)
 }
 
@@ -76,20 +150,63 @@ func TestInsertCard_DatabaseError(t *testing.T) {
 		Name:            "Lightning Bolt",
 		SetName:         "Alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
 	mockResult := mocks.NewResultMock()
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"),
-		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Foil}).Return(mockResult, fmt.Errorf("database error"))
+		[]interface{}{card.Name, card.SetName, card.CollectorNumber, card.Finish, card.Condition, card.Language, card.Quantity,
+			card.AcquisitionPrice, card.AcquisitionCurrency, card.AcquisitionCurrency, card.AcquisitionCurrency,
+			card.AcquisitionDate, card.AcquisitionDate, card.AcquisitionDate, card.CollectionID}).Return(mockResult, fmt.Errorf("database error"))
 
-	_, err := repo.InsertCard(context.Background(), card)
+	_, err := repo.InsertCard(context.Background(), testUserID, card)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to exec insert query in insert card")
 	mockDB.AssertExpectations(t)
 }
 
+func TestInsertCard_CollectionNotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	card := domain.Cards{Name: "Lightning Bolt", CollectionID: 3}
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, int64(3)}).Return(mockRowScanner)
+
+	_, err := repo.InsertCard(context.Background(), testUserID, card)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound{})
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInsertCard_ReaderForbidden(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	card := domain.Cards{Name: "Lightning Bolt", CollectionID: 3}
+
+	// the scanner mock leaves the role empty, which grants no write access
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, int64(3)}).Return(mockRowScanner)
+
+	_, err := repo.InsertCard(context.Background(), testUserID, card)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden{})
+	mockDB.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDeleteCard_Success(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockLogger := mocks.NewLogMock()
@@ -97,9 +214,9 @@ func TestDeleteCard_Success(t *testing.T) {
 
 	repo := New(mockDB, mockLogger)
 
-	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"1"}).Return(mockResult, nil)
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"1", testUserID}).Return(mockResult, nil)
 
-	err := repo.DeleteCard(context.Background(), "1")
+	err := repo.DeleteCard(context.Background(), testUserID, "1")
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
@@ -112,9 +229,9 @@ func TestDeleteCard_DatabaseError(t *testing.T) {
 	repo := New(mockDB, mockLogger)
 
 	mockResult := mocks.NewResultMock()
-	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"1"}).Return(mockResult, fmt.Errorf("database error"))
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"1", testUserID}).Return(mockResult, fmt.Errorf("database error"))
 
-	err := repo.DeleteCard(context.Background(), "1")
+	err := repo.DeleteCard(context.Background(), testUserID, "1")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to exec delete query in delete card")
@@ -130,10 +247,12 @@ func TestGetCardbyID_Success(t *testing.T) {
 
 	// Simple mock without trying to modify values
 	mockRowScanner.On("Scan").Return(nil)
-	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"1"}).Return(mockRowScanner)
+	mockDB.On("QueryRowContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "cards_current_price cd") && !strings.Contains(query, "ROW_NUMBER")
+	}), []interface{}{"1", testUserID}).Return(mockRowScanner)
 
 	// Test works with zero values due to mock limitations
-	result, err := repo.GetCardbyID(context.Background(), "1")
+	result, err := repo.GetCardbyID(context.Background(), testUserID, "1")
 
 	// With simple mock, no error but values remain zero
 	assert.NoError(t, err)
@@ -150,9 +269,9 @@ func TestGetCardbyID_NotFound(t *testing.T) {
 	repo := New(mockDB, mockLogger)
 
 	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
-	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"999"}).Return(mockRowScanner)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"999", testUserID}).Return(mockRowScanner)
 
-	_, err := repo.GetCardbyID(context.Background(), "999")
+	_, err := repo.GetCardbyID(context.Background(), testUserID, "999")
 
 	assert.Error(t, err)
 	assert.IsType(t, domain.ErrCardNotFound{}, err)
@@ -176,9 +295,9 @@ func TestGetCards_Success(t *testing.T) {
 	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
 	mockRowsScanner.On("Next").Return(false).Once()
 
-	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"Lightning Bolt"}).Return(mockRowsScanner, nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "Lightning Bolt"}).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCards(context.Background(), filters)
+	cards, err := repo.GetCards(context.Background(), testUserID, filters)
 
 	// With limited mock, we get at least 1 card with zero values
 	assert.NoError(t, err)
@@ -199,9 +318,9 @@ func TestGetCards_NoCards(t *testing.T) {
 	}
 
 	mockRowsScanner.On("Next").Return(false)
-	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"NonExistent"}).Return(mockRowsScanner, nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "NonExistent"}).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCards(context.Background(), filters)
+	cards, err := repo.GetCards(context.Background(), testUserID, filters)
 
 	assert.Error(t, err)
 	assert.IsType(t, domain.ErrCardNotFound{}, err)
@@ -214,6 +333,7 @@ func TestInsertCards_Success(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockLogger := mocks.NewLogMock()
 	mockResult := mocks.NewResultMock()
+	acquisitionPrice := 1.5
 
 	repo := New(mockDB, mockLogger)
 
@@ -222,24 +342,39 @@ func TestInsertCards_Success(t *testing.T) {
 			Name:            "Lightning Bolt",
 			SetName:         "Alpha",
 			CollectorNumber: "161",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
+			Condition:       "NM",
+			Language:        "en",
+			Quantity:        1,
 		},
 		{
 			Name:            "Counterspell",
 			SetName:         "Alpha",
 			CollectorNumber: "50",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
+			Condition:       "LP",
+			Language:        "pt",
+			Quantity:        3,
+			Acquisition: domain.Acquisition{
+				AcquisitionPrice:    &acquisitionPrice,
+				AcquisitionCurrency: "USD",
+			},
 		},
 	}
 
 	expectedArgs := []interface{}{
-		"Lightning Bolt", "Alpha", "161", false,
-		"Counterspell", "Alpha", "50", false,
+		"Lightning Bolt", "Alpha", "161", domain.FinishNonfoil, "NM", "en", int64(1), (*float64)(nil), "", "", "",
+		(*time.Time)(nil), (*time.Time)(nil), (*time.Time)(nil), int64(0),
+		"Counterspell", "Alpha", "50", domain.FinishNonfoil, "LP", "pt", int64(3), &acquisitionPrice, "USD", "USD", "USD",
+		(*time.Time)(nil), (*time.Time)(nil), (*time.Time)(nil), int64(0),
 	}
 
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), expectedArgs).Return(mockResult, nil)
 
-	err := repo.InsertCards(context.Background(), cards)
+	err := repo.InsertCards(context.Background(), testUserID, cards)
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
@@ -257,7 +392,7 @@ func TestInsertCards_EmptySlice(t *testing.T) {
 	// Even with empty slice, the method still executes the query
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
 
-	err := repo.InsertCards(context.Background(), cards)
+	err := repo.InsertCards(context.Background(), testUserID, cards)
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
@@ -274,20 +409,260 @@ func TestInsertCards_DatabaseError(t *testing.T) {
 			Name:            "Lightning Bolt",
 			SetName:         "Alpha",
 			CollectorNumber: "161",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
+			Condition:       "NM",
+			Language:        "en",
+			Quantity:        1,
 		},
 	}
 
 	expectedArgs := []interface{}{
-		"Lightning Bolt", "Alpha", "161", false,
+		"Lightning Bolt", "Alpha", "161", domain.FinishNonfoil, "NM", "en", int64(1), (*float64)(nil), "", "", "",
+		(*time.Time)(nil), (*time.Time)(nil), (*time.Time)(nil), int64(0),
 	}
 
 	mockResult := mocks.NewResultMock()
+	collectionScanner := mocks.NewRowScannerMock()
+	collectionScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(collectionScanner)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), expectedArgs).Return(mockResult, fmt.Errorf("database error"))
 
-	err := repo.InsertCards(context.Background(), cards)
+	err := repo.InsertCards(context.Background(), testUserID, cards)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to exec insert query in insert cards")
 	mockDB.AssertExpectations(t)
 }
+
+func TestSellCard_CardNotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1), testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.SellCard(context.Background(), testUserID, domain.Sale{CardID: 1, Quantity: 1})
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCardNotFound{}, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestSellCard_NotEnoughCopies(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	// the scanner mock leaves the card quantity at zero
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1), testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.SellCard(context.Background(), testUserID, domain.Sale{CardID: 1, Quantity: 1})
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrNotEnoughCopies{}, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestSellCard_BeginTxError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockTx := mocks.NewTransactionMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, fmt.Errorf("database error"))
+
+	_, err := repo.SellCard(context.Background(), testUserID, domain.Sale{CardID: 1, Quantity: 1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to begin transaction in sell card")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetRealizedGains_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "SUM(CASE WHEN cost_basis IS NULL THEN quantity ELSE 0 END) as unknown_cost_copies") &&
+			strings.Contains(query, "SUM(CASE WHEN cost_basis IS NULL THEN sale_price * quantity - fees END), 0) as unknown_cost_proceeds")
+	}), []interface{}{testUserID, start, end}).Return(mockRowsScanner, nil)
+
+	gains, err := repo.GetRealizedGains(context.Background(), testUserID, 2026)
+
+	assert.NoError(t, err)
+	assert.Len(t, gains, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetRealizedGains_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetRealizedGains(context.Background(), testUserID, 2026)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to exec query in get realized gains")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetExchangeRates_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockRowsScanner.On("Next").Return(true).Twice()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "FROM \n\t\texchange_rates brl")
+	}), []interface{}{domain.CurrencyEUR}).Return(mockRowsScanner, nil)
+
+	history, err := repo.GetExchangeRates(context.Background(), domain.CurrencyEUR)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetExchangeRates_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetExchangeRates(context.Background(), domain.CurrencyUSD)
+
+	assert.ErrorContains(t, err, "repository failed to exec query in get exchange rates")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetCardPriceHistoryPaginated_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution domain.PriceResolution
+		bucket     string
+	}{
+		{name: "should keep every point at daily resolution", resolution: domain.PriceResolutionDaily, bucket: "snapshot_id AS bucket_id"},
+		{name: "should roll snapshots up into weeks", resolution: domain.PriceResolutionWeekly, bucket: "INTERVAL WEEKDAY(period_start) DAY"},
+		{name: "should roll snapshots and weeks up into months", resolution: domain.PriceResolutionMonthly, bucket: "DATE_FORMAT(period_start, '%Y-%m-01')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := mocks.NewClientMock()
+			mockLogger := mocks.NewLogMock()
+			mockRowsScanner := mocks.NewRowsScannerMock()
+
+			repo := New(mockDB, mockLogger)
+
+			mockRowsScanner.On("Next").Return(true).Twice()
+			mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+			mockRowsScanner.On("Next").Return(false).Once()
+			mockRowsScanner.On("Err").Return(nil)
+			mockRowsScanner.On("Close").Return(nil)
+			mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+				return strings.Contains(query, "cards_details cd") && strings.Contains(query, "UNION ALL") &&
+					strings.Contains(query, "cards_price_aggregates pa") && strings.Contains(query, tt.bucket) &&
+					strings.Contains(query, "GROUP BY") && strings.Contains(query, "LIMIT ? OFFSET ?")
+			}), []interface{}{"1", testUserID, "1", testUserID, 10, 20}).Return(mockRowsScanner, nil)
+
+			points, err := repo.GetCardPriceHistoryPaginated(context.Background(), testUserID, "1", tt.resolution, 20, 10)
+
+			assert.NoError(t, err)
+			assert.Len(t, points, 2)
+			mockDB.AssertExpectations(t)
+			mockRowsScanner.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetCardPriceHistoryPaginated_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetCardPriceHistoryPaginated(context.Background(), testUserID, "1", domain.PriceResolutionDaily, 0, 10)
+
+	assert.ErrorContains(t, err, "repository failed to query in get card price history paginated")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetCardPriceHistoryCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "SELECT COUNT(*) FROM (") && strings.Contains(query, "INTERVAL WEEKDAY(period_start) DAY") &&
+			!strings.Contains(query, "LIMIT")
+	}), []interface{}{"1", testUserID, "1", testUserID}).Return(mockRowScanner)
+
+	_, err := repo.GetCardPriceHistoryCount(context.Background(), testUserID, "1", domain.PriceResolutionWeekly)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetCardPriceHistoryCount_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockLogger := mocks.NewLogMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB, mockLogger)
+
+	mockRowScanner.On("Scan").Return(fmt.Errorf("database error"))
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	_, err := repo.GetCardPriceHistoryCount(context.Background(), testUserID, "1", domain.PriceResolutionDaily)
+
+	assert.ErrorContains(t, err, "repository failed to scan row in get card price history count")
+	mockDB.AssertExpectations(t)
+}
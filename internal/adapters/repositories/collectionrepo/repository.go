@@ -0,0 +1,439 @@
+package collectionrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+	"strings"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// InsertCollection creates the collection with the user as its owner.
+func (r *repository) InsertCollection(ctx context.Context, userID int64, collection domain.Collection) (domain.Collection, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to begin transaction in insert collection: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertCollectionQuery := `
+	INSERT INTO collections 
+		(user_id, name, description) 
+	VALUES 
+		(?, ?, ?);`
+
+	res, err := tx.ExecContext(ctx, insertCollectionQuery, userID, collection.Name, collection.Description)
+	if err != nil {
+		if database.IsError(err, database.ErrDuplicateEntry) {
+			return domain.Collection{}, domain.ErrCollectionAlreadyExists{}
+		}
+		return domain.Collection{}, fmt.Errorf("repository failed to exec insert query in insert collection: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to get last inserted id in insert collection: %w", err)
+	}
+
+	insertOwnerQuery := `
+	INSERT INTO collection_members 
+		(collection_id, user_id, role) 
+	VALUES 
+		(?, ?, ?);`
+
+	_, err = tx.ExecContext(ctx, insertOwnerQuery, id, userID, domain.RoleOwner)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to exec insert owner query in insert collection: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to commit transaction in insert collection: %w", err)
+	}
+
+	collection.ID = id
+	collection.Role = domain.RoleOwner
+
+	return collection, nil
+}
+
+func (r *repository) GetCollections(ctx context.Context, userID int64) ([]domain.Collection, error) {
+	getCollectionsQuery := `
+	SELECT 
+		co.id,
+		co.name,
+		co.description,
+		co.is_default AND co.user_id = cm.user_id as is_default,
+		cm.role,
+		COALESCE(SUM(c.quantity), 0) as total_cards
+	FROM 
+		collections co
+	JOIN 
+		collection_members cm
+	ON 
+		cm.collection_id = co.id AND cm.user_id = ?
+	LEFT JOIN 
+		cards c
+	ON 
+		c.collection_id = co.id
+	GROUP BY 
+		co.id, co.name, co.description, co.is_default, co.user_id, cm.user_id, cm.role
+	ORDER BY co.id;`
+
+	rows, err := r.db.QueryContext(ctx, getCollectionsQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get collections: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []domain.Collection
+
+	for rows.Next() {
+		var collection domain.Collection
+		err := rows.Scan(&collection.ID, &collection.Name, &collection.Description, &collection.IsDefault, &collection.Role,
+			&collection.TotalCards)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get collections: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+func (r *repository) GetCollectionByID(ctx context.Context, userID int64, id string) (domain.Collection, error) {
+	return r.getCollectionByID(ctx, r.db, userID, id)
+}
+
+func (r *repository) UpdateCollection(ctx context.Context, userID int64, collection domain.UpdateCollection) (domain.Collection, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to begin transaction in update collection: %w", err)
+	}
+	defer tx.Rollback()
+
+	setClauses := make([]string, 0, 2)
+	values := make([]interface{}, 0, 4)
+
+	if len(collection.Name) != 0 {
+		setClauses = append(setClauses, "name = ?")
+		values = append(values, collection.Name)
+	}
+
+	if collection.Description != nil {
+		setClauses = append(setClauses, "description = ?")
+		values = append(values, *collection.Description)
+	}
+
+	values = append(values, collection.ID, userID)
+
+	updateCollectionQuery := fmt.Sprintf(`
+	UPDATE collections 
+	SET 
+		%s 
+	WHERE
+		id = ? AND user_id = ?;`, strings.Join(setClauses, ", "))
+
+	_, err = tx.ExecContext(ctx, updateCollectionQuery, values...)
+	if err != nil {
+		if database.IsError(err, database.ErrDuplicateEntry) {
+			return domain.Collection{}, domain.ErrCollectionAlreadyExists{}
+		}
+		return domain.Collection{}, fmt.Errorf("repository failed to exec update query in update collection: %w", err)
+	}
+
+	updated, err := r.getCollectionByID(ctx, tx, userID, collection.ID)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Collection{}, fmt.Errorf("repository failed to commit transaction in update collection: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *repository) DeleteCollection(ctx context.Context, userID int64, id string) error {
+	collection, err := r.getCollectionByID(ctx, r.db, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if collection.IsDefault {
+		return domain.ErrDefaultCollection{}
+	}
+
+	deleteCollectionQuery := `
+	DELETE FROM 
+		collections 
+	WHERE
+		id = ? AND user_id = ?`
+
+	res, err := r.db.ExecContext(ctx, deleteCollectionQuery, id, userID)
+	if err != nil {
+		if database.IsError(err, database.ErrRowIsReferenced) {
+			return domain.ErrCollectionNotEmpty{}
+		}
+		return fmt.Errorf("repository failed to exec delete query in delete collection: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected in delete collection: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrCollectionNotFound{}
+	}
+
+	return nil
+}
+
+// GetRole returns the role of the user in a collection.
+func (r *repository) GetRole(ctx context.Context, userID, collectionID int64) (domain.Role, error) {
+	getRoleQuery := `
+	SELECT 
+		role 
+	FROM 
+		collection_members 
+	WHERE 
+		user_id = ? AND collection_id = ?;`
+
+	var role domain.Role
+	err := r.db.QueryRowContext(ctx, getRoleQuery, userID, collectionID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.ErrCollectionNotFound{}
+		}
+		return "", fmt.Errorf("repository failed to scan row in get role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetCardRole returns the role of the user in the collection of a card.
+func (r *repository) GetCardRole(ctx context.Context, userID int64, cardID string) (domain.Role, error) {
+	getRoleQuery := `
+	SELECT 
+		cm.role 
+	FROM 
+		cards c
+	JOIN 
+		collection_members cm
+	ON 
+		cm.collection_id = c.collection_id AND cm.user_id = ?
+	WHERE 
+		c.id = ?;`
+
+	var role domain.Role
+	err := r.db.QueryRowContext(ctx, getRoleQuery, userID, cardID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.ErrCardNotFound{}
+		}
+		return "", fmt.Errorf("repository failed to scan row in get card role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *repository) GetMembers(ctx context.Context, collectionID int64) ([]domain.Member, error) {
+	getMembersQuery := `
+	SELECT 
+		u.id,
+		u.name,
+		u.email,
+		cm.role
+	FROM 
+		collection_members cm
+	JOIN 
+		users u
+	ON 
+		u.id = cm.user_id
+	WHERE 
+		cm.collection_id = ?
+	ORDER BY u.id;`
+
+	rows, err := r.db.QueryContext(ctx, getMembersQuery, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []domain.Member
+
+	for rows.Next() {
+		var member domain.Member
+		err := rows.Scan(&member.UserID, &member.Name, &member.Email, &member.Role)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get members: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get members: %w", err)
+	}
+
+	return members, nil
+}
+
+// InsertMember gives the user with the email a role in the collection. Inviting
+// an existing member changes its role.
+func (r *repository) InsertMember(ctx context.Context, collectionID int64, email string, role domain.Role) (domain.Member, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Member{}, fmt.Errorf("repository failed to begin transaction in insert member: %w", err)
+	}
+	defer tx.Rollback()
+
+	getUserQuery := `
+	SELECT 
+		u.id,
+		u.name,
+		u.email,
+		COALESCE(cm.role, '')
+	FROM 
+		users u
+	LEFT JOIN 
+		collection_members cm
+	ON 
+		cm.user_id = u.id AND cm.collection_id = ?
+	WHERE 
+		u.email = ?;`
+
+	var member domain.Member
+	err = tx.QueryRowContext(ctx, getUserQuery, collectionID, email).Scan(&member.UserID, &member.Name, &member.Email, &member.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Member{}, domain.ErrUserNotFound{}
+		}
+		return domain.Member{}, fmt.Errorf("repository failed to scan user in insert member: %w", err)
+	}
+
+	if member.Role == domain.RoleOwner {
+		return domain.Member{}, domain.ErrCollectionOwner{}
+	}
+
+	insertMemberQuery := `
+	INSERT INTO collection_members 
+		(collection_id, user_id, role) 
+	VALUES 
+		(?, ?, ?)
+	ON DUPLICATE KEY UPDATE 
+		role = VALUES(role);`
+
+	_, err = tx.ExecContext(ctx, insertMemberQuery, collectionID, member.UserID, role)
+	if err != nil {
+		return domain.Member{}, fmt.Errorf("repository failed to exec insert query in insert member: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Member{}, fmt.Errorf("repository failed to commit transaction in insert member: %w", err)
+	}
+
+	member.Role = role
+
+	return member, nil
+}
+
+func (r *repository) DeleteMember(ctx context.Context, collectionID, memberID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository failed to begin transaction in delete member: %w", err)
+	}
+	defer tx.Rollback()
+
+	getRoleQuery := `
+	SELECT 
+		role 
+	FROM 
+		collection_members 
+	WHERE 
+		collection_id = ? AND user_id = ?
+	FOR UPDATE;`
+
+	var role domain.Role
+	err = tx.QueryRowContext(ctx, getRoleQuery, collectionID, memberID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrMemberNotFound{}
+		}
+		return fmt.Errorf("repository failed to scan role in delete member: %w", err)
+	}
+
+	if role == domain.RoleOwner {
+		return domain.ErrCollectionOwner{}
+	}
+
+	deleteMemberQuery := `
+	DELETE FROM 
+		collection_members 
+	WHERE 
+		collection_id = ? AND user_id = ?;`
+
+	_, err = tx.ExecContext(ctx, deleteMemberQuery, collectionID, memberID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec delete query in delete member: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository failed to commit transaction in delete member: %w", err)
+	}
+
+	return nil
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) database.RowScanner
+}
+
+func (r *repository) getCollectionByID(ctx context.Context, db queryRower, userID int64, id interface{}) (domain.Collection, error) {
+	getCollectionQuery := `
+	SELECT 
+		co.id,
+		co.name,
+		co.description,
+		co.is_default AND co.user_id = cm.user_id as is_default,
+		cm.role,
+		COALESCE((SELECT SUM(c.quantity) FROM cards c WHERE c.collection_id = co.id), 0) as total_cards
+	FROM 
+		collections co
+	JOIN 
+		collection_members cm
+	ON 
+		cm.collection_id = co.id
+	WHERE 
+		co.id = ? AND cm.user_id = ?;`
+
+	var collection domain.Collection
+	err := db.QueryRowContext(ctx, getCollectionQuery, id, userID).Scan(&collection.ID, &collection.Name,
+		&collection.Description, &collection.IsDefault, &collection.Role, &collection.TotalCards)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Collection{}, domain.ErrCollectionNotFound{}
+		}
+		return domain.Collection{}, fmt.Errorf("repository failed to scan row in get collection by id: %w", err)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,356 @@
+package collectionrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestInsertCollection_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "Trade Binder", "cards for trade"}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(2), nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), testUserID, domain.RoleOwner}).Return(mockResult, nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	collection, err := repo.InsertCollection(context.Background(), testUserID, domain.Collection{Name: "Trade Binder", Description: "cards for trade"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Collection{ID: 2, Name: "Trade Binder", Description: "cards for trade", Role: domain.RoleOwner}, collection)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertCollection_AlreadyExists(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, &driver.MySQLError{Number: 1062})
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.InsertCollection(context.Background(), testUserID, domain.Collection{Name: "Trade Binder"})
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCollectionAlreadyExists{}, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestGetCollections_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Twice()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowsScanner, nil)
+
+	collections, err := repo.GetCollections(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, collections, 2)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetCollections_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetCollections(context.Background(), testUserID)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to exec query in get collections")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetCollectionByID_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"9", testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+
+	_, err := repo.GetCollectionByID(context.Background(), testUserID, "9")
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCollectionNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestUpdateCollection_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	description := "decks"
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"Commander", "decks", int64(2), testUserID}).Return(mockResult, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.UpdateCollection(context.Background(), testUserID, domain.UpdateCollection{ID: 2, Name: "Commander", Description: &description})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestUpdateCollection_AlreadyExists(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"Default", int64(2), testUserID}).Return(mockResult, &driver.MySQLError{Number: 1062})
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.UpdateCollection(context.Background(), testUserID, domain.UpdateCollection{ID: 2, Name: "Default"})
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCollectionAlreadyExists{}, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestDeleteCollection_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+
+	err := repo.DeleteCollection(context.Background(), testUserID, "2")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestDeleteCollection_NotEmpty(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"2", testUserID}).Return(mockResult, &driver.MySQLError{Number: 1451})
+
+	err := repo.DeleteCollection(context.Background(), testUserID, "2")
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCollectionNotEmpty{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestDeleteCollection_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"9", testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+
+	err := repo.DeleteCollection(context.Background(), testUserID, "9")
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrCollectionNotFound{}, err)
+	mockDB.AssertExpectations(t)
+	mockDB.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetRole_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, int64(2)}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+
+	_, err := repo.GetRole(context.Background(), testUserID, 2)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetRole_NotMember(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, int64(2)}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+
+	_, err := repo.GetRole(context.Background(), testUserID, 2)
+
+	assert.IsType(t, domain.ErrCollectionNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetCardRole_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "9"}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+
+	_, err := repo.GetCardRole(context.Background(), testUserID, "9")
+
+	assert.IsType(t, domain.ErrCardNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetMembers_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Twice()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2)}).Return(mockRowsScanner, nil)
+
+	members, err := repo.GetMembers(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestInsertMember_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	// the scanner mock leaves the user id at zero and the user without a role
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), "chandra@example.com"}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), int64(0), domain.RoleEditor}).Return(mockResult, nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	member, err := repo.InsertMember(context.Background(), 2, "chandra@example.com", domain.RoleEditor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleEditor, member.Role)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestInsertMember_UserNotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), "nobody@example.com"}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.InsertMember(context.Background(), 2, "nobody@example.com", domain.RoleReader)
+
+	assert.IsType(t, domain.ErrUserNotFound{}, err)
+	mockTx.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteMember_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), int64(5)}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.DeleteMember(context.Background(), 2, 5)
+
+	assert.IsType(t, domain.ErrMemberNotFound{}, err)
+	mockTx.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteMember_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), int64(5)}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), int64(5)}).Return(mockResult, nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.DeleteMember(context.Background(), 2, 5)
+
+	assert.NoError(t, err)
+	mockTx.AssertExpectations(t)
+}
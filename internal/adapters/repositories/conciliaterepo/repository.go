@@ -3,12 +3,15 @@ package conciliaterepo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"mtg-report/internal/adapters/entities"
 	"mtg-report/internal/adapters/factories"
+	"mtg-report/internal/adapters/repositories/currentprice"
 	"mtg-report/internal/core/domain"
 	database "mtg-report/internal/sources/databases/mysql"
 	"strings"
+	"time"
 )
 
 type repository struct {
@@ -21,22 +24,30 @@ func New(db database.Client) *repository {
 	}
 }
 
+// InsertCardDetails adds a price snapshot of each card to its history and
+// makes it the current price of the card, in a single transaction.
 func (r *repository) InsertCardDetails(ctx context.Context, cardDetails []domain.CardsDetails) error {
 	if len(cardDetails) == 0 {
 		return nil
 	}
 
 	valueStrings := make([]string, 0, len(cardDetails))
-	valueArgs := make([]interface{}, 0, len(cardDetails)*5)
+	valueArgs := make([]interface{}, 0, len(cardDetails)*7)
 
 	for _, card := range cardDetails {
-		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?)")
-		valueArgs = append(valueArgs, card.CardID, card.LastPrice, card.OldPrice, card.PriceChange, card.LastUpdate)
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs, card.CardID, card.LastPrice, card.OldPrice, card.PriceChange, card.ExchangeRate, card.PriceSource, card.LastUpdate)
 	}
 
-	insertCardQuery := fmt.Sprintf("INSERT INTO cards_details (card_id, last_price, old_price, price_change, last_update) VALUES %s", strings.Join(valueStrings, ", "))
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository failed to begin transaction in insert card details: %w", err)
+	}
+	defer tx.Rollback()
 
-	res, err := r.db.ExecContext(ctx, insertCardQuery, valueArgs...)
+	insertCardQuery := fmt.Sprintf("INSERT INTO cards_details (card_id, last_price, old_price, price_change, exchange_rate, price_source, last_update) VALUES %s", strings.Join(valueStrings, ", "))
+
+	res, err := tx.ExecContext(ctx, insertCardQuery, valueArgs...)
 	if err != nil {
 		return fmt.Errorf("repository failed to execute insert statement: %w", err)
 	}
@@ -46,10 +57,169 @@ func (r *repository) InsertCardDetails(ctx context.Context, cardDetails []domain
 		return fmt.Errorf("repository insert card details failed: %w", err)
 	}
 
+	currentPriceQuery := fmt.Sprintf("%s VALUES %s", currentprice.Insert, strings.Join(valueStrings, ", ")) + currentprice.Upsert
+
+	_, err = tx.ExecContext(ctx, currentPriceQuery, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec upsert query in insert card details: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository failed to commit transaction in insert card details: %w", err)
+	}
+
 	return nil
 }
 
-func (r *repository) GetCardsForUpdate(ctx context.Context, offset int, limit int) ([]domain.Cards, error) {
+// BackfillCurrentPrices fills cards_current_price from the latest snapshot of
+// each card in cards_details, for databases created before the table existed.
+// It can be run again safely, as newer current prices are kept.
+func (r *repository) BackfillCurrentPrices(ctx context.Context) (int64, error) {
+	backfillQuery := currentprice.Insert + `
+	SELECT 
+		card_id,
+		last_price,
+		old_price,
+		price_change,
+		exchange_rate,
+		price_source,
+		last_update
+	FROM 
+	(
+		SELECT *,
+			ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
+		FROM 
+			cards_details
+	) cd
+	WHERE 
+		cd.rn = 1` + currentprice.Upsert + `;`
+
+	res, err := r.db.ExecContext(ctx, backfillQuery)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec insert query in backfill current prices: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to get rows affected in backfill current prices: %w", err)
+	}
+
+	return rows, nil
+}
+
+// InsertRawPrices keeps the quotes behind each cards_details row, matched to
+// it by card_id and last_update.
+func (r *repository) InsertRawPrices(ctx context.Context, rawPrices []domain.RawPrice) error {
+	if len(rawPrices) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(rawPrices))
+	valueArgs := make([]interface{}, 0, len(rawPrices)*7)
+
+	for _, raw := range rawPrices {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs, raw.CardID, raw.QuotedAt, raw.Field, raw.Currency, raw.Value, raw.ExchangeRate, raw.Used)
+	}
+
+	insertRawQuery := fmt.Sprintf("INSERT INTO cards_raw_prices (card_id, quoted_at, field, currency, price, exchange_rate, used) VALUES %s", strings.Join(valueStrings, ", "))
+
+	_, err := r.db.ExecContext(ctx, insertRawQuery, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in insert raw prices: %w", err)
+	}
+
+	return nil
+}
+
+// InsertExchangeRates keeps the rates of the day, so values can later be shown
+// in other currencies at the rate of their date. A second run on the same day
+// overwrites them.
+func (r *repository) InsertExchangeRates(ctx context.Context, rates domain.ExchangeRates) error {
+	if len(rates.Rates) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(rates.Rates))
+	valueArgs := make([]interface{}, 0, len(rates.Rates)*4)
+
+	for _, currency := range rates.Currencies() {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?)")
+		valueArgs = append(valueArgs, rates.Date, rates.Base, currency, rates.Rates[currency])
+	}
+
+	insertRatesQuery := fmt.Sprintf("INSERT INTO exchange_rates (rate_date, base_code, currency, rate) VALUES %s ON DUPLICATE KEY UPDATE base_code = VALUES(base_code), rate = VALUES(rate)", strings.Join(valueStrings, ", "))
+
+	_, err := r.db.ExecContext(ctx, insertRatesQuery, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in insert exchange rates: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestExchangeRates returns the rates of the last day stored, so a run
+// can fall back on them when the exchange providers are down.
+func (r *repository) GetLatestExchangeRates(ctx context.Context) (domain.ExchangeRates, error) {
+	getRatesQuery := `
+	SELECT 
+		rate_date,
+		base_code,
+		currency,
+		rate
+	FROM 
+		exchange_rates
+	WHERE 
+		rate_date = (SELECT MAX(rate_date) FROM exchange_rates);`
+
+	rows, err := r.db.QueryContext(ctx, getRatesQuery)
+	if err != nil {
+		return domain.ExchangeRates{}, fmt.Errorf("repository failed to query in get latest exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := domain.ExchangeRates{Rates: map[string]float64{}}
+
+	for rows.Next() {
+		var currency string
+		var rate float64
+		err := rows.Scan(&rates.Date, &rates.Base, &currency, &rate)
+		if err != nil {
+			return domain.ExchangeRates{}, fmt.Errorf("repository failed to scan rows in get latest exchange rates: %w", err)
+		}
+		rates.Rates[currency] = rate
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.ExchangeRates{}, fmt.Errorf("repository failed after iterating rows in get latest exchange rates: %w", err)
+	}
+
+	if len(rates.Rates) == 0 {
+		return domain.ExchangeRates{}, domain.ErrExchangeRateNotFound{}
+	}
+
+	return rates, nil
+}
+
+// staleCondition keeps the cards whose latest price is older than the cutoff
+// of its tier, and the ones never priced.
+const staleCondition = `(cd.last_update IS NULL OR cd.last_update < CASE
+			WHEN cd.last_price >= ? THEN ?
+			WHEN cd.last_price < ? THEN ?
+			ELSE ?
+		END)`
+
+func staleArgs(cutoffs domain.StalenessCutoffs) []interface{} {
+	return []interface{}{cutoffs.HighValuePrice, cutoffs.HighValue, cutoffs.BulkPrice, cutoffs.Bulk, cutoffs.Default}
+}
+
+// GetCardsForUpdate pages through the stale cards in stock by id, returning
+// the ones after afterID, so a run can resume from the last card it
+// checkpointed. Paging by id rather than by offset keeps each page as cheap as
+// the first, and cards inserted or deleted during the run do not shift the
+// pages, so no card is skipped or priced twice.
+func (r *repository) GetCardsForUpdate(ctx context.Context, cutoffs domain.StalenessCutoffs, afterID int64, limit int) ([]domain.Cards, error) {
 	cards := []entities.MysqlCardInfo{}
 
 	getQuery := `
@@ -58,22 +228,27 @@ func (r *repository) GetCardsForUpdate(ctx context.Context, offset int, limit in
 		c.name,
 		c.set_name,
 		c.collector_number,
-		c.foil,
-		cd.last_price
+		c.finish,
+		c.card_condition,
+		c.language,
+		c.collection_id,
+		cd.last_price,
+		cd.last_update
 	FROM 
 		cards c 
 	LEFT JOIN 
-	(
-		SELECT *,
-			ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-		FROM 
-			cards_details
-	) cd
+		cards_current_price cd
 	ON 
-		c.id = cd.card_id AND cd.rn = 1
-	LIMIT ?, ?;
+		c.id = cd.card_id
+	WHERE 
+		c.quantity > 0 AND c.id > ? AND ` + staleCondition + `
+	ORDER BY c.id
+	LIMIT ?;
 	`
-	rows, err := r.db.QueryContext(ctx, getQuery, offset, limit)
+	args := append([]interface{}{afterID}, staleArgs(cutoffs)...)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, getQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("repository failed to query in get cards for update: %w", err)
 	}
@@ -81,7 +256,7 @@ func (r *repository) GetCardsForUpdate(ctx context.Context, offset int, limit in
 
 	for rows.Next() {
 		var card entities.MysqlCardInfo
-		err = rows.Scan(&card.ID, &card.Name, &card.SetName, &card.CollectorNumber, &card.Foil, &card.LastPrice)
+		err = rows.Scan(&card.ID, &card.Name, &card.SetName, &card.CollectorNumber, &card.Finish, &card.Condition, &card.Language, &card.CollectionID, &card.LastPrice, &card.LastUpdate)
 		if err != nil {
 			return nil, fmt.Errorf("repository failed to scan rows in get cards for update: %w", err)
 		}
@@ -95,6 +270,489 @@ func (r *repository) GetCardsForUpdate(ctx context.Context, offset int, limit in
 	return factories.CardsInfoToCardsDomain(cards), nil
 }
 
+// CountFreshCards counts the cards in stock whose price is still fresh, which
+// a run leaves out.
+func (r *repository) CountFreshCards(ctx context.Context, cutoffs domain.StalenessCutoffs) (int64, error) {
+	countQuery := `
+	SELECT 
+		COUNT(*)
+	FROM 
+		cards c 
+	LEFT JOIN 
+		cards_current_price cd
+	ON 
+		c.id = cd.card_id
+	WHERE 
+		c.quantity > 0 AND NOT ` + staleCondition + `;
+	`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery, staleArgs(cutoffs)...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan row in count fresh cards: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetPricedCards returns the set and collector number of every printing a run
+// prices: the cards with copies left and the wishlist items.
+func (r *repository) GetPricedCards(ctx context.Context) ([]domain.Cards, error) {
+	getQuery := `
+	SELECT 
+		set_name,
+		collector_number
+	FROM 
+		cards
+	WHERE 
+		quantity > 0
+	UNION
+	SELECT 
+		set_name,
+		collector_number
+	FROM 
+		wishlist;
+	`
+	rows, err := r.db.QueryContext(ctx, getQuery)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to query in get priced cards: %w", err)
+	}
+	defer rows.Close()
+
+	var cards []domain.Cards
+
+	for rows.Next() {
+		var card domain.Cards
+		err = rows.Scan(&card.SetName, &card.CollectorNumber)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get priced cards: %w", err)
+		}
+		cards = append(cards, card)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get priced cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// GetWishlistForUpdate pages through the wishlist by id, returning the items
+// after afterID.
+func (r *repository) GetWishlistForUpdate(ctx context.Context, afterID int64, limit int) ([]domain.WishlistItem, error) {
+	getQuery := `
+	SELECT 
+		id,
+		user_id,
+		name,
+		set_name,
+		collector_number,
+		finish,
+		max_price,
+		last_price,
+		last_update
+	FROM 
+		wishlist
+	WHERE 
+		id > ?
+	ORDER BY id
+	LIMIT ?;
+	`
+	rows, err := r.db.QueryContext(ctx, getQuery, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to query in get wishlist for update: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.WishlistItem
+
+	for rows.Next() {
+		var item domain.WishlistItem
+		err = rows.Scan(&item.ID, &item.UserID, &item.Name, &item.SetName, &item.CollectorNumber, &item.Finish,
+			&item.MaxPrice, &item.LastPrice, &item.LastUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan rows in get wishlist for update: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get wishlist for update: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *repository) UpdateWishlistPrices(ctx context.Context, items []domain.WishlistItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository failed to begin transaction in update wishlist prices: %w", err)
+	}
+	defer tx.Rollback()
+
+	updateQuery := `
+	UPDATE wishlist 
+	SET 
+		last_price = ?,
+		last_update = ?
+	WHERE 
+		id = ?;`
+
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, updateQuery, item.LastPrice, item.LastUpdate, item.ID)
+		if err != nil {
+			return fmt.Errorf("repository failed to exec update query in update wishlist prices: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository failed to commit transaction in update wishlist prices: %w", err)
+	}
+
+	return nil
+}
+
+// GetAlertCandidates returns, for every alert, the cards it watches that were
+// priced since the given time along with their old and new prices. Whether
+// each one fires is up to the alert.
+func (r *repository) GetAlertCandidates(ctx context.Context, since time.Time) ([]domain.AlertEvent, error) {
+	getQuery := `
+	SELECT 
+		a.id,
+		a.user_id,
+		a.card_id,
+		a.set_name,
+		a.kind,
+		a.direction,
+		a.threshold,
+		a.created_at,
+		u.email,
+		c.id,
+		c.name,
+		c.set_name,
+		cd.old_price,
+		cd.last_price
+	FROM 
+		alerts a
+	JOIN 
+		users u 
+	ON 
+		u.id = a.user_id
+	JOIN 
+		cards c 
+	ON 
+		c.id = a.card_id OR (a.card_id IS NULL AND c.set_name = a.set_name)
+	JOIN 
+		cards_details cd 
+	ON 
+		cd.card_id = c.id AND cd.last_update >= ?
+	WHERE 
+		c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = a.user_id)
+	ORDER BY a.user_id, a.id, c.id;
+	`
+	rows, err := r.db.QueryContext(ctx, getQuery, since)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to query in get alert candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.AlertEvent
+
+	for rows.Next() {
+		var event domain.AlertEvent
+		err = rows.Scan(&event.Alert.ID, &event.Alert.UserID, &event.Alert.CardID, &event.Alert.SetName, &event.Alert.Kind,
+			&event.Alert.Direction, &event.Alert.Threshold, &event.Alert.CreatedAt, &event.Email, &event.CardID, &event.CardName,
+			&event.CardSetName, &event.OldPrice, &event.NewPrice)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan rows in get alert candidates: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get alert candidates: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *repository) InsertAlertEvents(ctx context.Context, events []domain.AlertEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(events))
+	valueArgs := make([]interface{}, 0, len(events)*11)
+
+	for _, event := range events {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs, event.Alert.ID, event.Alert.UserID, event.CardID, event.CardName, event.CardSetName,
+			event.Alert.Kind, event.Alert.Direction, event.Alert.Threshold, event.OldPrice, event.NewPrice, event.TriggeredAt)
+	}
+
+	insertEventsQuery := fmt.Sprintf("INSERT INTO alert_events (alert_id, user_id, card_id, card_name, set_name, kind, direction, threshold, old_price, new_price, triggered_at) VALUES %s", strings.Join(valueStrings, ", "))
+
+	res, err := r.db.ExecContext(ctx, insertEventsQuery, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("repository failed to execute insert statement in insert alert events: %w", err)
+	}
+
+	err = getRowsAffected(res)
+	if err != nil {
+		return fmt.Errorf("repository insert alert events failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetPriceChanges returns the cards repriced since the given time, once for
+// each member of their collection with a webhook subscribed to price changes.
+func (r *repository) GetPriceChanges(ctx context.Context, since time.Time) ([]domain.PriceChange, error) {
+	getQuery := `
+	SELECT 
+		m.user_id,
+		c.id,
+		c.name,
+		c.set_name,
+		c.collector_number,
+		c.finish,
+		cd.old_price,
+		cd.last_price
+	FROM 
+		cards_details cd
+	JOIN 
+		cards c 
+	ON 
+		c.id = cd.card_id
+	JOIN 
+		collection_members m 
+	ON 
+		m.collection_id = c.collection_id
+	WHERE 
+		cd.last_update >= ? 
+		AND cd.last_price <> cd.old_price 
+		AND m.user_id IN (SELECT user_id FROM webhooks WHERE FIND_IN_SET(?, events) > 0)
+	ORDER BY m.user_id, c.id;
+	`
+	rows, err := r.db.QueryContext(ctx, getQuery, since, domain.EventCardPriceChanged)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to query in get price changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.PriceChange
+
+	for rows.Next() {
+		var change domain.PriceChange
+		err = rows.Scan(&change.UserID, &change.CardID, &change.Name, &change.SetName, &change.CollectorNumber, &change.Finish,
+			&change.OldPrice, &change.NewPrice)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan rows in get price changes: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// InsertConciliationRun records the start of a run and returns its id.
+func (r *repository) InsertConciliationRun(ctx context.Context, run domain.ConciliationRun) (int64, error) {
+	insertRunQuery := "INSERT INTO conciliation_runs (started_at, status) VALUES (?, ?)"
+
+	res, err := r.db.ExecContext(ctx, insertRunQuery, run.StartedAt, run.Status)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec insert query in insert conciliation run: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to get last insert id in insert conciliation run: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateConciliationRun saves the progress of a run, which is its checkpoint.
+func (r *repository) UpdateConciliationRun(ctx context.Context, run domain.ConciliationRun) error {
+	updateRunQuery := `
+	UPDATE 
+		conciliation_runs 
+	SET 
+		finished_at = ?,
+		status = ?,
+		exchange_rate = ?,
+		exchange_rate_source = ?,
+		last_card_id = ?,
+		processed = ?,
+		failed = ?,
+		skipped = ?,
+		retries = ?,
+		error_summary = ?
+	WHERE 
+		id = ?;`
+
+	_, err := r.db.ExecContext(ctx, updateRunQuery, run.FinishedAt, run.Status, run.ExchangeRate, run.ExchangeRateSource,
+		run.LastCardID, run.Processed, run.Failed, run.Skipped, run.Retries, run.ErrorSummary, run.ID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec update query in update conciliation run: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnfinishedConciliationRun returns the last run still marked as running,
+// which is one that died or timed out before finishing.
+func (r *repository) GetUnfinishedConciliationRun(ctx context.Context) (domain.ConciliationRun, error) {
+	getRunQuery := `
+	SELECT 
+		id,
+		started_at,
+		finished_at,
+		status,
+		exchange_rate,
+		exchange_rate_source,
+		last_card_id,
+		processed,
+		failed,
+		skipped,
+		retries,
+		error_summary
+	FROM 
+		conciliation_runs 
+	WHERE 
+		status = ?
+	ORDER BY started_at DESC, id DESC
+	LIMIT 1;`
+
+	var run domain.ConciliationRun
+	err := r.db.QueryRowContext(ctx, getRunQuery, domain.ConciliationRunning).
+		Scan(&run.ID, &run.StartedAt, &run.FinishedAt, &run.Status, &run.ExchangeRate, &run.ExchangeRateSource,
+			&run.LastCardID, &run.Processed, &run.Failed, &run.Skipped, &run.Retries, &run.ErrorSummary)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{}
+	}
+	if err != nil {
+		return domain.ConciliationRun{}, fmt.Errorf("repository failed to scan row in get unfinished conciliation run: %w", err)
+	}
+
+	return run, nil
+}
+
+func (r *repository) InsertConciliationFailures(ctx context.Context, failures []domain.ConciliationFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(failures))
+	valueArgs := make([]interface{}, 0, len(failures)*5)
+
+	for _, failure := range failures {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs, failure.RunID, failure.CardID, failure.Reason, failure.Error, failure.FailedAt)
+	}
+
+	insertFailuresQuery := fmt.Sprintf("INSERT INTO conciliation_failures (run_id, card_id, reason, error, failed_at) VALUES %s", strings.Join(valueStrings, ", "))
+
+	_, err := r.db.ExecContext(ctx, insertFailuresQuery, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in insert conciliation failures: %w", err)
+	}
+
+	return nil
+}
+
+// InsertQuarantinedPrice holds back a price that broke a sanity rule until it
+// is reviewed. The card keeps its old price meanwhile, so every run quarantines
+// it again: the pending price it had is superseded by the new one, leaving
+// only its latest quote to review.
+func (r *repository) InsertQuarantinedPrice(ctx context.Context, price domain.QuarantinedPrice) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository failed to begin transaction in insert quarantined price: %w", err)
+	}
+	defer tx.Rollback()
+
+	supersedeQuery := `
+	UPDATE 
+		cards_quarantined_prices 
+	SET 
+		status = ? 
+	WHERE 
+		card_id = ? AND status = ?;`
+
+	details := price.CardsDetails
+	_, err = tx.ExecContext(ctx, supersedeQuery, domain.QuarantineSuperseded, details.CardID, domain.QuarantinePending)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec supersede query in insert quarantined price: %w", err)
+	}
+
+	insertQuarantineQuery := `
+	INSERT INTO cards_quarantined_prices 
+		(run_id, card_id, last_price, old_price, price_change, exchange_rate, price_source, quoted_at, sanity_rule, status) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	_, err = tx.ExecContext(ctx, insertQuarantineQuery, price.RunID, details.CardID, details.LastPrice, details.OldPrice,
+		details.PriceChange, details.ExchangeRate, details.PriceSource, details.LastUpdate, price.Rule, price.Status)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in insert quarantined price: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository failed to commit transaction in insert quarantined price: %w", err)
+	}
+
+	return nil
+}
+
+// GetConciliationFailureReasons counts the failures of a run by reason, across
+// every invocation that worked on it.
+func (r *repository) GetConciliationFailureReasons(ctx context.Context, runID int64) (map[domain.ConciliationFailureReason]int64, error) {
+	getReasonsQuery := `
+	SELECT 
+		reason,
+		COUNT(*)
+	FROM 
+		conciliation_failures 
+	WHERE 
+		run_id = ?
+	GROUP BY reason;`
+
+	rows, err := r.db.QueryContext(ctx, getReasonsQuery, runID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to query in get conciliation failure reasons: %w", err)
+	}
+	defer rows.Close()
+
+	reasons := map[domain.ConciliationFailureReason]int64{}
+
+	for rows.Next() {
+		var reason domain.ConciliationFailureReason
+		var count int64
+		err := rows.Scan(&reason, &count)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan rows in get conciliation failure reasons: %w", err)
+		}
+		reasons[reason] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get conciliation failure reasons: %w", err)
+	}
+
+	return reasons, nil
+}
+
 func getRowsAffected(row sql.Result) error {
 	rows, err := row.RowsAffected()
 	if err != nil {
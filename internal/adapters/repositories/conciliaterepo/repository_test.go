@@ -2,7 +2,9 @@ package conciliaterepo
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 func TestInsertCardDetails_Success(t *testing.T) {
 	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
 	mockResult := mocks.NewResultMock()
 
 	repo := New(mockDB)
@@ -33,20 +36,255 @@ func TestInsertCardDetails_Success(t *testing.T) {
 			LastPrice:   5.25,
 			OldPrice:    4.00,
 			PriceChange: 1.25,
+			PriceSource: domain.SourceScryfallEUR,
 			LastUpdate:  &now,
 		},
 	}
 
+	args := []interface{}{
+		int64(1), 10.50, 9.00, 1.50, 0.0, domain.PriceSource(""), &now,
+		int64(2), 5.25, 4.00, 1.25, 0.0, domain.SourceScryfallEUR, &now,
+	}
+
 	mockResult.On("RowsAffected").Return(int64(2), nil)
-	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_details")
+	}), args).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_current_price") && strings.Contains(query, "ON DUPLICATE KEY UPDATE")
+	}), args).Return(mockResult, nil).Once()
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
 
 	err := repo.InsertCardDetails(context.Background(), cardDetails)
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
 	mockResult.AssertExpectations(t)
 }
 
+func TestInsertCardDetails_CurrentPriceError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	now := time.Now()
+	cardDetails := []domain.CardsDetails{{CardID: 1, LastPrice: 10.50, LastUpdate: &now}}
+
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_details")
+	}), mock.Anything).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_current_price")
+	}), mock.Anything).Return(mockResult, fmt.Errorf("database error")).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.InsertCardDetails(context.Background(), cardDetails)
+
+	assert.ErrorContains(t, err, "repository failed to exec upsert query in insert card details")
+	mockTx.AssertNotCalled(t, "Commit")
+	mockTx.AssertExpectations(t)
+}
+
+func TestBackfillCurrentPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockResult.On("RowsAffected").Return(int64(12), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_current_price") && strings.Contains(query, "cd.rn = 1") &&
+			strings.Contains(query, "ON DUPLICATE KEY UPDATE")
+	}), []interface{}(nil)).Return(mockResult, nil)
+
+	rows, err := repo.BackfillCurrentPrices(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), rows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBackfillCurrentPrices_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	rows, err := repo.BackfillCurrentPrices(context.Background())
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in backfill current prices")
+	assert.Equal(t, int64(0), rows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertRawPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	now := time.Now()
+	rate := 5.0
+	rawPrices := []domain.RawPrice{
+		{CardID: 1, QuotedAt: now, Field: "usd", Currency: domain.CurrencyUSD, Value: 10.50, ExchangeRate: &rate, Used: true},
+		{CardID: 1, QuotedAt: now, Field: "eur", Currency: domain.CurrencyEUR, Value: 9.00},
+	}
+
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_raw_prices") && strings.Count(query, "(?, ?, ?, ?, ?, ?, ?)") == 2
+	}), []interface{}{
+		int64(1), now, "usd", domain.CurrencyUSD, 10.50, &rate, true,
+		int64(1), now, "eur", domain.CurrencyEUR, 9.00, (*float64)(nil), false,
+	}).Return(mockResult, nil)
+
+	err := repo.InsertRawPrices(context.Background(), rawPrices)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertRawPrices_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	err := repo.InsertRawPrices(context.Background(), []domain.RawPrice{{CardID: 1, Field: "usd"}})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert raw prices")
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertExchangeRates_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	date := time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC)
+	rates := domain.ExchangeRates{Date: date, Base: domain.CurrencyUSD, Rates: map[string]float64{"USD": 1, "BRL": 5.25, "EUR": 0.92}}
+
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO exchange_rates") && strings.Count(query, "(?, ?, ?, ?)") == 3
+	}), []interface{}{
+		date, "USD", "BRL", 5.25,
+		date, "USD", "EUR", 0.92,
+		date, "USD", "USD", 1.0,
+	}).Return(mockResult, nil)
+
+	err := repo.InsertExchangeRates(context.Background(), rates)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertExchangeRates_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	err := repo.InsertExchangeRates(context.Background(), domain.ExchangeRates{Rates: map[string]float64{"BRL": 5}})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert exchange rates")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetLatestExchangeRates_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	date := time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC)
+	rows := []struct {
+		currency string
+		rate     float64
+	}{{"BRL", 5.25}, {"USD", 1}}
+
+	for _, row := range rows {
+		row := row
+		mockRowsScanner.On("Next").Return(true).Once()
+		mockRowsScanner.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			dest := args.Get(0).([]interface{})
+			*dest[0].(*time.Time) = date
+			*dest[1].(*string) = "USD"
+			*dest[2].(*string) = row.currency
+			*dest[3].(*float64) = row.rate
+		}).Return(nil).Once()
+	}
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "MAX(rate_date)")
+	}), mock.Anything).Return(mockRowsScanner, nil)
+
+	rates, err := repo.GetLatestExchangeRates(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ExchangeRates{Date: date, Base: "USD", Rates: map[string]float64{"BRL": 5.25, "USD": 1}}, rates)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetLatestExchangeRates_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(false)
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
+
+	_, err := repo.GetLatestExchangeRates(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrExchangeRateNotFound{})
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetLatestExchangeRates_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetLatestExchangeRates(context.Background())
+
+	assert.ErrorContains(t, err, "repository failed to query in get latest exchange rates")
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertRawPrices_EmptySlice(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	err := repo.InsertRawPrices(context.Background(), nil)
+
+	assert.NoError(t, err)
+	mockDB.AssertNotCalled(t, "ExecContext")
+}
+
 func TestInsertCardDetails_EmptySlice(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 
@@ -77,8 +315,11 @@ func TestInsertCardDetails_DatabaseError(t *testing.T) {
 		},
 	}
 
+	mockTx := mocks.NewTransactionMock()
 	mockResult := mocks.NewResultMock()
-	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+	mockTx.On("Rollback").Return(nil)
 
 	err := repo.InsertCardDetails(context.Background(), cardDetails)
 
@@ -104,8 +345,11 @@ func TestInsertCardDetails_NoRowsAffected(t *testing.T) {
 		},
 	}
 
+	mockTx := mocks.NewTransactionMock()
 	mockResult.On("RowsAffected").Return(int64(0), nil)
-	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
+	mockTx.On("Rollback").Return(nil)
 
 	err := repo.InsertCardDetails(context.Background(), cardDetails)
 
@@ -130,7 +374,7 @@ func TestGetCardsForUpdate_Success(t *testing.T) {
 
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCardsForUpdate(context.Background(), 0, 10)
+	cards, err := repo.GetCardsForUpdate(context.Background(), domain.StalenessCutoffs{}, 0, 10)
 
 	assert.NoError(t, err)
 	assert.Len(t, cards, 1)
@@ -150,7 +394,7 @@ func TestGetCardsForUpdate_NoCards(t *testing.T) {
 
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCardsForUpdate(context.Background(), 0, 10)
+	cards, err := repo.GetCardsForUpdate(context.Background(), domain.StalenessCutoffs{}, 0, 10)
 
 	assert.NoError(t, err)
 	assert.Len(t, cards, 0)
@@ -166,7 +410,7 @@ func TestGetCardsForUpdate_DatabaseError(t *testing.T) {
 	mockRowsScanner := mocks.NewRowsScannerMock()
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
 
-	cards, err := repo.GetCardsForUpdate(context.Background(), 0, 10)
+	cards, err := repo.GetCardsForUpdate(context.Background(), domain.StalenessCutoffs{}, 0, 10)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to query in get cards for update")
@@ -186,7 +430,7 @@ func TestGetCardsForUpdate_ScanError(t *testing.T) {
 
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCardsForUpdate(context.Background(), 0, 10)
+	cards, err := repo.GetCardsForUpdate(context.Background(), domain.StalenessCutoffs{}, 0, 10)
 
 	// O erro será causado pela conversão de tipo do ID
 	assert.Error(t, err)
@@ -196,24 +440,508 @@ func TestGetCardsForUpdate_ScanError(t *testing.T) {
 	mockRowsScanner.AssertExpectations(t)
 }
 
-func TestGetCardsForUpdate_WithOffsetAndLimit(t *testing.T) {
+func TestGetCardsForUpdate_AfterCursor(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockRowsScanner := mocks.NewRowsScannerMock()
 
 	repo := New(mockDB)
 
+	lastUpdate := time.Date(2026, time.January, 16, 3, 0, 0, 0, time.UTC)
+
 	mockRowsScanner.On("Next").Return(true).Once()
-	mockRowsScanner.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]interface{})
+		*dest[0].(*int64) = 11
+		*dest[9].(**time.Time) = &lastUpdate
+	}).Return(nil).Once()
 	mockRowsScanner.On("Next").Return(false).Once()
 	mockRowsScanner.On("Err").Return(nil)
 	mockRowsScanner.On("Close").Return(nil)
 
-	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "c.id > ?") && strings.Contains(query, "ORDER BY c.id") && !strings.Contains(query, "LIMIT ?, ?")
+	}), []interface{}{int64(10), 0.0, time.Time{}, 0.0, time.Time{}, time.Time{}, 5}).Return(mockRowsScanner, nil)
+
+	cards, err := repo.GetCardsForUpdate(context.Background(), domain.StalenessCutoffs{}, 10, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 1)
+	assert.Equal(t, int64(11), cards[0].ID)
+	assert.Equal(t, &lastUpdate, cards[0].LastUpdate)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetCardsForUpdate_StaleCards(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	startedAt := time.Date(2026, time.January, 16, 3, 0, 0, 0, time.UTC)
+	cutoffs := domain.StalenessCutoffs{
+		HighValuePrice: 100,
+		HighValue:      startedAt,
+		BulkPrice:      1,
+		Bulk:           startedAt.Add(-7 * 24 * time.Hour),
+		Default:        startedAt.Add(-24 * time.Hour),
+	}
+
+	mockRowsScanner.On("Next").Return(false)
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "cd.last_update IS NULL OR cd.last_update < CASE")
+	}), []interface{}{int64(0), 100.0, cutoffs.HighValue, 1.0, cutoffs.Bulk, cutoffs.Default, 10}).Return(mockRowsScanner, nil)
+
+	cards, err := repo.GetCardsForUpdate(context.Background(), cutoffs, 0, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 0)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCountFreshCards_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	startedAt := time.Date(2026, time.January, 16, 3, 0, 0, 0, time.UTC)
+	cutoffs := domain.StalenessCutoffs{HighValue: startedAt, Bulk: startedAt, Default: startedAt}
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "NOT (cd.last_update IS NULL")
+	}), []interface{}{0.0, startedAt, 0.0, startedAt, startedAt}).Return(mockRowScanner)
+
+	_, err := repo.CountFreshCards(context.Background(), cutoffs)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCountFreshCards_ScanError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(fmt.Errorf("scan error"))
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	count, err := repo.CountFreshCards(context.Background(), domain.StalenessCutoffs{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to scan row in count fresh cards")
+	assert.Equal(t, int64(0), count)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetPricedCards_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Twice()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "quantity > 0") && strings.Contains(query, "UNION") && strings.Contains(query, "wishlist")
+	}), []interface{}(nil)).Return(mockRowsScanner, nil)
+
+	cards, err := repo.GetPricedCards(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetPricedCards_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	cards, err := repo.GetPricedCards(context.Background())
+
+	assert.ErrorContains(t, err, "repository failed to query in get priced cards")
+	assert.Nil(t, cards)
+}
+
+func TestGetWishlistForUpdate_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "id > ?") && !strings.Contains(query, "LIMIT ?, ?")
+	}), []interface{}{int64(10), 10}).Return(mockRowsScanner, nil)
+
+	items, err := repo.GetWishlistForUpdate(context.Background(), 10, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestUpdateWishlistPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	lastPrice := 45.0
+	lastUpdate := time.Now()
+	items := []domain.WishlistItem{{ID: 3, LastPrice: &lastPrice, LastUpdate: &lastUpdate}}
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{&lastPrice, &lastUpdate, int64(3)}).Return(mockResult, nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.UpdateWishlistPrices(context.Background(), items)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestUpdateWishlistPrices_EmptySlice(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	err := repo.UpdateWishlistPrices(context.Background(), []domain.WishlistItem{})
+
+	assert.NoError(t, err)
+	mockDB.AssertNotCalled(t, "BeginTx", mock.Anything, mock.Anything)
+}
+
+func TestGetAlertCandidates_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	since := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{since}).Return(mockRowsScanner, nil)
+
+	events, err := repo.GetAlertCandidates(context.Background(), since)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestInsertAlertEvents_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	triggeredAt := time.Now()
+	events := []domain.AlertEvent{
+		{
+			Alert:       domain.Alert{ID: 1, UserID: 7, Kind: domain.AlertPercentage, Direction: domain.AlertUp, Threshold: 10},
+			CardID:      3,
+			CardName:    "Lightning Bolt",
+			CardSetName: "Alpha",
+			OldPrice:    100,
+			NewPrice:    120,
+			TriggeredAt: triggeredAt,
+		},
+	}
+
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(1), int64(7), int64(3), "Lightning Bolt",
+		"Alpha", domain.AlertPercentage, domain.AlertUp, 10.0, 100.0, 120.0, triggeredAt}).Return(mockResult, nil)
+
+	err := repo.InsertAlertEvents(context.Background(), events)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertAlertEvents_EmptySlice(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	err := repo.InsertAlertEvents(context.Background(), []domain.AlertEvent{})
+
+	assert.NoError(t, err)
+	mockDB.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetPriceChanges_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	since := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{since, domain.EventCardPriceChanged}).
+		Return(mockRowsScanner, nil)
+
+	changes, err := repo.GetPriceChanges(context.Background(), since)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestInsertConciliationRun_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	startedAt := time.Now()
+	run := domain.ConciliationRun{StartedAt: startedAt, Status: domain.ConciliationRunning}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{startedAt, domain.ConciliationRunning}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(3), nil)
+
+	id, err := repo.InsertConciliationRun(context.Background(), run)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertConciliationRun_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	_, err := repo.InsertConciliationRun(context.Background(), domain.ConciliationRun{})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert conciliation run")
+	mockDB.AssertExpectations(t)
+}
+
+func TestUpdateConciliationRun_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	finishedAt := time.Now()
+	rate := 5.0
+	run := domain.ConciliationRun{ID: 3, FinishedAt: &finishedAt, Status: domain.ConciliationFinished, ExchangeRate: &rate,
+		ExchangeRateSource: domain.ExchangeRateSourcePrimary, LastCardID: 40, Processed: 38, Failed: 2, Skipped: 1, Retries: 4,
+		ErrorSummary: "timeout: 2"}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{&finishedAt, domain.ConciliationFinished,
+		&rate, domain.ExchangeRateSourcePrimary, int64(40), int64(38), int64(2), int64(1), int64(4), "timeout: 2", int64(3)}).Return(mockResult, nil)
+
+	err := repo.UpdateConciliationRun(context.Background(), run)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetUnfinishedConciliationRun_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{domain.ConciliationRunning}).Return(mockRowScanner)
+
+	_, err := repo.GetUnfinishedConciliationRun(context.Background())
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetUnfinishedConciliationRun_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	_, err := repo.GetUnfinishedConciliationRun(context.Background())
+
+	assert.IsType(t, domain.ErrConciliationRunNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertConciliationFailures_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	failedAt := time.Now()
+	failures := []domain.ConciliationFailure{
+		{RunID: 3, CardID: 1, Reason: domain.FailurePriceUnavailable, Error: "not found", FailedAt: failedAt},
+		{RunID: 3, CardID: 2, Reason: domain.FailureTimeout, Error: "deadline exceeded", FailedAt: failedAt},
+	}
+
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "(?, ?, ?, ?, ?), (?, ?, ?, ?, ?)")
+	}), []interface{}{int64(3), int64(1), domain.FailurePriceUnavailable, "not found", failedAt,
+		int64(3), int64(2), domain.FailureTimeout, "deadline exceeded", failedAt}).Return(mockResult, nil)
+
+	err := repo.InsertConciliationFailures(context.Background(), failures)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertConciliationFailures_EmptySlice(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	err := repo.InsertConciliationFailures(context.Background(), nil)
+
+	assert.NoError(t, err)
+	mockDB.AssertNotCalled(t, "ExecContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInsertQuarantinedPrice_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	quotedAt := time.Now()
+	price := domain.QuarantinedPrice{
+		RunID: 3,
+		CardsDetails: domain.CardsDetails{
+			CardID:       1,
+			LastPrice:    500,
+			OldPrice:     10,
+			PriceChange:  490,
+			ExchangeRate: 5,
+			PriceSource:  domain.SourceScryfallUSD,
+			LastUpdate:   &quotedAt,
+		},
+		Rule:   domain.QuarantineMaxChange,
+		Status: domain.QuarantinePending,
+	}
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	supersede := mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "UPDATE") && strings.Contains(query, "cards_quarantined_prices")
+	}), []interface{}{domain.QuarantineSuperseded, int64(1), domain.QuarantinePending}).Return(mockResult, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_quarantined_prices")
+	}), []interface{}{int64(3), int64(1), 500.0, 10.0, 490.0, 5.0, domain.SourceScryfallUSD, &quotedAt,
+		domain.QuarantineMaxChange, domain.QuarantinePending}).Return(mockResult, nil).NotBefore(supersede)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.InsertQuarantinedPrice(context.Background(), price)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestInsertQuarantinedPrice_SupersedeError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error")).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.InsertQuarantinedPrice(context.Background(), domain.QuarantinedPrice{})
+
+	assert.ErrorContains(t, err, "repository failed to exec supersede query in insert quarantined price")
+	mockTx.AssertExpectations(t)
+}
+
+func TestInsertQuarantinedPrice_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error")).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.InsertQuarantinedPrice(context.Background(), domain.QuarantinedPrice{})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert quarantined price")
+	mockTx.AssertExpectations(t)
+}
+
+func TestGetConciliationFailureReasons_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]interface{})
+		*dest[0].(*domain.ConciliationFailureReason) = domain.FailureTimeout
+		*dest[1].(*int64) = 2
+	}).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(3)}).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCardsForUpdate(context.Background(), 10, 5)
+	reasons, err := repo.GetConciliationFailureReasons(context.Background(), 3)
 
 	assert.NoError(t, err)
-	assert.Len(t, cards, 1) // With zero values due to simple mock
+	assert.Equal(t, map[domain.ConciliationFailureReason]int64{domain.FailureTimeout: 2}, reasons)
 	mockDB.AssertExpectations(t)
 	mockRowsScanner.AssertExpectations(t)
 }
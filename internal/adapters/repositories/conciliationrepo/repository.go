@@ -0,0 +1,177 @@
+package conciliationrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+const runColumns = `
+		id,
+		started_at,
+		finished_at,
+		status,
+		exchange_rate,
+		exchange_rate_source,
+		last_card_id,
+		processed,
+		failed,
+		skipped,
+		retries,
+		error_summary`
+
+func (r *repository) GetConciliationRuns(ctx context.Context, offset, limit int) ([]domain.ConciliationRun, error) {
+	getRunsQuery := `
+	SELECT ` + runColumns + `
+	FROM 
+		conciliation_runs 
+	ORDER BY started_at DESC, id DESC
+	LIMIT ?, ?;`
+
+	rows, err := r.db.QueryContext(ctx, getRunsQuery, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get conciliation runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []domain.ConciliationRun
+
+	for rows.Next() {
+		var run domain.ConciliationRun
+		err := rows.Scan(&run.ID, &run.StartedAt, &run.FinishedAt, &run.Status, &run.ExchangeRate, &run.ExchangeRateSource,
+			&run.LastCardID, &run.Processed, &run.Failed, &run.Skipped, &run.Retries, &run.ErrorSummary)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get conciliation runs: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get conciliation runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (r *repository) GetConciliationRunsCount(ctx context.Context) (int64, error) {
+	countQuery := `
+	SELECT COUNT(*)
+	FROM 
+		conciliation_runs;`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan count in get conciliation runs count: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repository) GetConciliationRunByID(ctx context.Context, id string) (domain.ConciliationRun, error) {
+	getRunQuery := `
+	SELECT ` + runColumns + `
+	FROM 
+		conciliation_runs 
+	WHERE 
+		id = ?;`
+
+	var run domain.ConciliationRun
+	err := r.db.QueryRowContext(ctx, getRunQuery, id).
+		Scan(&run.ID, &run.StartedAt, &run.FinishedAt, &run.Status, &run.ExchangeRate, &run.ExchangeRateSource,
+			&run.LastCardID, &run.Processed, &run.Failed, &run.Skipped, &run.Retries, &run.ErrorSummary)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{}
+	}
+	if err != nil {
+		return domain.ConciliationRun{}, fmt.Errorf("repository failed to scan row in get conciliation run by id: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetConciliationFailures returns the failures of a run for the cards of the
+// collections the user is a member of.
+func (r *repository) GetConciliationFailures(ctx context.Context, userID, runID int64, offset, limit int) ([]domain.ConciliationFailure, error) {
+	getFailuresQuery := `
+	SELECT 
+		f.id,
+		f.run_id,
+		f.card_id,
+		c.name,
+		c.set_name,
+		c.collector_number,
+		f.reason,
+		f.error,
+		f.failed_at
+	FROM 
+		conciliation_failures f
+	JOIN 
+		cards c 
+	ON 
+		c.id = f.card_id
+	WHERE 
+		f.run_id = ? 
+		AND c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?)
+	ORDER BY f.failed_at, f.id
+	LIMIT ?, ?;`
+
+	rows, err := r.db.QueryContext(ctx, getFailuresQuery, runID, userID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get conciliation failures: %w", err)
+	}
+	defer rows.Close()
+
+	var failures []domain.ConciliationFailure
+
+	for rows.Next() {
+		var failure domain.ConciliationFailure
+		err := rows.Scan(&failure.ID, &failure.RunID, &failure.CardID, &failure.Name, &failure.SetName, &failure.CollectorNumber,
+			&failure.Reason, &failure.Error, &failure.FailedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get conciliation failures: %w", err)
+		}
+		failures = append(failures, failure)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get conciliation failures: %w", err)
+	}
+
+	return failures, nil
+}
+
+func (r *repository) GetConciliationFailuresCount(ctx context.Context, userID, runID int64) (int64, error) {
+	countQuery := `
+	SELECT COUNT(*)
+	FROM 
+		conciliation_failures f
+	JOIN 
+		cards c 
+	ON 
+		c.id = f.card_id
+	WHERE 
+		f.run_id = ? 
+		AND c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?);`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery, runID, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan count in get conciliation failures count: %w", err)
+	}
+
+	return count, nil
+}
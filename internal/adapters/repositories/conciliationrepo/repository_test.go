@@ -0,0 +1,123 @@
+package conciliationrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestGetConciliationRuns_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{20, 10}).Return(mockRowsScanner, nil)
+
+	runs, err := repo.GetConciliationRuns(context.Background(), 20, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetConciliationRuns_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	runs, err := repo.GetConciliationRuns(context.Background(), 0, 10)
+
+	assert.ErrorContains(t, err, "repository failed to exec query in get conciliation runs")
+	assert.Nil(t, runs)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetConciliationRunsCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	_, err := repo.GetConciliationRunsCount(context.Background())
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetConciliationRunByID_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"3"}).Return(mockRowScanner)
+
+	_, err := repo.GetConciliationRunByID(context.Background(), "3")
+
+	assert.IsType(t, domain.ErrConciliationRunNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetConciliationFailures_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "collection_members WHERE user_id = ?")
+	}), []interface{}{int64(3), testUserID, 0, 10}).Return(mockRowsScanner, nil)
+
+	failures, err := repo.GetConciliationFailures(context.Background(), testUserID, 3, 0, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, failures, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetConciliationFailuresCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(3), testUserID}).Return(mockRowScanner)
+
+	_, err := repo.GetConciliationFailuresCount(context.Background(), testUserID, 3)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
@@ -0,0 +1,22 @@
+// Package currentprice holds the SQL shared by the repositories that write
+// cards_current_price, so they all keep the latest price of a card the same
+// way.
+package currentprice
+
+// Insert is the head of an insert into cards_current_price, to be followed by
+// VALUES or a SELECT of the same columns and then Upsert.
+const Insert = "INSERT INTO cards_current_price (card_id, last_price, old_price, price_change, exchange_rate, price_source, last_update)"
+
+// Upsert keeps the latest price of each card in cards_current_price, so reads
+// do not look for it through the whole history. A row is only replaced by a
+// newer price, and last_update is assigned last since MySQL applies the
+// assignments in order. Columns are qualified as the backfill selects columns
+// of the same names.
+const Upsert = `
+	ON DUPLICATE KEY UPDATE
+		cards_current_price.last_price = IF(VALUES(last_update) >= cards_current_price.last_update, VALUES(last_price), cards_current_price.last_price),
+		cards_current_price.old_price = IF(VALUES(last_update) >= cards_current_price.last_update, VALUES(old_price), cards_current_price.old_price),
+		cards_current_price.price_change = IF(VALUES(last_update) >= cards_current_price.last_update, VALUES(price_change), cards_current_price.price_change),
+		cards_current_price.exchange_rate = IF(VALUES(last_update) >= cards_current_price.last_update, VALUES(exchange_rate), cards_current_price.exchange_rate),
+		cards_current_price.price_source = IF(VALUES(last_update) >= cards_current_price.last_update, VALUES(price_source), cards_current_price.price_source),
+		cards_current_price.last_update = GREATEST(cards_current_price.last_update, VALUES(last_update))`
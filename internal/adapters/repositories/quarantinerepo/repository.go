@@ -0,0 +1,224 @@
+package quarantinerepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mtg-report/internal/adapters/repositories/currentprice"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+	"time"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+const priceColumns = `
+		q.id,
+		q.run_id,
+		q.card_id,
+		c.name,
+		c.set_name,
+		c.collector_number,
+		q.last_price,
+		q.old_price,
+		q.price_change,
+		q.exchange_rate,
+		q.price_source,
+		q.quoted_at,
+		q.sanity_rule,
+		q.status,
+		q.reviewed_at,
+		q.reviewed_by`
+
+// scanPrice scans the columns of priceColumns into price, followed by extra.
+func scanPrice(scanner database.RowScanner, price *domain.QuarantinedPrice, extra ...interface{}) error {
+	details := &price.CardsDetails
+	dest := []interface{}{&price.ID, &price.RunID, &details.CardID, &price.Name, &price.SetName, &price.CollectorNumber,
+		&details.LastPrice, &details.OldPrice, &details.PriceChange, &details.ExchangeRate, &details.PriceSource,
+		&details.LastUpdate, &price.Rule, &price.Status, &price.ReviewedAt, &price.ReviewedBy}
+	return scanner.Scan(append(dest, extra...)...)
+}
+
+// GetQuarantinedPrices returns the quarantined prices of a status for the
+// cards of the collections the user is a member of, oldest first.
+func (r *repository) GetQuarantinedPrices(ctx context.Context, userID int64, status domain.QuarantineStatus, offset, limit int) ([]domain.QuarantinedPrice, error) {
+	getPricesQuery := `
+	SELECT ` + priceColumns + `
+	FROM
+		cards_quarantined_prices q
+	JOIN
+		cards c
+	ON
+		c.id = q.card_id
+	WHERE
+		q.status = ?
+		AND c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?)
+	ORDER BY q.id
+	LIMIT ?, ?;`
+
+	rows, err := r.db.QueryContext(ctx, getPricesQuery, status, userID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get quarantined prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []domain.QuarantinedPrice
+
+	for rows.Next() {
+		var price domain.QuarantinedPrice
+		err := scanPrice(rows, &price)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get quarantined prices: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get quarantined prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (r *repository) GetQuarantinedPricesCount(ctx context.Context, userID int64, status domain.QuarantineStatus) (int64, error) {
+	countQuery := `
+	SELECT COUNT(*)
+	FROM
+		cards_quarantined_prices q
+	JOIN
+		cards c
+	ON
+		c.id = q.card_id
+	WHERE
+		q.status = ?
+		AND c.collection_id IN (SELECT collection_id FROM collection_members WHERE user_id = ?);`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery, status, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan count in get quarantined prices count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetQuarantinedPriceByID returns a quarantined price along with the role of
+// the user in the collection of its card. Prices of collections the user is
+// not a member of are not found.
+func (r *repository) GetQuarantinedPriceByID(ctx context.Context, userID int64, id string) (domain.QuarantinedPrice, error) {
+	getPriceQuery := `
+	SELECT ` + priceColumns + `,
+		m.role
+	FROM
+		cards_quarantined_prices q
+	JOIN
+		cards c
+	ON
+		c.id = q.card_id
+	JOIN
+		collection_members m
+	ON
+		m.collection_id = c.collection_id AND m.user_id = ?
+	WHERE
+		q.id = ?;`
+
+	var price domain.QuarantinedPrice
+	err := scanPrice(r.db.QueryRowContext(ctx, getPriceQuery, userID, id), &price, &price.Role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.QuarantinedPrice{}, domain.ErrQuarantinedPriceNotFound{}
+	}
+	if err != nil {
+		return domain.QuarantinedPrice{}, fmt.Errorf("repository failed to scan row in get quarantined price by id: %w", err)
+	}
+
+	return price, nil
+}
+
+// AcceptQuarantinedPrice marks a pending price as accepted and stores it as a
+// snapshot of its card, taken when it was quoted, in a single transaction.
+func (r *repository) AcceptQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository failed to begin transaction in accept quarantined price: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = review(ctx, tx, userID, price.ID, domain.QuarantineAccepted, reviewedAt)
+	if err != nil {
+		return fmt.Errorf("repository failed to review in accept quarantined price: %w", err)
+	}
+
+	details := price.CardsDetails
+	args := []interface{}{details.CardID, details.LastPrice, details.OldPrice, details.PriceChange, details.ExchangeRate, details.PriceSource, details.LastUpdate}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO cards_details (card_id, last_price, old_price, price_change, exchange_rate, price_source, last_update) VALUES (?, ?, ?, ?, ?, ?, ?)", args...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in accept quarantined price: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, currentprice.Insert+" VALUES (?, ?, ?, ?, ?, ?, ?)"+currentprice.Upsert, args...)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec upsert query in accept quarantined price: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository failed to commit transaction in accept quarantined price: %w", err)
+	}
+
+	return nil
+}
+
+// RejectQuarantinedPrice marks a pending price as rejected, so it never
+// reaches the history.
+func (r *repository) RejectQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error {
+	err := review(ctx, r.db, userID, price.ID, domain.QuarantineRejected, reviewedAt)
+	if err != nil {
+		return fmt.Errorf("repository failed to review in reject quarantined price: %w", err)
+	}
+
+	return nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (database.Result, error)
+}
+
+// review settles a pending price. A price reviewed by someone else in the
+// meantime is left as it is.
+func review(ctx context.Context, db execer, userID, id int64, status domain.QuarantineStatus, reviewedAt time.Time) error {
+	reviewQuery := `
+	UPDATE
+		cards_quarantined_prices
+	SET
+		status = ?,
+		reviewed_at = ?,
+		reviewed_by = ?
+	WHERE
+		id = ? AND status = ?;`
+
+	res, err := db.ExecContext(ctx, reviewQuery, status, reviewedAt, userID, id, domain.QuarantinePending)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec update query: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrQuarantinedPriceReviewed{}
+	}
+
+	return nil
+}
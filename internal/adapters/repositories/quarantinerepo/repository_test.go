@@ -0,0 +1,206 @@
+package quarantinerepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestNew(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, mockDB, repo.db)
+}
+
+func TestGetQuarantinedPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "FROM\n\t\tcards_quarantined_prices q") && strings.Contains(query, "collection_members WHERE user_id = ?")
+	}), []interface{}{domain.QuarantinePending, testUserID, 0, 10}).Return(mockRowsScanner, nil)
+
+	prices, err := repo.GetQuarantinedPrices(context.Background(), testUserID, domain.QuarantinePending, 0, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetQuarantinedPrices_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	prices, err := repo.GetQuarantinedPrices(context.Background(), testUserID, domain.QuarantinePending, 0, 10)
+
+	assert.ErrorContains(t, err, "repository failed to exec query in get quarantined prices")
+	assert.Nil(t, prices)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetQuarantinedPricesCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{domain.QuarantineRejected, testUserID}).Return(mockRowScanner)
+
+	_, err := repo.GetQuarantinedPricesCount(context.Background(), testUserID, domain.QuarantineRejected)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetQuarantinedPriceByID_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "m.collection_id = c.collection_id AND m.user_id = ?")
+	}), []interface{}{testUserID, "3"}).Return(mockRowScanner)
+
+	_, err := repo.GetQuarantinedPriceByID(context.Background(), testUserID, "3")
+
+	assert.ErrorIs(t, err, domain.ErrQuarantinedPriceNotFound{})
+	mockDB.AssertExpectations(t)
+}
+
+func TestAcceptQuarantinedPrice_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	quotedAt := time.Date(2026, 1, 16, 3, 0, 0, 0, time.UTC)
+	reviewedAt := quotedAt.Add(5 * time.Hour)
+	price := domain.QuarantinedPrice{
+		ID: 3,
+		CardsDetails: domain.CardsDetails{
+			CardID:       1,
+			LastPrice:    500,
+			OldPrice:     10,
+			PriceChange:  490,
+			ExchangeRate: 5,
+			PriceSource:  domain.SourceScryfallUSD,
+			LastUpdate:   &quotedAt,
+		},
+	}
+	details := []interface{}{int64(1), 500.0, 10.0, 490.0, 5.0, domain.SourceScryfallUSD, &quotedAt}
+
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "UPDATE\n\t\tcards_quarantined_prices") && strings.Contains(query, "id = ? AND status = ?")
+	}), []interface{}{domain.QuarantineAccepted, reviewedAt, testUserID, int64(3), domain.QuarantinePending}).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_details")
+	}), details).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "INSERT INTO cards_current_price") && strings.Contains(query, "ON DUPLICATE KEY UPDATE")
+	}), details).Return(mockResult, nil).Once()
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.AcceptQuarantinedPrice(context.Background(), testUserID, price, reviewedAt)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestAcceptQuarantinedPrice_AlreadyReviewed(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	err := repo.AcceptQuarantinedPrice(context.Background(), testUserID, domain.QuarantinedPrice{ID: 3}, time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrQuarantinedPriceReviewed{})
+	mockTx.AssertNotCalled(t, "Commit")
+	mockTx.AssertExpectations(t)
+}
+
+func TestAcceptQuarantinedPrice_BeginTxError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, fmt.Errorf("database error"))
+
+	err := repo.AcceptQuarantinedPrice(context.Background(), testUserID, domain.QuarantinedPrice{ID: 3}, time.Now())
+
+	assert.ErrorContains(t, err, "repository failed to begin transaction in accept quarantined price")
+	mockDB.AssertExpectations(t)
+}
+
+func TestRejectQuarantinedPrice_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	reviewedAt := time.Date(2026, 1, 16, 8, 0, 0, 0, time.UTC)
+
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"),
+		[]interface{}{domain.QuarantineRejected, reviewedAt, testUserID, int64(3), domain.QuarantinePending}).Return(mockResult, nil)
+
+	err := repo.RejectQuarantinedPrice(context.Background(), testUserID, domain.QuarantinedPrice{ID: 3}, reviewedAt)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestRejectQuarantinedPrice_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	err := repo.RejectQuarantinedPrice(context.Background(), testUserID, domain.QuarantinedPrice{ID: 3}, time.Now())
+
+	assert.ErrorContains(t, err, "repository failed to review in reject quarantined price")
+}
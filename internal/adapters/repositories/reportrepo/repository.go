@@ -18,33 +18,85 @@ func New(db database.Client) *repository {
 	}
 }
 
-func (r *repository) InsertTotalPrice(ctx context.Context) error {
+func (r *repository) GetReportUsers(ctx context.Context, collectionID int64) ([]domain.User, error) {
+	getUsersQuery := `
+	SELECT 
+		u.id,
+		u.name,
+		u.email
+	FROM 
+		users u`
+
+	var values []interface{}
+	if collectionID != 0 {
+		getUsersQuery += `
+	WHERE 
+		u.id = (SELECT user_id FROM collections WHERE id = ?)`
+		values = append(values, collectionID)
+	}
+
+	getUsersQuery += `
+	ORDER BY u.id;`
+
+	rows, err := r.db.QueryContext(ctx, getUsersQuery, values...)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get report users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.ID, &user.Name, &user.Email)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get report users: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get report users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *repository) InsertTotalPrice(ctx context.Context, userID, collectionID int64) error {
+	// totals are tracked per user and collection, collection_id 0 holds all
+	// the cards of the user so scoped and unscoped reports never share a
+	// price history.
+	where, values := scope(userID, collectionID)
+
 	insertQuery := `
-	INSERT INTO prices (old_price, new_price, price_change, last_update)
+	INSERT INTO prices (user_id, collection_id, old_price, new_price, price_change, last_update)
 	SELECT 
+		?,
+		?,
 		COALESCE((SELECT new_price
 		FROM prices
+		WHERE user_id = ? AND collection_id = ?
 		ORDER BY last_update DESC
 		LIMIT 1), 0),
 		COALESCE(SUM(subquery.last_price), 0) AS new_price,
 		COALESCE(SUM(subquery.last_price), 0) - COALESCE((SELECT new_price
 									FROM prices
+									WHERE user_id = ? AND collection_id = ?
 									ORDER BY last_update DESC
 									LIMIT 1), 0),
 		NOW() AS last_update
 	FROM (
 		SELECT 
-			cd.last_price AS last_price
+			cd.last_price * c.quantity AS last_price
 		FROM cards c
-		LEFT JOIN (
-			SELECT *,
-				ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-			FROM cards_details
-		) cd
-		ON c.id = cd.card_id AND cd.rn = 1
+		LEFT JOIN cards_current_price cd
+		ON c.id = cd.card_id
+		WHERE ` + where + `
 	) AS subquery;`
 
-	res, err := r.db.ExecContext(ctx, insertQuery)
+	args := append([]interface{}{userID, collectionID, userID, collectionID, userID, collectionID}, values...)
+
+	res, err := r.db.ExecContext(ctx, insertQuery, args...)
 	if err != nil {
 		return fmt.Errorf("repository failed to exec insert query in insert total price: %w", err)
 	}
@@ -57,7 +109,9 @@ func (r *repository) InsertTotalPrice(ctx context.Context) error {
 	return nil
 }
 
-func (r *repository) GetCardsReport(ctx context.Context) ([]domain.Cards, error) {
+func (r *repository) GetCardsReport(ctx context.Context, userID, collectionID int64) ([]domain.Cards, error) {
+	where, values := scope(userID, collectionID)
+
 	getCardsQuery := `
 	SELECT * FROM 
 	(
@@ -66,7 +120,10 @@ func (r *repository) GetCardsReport(ctx context.Context) ([]domain.Cards, error)
 			name,
 			set_name,
 			collector_number,
-			foil,
+			finish,
+			card_condition,
+			language,
+			quantity,
 			COALESCE(cd.last_price, 0) as last_price,
 			COALESCE(cd.old_price, 0) as old_price,
 			COALESCE(cd.price_change, 0) as price_change,
@@ -74,18 +131,15 @@ func (r *repository) GetCardsReport(ctx context.Context) ([]domain.Cards, error)
 		FROM 
 			cards c
 		LEFT JOIN 
-		(
-			SELECT *,
-				ROW_NUMBER() OVER(PARTITION BY card_id ORDER BY last_update DESC) AS rn
-			FROM 
-				cards_details
-		) cd
+			cards_current_price cd
 		ON 
-			c.id = cd.card_id AND cd.rn = 1
+			c.id = cd.card_id
+		WHERE 
+			c.quantity > 0 AND ` + where + `
 	) main 
 	ORDER BY price_change DESC LIMIT 100;`
 
-	rows, err := r.db.QueryContext(ctx, getCardsQuery)
+	rows, err := r.db.QueryContext(ctx, getCardsQuery, values...)
 	if err != nil {
 		return nil, fmt.Errorf("repository failed to exec query in get cards report: %w", err)
 	}
@@ -95,7 +149,7 @@ func (r *repository) GetCardsReport(ctx context.Context) ([]domain.Cards, error)
 	for rows.Next() {
 		var cardDomain domain.Cards
 		err := rows.Scan(&cardDomain.ID, &cardDomain.Name, &cardDomain.SetName, &cardDomain.CollectorNumber,
-			&cardDomain.Foil, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate)
+			&cardDomain.Finish, &cardDomain.Condition, &cardDomain.Language, &cardDomain.Quantity, &cardDomain.LastPrice, &cardDomain.OldPrice, &cardDomain.PriceChange, &cardDomain.LastUpdate)
 		if err != nil {
 			return nil, fmt.Errorf("repository failed to scan row in get cards report: %w", err)
 		}
@@ -109,7 +163,7 @@ func (r *repository) GetCardsReport(ctx context.Context) ([]domain.Cards, error)
 	return cardsDomain, nil
 }
 
-func (r *repository) GetTotalPrice(ctx context.Context) (domain.CardsPrice, error) {
+func (r *repository) GetTotalPrice(ctx context.Context, userID, collectionID int64) (domain.CardsPrice, error) {
 	getPriceQuery := `
 	SELECT 
 		old_price,
@@ -118,9 +172,11 @@ func (r *repository) GetTotalPrice(ctx context.Context) (domain.CardsPrice, erro
 		last_update
 	FROM 
 		prices 
+	WHERE 
+		user_id = ? AND collection_id = ?
 	ORDER by last_update DESC LIMIT 1;`
 
-	row := r.db.QueryRowContext(ctx, getPriceQuery)
+	row := r.db.QueryRowContext(ctx, getPriceQuery, userID, collectionID)
 
 	var cardsPriceDomain domain.CardsPrice
 	err := row.Scan(&cardsPriceDomain.OldPrice, &cardsPriceDomain.NewPrice, &cardsPriceDomain.PriceChange, &cardsPriceDomain.LastUpdate)
@@ -135,6 +191,102 @@ func (r *repository) GetTotalPrice(ctx context.Context) (domain.CardsPrice, erro
 	return cardsPriceDomain, nil
 }
 
+func (r *repository) GetUnrealizedGain(ctx context.Context, userID, collectionID int64) (domain.UnrealizedGain, error) {
+	where, values := scope(userID, collectionID)
+
+	// only cards with a known cost basis take part, so market value and cost
+	// basis are always compared over the same set of cards.
+	getGainQuery := `
+	SELECT 
+		COALESCE(SUM(main.cost_basis), 0) as cost_basis,
+		COALESCE(SUM(main.market_value), 0) as market_value,
+		COALESCE(SUM(main.market_value - main.cost_basis), 0) as unrealized_gain
+	FROM 
+	(
+		SELECT 
+			c.acquisition_price * c.acquisition_rate * c.quantity as cost_basis,
+			COALESCE(cd.last_price, 0) * c.quantity as market_value
+		FROM 
+			cards c
+		LEFT JOIN 
+			cards_current_price cd
+		ON 
+			c.id = cd.card_id
+		WHERE 
+			` + where + `
+	) main
+	WHERE main.cost_basis IS NOT NULL;`
+
+	row := r.db.QueryRowContext(ctx, getGainQuery, values...)
+
+	var gain domain.UnrealizedGain
+	err := row.Scan(&gain.CostBasis, &gain.MarketValue, &gain.UnrealizedGain)
+	if err != nil {
+		return domain.UnrealizedGain{}, fmt.Errorf("repository failed to scan row in get unrealized gain: %w", err)
+	}
+
+	return gain, nil
+}
+
+// GetAffordableWishlist returns the wishlist items of the user whose last
+// known price is at or below the target price.
+func (r *repository) GetAffordableWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error) {
+	getWishlistQuery := `
+	SELECT 
+		id,
+		user_id,
+		name,
+		set_name,
+		collector_number,
+		finish,
+		max_price,
+		last_price,
+		last_update
+	FROM 
+		wishlist 
+	WHERE 
+		user_id = ? AND last_price IS NOT NULL AND last_price <= max_price
+	ORDER BY last_price - max_price;`
+
+	rows, err := r.db.QueryContext(ctx, getWishlistQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get affordable wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.WishlistItem
+
+	for rows.Next() {
+		var item domain.WishlistItem
+		err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.SetName, &item.CollectorNumber, &item.Finish,
+			&item.MaxPrice, &item.LastPrice, &item.LastUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get affordable wishlist: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get affordable wishlist: %w", err)
+	}
+
+	return items, nil
+}
+
+// scope restricts a query on cards c to the collections of the user, or to a
+// single one of them when collectionID is set.
+func scope(userID, collectionID int64) (string, []interface{}) {
+	clause := "c.collection_id IN (SELECT id FROM collections WHERE user_id = ?)"
+	values := []interface{}{userID}
+
+	if collectionID != 0 {
+		clause += " AND c.collection_id = ?"
+		values = append(values, collectionID)
+	}
+
+	return clause, values
+}
+
 func getRowsAffected(row sql.Result) error {
 	rows, err := row.RowsAffected()
 	if err != nil {
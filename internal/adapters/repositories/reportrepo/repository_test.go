@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 
 	"mtg-report/internal/core/domain"
@@ -13,6 +14,67 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+const testUserID int64 = 7
+
+func TestGetReportUsers_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Twice()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Twice()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}(nil)).Return(mockRowsScanner, nil)
+
+	users, err := repo.GetReportUsers(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetReportUsers_ScopedToCollection(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "SELECT user_id FROM collections WHERE id = ?")
+	}), []interface{}{int64(3)}).Return(mockRowsScanner, nil)
+
+	users, err := repo.GetReportUsers(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetReportUsers_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetReportUsers(context.Background(), 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to exec query in get report users")
+	mockDB.AssertExpectations(t)
+}
+
 func TestInsertTotalPrice_Success(t *testing.T) {
 	mockDB := mocks.NewClientMock()
 	mockResult := mocks.NewResultMock()
@@ -22,7 +84,25 @@ func TestInsertTotalPrice_Success(t *testing.T) {
 	mockResult.On("RowsAffected").Return(int64(1), nil)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
 
-	err := repo.InsertTotalPrice(context.Background())
+	err := repo.InsertTotalPrice(context.Background(), testUserID, 0)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertTotalPrice_ScopedToCollection(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "AND c.collection_id = ?")
+	}), []interface{}{testUserID, int64(3), testUserID, int64(3), testUserID, int64(3), testUserID, int64(3)}).Return(mockResult, nil)
+
+	err := repo.InsertTotalPrice(context.Background(), testUserID, 3)
 
 	assert.NoError(t, err)
 	mockDB.AssertExpectations(t)
@@ -37,7 +117,7 @@ func TestInsertTotalPrice_DatabaseError(t *testing.T) {
 	mockResult := mocks.NewResultMock()
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
 
-	err := repo.InsertTotalPrice(context.Background())
+	err := repo.InsertTotalPrice(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to exec insert query in insert total price")
@@ -53,7 +133,7 @@ func TestInsertTotalPrice_NoRowsAffected(t *testing.T) {
 	mockResult.On("RowsAffected").Return(int64(0), nil)
 	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
 
-	err := repo.InsertTotalPrice(context.Background())
+	err := repo.InsertTotalPrice(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository insert total price failed")
@@ -72,9 +152,33 @@ func TestGetCardsReport_Success(t *testing.T) {
 	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
 	mockRowsScanner.On("Next").Return(false).Once()
 
-	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "cards_current_price cd") && !strings.Contains(query, "ROW_NUMBER")
+	}), mock.Anything).Return(mockRowsScanner, nil)
+
+	cards, err := repo.GetCardsReport(context.Background(), testUserID, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, cards, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetCardsReport_ScopedToCollection(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
 
-	cards, err := repo.GetCardsReport(context.Background())
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "AND c.collection_id = ?")
+	}), []interface{}{testUserID, int64(3)}).Return(mockRowsScanner, nil)
+
+	cards, err := repo.GetCardsReport(context.Background(), testUserID, 3)
 
 	assert.NoError(t, err)
 	assert.Len(t, cards, 1)
@@ -91,7 +195,7 @@ func TestGetCardsReport_NoCards(t *testing.T) {
 	mockRowsScanner.On("Next").Return(false)
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, nil)
 
-	cards, err := repo.GetCardsReport(context.Background())
+	cards, err := repo.GetCardsReport(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.IsType(t, domain.ErrCardNotFound{}, err)
@@ -108,7 +212,7 @@ func TestGetCardsReport_DatabaseError(t *testing.T) {
 	mockRowsScanner := mocks.NewRowsScannerMock()
 	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowsScanner, fmt.Errorf("database error"))
 
-	cards, err := repo.GetCardsReport(context.Background())
+	cards, err := repo.GetCardsReport(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to exec query in get cards report")
@@ -125,7 +229,7 @@ func TestGetTotalPrice_Success(t *testing.T) {
 	mockRowScanner.On("Scan").Return(nil)
 	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
 
-	result, err := repo.GetTotalPrice(context.Background())
+	result, err := repo.GetTotalPrice(context.Background(), testUserID, 0)
 
 	// With zero values due to mock limitation
 	assert.NoError(t, err)
@@ -145,7 +249,7 @@ func TestGetTotalPrice_NotFound(t *testing.T) {
 	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
 	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
 
-	_, err := repo.GetTotalPrice(context.Background())
+	_, err := repo.GetTotalPrice(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.IsType(t, domain.ErrCardNotFound{}, err)
@@ -162,10 +266,82 @@ func TestGetTotalPrice_ScanError(t *testing.T) {
 	mockRowScanner.On("Scan").Return(fmt.Errorf("scan error"))
 	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
 
-	_, err := repo.GetTotalPrice(context.Background())
+	_, err := repo.GetTotalPrice(context.Background(), testUserID, 0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repository failed to scan row in get total price")
 	mockDB.AssertExpectations(t)
 	mockRowScanner.AssertExpectations(t)
 }
+
+func TestGetUnrealizedGain_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	result, err := repo.GetUnrealizedGain(context.Background(), testUserID, 0)
+
+	// With zero values due to mock limitation
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UnrealizedGain{}, result)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetUnrealizedGain_ScanError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(fmt.Errorf("scan error"))
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+
+	_, err := repo.GetUnrealizedGain(context.Background(), testUserID, 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to scan row in get unrealized gain")
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetAffordableWishlist_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "last_price <= max_price")
+	}), []interface{}{testUserID}).Return(mockRowsScanner, nil)
+
+	items, err := repo.GetAffordableWishlist(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetAffordableWishlist_DatabaseError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowsScanner, fmt.Errorf("database error"))
+
+	_, err := repo.GetAffordableWishlist(context.Background(), testUserID)
+
+	assert.ErrorContains(t, err, "repository failed to exec query in get affordable wishlist")
+	mockDB.AssertExpectations(t)
+}
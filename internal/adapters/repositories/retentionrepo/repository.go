@@ -0,0 +1,171 @@
+package retentionrepo
+
+import (
+	"context"
+	"fmt"
+	database "mtg-report/internal/sources/databases/mysql"
+	"time"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// mergeAggregate folds a rolled up period into the stored aggregate of the
+// same period, if any, so a period rolled up in parts keeps its open, close,
+// extremes and sample count. opened_at and closed_at are assigned last since
+// MySQL applies the assignments in order.
+const mergeAggregate = `
+	ON DUPLICATE KEY UPDATE
+		cards_price_aggregates.old_price = IF(VALUES(opened_at) < cards_price_aggregates.opened_at, VALUES(old_price), cards_price_aggregates.old_price),
+		cards_price_aggregates.open_price = IF(VALUES(opened_at) < cards_price_aggregates.opened_at, VALUES(open_price), cards_price_aggregates.open_price),
+		cards_price_aggregates.close_price = IF(VALUES(closed_at) > cards_price_aggregates.closed_at, VALUES(close_price), cards_price_aggregates.close_price),
+		cards_price_aggregates.min_price = LEAST(cards_price_aggregates.min_price, VALUES(min_price)),
+		cards_price_aggregates.max_price = GREATEST(cards_price_aggregates.max_price, VALUES(max_price)),
+		cards_price_aggregates.samples = cards_price_aggregates.samples + VALUES(samples),
+		cards_price_aggregates.opened_at = LEAST(cards_price_aggregates.opened_at, VALUES(opened_at)),
+		cards_price_aggregates.closed_at = GREATEST(cards_price_aggregates.closed_at, VALUES(closed_at))`
+
+// RollUpDailyPrices sums up the snapshots of cards_details taken before
+// `before` into weekly aggregates, then deletes them, in a single
+// transaction. The raw quotes behind them are kept, so history can still be
+// revalued and audited. Weeks start on Monday. It returns the number of
+// snapshots rolled up.
+func (r *repository) RollUpDailyPrices(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to begin transaction in roll up daily prices: %w", err)
+	}
+	defer tx.Rollback()
+
+	rollUpQuery := `
+	INSERT INTO cards_price_aggregates (card_id, resolution, period_start, old_price, open_price, close_price, min_price, max_price, opened_at, closed_at, samples)
+	SELECT
+		card_id,
+		'weekly',
+		period_start,
+		MAX(CASE WHEN first_rn = 1 THEN old_price END),
+		MAX(CASE WHEN first_rn = 1 THEN last_price END),
+		MAX(CASE WHEN last_rn = 1 THEN last_price END),
+		MIN(last_price),
+		MAX(last_price),
+		MIN(last_update),
+		MAX(last_update),
+		COUNT(*)
+	FROM
+	(
+		SELECT
+			card_id,
+			last_price,
+			old_price,
+			last_update,
+			DATE_SUB(DATE(last_update), INTERVAL WEEKDAY(last_update) DAY) AS period_start,
+			ROW_NUMBER() OVER(PARTITION BY card_id, DATE_SUB(DATE(last_update), INTERVAL WEEKDAY(last_update) DAY) ORDER BY last_update, id) AS first_rn,
+			ROW_NUMBER() OVER(PARTITION BY card_id, DATE_SUB(DATE(last_update), INTERVAL WEEKDAY(last_update) DAY) ORDER BY last_update DESC, id DESC) AS last_rn
+		FROM
+			cards_details
+		WHERE
+			last_update < ?
+	) history
+	GROUP BY
+		card_id, period_start` + mergeAggregate + `;`
+
+	_, err = tx.ExecContext(ctx, rollUpQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec insert query in roll up daily prices: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM cards_details WHERE last_update < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec delete query in roll up daily prices: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to get rows affected in roll up daily prices: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to commit transaction in roll up daily prices: %w", err)
+	}
+
+	return rows, nil
+}
+
+// RollUpWeeklyPrices sums up the weekly aggregates of the weeks starting
+// before `before` into monthly aggregates, then deletes them, in a single
+// transaction. A week belongs to the month it starts in. It returns the
+// number of weekly aggregates rolled up.
+func (r *repository) RollUpWeeklyPrices(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to begin transaction in roll up weekly prices: %w", err)
+	}
+	defer tx.Rollback()
+
+	rollUpQuery := `
+	INSERT INTO cards_price_aggregates (card_id, resolution, period_start, old_price, open_price, close_price, min_price, max_price, opened_at, closed_at, samples)
+	SELECT
+		card_id,
+		'monthly',
+		month_start,
+		MAX(CASE WHEN first_rn = 1 THEN old_price END),
+		MAX(CASE WHEN first_rn = 1 THEN open_price END),
+		MAX(CASE WHEN last_rn = 1 THEN close_price END),
+		MIN(min_price),
+		MAX(max_price),
+		MIN(opened_at),
+		MAX(closed_at),
+		SUM(samples)
+	FROM
+	(
+		SELECT
+			card_id,
+			old_price,
+			open_price,
+			close_price,
+			min_price,
+			max_price,
+			opened_at,
+			closed_at,
+			samples,
+			CAST(DATE_FORMAT(period_start, '%Y-%m-01') AS DATE) AS month_start,
+			ROW_NUMBER() OVER(PARTITION BY card_id, DATE_FORMAT(period_start, '%Y-%m-01') ORDER BY opened_at, id) AS first_rn,
+			ROW_NUMBER() OVER(PARTITION BY card_id, DATE_FORMAT(period_start, '%Y-%m-01') ORDER BY closed_at DESC, id DESC) AS last_rn
+		FROM
+			cards_price_aggregates
+		WHERE
+			resolution = 'weekly' AND period_start < ?
+	) weeks
+	GROUP BY
+		card_id, month_start` + mergeAggregate + `;`
+
+	_, err = tx.ExecContext(ctx, rollUpQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec insert query in roll up weekly prices: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM cards_price_aggregates WHERE resolution = 'weekly' AND period_start < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to exec delete query in roll up weekly prices: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to get rows affected in roll up weekly prices: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to commit transaction in roll up weekly prices: %w", err)
+	}
+
+	return rows, nil
+}
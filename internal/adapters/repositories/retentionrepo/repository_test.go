@@ -0,0 +1,134 @@
+package retentionrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+
+	repo := New(mockDB)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, mockDB, repo.db)
+}
+
+func TestRollUpDailyPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	before := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	mockResult.On("RowsAffected").Return(int64(30), nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_price_aggregates") && strings.Contains(query, "'weekly'") &&
+			strings.Contains(query, "FROM\n\t\t\tcards_details") && strings.Contains(query, "ON DUPLICATE KEY UPDATE")
+	}), []interface{}{before}).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, "DELETE FROM cards_details WHERE last_update < ?", []interface{}{before}).Return(mockResult, nil).Once()
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	rows, err := repo.RollUpDailyPrices(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), rows)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "ExecContext", mock.Anything, "DELETE FROM cards_raw_prices WHERE quoted_at < ?", mock.Anything)
+}
+
+func TestRollUpDailyPrices_InsertError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error")).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	rows, err := repo.RollUpDailyPrices(context.Background(), time.Now())
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in roll up daily prices")
+	assert.Equal(t, int64(0), rows)
+	mockTx.AssertNotCalled(t, "Commit")
+	mockTx.AssertExpectations(t)
+}
+
+func TestRollUpDailyPrices_BeginTxError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, fmt.Errorf("database error"))
+
+	_, err := repo.RollUpDailyPrices(context.Background(), time.Now())
+
+	assert.ErrorContains(t, err, "repository failed to begin transaction in roll up daily prices")
+	mockDB.AssertExpectations(t)
+}
+
+func TestRollUpWeeklyPrices_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	before := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	mockResult.On("RowsAffected").Return(int64(4), nil)
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_price_aggregates") && strings.Contains(query, "'monthly'") &&
+			strings.Contains(query, "resolution = 'weekly' AND period_start < ?") && strings.Contains(query, "ON DUPLICATE KEY UPDATE")
+	}), []interface{}{before}).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, "DELETE FROM cards_price_aggregates WHERE resolution = 'weekly' AND period_start < ?", []interface{}{before}).Return(mockResult, nil).Once()
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	rows, err := repo.RollUpWeeklyPrices(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), rows)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestRollUpWeeklyPrices_DeleteError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "INSERT INTO cards_price_aggregates")
+	}), mock.Anything).Return(mockResult, nil).Once()
+	mockTx.On("ExecContext", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.HasPrefix(query, "DELETE FROM cards_price_aggregates")
+	}), mock.Anything).Return(mockResult, fmt.Errorf("database error")).Once()
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.RollUpWeeklyPrices(context.Background(), time.Now())
+
+	assert.ErrorContains(t, err, "repository failed to exec delete query in roll up weekly prices")
+	mockTx.AssertNotCalled(t, "Commit")
+	mockTx.AssertExpectations(t)
+}
@@ -0,0 +1,132 @@
+package sharerepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) InsertShare(ctx context.Context, share domain.Share, tokenHash string) (domain.Share, error) {
+	insertShareQuery := `
+	INSERT INTO collection_shares 
+		(collection_id, token_hash, hide_cost_basis, created_at) 
+	VALUES 
+		(?, ?, ?, ?);`
+
+	res, err := r.db.ExecContext(ctx, insertShareQuery, share.CollectionID, tokenHash, share.HideCostBasis, share.CreatedAt)
+	if err != nil {
+		return domain.Share{}, fmt.Errorf("repository failed to exec insert query in insert share: %w", err)
+	}
+
+	share.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.Share{}, fmt.Errorf("repository failed to get last inserted id in insert share: %w", err)
+	}
+
+	return share, nil
+}
+
+func (r *repository) GetShares(ctx context.Context, collectionID int64) ([]domain.Share, error) {
+	getSharesQuery := `
+	SELECT 
+		id,
+		collection_id,
+		hide_cost_basis,
+		created_at
+	FROM 
+		collection_shares 
+	WHERE 
+		collection_id = ?
+	ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, getSharesQuery, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get shares: %w", err)
+	}
+	defer rows.Close()
+
+	var shares []domain.Share
+
+	for rows.Next() {
+		var share domain.Share
+		err := rows.Scan(&share.ID, &share.CollectionID, &share.HideCostBasis, &share.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get shares: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get shares: %w", err)
+	}
+
+	return shares, nil
+}
+
+func (r *repository) DeleteShare(ctx context.Context, collectionID, shareID int64) error {
+	deleteShareQuery := `
+	DELETE FROM collection_shares 
+	WHERE 
+		id = ? AND collection_id = ?;`
+
+	res, err := r.db.ExecContext(ctx, deleteShareQuery, shareID, collectionID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec delete query in delete share: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected in delete share: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrShareNotFound{}
+	}
+
+	return nil
+}
+
+// GetShareByToken returns the share with the collection's name and owner, the
+// user whose cards the share shows.
+func (r *repository) GetShareByToken(ctx context.Context, tokenHash string) (domain.Share, error) {
+	getShareQuery := `
+	SELECT 
+		cs.id,
+		cs.collection_id,
+		co.name,
+		co.user_id,
+		cs.hide_cost_basis,
+		cs.created_at
+	FROM 
+		collection_shares cs
+	JOIN 
+		collections co
+	ON 
+		co.id = cs.collection_id
+	WHERE 
+		cs.token_hash = ?;`
+
+	var share domain.Share
+	err := r.db.QueryRowContext(ctx, getShareQuery, tokenHash).Scan(&share.ID, &share.CollectionID, &share.CollectionName,
+		&share.OwnerID, &share.HideCostBasis, &share.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Share{}, domain.ErrShareNotFound{}
+		}
+		return domain.Share{}, fmt.Errorf("repository failed to scan row in get share by token: %w", err)
+	}
+
+	return share, nil
+}
@@ -0,0 +1,131 @@
+package sharerepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInsertShare_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	createdAt := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), "hash", true, createdAt}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(3), nil)
+
+	share, err := repo.InsertShare(context.Background(), domain.Share{CollectionID: 2, HideCostBasis: true, CreatedAt: createdAt}, "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Share{ID: 3, CollectionID: 2, HideCostBasis: true, CreatedAt: createdAt}, share)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertShare_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	_, err := repo.InsertShare(context.Background(), domain.Share{CollectionID: 2}, "hash")
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert share")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetShares_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2)}).Return(mockRowsScanner, nil)
+
+	shares, err := repo.GetShares(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, shares, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestDeleteShare_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(3), int64(2)}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+
+	err := repo.DeleteShare(context.Background(), 2, 3)
+
+	assert.IsType(t, domain.ErrShareNotFound{}, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestDeleteShare_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(3), int64(2)}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+
+	err := repo.DeleteShare(context.Background(), 2, 3)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetShareByToken_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"hash"}).Return(mockRowScanner)
+
+	_, err := repo.GetShareByToken(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetShareByToken_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"hash"}).Return(mockRowScanner)
+
+	_, err := repo.GetShareByToken(context.Background(), "hash")
+
+	assert.IsType(t, domain.ErrShareNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,120 @@
+package userrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// InsertUser creates the user together with its default collection.
+func (r *repository) InsertUser(ctx context.Context, user domain.User, apiKeyHash string) (domain.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to begin transaction in insert user: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertUserQuery := `
+	INSERT INTO users 
+		(name, email, api_key_hash) 
+	VALUES 
+		(?, ?, ?);`
+
+	res, err := tx.ExecContext(ctx, insertUserQuery, user.Name, user.Email, apiKeyHash)
+	if err != nil {
+		if database.IsError(err, database.ErrDuplicateEntry) {
+			return domain.User{}, domain.ErrUserAlreadyExists{}
+		}
+		return domain.User{}, fmt.Errorf("repository failed to exec insert query in insert user: %w", err)
+	}
+
+	user.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to get last inserted id in insert user: %w", err)
+	}
+
+	insertCollectionQuery := `
+	INSERT INTO collections 
+		(user_id, name, is_default) 
+	VALUES 
+		(?, ?, 1);`
+
+	res, err = tx.ExecContext(ctx, insertCollectionQuery, user.ID, domain.DefaultCollectionName)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to exec insert collection query in insert user: %w", err)
+	}
+
+	collectionID, err := res.LastInsertId()
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to get last inserted collection id in insert user: %w", err)
+	}
+
+	insertOwnerQuery := `
+	INSERT INTO collection_members 
+		(collection_id, user_id, role) 
+	VALUES 
+		(?, ?, ?);`
+
+	_, err = tx.ExecContext(ctx, insertOwnerQuery, collectionID, user.ID, domain.RoleOwner)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to exec insert owner query in insert user: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.User{}, fmt.Errorf("repository failed to commit transaction in insert user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *repository) GetUserByAPIKey(ctx context.Context, apiKeyHash string) (domain.User, error) {
+	getUserQuery := `
+	SELECT 
+		id,
+		name,
+		email
+	FROM 
+		users 
+	WHERE 
+		api_key_hash = ?;`
+
+	var user domain.User
+	err := r.db.QueryRowContext(ctx, getUserQuery, apiKeyHash).Scan(&user.ID, &user.Name, &user.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.User{}, domain.ErrUnauthorized{}
+		}
+		return domain.User{}, fmt.Errorf("repository failed to scan row in get user by api key: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *repository) UpdateAPIKey(ctx context.Context, userID int64, apiKeyHash string) error {
+	updateAPIKeyQuery := `
+	UPDATE users 
+	SET 
+		api_key_hash = ? 
+	WHERE 
+		id = ?;`
+
+	_, err := r.db.ExecContext(ctx, updateAPIKeyQuery, apiKeyHash, userID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec update query in update api key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package userrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInsertUser_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+	collectionResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"Jace", "jace@example.com", "hash"}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(2), nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(2), domain.DefaultCollectionName}).Return(collectionResult, nil)
+	collectionResult.On("LastInsertId").Return(int64(5), nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(5), int64(2), domain.RoleOwner}).Return(collectionResult, nil)
+	mockTx.On("Commit").Return(nil)
+	mockTx.On("Rollback").Return(nil)
+
+	user, err := repo.InsertUser(context.Background(), domain.User{Name: "Jace", Email: "jace@example.com"}, "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.User{ID: 2, Name: "Jace", Email: "jace@example.com"}, user)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+	collectionResult.AssertExpectations(t)
+}
+
+func TestInsertUser_AlreadyExists(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, nil)
+	mockTx.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, &driver.MySQLError{Number: 1062})
+	mockTx.On("Rollback").Return(nil)
+
+	_, err := repo.InsertUser(context.Background(), domain.User{Name: "Jace", Email: "jace@example.com"}, "hash")
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrUserAlreadyExists{}, err)
+	mockDB.AssertExpectations(t)
+	mockTx.AssertExpectations(t)
+}
+
+func TestInsertUser_BeginTxError(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockTx := mocks.NewTransactionMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("BeginTx", mock.Anything, mock.Anything).Return(mockTx, fmt.Errorf("database error"))
+
+	_, err := repo.InsertUser(context.Background(), domain.User{Name: "Jace"}, "hash")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository failed to begin transaction in insert user")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetUserByAPIKey_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"hash"}).Return(mockRowScanner)
+
+	_, err := repo.GetUserByAPIKey(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestGetUserByAPIKey_Unauthorized(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"hash"}).Return(mockRowScanner)
+
+	_, err := repo.GetUserByAPIKey(context.Background(), "hash")
+
+	assert.Error(t, err)
+	assert.IsType(t, domain.ErrUnauthorized{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestUpdateAPIKey_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"hash", int64(2)}).Return(mockResult, nil)
+
+	err := repo.UpdateAPIKey(context.Background(), 2, "hash")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,279 @@
+package webhookrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+	"strings"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) InsertWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	insertWebhookQuery := `
+	INSERT INTO webhooks 
+		(user_id, url, secret, events, created_at) 
+	VALUES 
+		(?, ?, ?, ?, ?);`
+
+	res, err := r.db.ExecContext(ctx, insertWebhookQuery, webhook.UserID, webhook.URL, webhook.Secret,
+		joinEvents(webhook.Events), webhook.CreatedAt)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("repository failed to exec insert query in insert webhook: %w", err)
+	}
+
+	webhook.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("repository failed to get last inserted id in insert webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *repository) GetWebhooks(ctx context.Context, userID int64) ([]domain.Webhook, error) {
+	getWebhooksQuery := `
+	SELECT 
+		id,
+		user_id,
+		url,
+		events,
+		created_at
+	FROM 
+		webhooks 
+	WHERE 
+		user_id = ?
+	ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, getWebhooksQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+
+	for rows.Next() {
+		var webhook domain.Webhook
+		var events string
+		err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &events, &webhook.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get webhooks: %w", err)
+		}
+		webhook.Events = splitEvents(events)
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *repository) GetWebhookByID(ctx context.Context, userID int64, id string) (domain.Webhook, error) {
+	getWebhookQuery := `
+	SELECT 
+		id,
+		user_id,
+		url,
+		events,
+		created_at
+	FROM 
+		webhooks 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	var webhook domain.Webhook
+	var events string
+	err := r.db.QueryRowContext(ctx, getWebhookQuery, id, userID).
+		Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &events, &webhook.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound{}
+	}
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("repository failed to scan row in get webhook by id: %w", err)
+	}
+
+	webhook.Events = splitEvents(events)
+
+	return webhook, nil
+}
+
+func (r *repository) DeleteWebhook(ctx context.Context, userID int64, id string) error {
+	deleteWebhookQuery := `
+	DELETE FROM webhooks 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	res, err := r.db.ExecContext(ctx, deleteWebhookQuery, id, userID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec delete query in delete webhook: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected in delete webhook: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrWebhookNotFound{}
+	}
+
+	return nil
+}
+
+// GetSubscribedWebhooks returns the webhooks subscribed to event, of every
+// user when userID is 0.
+func (r *repository) GetSubscribedWebhooks(ctx context.Context, event domain.WebhookEvent, userID int64) ([]domain.Webhook, error) {
+	getSubscribedQuery := `
+	SELECT 
+		id,
+		user_id,
+		url,
+		secret,
+		events,
+		created_at
+	FROM 
+		webhooks 
+	WHERE 
+		FIND_IN_SET(?, events) > 0 AND (? = 0 OR user_id = ?)
+	ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, getSubscribedQuery, event, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get subscribed webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+
+	for rows.Next() {
+		var webhook domain.Webhook
+		var events string
+		err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Secret, &events, &webhook.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get subscribed webhooks: %w", err)
+		}
+		webhook.Events = splitEvents(events)
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get subscribed webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *repository) InsertWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	insertDeliveryQuery := `
+	INSERT INTO webhook_deliveries 
+		(webhook_id, delivery_id, event, attempt, status_code, error, success, created_at) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?, ?, ?);`
+
+	_, err := r.db.ExecContext(ctx, insertDeliveryQuery, delivery.WebhookID, delivery.DeliveryID, delivery.Event,
+		delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.Success, delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec insert query in insert webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetWebhookDeliveries(ctx context.Context, webhookID int64, offset, limit int) ([]domain.WebhookDelivery, error) {
+	getDeliveriesQuery := `
+	SELECT 
+		id,
+		webhook_id,
+		delivery_id,
+		event,
+		attempt,
+		status_code,
+		error,
+		success,
+		created_at
+	FROM 
+		webhook_deliveries 
+	WHERE 
+		webhook_id = ?
+	ORDER BY created_at DESC, id DESC
+	LIMIT ?, ?;`
+
+	rows, err := r.db.QueryContext(ctx, getDeliveriesQuery, webhookID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.DeliveryID, &delivery.Event, &delivery.Attempt,
+			&delivery.StatusCode, &delivery.Error, &delivery.Success, &delivery.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get webhook deliveries: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *repository) GetWebhookDeliveriesCount(ctx context.Context, webhookID int64) (int64, error) {
+	countQuery := `
+	SELECT COUNT(*)
+	FROM 
+		webhook_deliveries 
+	WHERE 
+		webhook_id = ?;`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, countQuery, webhookID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository failed to scan count in get webhook deliveries count: %w", err)
+	}
+
+	return count, nil
+}
+
+// events are stored as a comma separated list so they can be matched with
+// FIND_IN_SET.
+func joinEvents(events []domain.WebhookEvent) string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+
+	return strings.Join(names, ",")
+}
+
+func splitEvents(events string) []domain.WebhookEvent {
+	if events == "" {
+		return nil
+	}
+
+	names := strings.Split(events, ",")
+	webhookEvents := make([]domain.WebhookEvent, 0, len(names))
+	for _, name := range names {
+		webhookEvents = append(webhookEvents, domain.WebhookEvent(name))
+	}
+
+	return webhookEvents
+}
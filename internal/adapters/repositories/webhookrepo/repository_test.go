@@ -0,0 +1,207 @@
+package webhookrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestInsertWebhook_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	createdAt := time.Now()
+	webhook := domain.Webhook{
+		UserID:    testUserID,
+		URL:       "https://hooks.test.com",
+		Secret:    "secret",
+		Events:    []domain.WebhookEvent{domain.EventConciliationFinished, domain.EventCardPriceChanged},
+		CreatedAt: createdAt,
+	}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "https://hooks.test.com", "secret",
+		"conciliation.finished,card.price_changed", createdAt}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(4), nil)
+
+	got, err := repo.InsertWebhook(context.Background(), webhook)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got.ID)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertWebhook_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	_, err := repo.InsertWebhook(context.Background(), domain.Webhook{UserID: testUserID})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert webhook")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetWebhooks_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowsScanner, nil)
+
+	webhooks, err := repo.GetWebhooks(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetWebhookByID_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"4", testUserID}).Return(mockRowScanner)
+
+	_, err := repo.GetWebhookByID(context.Background(), testUserID, "4")
+
+	assert.IsType(t, domain.ErrWebhookNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestDeleteWebhook_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"4", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+
+	err := repo.DeleteWebhook(context.Background(), testUserID, "4")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"4", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+
+	err := repo.DeleteWebhook(context.Background(), testUserID, "4")
+
+	assert.IsType(t, domain.ErrWebhookNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetSubscribedWebhooks_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"),
+		[]interface{}{domain.EventReportGenerated, testUserID, testUserID}).Return(mockRowsScanner, nil)
+
+	webhooks, err := repo.GetSubscribedWebhooks(context.Background(), domain.EventReportGenerated, testUserID)
+
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestInsertWebhookDelivery_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	createdAt := time.Now()
+	delivery := domain.WebhookDelivery{WebhookID: 4, DeliveryID: "abc", Event: domain.EventReportGenerated, Attempt: 2,
+		StatusCode: 500, Error: "unexpected status 500", CreatedAt: createdAt}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(4), "abc", domain.EventReportGenerated,
+		2, 500, "unexpected status 500", false, createdAt}).Return(mockResult, nil)
+
+	err := repo.InsertWebhookDelivery(context.Background(), delivery)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetWebhookDeliveries_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(4), 0, 10}).Return(mockRowsScanner, nil)
+
+	deliveries, err := repo.GetWebhookDeliveries(context.Background(), 4, 0, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestGetWebhookDeliveriesCount_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowScanner.On("Scan").Return(nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(4)}).Return(mockRowScanner)
+
+	_, err := repo.GetWebhookDeliveriesCount(context.Background(), 4)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestSplitEvents(t *testing.T) {
+	assert.Nil(t, splitEvents(""))
+	assert.Equal(t, []domain.WebhookEvent{domain.EventReportGenerated, domain.EventCardPriceChanged},
+		splitEvents("report.generated,card.price_changed"))
+}
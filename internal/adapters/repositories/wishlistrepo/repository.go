@@ -0,0 +1,154 @@
+package wishlistrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	database "mtg-report/internal/sources/databases/mysql"
+)
+
+type repository struct {
+	db database.Client
+}
+
+func New(db database.Client) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) InsertWishlistItem(ctx context.Context, userID int64, item domain.WishlistItem) (domain.WishlistItem, error) {
+	insertItemQuery := `
+	INSERT INTO wishlist 
+		(user_id, name, set_name, collector_number, finish, max_price) 
+	VALUES 
+		(?, ?, ?, ?, ?, ?);`
+
+	res, err := r.db.ExecContext(ctx, insertItemQuery, userID, item.Name, item.SetName, item.CollectorNumber, item.Finish, item.MaxPrice)
+	if err != nil {
+		if database.IsError(err, database.ErrDuplicateEntry) {
+			return domain.WishlistItem{}, domain.ErrWishlistItemAlreadyExists{}
+		}
+		return domain.WishlistItem{}, fmt.Errorf("repository failed to exec insert query in insert wishlist item: %w", err)
+	}
+
+	item.ID, err = res.LastInsertId()
+	if err != nil {
+		return domain.WishlistItem{}, fmt.Errorf("repository failed to get last inserted id in insert wishlist item: %w", err)
+	}
+
+	item.UserID = userID
+
+	return item, nil
+}
+
+func (r *repository) GetWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error) {
+	getWishlistQuery := `
+	SELECT 
+		id,
+		user_id,
+		name,
+		set_name,
+		collector_number,
+		finish,
+		max_price,
+		last_price,
+		last_update
+	FROM 
+		wishlist 
+	WHERE 
+		user_id = ?
+	ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, getWishlistQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository failed to exec query in get wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.WishlistItem
+
+	for rows.Next() {
+		var item domain.WishlistItem
+		err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.SetName, &item.CollectorNumber, &item.Finish,
+			&item.MaxPrice, &item.LastPrice, &item.LastUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("repository failed to scan row in get wishlist: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository failed after iterating rows in get wishlist: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *repository) UpdateWishlistItem(ctx context.Context, userID, id int64, maxPrice float64) (domain.WishlistItem, error) {
+	updateItemQuery := `
+	UPDATE wishlist 
+	SET 
+		max_price = ? 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	_, err := r.db.ExecContext(ctx, updateItemQuery, maxPrice, id, userID)
+	if err != nil {
+		return domain.WishlistItem{}, fmt.Errorf("repository failed to exec update query in update wishlist item: %w", err)
+	}
+
+	// rows affected is zero when the price does not change, so the item is
+	// read back to tell a missing item apart.
+	getItemQuery := `
+	SELECT 
+		id,
+		user_id,
+		name,
+		set_name,
+		collector_number,
+		finish,
+		max_price,
+		last_price,
+		last_update
+	FROM 
+		wishlist 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	var item domain.WishlistItem
+	err = r.db.QueryRowContext(ctx, getItemQuery, id, userID).Scan(&item.ID, &item.UserID, &item.Name, &item.SetName,
+		&item.CollectorNumber, &item.Finish, &item.MaxPrice, &item.LastPrice, &item.LastUpdate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.WishlistItem{}, domain.ErrWishlistItemNotFound{}
+		}
+		return domain.WishlistItem{}, fmt.Errorf("repository failed to scan row in update wishlist item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *repository) DeleteWishlistItem(ctx context.Context, userID int64, id string) error {
+	deleteItemQuery := `
+	DELETE FROM wishlist 
+	WHERE 
+		id = ? AND user_id = ?;`
+
+	res, err := r.db.ExecContext(ctx, deleteItemQuery, id, userID)
+	if err != nil {
+		return fmt.Errorf("repository failed to exec delete query in delete wishlist item: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository failed to get rows affected in delete wishlist item: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrWishlistItemNotFound{}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package wishlistrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+func TestInsertWishlistItem_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	item := domain.WishlistItem{Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", Finish: domain.FinishNonfoil, MaxPrice: 50}
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID, "Lightning Bolt", "Alpha", "161", domain.FinishNonfoil, 50.0}).Return(mockResult, nil)
+	mockResult.On("LastInsertId").Return(int64(3), nil)
+
+	got, err := repo.InsertWishlistItem(context.Background(), testUserID, item)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got.ID)
+	assert.Equal(t, testUserID, got.UserID)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestInsertWishlistItem_AlreadyExists(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, &driver.MySQLError{Number: 1062})
+
+	_, err := repo.InsertWishlistItem(context.Background(), testUserID, domain.WishlistItem{Name: "Lightning Bolt"})
+
+	assert.IsType(t, domain.ErrWishlistItemAlreadyExists{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInsertWishlistItem_Error(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, fmt.Errorf("database error"))
+
+	_, err := repo.InsertWishlistItem(context.Background(), testUserID, domain.WishlistItem{Name: "Lightning Bolt"})
+
+	assert.ErrorContains(t, err, "repository failed to exec insert query in insert wishlist item")
+	mockDB.AssertExpectations(t)
+}
+
+func TestGetWishlist_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockRowsScanner := mocks.NewRowsScannerMock()
+
+	repo := New(mockDB)
+
+	mockRowsScanner.On("Next").Return(true).Once()
+	mockRowsScanner.On("Scan", mock.Anything).Return(nil).Once()
+	mockRowsScanner.On("Next").Return(false).Once()
+	mockRowsScanner.On("Err").Return(nil)
+	mockRowsScanner.On("Close").Return(nil)
+	mockDB.On("QueryContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{testUserID}).Return(mockRowsScanner, nil)
+
+	items, err := repo.GetWishlist(context.Background(), testUserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	mockDB.AssertExpectations(t)
+	mockRowsScanner.AssertExpectations(t)
+}
+
+func TestUpdateWishlistItem_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{40.0, int64(3), testUserID}).Return(mockResult, nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{int64(3), testUserID}).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(nil)
+
+	_, err := repo.UpdateWishlistItem(context.Background(), testUserID, 3, 40)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockRowScanner.AssertExpectations(t)
+}
+
+func TestUpdateWishlistItem_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+	mockRowScanner := mocks.NewRowScannerMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockResult, nil)
+	mockDB.On("QueryRowContext", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRowScanner)
+	mockRowScanner.On("Scan").Return(sql.ErrNoRows)
+
+	_, err := repo.UpdateWishlistItem(context.Background(), testUserID, 3, 40)
+
+	assert.IsType(t, domain.ErrWishlistItemNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestDeleteWishlistItem_Success(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"3", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(1), nil)
+
+	err := repo.DeleteWishlistItem(context.Background(), testUserID, "3")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+	mockResult.AssertExpectations(t)
+}
+
+func TestDeleteWishlistItem_NotFound(t *testing.T) {
+	mockDB := mocks.NewClientMock()
+	mockResult := mocks.NewResultMock()
+
+	repo := New(mockDB)
+
+	mockDB.On("ExecContext", mock.Anything, mock.AnythingOfType("string"), []interface{}{"3", testUserID}).Return(mockResult, nil)
+	mockResult.On("RowsAffected").Return(int64(0), nil)
+
+	err := repo.DeleteWishlistItem(context.Background(), testUserID, "3")
+
+	assert.IsType(t, domain.ErrWishlistItemNotFound{}, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+type AlertKind string
+
+const (
+	// AlertPercentage triggers when the price moves by at least Threshold
+	// percent in a single conciliation.
+	AlertPercentage AlertKind = "percentage"
+	// AlertAbsolute triggers when the price crosses Threshold, in BRL.
+	AlertAbsolute AlertKind = "absolute"
+)
+
+type AlertDirection string
+
+const (
+	AlertUp   AlertDirection = "up"
+	AlertDown AlertDirection = "down"
+)
+
+func ValidAlertKind(kind AlertKind) bool {
+	return kind == AlertPercentage || kind == AlertAbsolute
+}
+
+func ValidAlertDirection(direction AlertDirection) bool {
+	return direction == AlertUp || direction == AlertDown
+}
+
+// Alert watches a single card, when CardID is set, or every card of the user
+// in SetName.
+type Alert struct {
+	ID        int64
+	UserID    int64
+	CardID    *int64
+	SetName   string
+	Kind      AlertKind
+	Direction AlertDirection
+	Threshold float64
+	CreatedAt time.Time
+}
+
+// Triggered reports whether a price change from oldPrice to newPrice fires the
+// alert. Cards priced for the first time have no change to compare.
+func (a Alert) Triggered(oldPrice, newPrice float64) bool {
+	if oldPrice <= 0 {
+		return false
+	}
+
+	switch a.Kind {
+	case AlertPercentage:
+		change := (newPrice - oldPrice) / oldPrice * 100
+		if a.Direction == AlertDown {
+			change = -change
+		}
+		return change >= a.Threshold
+	case AlertAbsolute:
+		if a.Direction == AlertDown {
+			return oldPrice > a.Threshold && newPrice <= a.Threshold
+		}
+		return oldPrice < a.Threshold && newPrice >= a.Threshold
+	}
+
+	return false
+}
+
+// AlertEvent is a price change of a card that fired an alert. It keeps a copy
+// of the alert so the history outlives it.
+type AlertEvent struct {
+	ID          int64
+	Alert       Alert
+	Email       string
+	CardID      int64
+	CardName    string
+	CardSetName string
+	OldPrice    float64
+	NewPrice    float64
+	TriggeredAt time.Time
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlert_Triggered(t *testing.T) {
+	tests := []struct {
+		name     string
+		alert    Alert
+		oldPrice float64
+		newPrice float64
+		want     bool
+	}{
+		{name: "should trigger a rise above the percentage",
+			alert: Alert{Kind: AlertPercentage, Direction: AlertUp, Threshold: 10}, oldPrice: 100, newPrice: 110, want: true},
+		{name: "should not trigger a smaller rise",
+			alert: Alert{Kind: AlertPercentage, Direction: AlertUp, Threshold: 10}, oldPrice: 100, newPrice: 109, want: false},
+		{name: "should not trigger a drop on an up alert",
+			alert: Alert{Kind: AlertPercentage, Direction: AlertUp, Threshold: 10}, oldPrice: 100, newPrice: 50, want: false},
+		{name: "should trigger a drop below the percentage",
+			alert: Alert{Kind: AlertPercentage, Direction: AlertDown, Threshold: 10}, oldPrice: 100, newPrice: 85, want: true},
+		{name: "should not trigger a card priced for the first time",
+			alert: Alert{Kind: AlertPercentage, Direction: AlertUp, Threshold: 10}, oldPrice: 0, newPrice: 50, want: false},
+		{name: "should trigger when the price crosses the value going up",
+			alert: Alert{Kind: AlertAbsolute, Direction: AlertUp, Threshold: 100}, oldPrice: 95, newPrice: 100, want: true},
+		{name: "should not trigger when the price was already above the value",
+			alert: Alert{Kind: AlertAbsolute, Direction: AlertUp, Threshold: 100}, oldPrice: 101, newPrice: 120, want: false},
+		{name: "should trigger when the price crosses the value going down",
+			alert: Alert{Kind: AlertAbsolute, Direction: AlertDown, Threshold: 100}, oldPrice: 105, newPrice: 99, want: true},
+		{name: "should not trigger when the price stays above the value",
+			alert: Alert{Kind: AlertAbsolute, Direction: AlertDown, Threshold: 100}, oldPrice: 120, newPrice: 101, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.alert.Triggered(tt.oldPrice, tt.newPrice))
+		})
+	}
+}
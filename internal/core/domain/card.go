@@ -2,19 +2,168 @@ package domain
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	ConditionNearMint         = "NM"
+	ConditionLightlyPlayed    = "LP"
+	ConditionModeratelyPlayed = "MP"
+	ConditionHeavilyPlayed    = "HP"
+	ConditionDamaged          = "DMG"
+
+	DefaultCondition = ConditionNearMint
+	DefaultLanguage  = "en"
+
+	FinishNonfoil  Finish = "nonfoil"
+	FinishFoil     Finish = "foil"
+	FinishEtched   Finish = "etched"
+	FinishGilded   Finish = "gilded"
+	FinishTextured Finish = "textured"
+	FinishSurge    Finish = "surge"
+
+	DefaultFinish = FinishNonfoil
+
+	CurrencyBRL = "BRL"
+	CurrencyUSD = "USD"
+
+	DefaultCurrency = CurrencyBRL
+	DateLayout      = "2006-01-02"
+)
+
+// Finish is the printing treatment of a card. Gilded, textured and surge foils
+// are foils with their own collector numbers, so they are priced as foils.
+type Finish string
+
+var finishes = map[Finish]struct{}{
+	FinishNonfoil:  {},
+	FinishFoil:     {},
+	FinishEtched:   {},
+	FinishGilded:   {},
+	FinishTextured: {},
+	FinishSurge:    {},
+}
+
+// IsFoil reports whether the finish is any foil treatment.
+func (f Finish) IsFoil() bool {
+	return f != FinishNonfoil
+}
+
+var conditions = map[string]struct{}{
+	ConditionNearMint:         {},
+	ConditionLightlyPlayed:    {},
+	ConditionModeratelyPlayed: {},
+	ConditionHeavilyPlayed:    {},
+	ConditionDamaged:          {},
+}
+
+// languages follows the language codes used by Scryfall.
+var languages = map[string]struct{}{
+	"en": {}, "es": {}, "fr": {}, "de": {}, "it": {}, "pt": {}, "ja": {}, "ko": {},
+	"ru": {}, "zhs": {}, "zht": {}, "he": {}, "la": {}, "grc": {}, "ar": {}, "sa": {}, "ph": {},
+}
+
+func ValidCondition(condition string) bool {
+	_, ok := conditions[condition]
+	return ok
+}
+
+func ValidLanguage(language string) bool {
+	_, ok := languages[language]
+	return ok
+}
+
+func ValidFinish(finish Finish) bool {
+	_, ok := finishes[finish]
+	return ok
+}
+
+// NormalizeFinish lowercases the finish and maps the true and false of the
+// former foil flag to foil and nonfoil, so older files are still accepted.
+func NormalizeFinish(finish string) Finish {
+	finish = strings.ToLower(finish)
+	switch finish {
+	case "true":
+		return FinishFoil
+	case "false":
+		return FinishNonfoil
+	}
+	return Finish(finish)
+}
+
+func ValidCurrency(currency string) bool {
+	return currency == CurrencyBRL || currency == CurrencyUSD
+}
+
+func NormalizeCurrency(currency string) string {
+	if len(currency) == 0 {
+		return DefaultCurrency
+	}
+	return strings.ToUpper(currency)
+}
+
+func NormalizeCondition(condition string) string {
+	if len(condition) == 0 {
+		return DefaultCondition
+	}
+	return strings.ToUpper(condition)
+}
+
+func NormalizeLanguage(language string) string {
+	if len(language) == 0 {
+		return DefaultLanguage
+	}
+	return strings.ToLower(language)
+}
+
 type Cards struct {
 	ID              int64
 	Name            string
 	SetName         string
 	CollectorNumber string
-	Foil            bool
+	Finish          Finish
+	Condition       string
+	Language        string
+	Quantity        int64
+	CollectionID    int64
+	Acquisition
 	CardsDetails
 }
 
-func (c *Cards) ValidateCardFields(foil string) error {
+// Acquisition holds what was paid for each copy of a card entry.
+// AcquisitionRate is the value in BRL of one unit of AcquisitionCurrency on
+// the acquisition date, stored with the acquisition so the cost does not move
+// with later exchange rates.
+type Acquisition struct {
+	AcquisitionPrice    *float64
+	AcquisitionCurrency string
+	AcquisitionRate     *float64
+	AcquisitionDate     *time.Time
+}
+
+// CostBasis returns the amount paid for all copies in BRL, at the exchange
+// rate of the acquisition date. It is not available for USD acquisitions made
+// before any exchange rate was stored.
+func (c Cards) CostBasis() (float64, bool) {
+	if c.AcquisitionPrice == nil || c.AcquisitionRate == nil {
+		return 0, false
+	}
+
+	return *c.AcquisitionPrice * *c.AcquisitionRate * float64(c.Quantity), true
+}
+
+func (c Cards) UnrealizedGain() (float64, bool) {
+	costBasis, ok := c.CostBasis()
+	if !ok {
+		return 0, false
+	}
+
+	return c.LastPrice*float64(c.Quantity) - costBasis, true
+}
+
+func (c *Cards) ValidateCardFields(finish, condition, language, quantity string) error {
 	if len(c.Name) == 0 {
 		return errors.New("name is required")
 	}
@@ -23,28 +172,94 @@ func (c *Cards) ValidateCardFields(foil string) error {
 		return errors.New("collector number is required")
 	}
 
-	if foil == "true" {
-		c.Foil = true
-	} else if foil == "false" {
-		c.Foil = false
-	} else {
-		return errors.New("foil bool is required")
+	if len(finish) == 0 {
+		return errors.New("finish is required")
+	}
+
+	c.Finish = NormalizeFinish(finish)
+	if !ValidFinish(c.Finish) {
+		return errors.New("finish must be one of nonfoil, foil, etched, gilded, textured or surge")
+	}
+
+	c.Condition = NormalizeCondition(condition)
+	if !ValidCondition(c.Condition) {
+		return errors.New("condition must be one of NM, LP, MP, HP or DMG")
+	}
+
+	c.Language = NormalizeLanguage(language)
+	if !ValidLanguage(c.Language) {
+		return errors.New("language is not supported")
+	}
+
+	c.Quantity = 1
+	if len(quantity) != 0 {
+		q, err := strconv.ParseInt(quantity, 10, 64)
+		if err != nil || q < 1 {
+			return errors.New("quantity must be a positive number")
+		}
+		c.Quantity = q
+	}
+
+	return nil
+}
+
+func (c *Cards) ValidateAcquisitionFields(price, currency, date string) error {
+	c.AcquisitionCurrency = NormalizeCurrency(currency)
+	if !ValidCurrency(c.AcquisitionCurrency) {
+		return errors.New("acquisition currency must be BRL or USD")
+	}
+
+	if len(price) != 0 {
+		p, err := strconv.ParseFloat(price, 64)
+		if err != nil || p < 0 {
+			return errors.New("acquisition price must be a positive number")
+		}
+		c.AcquisitionPrice = &p
+	}
+
+	if len(date) != 0 {
+		d, err := time.Parse(DateLayout, date)
+		if err != nil {
+			return errors.New("acquisition date must be in YYYY-MM-DD format")
+		}
+		c.AcquisitionDate = &d
+	}
+
+	return nil
+}
+
+// ValidateCollectionField leaves CollectionID at zero when it is empty, so the
+// card goes to the default collection of the user.
+func (c *Cards) ValidateCollectionField(collectionID string) error {
+	c.CollectionID = 0
+	if len(collectionID) != 0 {
+		id, err := strconv.ParseInt(collectionID, 10, 64)
+		if err != nil || id < 1 {
+			return errors.New("collection id must be a positive number")
+		}
+		c.CollectionID = id
 	}
 
 	return nil
 }
 
 type CardsDetails struct {
-	CardID      int64
-	LastPrice   float64
-	OldPrice    float64
-	PriceChange float64
-	LastUpdate  *time.Time
+	CardID       int64
+	LastPrice    float64
+	OldPrice     float64
+	PriceChange  float64
+	ExchangeRate float64
+	PriceSource  PriceSource
+	LastUpdate   *time.Time
+	RawPrices    []RawPrice
 }
 
 type UpdateCard struct {
-	ID   int64
-	Name string
+	ID           int64
+	Name         string
+	Quantity     int64
+	CollectionID int64
+	Acquisition
 }
 
 type CardsPrice struct {
@@ -55,8 +270,16 @@ type CardsPrice struct {
 }
 
 type CollectionStats struct {
-	TotalCards int64
-	FoilCards  int64
-	UniqueSets int64
-	TotalValue float64
+	TotalCards     int64
+	FoilCards      int64
+	UniqueSets     int64
+	TotalValue     float64
+	TotalCostBasis float64
+	UnrealizedGain float64
+}
+
+type UnrealizedGain struct {
+	CostBasis      float64
+	MarketValue    float64
+	UnrealizedGain float64
 }
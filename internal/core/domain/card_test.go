@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCards_CostBasisAndUnrealizedGain(t *testing.T) {
+	price := 10.0
+	brl := 1.0
+	usd := 5.0
+
+	tests := []struct {
+		name         string
+		card         Cards
+		wantCost     float64
+		wantGain     float64
+		wantComputed bool
+	}{
+		{
+			name:         "should not compute when acquisition price is missing",
+			card:         Cards{Quantity: 2, CardsDetails: CardsDetails{LastPrice: 15}},
+			wantComputed: false,
+		},
+		{
+			name: "should compute brl acquisitions directly",
+			card: Cards{
+				Quantity:     2,
+				Acquisition:  Acquisition{AcquisitionPrice: &price, AcquisitionCurrency: CurrencyBRL, AcquisitionRate: &brl},
+				CardsDetails: CardsDetails{LastPrice: 15},
+			},
+			wantCost:     20,
+			wantGain:     10,
+			wantComputed: true,
+		},
+		{
+			name: "should convert usd acquisitions with the rate of the acquisition date",
+			card: Cards{
+				Quantity:     2,
+				Acquisition:  Acquisition{AcquisitionPrice: &price, AcquisitionCurrency: CurrencyUSD, AcquisitionRate: &usd},
+				CardsDetails: CardsDetails{LastPrice: 40, ExchangeRate: 6},
+			},
+			wantCost:     100,
+			wantGain:     -20,
+			wantComputed: true,
+		},
+		{
+			name: "should not compute usd acquisitions without exchange rate",
+			card: Cards{
+				Quantity:     2,
+				Acquisition:  Acquisition{AcquisitionPrice: &price, AcquisitionCurrency: CurrencyUSD},
+				CardsDetails: CardsDetails{LastPrice: 40, ExchangeRate: 6},
+			},
+			wantComputed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, ok := tt.card.CostBasis()
+			assert.Equal(t, tt.wantComputed, ok)
+			assert.Equal(t, tt.wantCost, cost)
+
+			gain, ok := tt.card.UnrealizedGain()
+			assert.Equal(t, tt.wantComputed, ok)
+			assert.Equal(t, tt.wantGain, gain)
+		})
+	}
+}
+
+func TestCards_ValidateAcquisitionFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    string
+		currency string
+		date     string
+		wantErr  bool
+	}{
+		{name: "should accept empty fields", wantErr: false},
+		{name: "should accept all fields", price: "12.50", currency: "usd", date: "2026-01-15", wantErr: false},
+		{name: "should reject invalid currency", price: "12.50", currency: "JPY", wantErr: true},
+		{name: "should reject invalid date", price: "12.50", date: "15/01/2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := Cards{}
+			err := card.ValidateAcquisitionFields(tt.price, tt.currency, tt.date)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCards_ValidateCollectionField(t *testing.T) {
+	card := Cards{}
+	assert.NoError(t, card.ValidateCollectionField(""))
+	assert.Equal(t, int64(0), card.CollectionID)
+
+	assert.NoError(t, card.ValidateCollectionField("3"))
+	assert.Equal(t, int64(3), card.CollectionID)
+
+	assert.Error(t, card.ValidateCollectionField("0"))
+	assert.Error(t, card.ValidateCollectionField("abc"))
+}
+
+func TestNormalizeFinish(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Finish
+		valid bool
+	}{
+		{input: "nonfoil", want: FinishNonfoil, valid: true},
+		{input: "Etched", want: FinishEtched, valid: true},
+		{input: "true", want: FinishFoil, valid: true},
+		{input: "false", want: FinishNonfoil, valid: true},
+		{input: "shiny", want: Finish("shiny"), valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := NormalizeFinish(tt.input)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.valid, ValidFinish(got))
+		})
+	}
+}
+
+func TestFinish_IsFoil(t *testing.T) {
+	assert.False(t, FinishNonfoil.IsFoil())
+	assert.True(t, FinishFoil.IsFoil())
+	assert.True(t, FinishEtched.IsFoil())
+	assert.True(t, FinishSurge.IsFoil())
+}
@@ -0,0 +1,22 @@
+package domain
+
+// Collection is owned by the user who created it and can be shared with other
+// members. Every user has one default collection, created with the user, which
+// receives the cards inserted without a collection and cannot be deleted.
+type Collection struct {
+	ID          int64
+	Name        string
+	Description string
+	IsDefault   bool
+	Role        Role
+	TotalCards  int64
+}
+
+type UpdateCollection struct {
+	ID          int64
+	Name        string
+	Description *string
+}
+
+// DefaultCollectionName names the collection created with every user.
+const DefaultCollectionName = "Default"
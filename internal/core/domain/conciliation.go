@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ConciliationStatus string
+
+const (
+	ConciliationRunning  ConciliationStatus = "running"
+	ConciliationFinished ConciliationStatus = "finished"
+	ConciliationFailed   ConciliationStatus = "failed"
+)
+
+// ConciliationRun is a run of the conciliate job. LastCardID is its
+// checkpoint: every card up to it was already handled, so a run that was
+// interrupted resumes after it.
+type ConciliationRun struct {
+	ID                 int64
+	StartedAt          time.Time
+	FinishedAt         *time.Time
+	Status             ConciliationStatus
+	ExchangeRate       *float64
+	ExchangeRateSource ExchangeRateSource
+	LastCardID         int64
+	Processed          int64
+	Failed             int64
+	Skipped            int64
+	Retries            int64
+	ErrorSummary       string
+}
+
+// Resumable tells whether an unfinished run can be resumed at now. Only runs
+// started on the same day are, so a run never mixes the prices of two days.
+func (r ConciliationRun) Resumable(now time.Time) bool {
+	if r.Status != ConciliationRunning {
+		return false
+	}
+
+	y1, m1, d1 := r.StartedAt.Date()
+	y2, m2, d2 := now.In(r.StartedAt.Location()).Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+type ConciliationFailureReason string
+
+const (
+	FailurePriceUnavailable ConciliationFailureReason = "price_unavailable"
+	FailureTimeout          ConciliationFailureReason = "timeout"
+	FailureNoExchangeRate   ConciliationFailureReason = "no_exchange_rate"
+	FailureInsert           ConciliationFailureReason = "insert_failed"
+	FailureQuarantined      ConciliationFailureReason = "quarantined"
+)
+
+// ConciliationFailure is a card a run could not price, with the reason and the
+// error behind it.
+type ConciliationFailure struct {
+	ID              int64
+	RunID           int64
+	CardID          int64
+	Name            string
+	SetName         string
+	CollectorNumber string
+	Reason          ConciliationFailureReason
+	Error           string
+	FailedAt        time.Time
+}
+
+// SummarizeFailures describes how many cards failed for each reason, such as
+// "price_unavailable: 3, timeout: 1", with the most frequent reasons first.
+func SummarizeFailures(counts map[ConciliationFailureReason]int64) string {
+	reasons := make([]ConciliationFailureReason, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%s: %d", reason, counts[reason]))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConciliationRun_Resumable(t *testing.T) {
+	startedAt := time.Date(2024, 5, 10, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		run  ConciliationRun
+		now  time.Time
+		want bool
+	}{
+		{"running on the same day", ConciliationRun{Status: ConciliationRunning, StartedAt: startedAt}, startedAt.Add(5 * time.Hour), true},
+		{"running on the next day", ConciliationRun{Status: ConciliationRunning, StartedAt: startedAt}, startedAt.Add(22 * time.Hour), false},
+		{"finished", ConciliationRun{Status: ConciliationFinished, StartedAt: startedAt}, startedAt.Add(time.Hour), false},
+		{"failed", ConciliationRun{Status: ConciliationFailed, StartedAt: startedAt}, startedAt.Add(time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.run.Resumable(tt.now))
+		})
+	}
+}
+
+func TestSummarizeFailures(t *testing.T) {
+	summary := SummarizeFailures(map[ConciliationFailureReason]int64{
+		FailureTimeout:          1,
+		FailurePriceUnavailable: 3,
+		FailureNoExchangeRate:   1,
+	})
+
+	assert.Equal(t, "price_unavailable: 3, no_exchange_rate: 1, timeout: 1", summary)
+	assert.Equal(t, "", SummarizeFailures(nil))
+}
@@ -23,3 +23,135 @@ type ErrInvalidSetName struct{}
 func (e ErrInvalidSetName) Error() string {
 	return "invalid set name"
 }
+
+type ErrNotEnoughCopies struct{}
+
+func (e ErrNotEnoughCopies) Error() string {
+	return "not enough copies to sell"
+}
+
+type ErrCollectionNotFound struct{}
+
+func (e ErrCollectionNotFound) Error() string {
+	return "collection not found"
+}
+
+type ErrCollectionAlreadyExists struct{}
+
+func (e ErrCollectionAlreadyExists) Error() string {
+	return "collection already exists"
+}
+
+type ErrCollectionNotEmpty struct{}
+
+func (e ErrCollectionNotEmpty) Error() string {
+	return "collection still has cards"
+}
+
+type ErrDefaultCollection struct{}
+
+func (e ErrDefaultCollection) Error() string {
+	return "default collection cannot be deleted"
+}
+
+type ErrUserAlreadyExists struct{}
+
+func (e ErrUserAlreadyExists) Error() string {
+	return "user already exists"
+}
+
+type ErrUnauthorized struct{}
+
+func (e ErrUnauthorized) Error() string {
+	return "invalid or missing api key"
+}
+
+type ErrForbidden struct{}
+
+func (e ErrForbidden) Error() string {
+	return "not enough permissions on the collection"
+}
+
+type ErrUserNotFound struct{}
+
+func (e ErrUserNotFound) Error() string {
+	return "user not found"
+}
+
+type ErrMemberNotFound struct{}
+
+func (e ErrMemberNotFound) Error() string {
+	return "member not found"
+}
+
+type ErrCollectionOwner struct{}
+
+func (e ErrCollectionOwner) Error() string {
+	return "collection owner cannot be changed or removed"
+}
+
+type ErrShareNotFound struct{}
+
+func (e ErrShareNotFound) Error() string {
+	return "share link not found"
+}
+
+type ErrWishlistItemNotFound struct{}
+
+func (e ErrWishlistItemNotFound) Error() string {
+	return "wishlist item not found"
+}
+
+type ErrWishlistItemAlreadyExists struct{}
+
+func (e ErrWishlistItemAlreadyExists) Error() string {
+	return "wishlist item already exists"
+}
+
+type ErrAlertNotFound struct{}
+
+func (e ErrAlertNotFound) Error() string {
+	return "alert not found"
+}
+
+type ErrWebhookNotFound struct{}
+
+func (e ErrWebhookNotFound) Error() string {
+	return "webhook not found"
+}
+
+type ErrWebhookURLNotAllowed struct{}
+
+func (e ErrWebhookURLNotAllowed) Error() string {
+	return "webhook url must resolve to public addresses"
+}
+
+type ErrExchangeRateNotFound struct{}
+
+func (e ErrExchangeRateNotFound) Error() string {
+	return "no exchange rate stored for the currency"
+}
+
+type ErrExchangeRateUnavailable struct{}
+
+func (e ErrExchangeRateUnavailable) Error() string {
+	return "no exchange rate available from the providers or the stored rates"
+}
+
+type ErrConciliationRunNotFound struct{}
+
+func (e ErrConciliationRunNotFound) Error() string {
+	return "conciliation run not found"
+}
+
+type ErrQuarantinedPriceNotFound struct{}
+
+func (e ErrQuarantinedPriceNotFound) Error() string {
+	return "quarantined price not found"
+}
+
+type ErrQuarantinedPriceReviewed struct{}
+
+func (e ErrQuarantinedPriceReviewed) Error() string {
+	return "quarantined price already reviewed"
+}
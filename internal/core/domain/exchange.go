@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ExchangeRates are the conversion rates published for a day, as the value of
+// one unit of Base in every other currency.
+type ExchangeRates struct {
+	Date  time.Time
+	Base  string
+	Rates map[string]float64
+}
+
+// BRL returns the value in BRL of one unit of currency, derived from the rates
+// of both against the base currency.
+func (e ExchangeRates) BRL(currency string) (float64, bool) {
+	brl, ok := e.Rates[CurrencyBRL]
+	if !ok || brl == 0 {
+		return 0, false
+	}
+
+	rate, ok := e.Rates[currency]
+	if !ok || rate == 0 {
+		return 0, false
+	}
+
+	return brl / rate, true
+}
+
+// Currencies returns the currencies of the rates in alphabetical order.
+func (e ExchangeRates) Currencies() []string {
+	currencies := make([]string, 0, len(e.Rates))
+	for currency := range e.Rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
+
+// ExchangeRate is the value in BRL of one unit of a currency on Date.
+type ExchangeRate struct {
+	Date time.Time
+	Rate float64
+}
+
+// ExchangeRateHistory is the daily history of a currency, oldest first.
+type ExchangeRateHistory []ExchangeRate
+
+// At returns the rate in effect at t: the last one published on or before
+// its day, or the oldest one when t is older than the history. A zero t gets
+// the latest rate.
+func (h ExchangeRateHistory) At(t time.Time) (float64, bool) {
+	if len(h) == 0 {
+		return 0, false
+	}
+
+	if t.IsZero() {
+		return h[len(h)-1].Rate, true
+	}
+
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	i := sort.Search(len(h), func(i int) bool {
+		return h[i].Date.After(day)
+	})
+	if i == 0 {
+		return h[0].Rate, true
+	}
+
+	return h[i-1].Rate, true
+}
+
+// ConvertBRL re-expresses a value in BRL in the currency of rate, rounded to
+// cents.
+func ConvertBRL(value, rate float64) float64 {
+	return math.Round(value/rate*100) / 100
+}
+
+// ValidValuationCurrency reports whether collection values can be shown in
+// currency.
+func ValidValuationCurrency(currency string) bool {
+	return currency == CurrencyBRL || currency == CurrencyUSD || currency == CurrencyEUR
+}
+
+// ExchangeRateSource tells where the rates used by a conciliation came from.
+type ExchangeRateSource string
+
+const (
+	ExchangeRateSourcePrimary   ExchangeRateSource = "primary"
+	ExchangeRateSourceStored    ExchangeRateSource = "stored"
+	ExchangeRateSourceSecondary ExchangeRateSource = "secondary"
+)
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRates_BRL(t *testing.T) {
+	rates := ExchangeRates{Base: CurrencyUSD, Rates: map[string]float64{"USD": 1, "BRL": 5, "EUR": 0.8}}
+
+	usd, ok := rates.BRL(CurrencyUSD)
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, usd)
+
+	eur, ok := rates.BRL(CurrencyEUR)
+	assert.True(t, ok)
+	assert.Equal(t, 6.25, eur)
+
+	_, ok = rates.BRL("JPY")
+	assert.False(t, ok)
+
+	_, ok = ExchangeRates{Rates: map[string]float64{"USD": 1}}.BRL(CurrencyUSD)
+	assert.False(t, ok)
+}
+
+func TestExchangeRateHistory_At(t *testing.T) {
+	history := ExchangeRateHistory{
+		{Date: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 5},
+		{Date: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), Rate: 6},
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{name: "should use the oldest rate before the history", at: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), want: 5},
+		{name: "should use the rate of the same day", at: time.Date(2026, 1, 12, 23, 0, 0, 0, time.UTC), want: 6},
+		{name: "should use the last rate before a day without one", at: time.Date(2026, 1, 11, 8, 0, 0, 0, time.UTC), want: 5},
+		{name: "should use the latest rate for a zero time", want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := history.At(tt.at)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := ExchangeRateHistory(nil).At(time.Now())
+	assert.False(t, ok)
+}
+
+func TestConvertBRL(t *testing.T) {
+	assert.Equal(t, 1.85, ConvertBRL(10, 5.4))
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// PriceResolution is the period a point of the price history covers.
+type PriceResolution string
+
+const (
+	PriceResolutionDaily   PriceResolution = "daily"
+	PriceResolutionWeekly  PriceResolution = "weekly"
+	PriceResolutionMonthly PriceResolution = "monthly"
+)
+
+// ValidPriceResolution reports whether the price history can be shown at
+// resolution.
+func ValidPriceResolution(resolution PriceResolution) bool {
+	return resolution == PriceResolutionDaily || resolution == PriceResolutionWeekly || resolution == PriceResolutionMonthly
+}
+
+// PeriodStart returns the day the period of resolution holding t starts on:
+// the day itself, the Monday of its week or the first day of its month.
+func PeriodStart(t time.Time, resolution PriceResolution) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch resolution {
+	case PriceResolutionWeekly:
+		weekday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -weekday)
+	case PriceResolutionMonthly:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// PricePoint is a point of the price history of a card. A daily point is a
+// single snapshot, while weekly and monthly points sum up the snapshots of
+// their period, with the price before it in OldPrice.
+type PricePoint struct {
+	Resolution  PriceResolution
+	PeriodStart time.Time
+	OldPrice    float64
+	Open        float64
+	Close       float64
+	Min         float64
+	Max         float64
+	OpenedAt    time.Time
+	ClosedAt    time.Time
+	Samples     int64
+}
+
+// Snapshot is the daily point of a single price snapshot.
+func Snapshot(lastPrice, oldPrice float64, lastUpdate time.Time) PricePoint {
+	return PricePoint{
+		Resolution:  PriceResolutionDaily,
+		PeriodStart: PeriodStart(lastUpdate, PriceResolutionDaily),
+		OldPrice:    oldPrice,
+		Open:        lastPrice,
+		Close:       lastPrice,
+		Min:         lastPrice,
+		Max:         lastPrice,
+		OpenedAt:    lastUpdate,
+		ClosedAt:    lastUpdate,
+		Samples:     1,
+	}
+}
+
+// PriceChange is the change of the price over the period, in cents as
+// prices are stored.
+func (p PricePoint) PriceChange() float64 {
+	return math.Round((p.Close-p.OldPrice)*100) / 100
+}
+
+// RetentionPolicy tells how long the price history is kept at each
+// resolution. Snapshots older than DailyWindow are rolled up into weekly
+// points, and weekly points older than WeeklyWindow into monthly ones.
+type RetentionPolicy struct {
+	DailyWindow  time.Duration
+	WeeklyWindow time.Duration
+}
+
+// RetentionCutoffs are the days before which the history is rolled up. They
+// fall on period boundaries, so a period is never rolled up in parts.
+type RetentionCutoffs struct {
+	Daily  time.Time
+	Weekly time.Time
+}
+
+// Cutoffs resolves the policy at now: the daily cutoff is moved back to the
+// start of its week and the weekly one to the start of its month.
+func (p RetentionPolicy) Cutoffs(now time.Time) RetentionCutoffs {
+	return RetentionCutoffs{
+		Daily:  PeriodStart(now.Add(-p.DailyWindow), PriceResolutionWeekly),
+		Weekly: PeriodStart(now.Add(-p.WeeklyWindow), PriceResolutionMonthly),
+	}
+}
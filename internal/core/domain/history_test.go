@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// 2026-01-16 is a Friday.
+	at := time.Date(2026, 1, 16, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), PeriodStart(at, PriceResolutionDaily))
+	assert.Equal(t, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), PeriodStart(at, PriceResolutionWeekly))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), PeriodStart(at, PriceResolutionMonthly))
+
+	sunday := time.Date(2026, 1, 18, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), PeriodStart(sunday, PriceResolutionWeekly))
+}
+
+func TestValidPriceResolution(t *testing.T) {
+	assert.True(t, ValidPriceResolution(PriceResolutionDaily))
+	assert.True(t, ValidPriceResolution(PriceResolutionWeekly))
+	assert.True(t, ValidPriceResolution(PriceResolutionMonthly))
+	assert.False(t, ValidPriceResolution("yearly"))
+}
+
+func TestPricePoint_PriceChange(t *testing.T) {
+	at := time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2.0, Snapshot(10, 8, at).PriceChange())
+	assert.Equal(t, -0.2, Snapshot(0.1, 0.3, at).PriceChange())
+}
+
+func TestRetentionPolicy_Cutoffs(t *testing.T) {
+	now := time.Date(2026, 4, 16, 3, 0, 0, 0, time.UTC)
+
+	cutoffs := RetentionPolicy{DailyWindow: 90 * 24 * time.Hour, WeeklyWindow: 365 * 24 * time.Hour}.Cutoffs(now)
+
+	// 90 days before is Friday 2026-01-16, a year before is 2025-04-16.
+	assert.Equal(t, RetentionCutoffs{
+		Daily:  time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		Weekly: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, cutoffs)
+}
@@ -0,0 +1,35 @@
+package domain
+
+// Role is the access a member has to a collection. Each role includes the
+// rights of the roles before it.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleEditor Role = "editor"
+	RoleOwner  Role = "owner"
+)
+
+var roleRanks = map[Role]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Includes reports whether r grants the rights of required.
+func (r Role) Includes(required Role) bool {
+	return roleRanks[r] > 0 && roleRanks[r] >= roleRanks[required]
+}
+
+// ValidMemberRole reports whether role can be given to an invited member. A
+// collection has a single owner, the user who created it.
+func ValidMemberRole(role Role) bool {
+	return role == RoleReader || role == RoleEditor
+}
+
+type Member struct {
+	UserID int64
+	Name   string
+	Email  string
+	Role   Role
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole_Includes(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{role: RoleOwner, required: RoleEditor, want: true},
+		{role: RoleEditor, required: RoleEditor, want: true},
+		{role: RoleEditor, required: RoleReader, want: true},
+		{role: RoleReader, required: RoleEditor, want: false},
+		{role: RoleEditor, required: RoleOwner, want: false},
+		{role: Role(""), required: RoleReader, want: false},
+		{role: Role("admin"), required: RoleReader, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"_"+string(tt.required), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.Includes(tt.required))
+		})
+	}
+}
+
+func TestValidMemberRole(t *testing.T) {
+	assert.True(t, ValidMemberRole(RoleReader))
+	assert.True(t, ValidMemberRole(RoleEditor))
+	assert.False(t, ValidMemberRole(RoleOwner))
+	assert.False(t, ValidMemberRole(Role("admin")))
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PriceSource is where the conciliate job takes the price of a card from.
+type PriceSource string
+
+const (
+	SourceScryfallUSD PriceSource = "scryfall_usd"
+	SourceScryfallEUR PriceSource = "scryfall_eur"
+	SourceMTGOTix     PriceSource = "mtgo_tix"
+	SourcePriceList   PriceSource = "price_list"
+
+	DefaultPriceSource = SourceScryfallUSD
+
+	CurrencyEUR = "EUR"
+	// CurrencyTIX is the MTGO event ticket, valued at par with USD, the price
+	// Wizards sells them at.
+	CurrencyTIX = "TIX"
+)
+
+func ValidPriceSource(source PriceSource) bool {
+	switch source {
+	case SourceScryfallUSD, SourceScryfallEUR, SourceMTGOTix, SourcePriceList:
+		return true
+	}
+	return false
+}
+
+// Price is a quote for a card in the currency of its source. Field names the
+// quote it was taken from, and Raw keeps every quote the source returned.
+type Price struct {
+	Value    float64
+	Currency string
+	Source   PriceSource
+	Field    string
+	Raw      []RawPrice
+}
+
+// RawPrice is a quote as returned by a price source, before any conversion.
+// ExchangeRate is the value of one unit of Currency in BRL when the card was
+// priced, nil when it was unknown, and Used marks the quote LastPrice came
+// from.
+type RawPrice struct {
+	CardID       int64
+	QuotedAt     time.Time
+	Field        string
+	Currency     string
+	Value        float64
+	ExchangeRate *float64
+	Used         bool
+}
+
+// PriceSources chooses the price source of each card. A source set for the
+// card wins over the one set for its collection, which wins over Default.
+type PriceSources struct {
+	Default     PriceSource
+	Collections map[int64]PriceSource
+	Cards       map[int64]PriceSource
+}
+
+// NewPriceSources builds the sources from their names, keyed by collection and
+// card ID, and refuses unknown sources.
+func NewPriceSources(def string, collections, cards map[string]string) (PriceSources, error) {
+	sources := PriceSources{
+		Default:     PriceSource(def),
+		Collections: make(map[int64]PriceSource, len(collections)),
+		Cards:       make(map[int64]PriceSource, len(cards)),
+	}
+	if len(def) == 0 {
+		sources.Default = DefaultPriceSource
+	}
+
+	if !ValidPriceSource(sources.Default) {
+		return PriceSources{}, fmt.Errorf("unknown price source %q", def)
+	}
+
+	for _, m := range []struct {
+		names   map[string]string
+		sources map[int64]PriceSource
+	}{
+		{collections, sources.Collections},
+		{cards, sources.Cards},
+	} {
+		for key, name := range m.names {
+			id, err := strconv.ParseInt(key, 10, 64)
+			if err != nil || id < 1 {
+				return PriceSources{}, fmt.Errorf("price source id must be a positive number, got %q", key)
+			}
+
+			if !ValidPriceSource(PriceSource(name)) {
+				return PriceSources{}, fmt.Errorf("unknown price source %q", name)
+			}
+			m.sources[id] = PriceSource(name)
+		}
+	}
+
+	return sources, nil
+}
+
+func (p PriceSources) For(card Cards) PriceSource {
+	if source, ok := p.Cards[card.ID]; ok {
+		return source
+	}
+
+	if source, ok := p.Collections[card.CollectionID]; ok {
+		return source
+	}
+
+	if len(p.Default) == 0 {
+		return DefaultPriceSource
+	}
+	return p.Default
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPriceSources(t *testing.T) {
+	t.Run("should default to scryfall usd", func(t *testing.T) {
+		sources, err := NewPriceSources("", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, SourceScryfallUSD, sources.Default)
+	})
+
+	t.Run("should key the sources by id", func(t *testing.T) {
+		sources, err := NewPriceSources("scryfall_eur", map[string]string{"2": "mtgo_tix"}, map[string]string{"15": "price_list"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, PriceSources{
+			Default:     SourceScryfallEUR,
+			Collections: map[int64]PriceSource{2: SourceMTGOTix},
+			Cards:       map[int64]PriceSource{15: SourcePriceList},
+		}, sources)
+	})
+
+	t.Run("should refuse unknown sources", func(t *testing.T) {
+		_, err := NewPriceSources("cardkingdom", nil, nil)
+		assert.EqualError(t, err, `unknown price source "cardkingdom"`)
+
+		_, err = NewPriceSources("", map[string]string{"2": "cardkingdom"}, nil)
+		assert.EqualError(t, err, `unknown price source "cardkingdom"`)
+	})
+
+	t.Run("should refuse invalid ids", func(t *testing.T) {
+		_, err := NewPriceSources("", nil, map[string]string{"abc": "mtgo_tix"})
+		assert.Error(t, err)
+	})
+}
+
+func TestPriceSources_For(t *testing.T) {
+	sources := PriceSources{
+		Default:     SourceScryfallUSD,
+		Collections: map[int64]PriceSource{2: SourceScryfallEUR},
+		Cards:       map[int64]PriceSource{15: SourceMTGOTix},
+	}
+
+	assert.Equal(t, SourceMTGOTix, sources.For(Cards{ID: 15, CollectionID: 2}))
+	assert.Equal(t, SourceScryfallEUR, sources.For(Cards{ID: 16, CollectionID: 2}))
+	assert.Equal(t, SourceScryfallUSD, sources.For(Cards{ID: 16, CollectionID: 3}))
+	assert.Equal(t, SourceScryfallUSD, PriceSources{}.For(Cards{ID: 1}))
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// QuarantineRule is the sanity rule a quarantined price broke.
+type QuarantineRule string
+
+const (
+	QuarantineMaxChange  QuarantineRule = "max_change"
+	QuarantineCeiling    QuarantineRule = "ceiling"
+	QuarantineDropToZero QuarantineRule = "drop_to_zero"
+)
+
+// QuarantineStatus is where a quarantined price is in its review. A pending
+// price is superseded when a later run quarantines the card again, so only
+// the latest quote of a card is ever pending.
+type QuarantineStatus string
+
+const (
+	QuarantinePending    QuarantineStatus = "pending"
+	QuarantineAccepted   QuarantineStatus = "accepted"
+	QuarantineRejected   QuarantineStatus = "rejected"
+	QuarantineSuperseded QuarantineStatus = "superseded"
+)
+
+// ValidQuarantineStatus reports whether quarantined prices can be listed by
+// status.
+func ValidQuarantineStatus(status QuarantineStatus) bool {
+	return status == QuarantinePending || status == QuarantineAccepted || status == QuarantineRejected ||
+		status == QuarantineSuperseded
+}
+
+// SanityRules tell which new prices of a card are too suspicious to be stored
+// without a review. MaxChange is the largest relative change from the old
+// price, so 4 lets a price grow up to five times, Ceiling the largest price in
+// BRL, and DropToZero catches a priced card losing its price. A zero MaxChange
+// or Ceiling disables its rule.
+type SanityRules struct {
+	MaxChange  float64
+	Ceiling    float64
+	DropToZero bool
+}
+
+// Check returns the first rule the change from oldPrice to newPrice breaks.
+// Only the ceiling applies to cards that were never priced, since there is no
+// old price to compare with.
+func (s SanityRules) Check(oldPrice, newPrice float64) (QuarantineRule, bool) {
+	if s.Ceiling > 0 && newPrice > s.Ceiling {
+		return QuarantineCeiling, true
+	}
+
+	if oldPrice <= 0 {
+		return "", false
+	}
+
+	if newPrice <= 0 {
+		return QuarantineDropToZero, s.DropToZero
+	}
+
+	if s.MaxChange > 0 && math.Abs(newPrice-oldPrice)/oldPrice > s.MaxChange {
+		return QuarantineMaxChange, true
+	}
+
+	return "", false
+}
+
+// QuarantinedPrice is a price conciliation held back for breaking a sanity
+// rule. It only reaches the price history once accepted. Role is the access
+// the user reviewing it has to the collection of the card.
+type QuarantinedPrice struct {
+	ID              int64
+	RunID           int64
+	CardsDetails    CardsDetails
+	Name            string
+	SetName         string
+	CollectorNumber string
+	Rule            QuarantineRule
+	Status          QuarantineStatus
+	ReviewedAt      *time.Time
+	ReviewedBy      *int64
+	Role            Role
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanityRules_Check(t *testing.T) {
+	rules := SanityRules{MaxChange: 4, Ceiling: 50000, DropToZero: true}
+
+	tests := []struct {
+		name     string
+		oldPrice float64
+		newPrice float64
+		rule     QuarantineRule
+		broken   bool
+	}{
+		{name: "steady price", oldPrice: 10, newPrice: 12},
+		{name: "five times the old price", oldPrice: 10, newPrice: 50},
+		{name: "fifty times the old price", oldPrice: 10, newPrice: 500, rule: QuarantineMaxChange, broken: true},
+		{name: "above the ceiling", oldPrice: 49000, newPrice: 51000, rule: QuarantineCeiling, broken: true},
+		{name: "drop to zero", oldPrice: 10, newPrice: 0, rule: QuarantineDropToZero, broken: true},
+		{name: "never priced", oldPrice: 0, newPrice: 500},
+		{name: "never priced above the ceiling", oldPrice: 0, newPrice: 60000, rule: QuarantineCeiling, broken: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, broken := rules.Check(tt.oldPrice, tt.newPrice)
+
+			assert.Equal(t, tt.broken, broken)
+			if tt.broken {
+				assert.Equal(t, tt.rule, rule)
+			}
+		})
+	}
+}
+
+func TestSanityRules_CheckDisabledRules(t *testing.T) {
+	_, broken := SanityRules{}.Check(10, 0)
+	assert.False(t, broken)
+
+	_, broken = SanityRules{}.Check(10, 100000)
+	assert.False(t, broken)
+}
+
+func TestValidQuarantineStatus(t *testing.T) {
+	assert.True(t, ValidQuarantineStatus(QuarantinePending))
+	assert.True(t, ValidQuarantineStatus(QuarantineAccepted))
+	assert.True(t, ValidQuarantineStatus(QuarantineRejected))
+	assert.True(t, ValidQuarantineStatus(QuarantineSuperseded))
+	assert.False(t, ValidQuarantineStatus("deleted"))
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type Sale struct {
+	ID                int64
+	CardID            int64
+	Quantity          int64
+	SalePrice         float64
+	Fees              float64
+	SaleDate          time.Time
+	CostBasis         *float64
+	RemainingQuantity int64
+}
+
+// Proceeds returns what was received for the sold copies after fees.
+func (s Sale) Proceeds() float64 {
+	return s.SalePrice*float64(s.Quantity) - s.Fees
+}
+
+// RealizedGain treats an unknown cost basis as zero, so the whole proceeds
+// are reported as gain.
+func (s Sale) RealizedGain() float64 {
+	if s.CostBasis == nil {
+		return s.Proceeds()
+	}
+
+	return s.Proceeds() - *s.CostBasis
+}
+
+// MonthlyRealizedGain sums up the sales of a month. UnknownCostCopies and
+// UnknownCostProceeds, the proceeds net of fees, count the sales without a
+// known cost basis, which are part of the other totals but not of the gain.
+type MonthlyRealizedGain struct {
+	Month               time.Month
+	CopiesSold          int64
+	GrossProceeds       float64
+	Fees                float64
+	CostBasis           float64
+	UnknownCostCopies   int64
+	UnknownCostProceeds float64
+}
+
+func (m MonthlyRealizedGain) RealizedGain() float64 {
+	return m.GrossProceeds - m.Fees - m.CostBasis - m.UnknownCostProceeds
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSale_RealizedGain(t *testing.T) {
+	costBasis := 12.0
+
+	tests := []struct {
+		name string
+		sale Sale
+		want float64
+	}{
+		{
+			name: "should subtract fees and cost basis from the proceeds",
+			sale: Sale{Quantity: 2, SalePrice: 10, Fees: 2, CostBasis: &costBasis},
+			want: 6,
+		},
+		{
+			name: "should report the whole proceeds when cost basis is unknown",
+			sale: Sale{Quantity: 2, SalePrice: 10, Fees: 2},
+			want: 18,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sale.RealizedGain())
+		})
+	}
+}
+
+func TestMonthlyRealizedGain_RealizedGain(t *testing.T) {
+	tests := []struct {
+		name string
+		gain MonthlyRealizedGain
+		want float64
+	}{
+		{
+			name: "should subtract fees and cost basis from the proceeds",
+			gain: MonthlyRealizedGain{GrossProceeds: 30, Fees: 3, CostBasis: 20},
+			want: 7,
+		},
+		{
+			name: "should leave sales without a known cost basis out",
+			gain: MonthlyRealizedGain{GrossProceeds: 50, Fees: 5, CostBasis: 20, UnknownCostCopies: 1, UnknownCostProceeds: 18},
+			want: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.gain.RealizedGain())
+		})
+	}
+}
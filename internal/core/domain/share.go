@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Share is a read-only link to a collection for people without an API key.
+// Anyone with its token sees the cards of the collection until the owner
+// revokes it.
+type Share struct {
+	ID             int64
+	CollectionID   int64
+	CollectionName string
+	OwnerID        int64
+	HideCostBasis  bool
+	CreatedAt      time.Time
+}
+
+// HashShareToken returns the SHA-256 of a share token in hex. Like API keys,
+// only the hash is stored.
+func HashShareToken(token string) string {
+	return hashSecret(token)
+}
+
+// NewShareToken returns a random share token of 64 hex characters.
+func NewShareToken() (string, error) {
+	token, err := newSecret()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShareToken(t *testing.T) {
+	token, err := NewShareToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	other, err := NewShareToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestHashShareToken(t *testing.T) {
+	hash := HashShareToken("token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, HashAPIKey("token"), hash)
+	assert.NotEqual(t, "token", hash)
+}
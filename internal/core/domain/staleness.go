@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// StalenessTier refreshes the cards whose last price in BRL is past Price at
+// their own MaxAge. A tier with a zero Price is disabled.
+type StalenessTier struct {
+	Price  float64
+	MaxAge time.Duration
+}
+
+// StalenessPolicy tells how old the price of a card may get before a run
+// reprices it. High value cards, at or above HighValue.Price, and bulk cards,
+// below Bulk.Price, have their own ages, while every other card uses MaxAge.
+// Cards that were never priced are always stale.
+type StalenessPolicy struct {
+	MaxAge    time.Duration
+	HighValue StalenessTier
+	Bulk      StalenessTier
+}
+
+// StalenessCutoffs are the times before which the price of a card of each
+// tier is stale.
+type StalenessCutoffs struct {
+	HighValuePrice float64
+	HighValue      time.Time
+	BulkPrice      float64
+	Bulk           time.Time
+	Default        time.Time
+}
+
+// Cutoffs resolves the policy for a run started at startedAt. Cards priced
+// by the run itself are never stale, even with a zero age, so a resumed run
+// does not price them again. A disabled tier falls back on the default
+// cutoff.
+func (p StalenessPolicy) Cutoffs(startedAt time.Time) StalenessCutoffs {
+	// cards_details.last_update only keeps whole seconds.
+	startedAt = startedAt.Truncate(time.Second)
+
+	cutoffs := StalenessCutoffs{
+		Default: startedAt.Add(-p.MaxAge),
+	}
+	cutoffs.HighValue = cutoffs.Default
+	cutoffs.Bulk = cutoffs.Default
+
+	if p.HighValue.Price > 0 {
+		cutoffs.HighValuePrice = p.HighValue.Price
+		cutoffs.HighValue = startedAt.Add(-p.HighValue.MaxAge)
+	}
+
+	if p.Bulk.Price > 0 {
+		cutoffs.BulkPrice = p.Bulk.Price
+		cutoffs.Bulk = startedAt.Add(-p.Bulk.MaxAge)
+	}
+
+	return cutoffs
+}
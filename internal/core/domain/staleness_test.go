@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStalenessPolicy_Cutoffs(t *testing.T) {
+	startedAt := time.Date(2026, 1, 16, 3, 0, 0, 500, time.UTC)
+	start := time.Date(2026, 1, 16, 3, 0, 0, 0, time.UTC)
+
+	policy := StalenessPolicy{
+		MaxAge:    24 * time.Hour,
+		HighValue: StalenessTier{Price: 100},
+		Bulk:      StalenessTier{Price: 1, MaxAge: 7 * 24 * time.Hour},
+	}
+
+	cutoffs := policy.Cutoffs(startedAt)
+
+	assert.Equal(t, StalenessCutoffs{
+		HighValuePrice: 100,
+		HighValue:      start,
+		BulkPrice:      1,
+		Bulk:           start.Add(-7 * 24 * time.Hour),
+		Default:        start.Add(-24 * time.Hour),
+	}, cutoffs)
+}
+
+func TestStalenessPolicy_CutoffsOfDisabledTiers(t *testing.T) {
+	start := time.Date(2026, 1, 16, 3, 0, 0, 0, time.UTC)
+
+	cutoffs := StalenessPolicy{MaxAge: time.Hour, Bulk: StalenessTier{MaxAge: 7 * 24 * time.Hour}}.Cutoffs(start)
+
+	assert.Equal(t, 0.0, cutoffs.HighValuePrice)
+	assert.Equal(t, 0.0, cutoffs.BulkPrice)
+	assert.Equal(t, start.Add(-time.Hour), cutoffs.HighValue)
+	assert.Equal(t, start.Add(-time.Hour), cutoffs.Bulk)
+	assert.Equal(t, start.Add(-time.Hour), cutoffs.Default)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+type User struct {
+	ID    int64
+	Name  string
+	Email string
+}
+
+type userContextKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user of ctx. A context without one
+// returns the zero User, whose ID matches no rows.
+func UserFromContext(ctx context.Context) User {
+	user, _ := ctx.Value(userContextKey{}).(User)
+	return user
+}
+
+// HashAPIKey returns the SHA-256 of an API key in hex. Only the hash is
+// stored, the key itself is shown once when it is created.
+func HashAPIKey(apiKey string) string {
+	return hashSecret(apiKey)
+}
+
+// NewAPIKey returns a random API key of 64 hex characters.
+func NewAPIKey() (string, error) {
+	key, err := newSecret()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
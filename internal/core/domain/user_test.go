@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserContext(t *testing.T) {
+	user := User{ID: 1, Name: "Jace", Email: "jace@example.com"}
+
+	ctx := WithUser(context.Background(), user)
+
+	assert.Equal(t, user, UserFromContext(ctx))
+	assert.Equal(t, User{}, UserFromContext(context.Background()))
+}
+
+func TestHashAPIKey(t *testing.T) {
+	hash := HashAPIKey("secret")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashAPIKey("secret"))
+	assert.NotEqual(t, hash, HashAPIKey("other"))
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, err := NewAPIKey()
+	assert.NoError(t, err)
+	assert.Len(t, key, 64)
+
+	other, err := NewAPIKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+type WebhookEvent string
+
+const (
+	// EventConciliationFinished is sent to every subscriber at the end of a
+	// conciliation.
+	EventConciliationFinished WebhookEvent = "conciliation.finished"
+	// EventReportGenerated is sent to a user once their report is emailed.
+	EventReportGenerated WebhookEvent = "report.generated"
+	// EventCardPriceChanged is sent to a user with the cards a conciliation
+	// repriced.
+	EventCardPriceChanged WebhookEvent = "card.price_changed"
+)
+
+func ValidWebhookEvent(event WebhookEvent) bool {
+	return event == EventConciliationFinished || event == EventReportGenerated || event == EventCardPriceChanged
+}
+
+// Webhook is a URL that receives the events it is subscribed to as signed
+// JSON POSTs.
+type Webhook struct {
+	ID        int64
+	UserID    int64
+	URL       string
+	Secret    string
+	Events    []WebhookEvent
+	CreatedAt time.Time
+}
+
+// WebhookDelivery is a single attempt to POST an event to a webhook. Retries
+// of the same event share the DeliveryID.
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  int64
+	DeliveryID string
+	Event      WebhookEvent
+	Attempt    int
+	StatusCode int
+	Error      string
+	Success    bool
+	CreatedAt  time.Time
+}
+
+// PriceChange is a card of UserID repriced by a conciliation.
+type PriceChange struct {
+	UserID          int64
+	CardID          int64
+	Name            string
+	SetName         string
+	CollectorNumber string
+	Finish          Finish
+	OldPrice        float64
+	NewPrice        float64
+}
+
+// NewWebhookSecret returns a random signing secret of 64 hex characters.
+// Unlike API keys it is stored as is, since it is needed to sign payloads.
+func NewWebhookSecret() (string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// NewWebhookDeliveryID returns a random id of 64 hex characters, shared by
+// the attempts to deliver the same event.
+func NewWebhookDeliveryID() (string, error) {
+	id, err := newSecret()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook delivery id: %w", err)
+	}
+
+	return id, nil
+}
+
+// SignWebhookPayload returns the HMAC-SHA256 of payload with secret in hex.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidWebhookEvent(t *testing.T) {
+	assert.True(t, ValidWebhookEvent(EventConciliationFinished))
+	assert.True(t, ValidWebhookEvent(EventReportGenerated))
+	assert.True(t, ValidWebhookEvent(EventCardPriceChanged))
+	assert.False(t, ValidWebhookEvent("card.deleted"))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	payload := []byte(`{"event":"report.generated"}`)
+
+	// echo -n '{"event":"report.generated"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "3ad09f046593f068dae6a2d54f3ebeda58d3195adbd5cb6c6c80f90780a1b36b", SignWebhookPayload("secret", payload))
+	assert.NotEqual(t, SignWebhookPayload("secret", payload), SignWebhookPayload("other", payload))
+}
+
+func TestNewWebhookSecret(t *testing.T) {
+	secret, err := NewWebhookSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 64)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// WishlistItem is a printing the user wants to buy for up to MaxPrice, in BRL.
+// The conciliate job keeps LastPrice, the near mint market price, up to date.
+type WishlistItem struct {
+	ID              int64
+	UserID          int64
+	Name            string
+	SetName         string
+	CollectorNumber string
+	Finish          Finish
+	MaxPrice        float64
+	LastPrice       *float64
+	LastUpdate      *time.Time
+}
+
+// Affordable reports whether the item was last priced at or below its target.
+func (w WishlistItem) Affordable() bool {
+	return w.LastPrice != nil && *w.LastPrice <= w.MaxPrice
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWishlistItem_Affordable(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name string
+		item WishlistItem
+		want bool
+	}{
+		{name: "should not be affordable before it is priced", item: WishlistItem{MaxPrice: 10}, want: false},
+		{name: "should be affordable below the target", item: WishlistItem{MaxPrice: 10, LastPrice: price(9.99)}, want: true},
+		{name: "should be affordable at the target", item: WishlistItem{MaxPrice: 10, LastPrice: price(10)}, want: true},
+		{name: "should not be affordable above the target", item: WishlistItem{MaxPrice: 10, LastPrice: price(10.01)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.Affordable())
+		})
+	}
+}
@@ -4,10 +4,76 @@ type RequestInsertCard struct {
 	Name            string `json:"name,omitempty"`
 	SetName         string `json:"set_name,omitempty"`
 	CollectorNumber string `json:"collector_number,omitempty"`
-	Foil            *bool  `json:"foil,omitempty"`
+	Finish          string `json:"finish,omitempty"`
+	Condition       string `json:"condition,omitempty"`
+	Language        string `json:"language,omitempty"`
+	Quantity        *int64 `json:"quantity,omitempty"`
+	CollectionID    *int64 `json:"collection_id,omitempty"`
+	RequestAcquisition
 }
 
 type RequestUpdateCard struct {
-	ID   string
-	Name string `json:"name,omitempty"`
+	ID           string
+	Name         string `json:"name,omitempty"`
+	Quantity     *int64 `json:"quantity,omitempty"`
+	CollectionID *int64 `json:"collection_id,omitempty"`
+	RequestAcquisition
+}
+
+type RequestAcquisition struct {
+	AcquisitionPrice    *float64 `json:"acquisition_price,omitempty"`
+	AcquisitionCurrency string   `json:"acquisition_currency,omitempty"`
+	AcquisitionDate     string   `json:"acquisition_date,omitempty"`
+}
+
+type RequestSellCard struct {
+	ID        string
+	Quantity  *int64   `json:"quantity,omitempty"`
+	SalePrice *float64 `json:"sale_price,omitempty"`
+	Fees      float64  `json:"fees,omitempty"`
+	SaleDate  string   `json:"sale_date,omitempty"`
+}
+
+type RequestCollection struct {
+	ID          string
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type RequestUser struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type RequestMember struct {
+	CollectionID string
+	Email        string `json:"email,omitempty"`
+	Role         string `json:"role,omitempty"`
+}
+
+type RequestShare struct {
+	CollectionID  string
+	HideCostBasis bool `json:"hide_cost_basis,omitempty"`
+}
+
+type RequestWishlistItem struct {
+	ID              string
+	Name            string   `json:"name,omitempty"`
+	SetName         string   `json:"set_name,omitempty"`
+	CollectorNumber string   `json:"collector_number,omitempty"`
+	Finish          string   `json:"finish,omitempty"`
+	MaxPrice        *float64 `json:"max_price,omitempty"`
+}
+
+type RequestAlert struct {
+	CardID    *int64   `json:"card_id,omitempty"`
+	SetName   string   `json:"set_name,omitempty"`
+	Kind      string   `json:"kind,omitempty"`
+	Direction string   `json:"direction,omitempty"`
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+type RequestWebhook struct {
+	URL    string   `json:"url,omitempty"`
+	Events []string `json:"events,omitempty"`
 }
@@ -7,7 +7,18 @@ type ResponseInsertCard struct {
 	Name            string `json:"name"`
 	Set             string `json:"set"`
 	CollectorNumber string `json:"collector_number"`
-	Foil            bool   `json:"foil"`
+	Finish          string `json:"finish"`
+	Condition       string `json:"condition"`
+	Language        string `json:"language"`
+	Quantity        int64  `json:"quantity"`
+	CollectionID    int64  `json:"collection_id"`
+	ResponseAcquisition
+}
+
+type ResponseAcquisition struct {
+	AcquisitionPrice    *float64 `json:"acquisition_price,omitempty"`
+	AcquisitionCurrency string   `json:"acquisition_currency,omitempty"`
+	AcquisitionDate     string   `json:"acquisition_date,omitempty"`
 }
 
 type ResponseCard struct {
@@ -15,11 +26,30 @@ type ResponseCard struct {
 	Name            string    `json:"name"`
 	Set             string    `json:"set"`
 	CollectorNumber string    `json:"collector_number"`
-	Foil            bool      `json:"foil"`
+	Finish          string    `json:"finish"`
+	Condition       string    `json:"condition"`
+	Language        string    `json:"language"`
+	Quantity        int64     `json:"quantity"`
+	CollectionID    int64     `json:"collection_id"`
 	LastPrice       float64   `json:"last_price"`
 	OldPrice        float64   `json:"old_price"`
 	PriceChange     float64   `json:"price_change"`
 	LastUpdate      time.Time `json:"last_update"`
+	ResponseAcquisition
+	CostBasis      *float64             `json:"cost_basis,omitempty"`
+	UnrealizedGain *float64             `json:"unrealized_gain,omitempty"`
+	Period         *ResponsePricePeriod `json:"period,omitempty"`
+}
+
+// ResponsePricePeriod sums up the prices of a weekly or monthly point of the
+// price history, whose last_price is the close of the period.
+type ResponsePricePeriod struct {
+	Resolution string  `json:"resolution"`
+	Start      string  `json:"start"`
+	Open       float64 `json:"open"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Samples    int64   `json:"samples"`
 }
 
 type ResponseConciliateJob struct {
@@ -33,11 +63,275 @@ type ResponsePaginatedCards struct {
 	Limit      int            `json:"limit"`
 	Total      int64          `json:"total"`
 	TotalPages int            `json:"total_pages"`
+	Currency   string         `json:"currency,omitempty"`
 }
 
 type ResponseCollectionStats struct {
-	TotalCards int64   `json:"total_cards"`
-	FoilCards  int64   `json:"foil_cards"`
-	UniqueSets int64   `json:"unique_sets"`
-	TotalValue float64 `json:"total_value"`
+	TotalCards     int64   `json:"total_cards"`
+	FoilCards      int64   `json:"foil_cards"`
+	UniqueSets     int64   `json:"unique_sets"`
+	TotalValue     float64 `json:"total_value"`
+	TotalCostBasis float64 `json:"total_cost_basis"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+	Currency       string  `json:"currency"`
+}
+
+type ResponseSale struct {
+	ID                int64    `json:"id"`
+	CardID            int64    `json:"card_id"`
+	Quantity          int64    `json:"quantity"`
+	SalePrice         float64  `json:"sale_price"`
+	Fees              float64  `json:"fees"`
+	SaleDate          string   `json:"sale_date"`
+	CostBasis         *float64 `json:"cost_basis,omitempty"`
+	RealizedGain      float64  `json:"realized_gain"`
+	RemainingQuantity int64    `json:"remaining_quantity"`
+}
+
+type ResponseMonthlyRealizedGain struct {
+	Month               string  `json:"month"`
+	CopiesSold          int64   `json:"copies_sold"`
+	GrossProceeds       float64 `json:"gross_proceeds"`
+	Fees                float64 `json:"fees"`
+	CostBasis           float64 `json:"cost_basis"`
+	RealizedGain        float64 `json:"realized_gain"`
+	UnknownCostCopies   int64   `json:"unknown_cost_copies"`
+	UnknownCostProceeds float64 `json:"unknown_cost_proceeds"`
+}
+
+type ResponseRealizedGains struct {
+	Year                     int                           `json:"year"`
+	Months                   []ResponseMonthlyRealizedGain `json:"months"`
+	TotalGrossProceeds       float64                       `json:"total_gross_proceeds"`
+	TotalFees                float64                       `json:"total_fees"`
+	TotalCostBasis           float64                       `json:"total_cost_basis"`
+	TotalRealizedGain        float64                       `json:"total_realized_gain"`
+	TotalUnknownCostCopies   int64                         `json:"total_unknown_cost_copies"`
+	TotalUnknownCostProceeds float64                       `json:"total_unknown_cost_proceeds"`
+}
+
+type ResponseCollection struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"is_default"`
+	Role        string `json:"role"`
+	TotalCards  int64  `json:"total_cards"`
+}
+
+type ResponseMember struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+type ResponseShare struct {
+	ID            int64     `json:"id"`
+	CollectionID  int64     `json:"collection_id"`
+	Token         string    `json:"token,omitempty"`
+	URL           string    `json:"url,omitempty"`
+	HideCostBasis bool      `json:"hide_cost_basis"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ResponseSharedCollection struct {
+	Name       string                 `json:"name"`
+	TotalValue float64                `json:"total_value"`
+	Cards      ResponsePaginatedCards `json:"cards"`
+}
+
+type ResponseWishlistItem struct {
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Set             string     `json:"set"`
+	CollectorNumber string     `json:"collector_number"`
+	Finish          string     `json:"finish"`
+	MaxPrice        float64    `json:"max_price"`
+	LastPrice       *float64   `json:"last_price,omitempty"`
+	LastUpdate      *time.Time `json:"last_update,omitempty"`
+	Affordable      bool       `json:"affordable"`
+}
+
+type ResponseAlert struct {
+	ID        int64     `json:"id"`
+	CardID    *int64    `json:"card_id,omitempty"`
+	SetName   string    `json:"set_name,omitempty"`
+	Kind      string    `json:"kind"`
+	Direction string    `json:"direction"`
+	Threshold float64   `json:"threshold"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ResponseAlertEvent struct {
+	ID          int64     `json:"id"`
+	AlertID     int64     `json:"alert_id,omitempty"`
+	CardID      int64     `json:"card_id,omitempty"`
+	Name        string    `json:"name"`
+	Set         string    `json:"set"`
+	Kind        string    `json:"kind"`
+	Direction   string    `json:"direction"`
+	Threshold   float64   `json:"threshold"`
+	OldPrice    float64   `json:"old_price"`
+	NewPrice    float64   `json:"new_price"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+type ResponsePaginatedAlertEvents struct {
+	Events     []ResponseAlertEvent `json:"events"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	Total      int64                `json:"total"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+type ResponseWebhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ResponseWebhookDelivery struct {
+	ID         int64     `json:"id"`
+	DeliveryID string    `json:"delivery_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Success    bool      `json:"success"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ResponsePaginatedWebhookDeliveries struct {
+	Deliveries []ResponseWebhookDelivery `json:"deliveries"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+	Total      int64                     `json:"total"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+type ResponseConciliation struct {
+	ID                 int64      `json:"id"`
+	StartedAt          time.Time  `json:"started_at"`
+	FinishedAt         *time.Time `json:"finished_at"`
+	Status             string     `json:"status"`
+	ExchangeRate       *float64   `json:"exchange_rate"`
+	ExchangeRateSource string     `json:"exchange_rate_source,omitempty"`
+	Processed          int64      `json:"processed"`
+	Failed             int64      `json:"failed"`
+	Skipped            int64      `json:"skipped"`
+	Retries            int64      `json:"retries"`
+	ErrorSummary       string     `json:"error_summary,omitempty"`
+}
+
+type ResponsePaginatedConciliations struct {
+	Conciliations []ResponseConciliation `json:"conciliations"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+	Total         int64                  `json:"total"`
+	TotalPages    int                    `json:"total_pages"`
+}
+
+type ResponseConciliationFailure struct {
+	ID              int64     `json:"id"`
+	CardID          int64     `json:"card_id"`
+	Name            string    `json:"name"`
+	SetName         string    `json:"set_name"`
+	CollectorNumber string    `json:"collector_number"`
+	Reason          string    `json:"reason"`
+	Error           string    `json:"error"`
+	FailedAt        time.Time `json:"failed_at"`
+}
+
+type ResponsePaginatedConciliationFailures struct {
+	Failures   []ResponseConciliationFailure `json:"failures"`
+	Page       int                           `json:"page"`
+	Limit      int                           `json:"limit"`
+	Total      int64                         `json:"total"`
+	TotalPages int                           `json:"total_pages"`
+}
+
+type ResponseQuarantinedPrice struct {
+	ID              int64      `json:"id"`
+	RunID           int64      `json:"run_id"`
+	CardID          int64      `json:"card_id"`
+	Name            string     `json:"name"`
+	SetName         string     `json:"set_name"`
+	CollectorNumber string     `json:"collector_number"`
+	LastPrice       float64    `json:"last_price"`
+	OldPrice        float64    `json:"old_price"`
+	PriceChange     float64    `json:"price_change"`
+	PriceSource     string     `json:"price_source"`
+	QuotedAt        *time.Time `json:"quoted_at"`
+	Rule            string     `json:"rule"`
+	Status          string     `json:"status"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type ResponsePaginatedQuarantinedPrices struct {
+	Prices     []ResponseQuarantinedPrice `json:"prices"`
+	Page       int                        `json:"page"`
+	Limit      int                        `json:"limit"`
+	Total      int64                      `json:"total"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+// WebhookPayload is the body POSTed to webhooks, Data depends on Event.
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type WebhookConciliationFinished struct {
+	RunID           int64     `json:"run_id"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+	CardsUpdated    int64     `json:"cards_updated"`
+	CardsFailed     int64     `json:"cards_failed"`
+	AlertsTriggered int       `json:"alerts_triggered"`
+	// ExchangeRateSource is where the rates of the run came from: primary,
+	// stored or secondary.
+	ExchangeRateSource string    `json:"exchange_rate_source"`
+	ExchangeRateDate   time.Time `json:"exchange_rate_date"`
+	// Retries counts the requests to the price and exchange sources that
+	// were retried during the run.
+	Retries int64 `json:"retries"`
+}
+
+type WebhookPriceChange struct {
+	CardID          int64   `json:"card_id"`
+	Name            string  `json:"name"`
+	Set             string  `json:"set"`
+	CollectorNumber string  `json:"collector_number"`
+	Finish          string  `json:"finish"`
+	OldPrice        float64 `json:"old_price"`
+	NewPrice        float64 `json:"new_price"`
+}
+
+type WebhookCardPriceChanged struct {
+	Cards []WebhookPriceChange `json:"cards"`
+}
+
+type WebhookReportGenerated struct {
+	CollectionID   int64   `json:"collection_id,omitempty"`
+	Cards          int     `json:"cards"`
+	TotalPrice     float64 `json:"total_price"`
+	PriceChange    float64 `json:"price_change"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+}
+
+type ResponseError struct {
+	Error        string `json:"error"`
+	RequiredRole string `json:"required_role,omitempty"`
+}
+
+type ResponseUser struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	APIKey string `json:"api_key,omitempty"`
 }
@@ -1,5 +1,6 @@
 package ports
 
 type Email interface {
-	SendEmail(cardsTable, cardsPriceTable string) error
+	SendEmail(to, cardsTable, cardsPriceTable, wishlistTable string) error
+	SendAlerts(to, alertsTable string) error
 }
@@ -6,9 +6,25 @@ import (
 )
 
 type CardGateway interface {
-	GetCardPrice(ctx context.Context, card domain.Cards) (float64, error)
+	GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error)
 }
 
 type ExchangeGateway interface {
-	GetUSD(ctx context.Context) (float64, error)
+	GetRates(ctx context.Context) (domain.ExchangeRates, error)
+}
+
+type WebhookGateway interface {
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// URLGuard checks that a user supplied url does not point to the server's own
+// network.
+type URLGuard interface {
+	CheckURL(ctx context.Context, url string) error
+}
+
+// RetryCounter counts the requests to the price and exchange sources that were
+// retried.
+type RetryCounter interface {
+	Retries() int64
 }
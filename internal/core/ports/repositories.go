@@ -3,30 +3,126 @@ package ports
 import (
 	"context"
 	"mtg-report/internal/core/domain"
+	"time"
 )
 
 type CardsRepository interface {
-	InsertCard(ctx context.Context, card domain.Cards) (domain.Cards, error)
-	InsertCards(ctx context.Context, cards []domain.Cards) error
-	GetCardbyID(ctx context.Context, id string) (domain.Cards, error)
-	GetCards(ctx context.Context, filters map[string]string) ([]domain.Cards, error)
-	GetCardsPaginated(ctx context.Context, filters map[string]string, offset, limit int) ([]domain.Cards, error)
-	GetCardsCount(ctx context.Context, filters map[string]string) (int64, error)
-	DeleteCard(ctx context.Context, id string) error
-	GetCardHistory(ctx context.Context, id string) ([]domain.Cards, error)
-	GetCardHistoryPaginated(ctx context.Context, id string, offset, limit int) ([]domain.Cards, error)
-	GetCardHistoryCount(ctx context.Context, id string) (int64, error)
-	UpdateCard(ctx context.Context, card domain.UpdateCard) (domain.Cards, error)
-	GetCollectionStats(ctx context.Context) (domain.CollectionStats, error)
+	InsertCard(ctx context.Context, userID int64, card domain.Cards) (domain.Cards, error)
+	InsertCards(ctx context.Context, userID int64, cards []domain.Cards) error
+	GetCardbyID(ctx context.Context, userID int64, id string) (domain.Cards, error)
+	GetCards(ctx context.Context, userID int64, filters map[string]string) ([]domain.Cards, error)
+	GetCardsPaginated(ctx context.Context, userID int64, filters map[string]string, offset, limit int) ([]domain.Cards, error)
+	GetCardsCount(ctx context.Context, userID int64, filters map[string]string) (int64, error)
+	DeleteCard(ctx context.Context, userID int64, id string) error
+	GetCardHistory(ctx context.Context, userID int64, id string) ([]domain.Cards, error)
+	GetCardPriceHistoryPaginated(ctx context.Context, userID int64, id string, resolution domain.PriceResolution, offset, limit int) ([]domain.PricePoint, error)
+	GetCardPriceHistoryCount(ctx context.Context, userID int64, id string, resolution domain.PriceResolution) (int64, error)
+	UpdateCard(ctx context.Context, userID int64, card domain.UpdateCard) (domain.Cards, error)
+	GetCollectionStats(ctx context.Context, userID, collectionID int64) (domain.CollectionStats, error)
+	SellCard(ctx context.Context, userID int64, sale domain.Sale) (domain.Sale, error)
+	GetRealizedGains(ctx context.Context, userID int64, year int) ([]domain.MonthlyRealizedGain, error)
+	GetExchangeRates(ctx context.Context, currency string) (domain.ExchangeRateHistory, error)
+}
+
+type CollectionsRepository interface {
+	InsertCollection(ctx context.Context, userID int64, collection domain.Collection) (domain.Collection, error)
+	GetCollections(ctx context.Context, userID int64) ([]domain.Collection, error)
+	GetCollectionByID(ctx context.Context, userID int64, id string) (domain.Collection, error)
+	UpdateCollection(ctx context.Context, userID int64, collection domain.UpdateCollection) (domain.Collection, error)
+	DeleteCollection(ctx context.Context, userID int64, id string) error
+	GetRole(ctx context.Context, userID, collectionID int64) (domain.Role, error)
+	GetCardRole(ctx context.Context, userID int64, cardID string) (domain.Role, error)
+	GetMembers(ctx context.Context, collectionID int64) ([]domain.Member, error)
+	InsertMember(ctx context.Context, collectionID int64, email string, role domain.Role) (domain.Member, error)
+	DeleteMember(ctx context.Context, collectionID, memberID int64) error
 }
 
 type ConciliateRepository interface {
-	GetCardsForUpdate(ctx context.Context, offset int, limit int) ([]domain.Cards, error)
+	GetCardsForUpdate(ctx context.Context, cutoffs domain.StalenessCutoffs, afterID int64, limit int) ([]domain.Cards, error)
+	CountFreshCards(ctx context.Context, cutoffs domain.StalenessCutoffs) (int64, error)
 	InsertCardDetails(ctx context.Context, cards []domain.CardsDetails) error
+	InsertRawPrices(ctx context.Context, rawPrices []domain.RawPrice) error
+	InsertExchangeRates(ctx context.Context, rates domain.ExchangeRates) error
+	GetLatestExchangeRates(ctx context.Context) (domain.ExchangeRates, error)
+	GetPricedCards(ctx context.Context) ([]domain.Cards, error)
+	GetWishlistForUpdate(ctx context.Context, afterID int64, limit int) ([]domain.WishlistItem, error)
+	UpdateWishlistPrices(ctx context.Context, items []domain.WishlistItem) error
+	GetAlertCandidates(ctx context.Context, since time.Time) ([]domain.AlertEvent, error)
+	InsertAlertEvents(ctx context.Context, events []domain.AlertEvent) error
+	GetPriceChanges(ctx context.Context, since time.Time) ([]domain.PriceChange, error)
+	InsertConciliationRun(ctx context.Context, run domain.ConciliationRun) (int64, error)
+	UpdateConciliationRun(ctx context.Context, run domain.ConciliationRun) error
+	GetUnfinishedConciliationRun(ctx context.Context) (domain.ConciliationRun, error)
+	InsertConciliationFailures(ctx context.Context, failures []domain.ConciliationFailure) error
+	GetConciliationFailureReasons(ctx context.Context, runID int64) (map[domain.ConciliationFailureReason]int64, error)
+	InsertQuarantinedPrice(ctx context.Context, price domain.QuarantinedPrice) error
+}
+
+type UsersRepository interface {
+	InsertUser(ctx context.Context, user domain.User, apiKeyHash string) (domain.User, error)
+	GetUserByAPIKey(ctx context.Context, apiKeyHash string) (domain.User, error)
+	UpdateAPIKey(ctx context.Context, userID int64, apiKeyHash string) error
+}
+
+type SharesRepository interface {
+	InsertShare(ctx context.Context, share domain.Share, tokenHash string) (domain.Share, error)
+	GetShares(ctx context.Context, collectionID int64) ([]domain.Share, error)
+	DeleteShare(ctx context.Context, collectionID, shareID int64) error
+	GetShareByToken(ctx context.Context, tokenHash string) (domain.Share, error)
+}
+
+type WishlistRepository interface {
+	InsertWishlistItem(ctx context.Context, userID int64, item domain.WishlistItem) (domain.WishlistItem, error)
+	GetWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error)
+	UpdateWishlistItem(ctx context.Context, userID, id int64, maxPrice float64) (domain.WishlistItem, error)
+	DeleteWishlistItem(ctx context.Context, userID int64, id string) error
+}
+
+type AlertsRepository interface {
+	InsertAlert(ctx context.Context, userID int64, alert domain.Alert) (domain.Alert, error)
+	GetAlerts(ctx context.Context, userID int64) ([]domain.Alert, error)
+	DeleteAlert(ctx context.Context, userID int64, id string) error
+	GetAlertEvents(ctx context.Context, userID int64, offset, limit int) ([]domain.AlertEvent, error)
+	GetAlertEventsCount(ctx context.Context, userID int64) (int64, error)
+}
+
+type WebhooksRepository interface {
+	InsertWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	GetWebhooks(ctx context.Context, userID int64) ([]domain.Webhook, error)
+	GetWebhookByID(ctx context.Context, userID int64, id string) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID int64, id string) error
+	GetSubscribedWebhooks(ctx context.Context, event domain.WebhookEvent, userID int64) ([]domain.Webhook, error)
+	InsertWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID int64, offset, limit int) ([]domain.WebhookDelivery, error)
+	GetWebhookDeliveriesCount(ctx context.Context, webhookID int64) (int64, error)
+}
+
+type ConciliationsRepository interface {
+	GetConciliationRuns(ctx context.Context, offset, limit int) ([]domain.ConciliationRun, error)
+	GetConciliationRunsCount(ctx context.Context) (int64, error)
+	GetConciliationRunByID(ctx context.Context, id string) (domain.ConciliationRun, error)
+	GetConciliationFailures(ctx context.Context, userID, runID int64, offset, limit int) ([]domain.ConciliationFailure, error)
+	GetConciliationFailuresCount(ctx context.Context, userID, runID int64) (int64, error)
+}
+
+type QuarantineRepository interface {
+	GetQuarantinedPrices(ctx context.Context, userID int64, status domain.QuarantineStatus, offset, limit int) ([]domain.QuarantinedPrice, error)
+	GetQuarantinedPricesCount(ctx context.Context, userID int64, status domain.QuarantineStatus) (int64, error)
+	GetQuarantinedPriceByID(ctx context.Context, userID int64, id string) (domain.QuarantinedPrice, error)
+	AcceptQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error
+	RejectQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error
 }
 
 type ReportRepository interface {
-	InsertTotalPrice(ctx context.Context) error
-	GetCardsReport(ctx context.Context) ([]domain.Cards, error)
-	GetTotalPrice(ctx context.Context) (domain.CardsPrice, error)
+	GetReportUsers(ctx context.Context, collectionID int64) ([]domain.User, error)
+	InsertTotalPrice(ctx context.Context, userID, collectionID int64) error
+	GetCardsReport(ctx context.Context, userID, collectionID int64) ([]domain.Cards, error)
+	GetTotalPrice(ctx context.Context, userID, collectionID int64) (domain.CardsPrice, error)
+	GetUnrealizedGain(ctx context.Context, userID, collectionID int64) (domain.UnrealizedGain, error)
+	GetAffordableWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error)
+}
+
+type RetentionRepository interface {
+	RollUpDailyPrices(ctx context.Context, before time.Time) (int64, error)
+	RollUpWeeklyPrices(ctx context.Context, before time.Time) (int64, error)
 }
@@ -3,20 +3,89 @@ package ports
 import (
 	"context"
 	"mime/multipart"
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
 )
 
 type CardService interface {
 	InsertCard(ctx context.Context, card dtos.RequestInsertCard) (dtos.ResponseInsertCard, error)
-	InsertCards(ctx context.Context, file multipart.File) (int64, int64)
+	InsertCards(ctx context.Context, file multipart.File, collectionID int64) (int64, int64)
 	GetCardbyID(ctx context.Context, id string) (dtos.ResponseCard, error)
 	GetCards(ctx context.Context, filters map[string]string) ([]dtos.ResponseCard, error)
-	GetCardsPaginated(ctx context.Context, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error)
+	GetCardsPaginated(ctx context.Context, filters map[string]string, currency string, page, limit int) (dtos.ResponsePaginatedCards, error)
 	DeleteCard(ctx context.Context, id string) error
 	GetCardHistory(ctx context.Context, id string) ([]dtos.ResponseCard, error)
-	GetCardHistoryPaginated(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedCards, error)
+	GetCardHistoryPaginated(ctx context.Context, id, currency string, resolution domain.PriceResolution, page, limit int) (dtos.ResponsePaginatedCards, error)
 	UpdateCard(ctx context.Context, cardRequest dtos.RequestUpdateCard) (dtos.ResponseInsertCard, error)
-	GetCollectionStats(ctx context.Context) (dtos.ResponseCollectionStats, error)
+	GetCollectionStats(ctx context.Context, collectionID int64, currency string) (dtos.ResponseCollectionStats, error)
+	SellCard(ctx context.Context, saleRequest dtos.RequestSellCard) (dtos.ResponseSale, error)
+	GetRealizedGains(ctx context.Context, year int) (dtos.ResponseRealizedGains, error)
+}
+
+type CollectionService interface {
+	InsertCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error)
+	GetCollections(ctx context.Context) ([]dtos.ResponseCollection, error)
+	GetCollectionByID(ctx context.Context, id string) (dtos.ResponseCollection, error)
+	UpdateCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error)
+	DeleteCollection(ctx context.Context, id string) error
+	GetRole(ctx context.Context, collectionID int64) (domain.Role, error)
+	GetCardRole(ctx context.Context, cardID string) (domain.Role, error)
+	GetMembers(ctx context.Context, collectionID string) ([]dtos.ResponseMember, error)
+	InsertMember(ctx context.Context, memberRequest dtos.RequestMember) (dtos.ResponseMember, error)
+	DeleteMember(ctx context.Context, collectionID, userID string) error
+}
+
+type UserService interface {
+	InsertUser(ctx context.Context, userRequest dtos.RequestUser) (dtos.ResponseUser, error)
+	Authenticate(ctx context.Context, apiKey string) (domain.User, error)
+	RotateAPIKey(ctx context.Context) (dtos.ResponseUser, error)
+}
+
+type ShareService interface {
+	InsertShare(ctx context.Context, shareRequest dtos.RequestShare) (dtos.ResponseShare, error)
+	GetShares(ctx context.Context, collectionID string) ([]dtos.ResponseShare, error)
+	DeleteShare(ctx context.Context, collectionID, shareID string) error
+	GetSharedCards(ctx context.Context, token string, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error)
+	GetSharedCollection(ctx context.Context, token string, page, limit int) (dtos.ResponseSharedCollection, error)
+}
+
+type WishlistService interface {
+	InsertWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error)
+	GetWishlist(ctx context.Context) ([]dtos.ResponseWishlistItem, error)
+	UpdateWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error)
+	DeleteWishlistItem(ctx context.Context, id string) error
+}
+
+type AlertService interface {
+	InsertAlert(ctx context.Context, alertRequest dtos.RequestAlert) (dtos.ResponseAlert, error)
+	GetAlerts(ctx context.Context) ([]dtos.ResponseAlert, error)
+	DeleteAlert(ctx context.Context, id string) error
+	GetAlertHistory(ctx context.Context, page, limit int) (dtos.ResponsePaginatedAlertEvents, error)
+}
+
+type WebhookService interface {
+	InsertWebhook(ctx context.Context, webhookRequest dtos.RequestWebhook) (dtos.ResponseWebhook, error)
+	GetWebhooks(ctx context.Context) ([]dtos.ResponseWebhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedWebhookDeliveries, error)
+}
+
+type ConciliationService interface {
+	GetConciliations(ctx context.Context, page, limit int) (dtos.ResponsePaginatedConciliations, error)
+	GetConciliationFailures(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedConciliationFailures, error)
+}
+
+type QuarantineService interface {
+	GetQuarantinedPrices(ctx context.Context, status domain.QuarantineStatus, page, limit int) (dtos.ResponsePaginatedQuarantinedPrices, error)
+	AcceptQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error)
+	RejectQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error)
+}
+
+// WebhookDispatcher delivers events to the webhooks subscribed to them. Notify
+// only reaches the webhooks of userID, Broadcast reaches every subscriber.
+type WebhookDispatcher interface {
+	Notify(ctx context.Context, userID int64, event domain.WebhookEvent, data interface{}) error
+	Broadcast(ctx context.Context, event domain.WebhookEvent, data interface{}) error
 }
 
 type PriceService interface {
@@ -26,3 +95,7 @@ type PriceService interface {
 type ReportService interface {
 	ProcessAndSend(ctx context.Context) error
 }
+
+type RetentionService interface {
+	RollUp(ctx context.Context) error
+}
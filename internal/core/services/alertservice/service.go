@@ -0,0 +1,129 @@
+package alertservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"strings"
+	"time"
+)
+
+type service struct {
+	alertsRepository ports.AlertsRepository
+	log              logrus.Logger
+}
+
+func New(ar ports.AlertsRepository, log logrus.Logger) *service {
+	return &service{
+		alertsRepository: ar,
+		log:              log,
+	}
+}
+
+func (s *service) InsertAlert(ctx context.Context, alertRequest dtos.RequestAlert) (dtos.ResponseAlert, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	alert := domain.Alert{
+		CardID:    alertRequest.CardID,
+		Kind:      domain.AlertKind(strings.ToLower(alertRequest.Kind)),
+		Direction: domain.AlertDirection(strings.ToLower(alertRequest.Direction)),
+		Threshold: *alertRequest.Threshold,
+		CreatedAt: time.Now(),
+	}
+
+	if alert.CardID == nil {
+		alert.SetName = alertRequest.SetName
+	}
+
+	alert, err := s.alertsRepository.InsertAlert(ctx, userID, alert)
+	if err != nil {
+		return dtos.ResponseAlert{}, fmt.Errorf("service failed to insert alert: %w", err)
+	}
+
+	return toResponseAlert(alert), nil
+}
+
+func (s *service) GetAlerts(ctx context.Context) ([]dtos.ResponseAlert, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	alertsDomain, err := s.alertsRepository.GetAlerts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get alerts: %w", err)
+	}
+
+	alerts := make([]dtos.ResponseAlert, 0, len(alertsDomain))
+	for _, alert := range alertsDomain {
+		alerts = append(alerts, toResponseAlert(alert))
+	}
+
+	return alerts, nil
+}
+
+func (s *service) DeleteAlert(ctx context.Context, id string) error {
+	userID := domain.UserFromContext(ctx).ID
+
+	err := s.alertsRepository.DeleteAlert(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("service failed to delete alert: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetAlertHistory(ctx context.Context, page, limit int) (dtos.ResponsePaginatedAlertEvents, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	offset := (page - 1) * limit
+
+	total, err := s.alertsRepository.GetAlertEventsCount(ctx, userID)
+	if err != nil {
+		return dtos.ResponsePaginatedAlertEvents{}, fmt.Errorf("service failed to get alert events count: %w", err)
+	}
+
+	eventsDomain, err := s.alertsRepository.GetAlertEvents(ctx, userID, offset, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedAlertEvents{}, fmt.Errorf("service failed to get alert events: %w", err)
+	}
+
+	events := make([]dtos.ResponseAlertEvent, 0, len(eventsDomain))
+	for _, event := range eventsDomain {
+		events = append(events, dtos.ResponseAlertEvent{
+			ID:          event.ID,
+			AlertID:     event.Alert.ID,
+			CardID:      event.CardID,
+			Name:        event.CardName,
+			Set:         event.CardSetName,
+			Kind:        string(event.Alert.Kind),
+			Direction:   string(event.Alert.Direction),
+			Threshold:   event.Alert.Threshold,
+			OldPrice:    event.OldPrice,
+			NewPrice:    event.NewPrice,
+			TriggeredAt: event.TriggeredAt,
+		})
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return dtos.ResponsePaginatedAlertEvents{
+		Events:     events,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func toResponseAlert(alert domain.Alert) dtos.ResponseAlert {
+	return dtos.ResponseAlert{
+		ID:        alert.ID,
+		CardID:    alert.CardID,
+		SetName:   alert.SetName,
+		Kind:      string(alert.Kind),
+		Direction: string(alert.Direction),
+		Threshold: alert.Threshold,
+		CreatedAt: alert.CreatedAt,
+	}
+}
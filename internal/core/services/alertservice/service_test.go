@@ -0,0 +1,163 @@
+package alertservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewAlertsRepositoryMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertAlert(t *testing.T) {
+	t.Run("should insert a card alert for the user", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("InsertAlert", mock.Anything, testUserID, mock.MatchedBy(func(alert domain.Alert) bool {
+			return *alert.CardID == 3 && alert.SetName == "" && alert.Kind == domain.AlertPercentage &&
+				alert.Direction == domain.AlertUp && alert.Threshold == 10 && !alert.CreatedAt.IsZero()
+		})).Return(domain.Alert{ID: 1, UserID: testUserID, CardID: int64Ptr(3), Kind: domain.AlertPercentage,
+			Direction: domain.AlertUp, Threshold: 10}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.InsertAlert(userCtx, dtos.RequestAlert{CardID: int64Ptr(3), SetName: "Alpha",
+			Kind: "Percentage", Direction: "UP", Threshold: float64Ptr(10)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponseAlert{ID: 1, CardID: int64Ptr(3), Kind: "percentage", Direction: "up", Threshold: 10}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should insert a set alert for the user", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("InsertAlert", mock.Anything, testUserID, mock.MatchedBy(func(alert domain.Alert) bool {
+			return alert.CardID == nil && alert.SetName == "Alpha" && alert.Kind == domain.AlertAbsolute
+		})).Return(domain.Alert{ID: 2, SetName: "Alpha", Kind: domain.AlertAbsolute, Direction: domain.AlertDown, Threshold: 100}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.InsertAlert(userCtx, dtos.RequestAlert{SetName: "Alpha", Kind: "absolute", Direction: "down",
+			Threshold: float64Ptr(100)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Alpha", got.SetName)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("InsertAlert", mock.Anything, testUserID, mock.Anything).Return(domain.Alert{}, domain.ErrCardNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.InsertAlert(userCtx, dtos.RequestAlert{CardID: int64Ptr(3), Kind: "absolute", Direction: "up",
+			Threshold: float64Ptr(100)})
+
+		assert.ErrorIs(t, err, domain.ErrCardNotFound{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_GetAlerts(t *testing.T) {
+	t.Run("should return an empty list when the user has no alerts", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("GetAlerts", mock.Anything, testUserID).Return([]domain.Alert(nil), nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetAlerts(userCtx)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("GetAlerts", mock.Anything, testUserID).Return([]domain.Alert(nil), errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetAlerts(userCtx)
+
+		assert.ErrorContains(t, err, "service failed to get alerts")
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_DeleteAlert(t *testing.T) {
+	repoMock := mocks.NewAlertsRepositoryMock()
+	repoMock.On("DeleteAlert", mock.Anything, testUserID, "1").Return(domain.ErrAlertNotFound{})
+
+	service := New(repoMock, mocks.NewLogMock())
+	err := service.DeleteAlert(userCtx, "1")
+
+	assert.ErrorIs(t, err, domain.ErrAlertNotFound{})
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_GetAlertHistory(t *testing.T) {
+	t.Run("should paginate the triggered alerts", func(t *testing.T) {
+		triggeredAt := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("GetAlertEventsCount", mock.Anything, testUserID).Return(int64(6), nil)
+		repoMock.On("GetAlertEvents", mock.Anything, testUserID, 5, 5).Return([]domain.AlertEvent{
+			{
+				ID:          9,
+				Alert:       domain.Alert{ID: 1, Kind: domain.AlertPercentage, Direction: domain.AlertUp, Threshold: 10},
+				CardID:      3,
+				CardName:    "Lightning Bolt",
+				CardSetName: "Alpha",
+				OldPrice:    100,
+				NewPrice:    120,
+				TriggeredAt: triggeredAt,
+			},
+		}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetAlertHistory(userCtx, 2, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponsePaginatedAlertEvents{
+			Events: []dtos.ResponseAlertEvent{
+				{ID: 9, AlertID: 1, CardID: 3, Name: "Lightning Bolt", Set: "Alpha", Kind: "percentage", Direction: "up",
+					Threshold: 10, OldPrice: 100, NewPrice: 120, TriggeredAt: triggeredAt},
+			},
+			Page:       2,
+			Limit:      5,
+			Total:      6,
+			TotalPages: 2,
+		}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewAlertsRepositoryMock()
+		repoMock.On("GetAlertEventsCount", mock.Anything, testUserID).Return(int64(0), errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetAlertHistory(userCtx, 1, 10)
+
+		assert.ErrorContains(t, err, "service failed to get alert events count")
+		repoMock.AssertExpectations(t)
+	})
+}
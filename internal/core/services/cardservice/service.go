@@ -14,6 +14,8 @@ import (
 	"time"
 )
 
+const defaultQuantity int64 = 1
+
 type service struct {
 	cardsRepository ports.CardsRepository
 	commitSize      int
@@ -29,109 +31,101 @@ func New(cr ports.CardsRepository, commitSize int, log logrus.Logger) *service {
 }
 
 func (c *service) InsertCard(ctx context.Context, cardRequest dtos.RequestInsertCard) (dtos.ResponseInsertCard, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	quantity := defaultQuantity
+	if cardRequest.Quantity != nil {
+		quantity = *cardRequest.Quantity
+	}
+
 	cardDomain := domain.Cards{
 		Name:            cardRequest.Name,
 		SetName:         cardRequest.SetName,
 		CollectorNumber: cardRequest.CollectorNumber,
-		Foil:            *cardRequest.Foil,
+		Finish:          domain.NormalizeFinish(cardRequest.Finish),
+		Condition:       domain.NormalizeCondition(cardRequest.Condition),
+		Language:        domain.NormalizeLanguage(cardRequest.Language),
+		Quantity:        quantity,
+		Acquisition:     toAcquisition(cardRequest.RequestAcquisition),
+	}
+
+	if cardRequest.CollectionID != nil {
+		cardDomain.CollectionID = *cardRequest.CollectionID
+	}
+
+	if len(cardDomain.AcquisitionCurrency) == 0 {
+		cardDomain.AcquisitionCurrency = domain.DefaultCurrency
 	}
 
-	cardDomain, err := c.cardsRepository.InsertCard(ctx, cardDomain)
+	cardDomain, err := c.cardsRepository.InsertCard(ctx, userID, cardDomain)
 	if err != nil {
 		return dtos.ResponseInsertCard{}, fmt.Errorf("service failed to insert card: %w", err)
 	}
 
-	return dtos.ResponseInsertCard{
-		ID:              cardDomain.ID,
-		Name:            cardDomain.Name,
-		Set:             cardDomain.SetName,
-		CollectorNumber: cardDomain.CollectorNumber,
-		Foil:            cardDomain.Foil,
-	}, nil
+	return toResponseInsertCard(cardDomain), nil
 }
 
 func (c *service) GetCardbyID(ctx context.Context, id string) (dtos.ResponseCard, error) {
-	cardDomain, err := c.cardsRepository.GetCardbyID(ctx, id)
+	userID := domain.UserFromContext(ctx).ID
+
+	cardDomain, err := c.cardsRepository.GetCardbyID(ctx, userID, id)
 	if err != nil {
 		return dtos.ResponseCard{}, fmt.Errorf("service failed to get card: %w", err)
 	}
 
-	var lastUpdate time.Time
-	if cardDomain.LastUpdate != nil {
-		lastUpdate = *cardDomain.LastUpdate
-	}
-
-	return dtos.ResponseCard{
-		ID:              cardDomain.ID,
-		Name:            cardDomain.Name,
-		Set:             cardDomain.SetName,
-		CollectorNumber: cardDomain.CollectorNumber,
-		Foil:            cardDomain.Foil,
-		LastPrice:       cardDomain.LastPrice,
-		OldPrice:        cardDomain.OldPrice,
-		PriceChange:     cardDomain.PriceChange,
-		LastUpdate:      lastUpdate,
-	}, nil
+	return toResponseCard(cardDomain), nil
 }
 
 func (c *service) GetCards(ctx context.Context, filters map[string]string) ([]dtos.ResponseCard, error) {
-	cardsDomain, err := c.cardsRepository.GetCards(ctx, filters)
+	userID := domain.UserFromContext(ctx).ID
+
+	cardsDomain, err := c.cardsRepository.GetCards(ctx, userID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("service failed to get card: %w", err)
 	}
 
 	cards := make([]dtos.ResponseCard, 0, len(cardsDomain))
 	for _, card := range cardsDomain {
-		var lastUpdate time.Time
-		if card.LastUpdate != nil {
-			lastUpdate = *card.LastUpdate
-		}
-
-		cards = append(cards, dtos.ResponseCard{
-			ID:              card.ID,
-			Name:            card.Name,
-			Set:             card.SetName,
-			CollectorNumber: card.CollectorNumber,
-			Foil:            card.Foil,
-			LastPrice:       card.LastPrice,
-			OldPrice:        card.OldPrice,
-			PriceChange:     card.PriceChange,
-			LastUpdate:      lastUpdate,
-		})
+		cards = append(cards, toResponseCard(card))
 	}
 
 	return cards, nil
 }
 
 func (c *service) UpdateCard(ctx context.Context, cardRequest dtos.RequestUpdateCard) (dtos.ResponseInsertCard, error) {
+	userID := domain.UserFromContext(ctx).ID
+
 	id, err := strconv.ParseInt(cardRequest.ID, 10, 64)
 	if err != nil {
 		return dtos.ResponseInsertCard{}, fmt.Errorf("service failed to parse id in update card: %w", err)
 	}
 
 	updateCard := domain.UpdateCard{
-		ID:   id,
-		Name: cardRequest.Name,
+		ID:          id,
+		Name:        cardRequest.Name,
+		Acquisition: toAcquisition(cardRequest.RequestAcquisition),
 	}
 
-	cardsDomain, err := c.cardsRepository.UpdateCard(ctx, updateCard)
-	if err != nil {
-		return dtos.ResponseInsertCard{}, fmt.Errorf("service failed to update card: %w", err)
+	if cardRequest.Quantity != nil {
+		updateCard.Quantity = *cardRequest.Quantity
 	}
 
-	card := dtos.ResponseInsertCard{
-		ID:              cardsDomain.ID,
-		Name:            cardsDomain.Name,
-		Set:             cardsDomain.SetName,
-		CollectorNumber: cardsDomain.CollectorNumber,
-		Foil:            cardsDomain.Foil,
+	if cardRequest.CollectionID != nil {
+		updateCard.CollectionID = *cardRequest.CollectionID
 	}
 
-	return card, nil
+	cardsDomain, err := c.cardsRepository.UpdateCard(ctx, userID, updateCard)
+	if err != nil {
+		return dtos.ResponseInsertCard{}, fmt.Errorf("service failed to update card: %w", err)
+	}
+
+	return toResponseInsertCard(cardsDomain), nil
 }
 
 func (c *service) DeleteCard(ctx context.Context, id string) error {
-	err := c.cardsRepository.DeleteCard(ctx, id)
+	userID := domain.UserFromContext(ctx).ID
+
+	err := c.cardsRepository.DeleteCard(ctx, userID, id)
 	if err != nil {
 		return fmt.Errorf("service failed to delete card: %w", err)
 	}
@@ -140,35 +134,24 @@ func (c *service) DeleteCard(ctx context.Context, id string) error {
 }
 
 func (c *service) GetCardHistory(ctx context.Context, id string) ([]dtos.ResponseCard, error) {
-	cards, err := c.cardsRepository.GetCardHistory(ctx, id)
+	userID := domain.UserFromContext(ctx).ID
+
+	cards, err := c.cardsRepository.GetCardHistory(ctx, userID, id)
 	if err != nil {
 		return nil, fmt.Errorf("service failed to get card history: %w", err)
 	}
 
 	cardsResponse := make([]dtos.ResponseCard, 0, len(cards))
 	for _, card := range cards {
-		var lastUpdate time.Time
-		if card.LastUpdate != nil {
-			lastUpdate = *card.LastUpdate
-		}
-
-		cardsResponse = append(cardsResponse, dtos.ResponseCard{
-			ID:              card.ID,
-			Name:            card.Name,
-			Set:             card.SetName,
-			CollectorNumber: card.CollectorNumber,
-			Foil:            card.Foil,
-			LastPrice:       card.LastPrice,
-			OldPrice:        card.OldPrice,
-			PriceChange:     card.PriceChange,
-			LastUpdate:      lastUpdate,
-		})
+		cardsResponse = append(cardsResponse, toResponseCard(card))
 	}
 
 	return cardsResponse, nil
 }
 
-func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64, int64) {
+func (c *service) InsertCards(ctx context.Context, file multipart.File, collectionID int64) (int64, int64) {
+	userID := domain.UserFromContext(ctx).ID
+
 	var cardsProcessed int64
 	var cardsNotProcessed int64
 	cardsCh := make(chan []domain.Cards, 0)
@@ -176,7 +159,7 @@ func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64,
 
 	scanner := bufio.NewScanner(file)
 
-	re := regexp.MustCompile(`name: ([\p{L}\s-,'"!?]+), set_name: ([\p{L}\s-]+), collector_number: ([\w\s]+), foil: ([\w\s]+)`)
+	re := regexp.MustCompile(`name: ([\p{L}\s-,'"!?]+), set_name: ([\p{L}\s-]+), collector_number: ([\w\s]+), (?:finish|foil): (\w+)(?:, condition: (\w+))?(?:, language: (\w+))?(?:, quantity: (\d+))?(?:, acquisition_price: ([\d.]+))?(?:, acquisition_currency: (\w+))?(?:, acquisition_date: ([\d-]+))?(?:, collection_id: (\d+))?`)
 
 	go func() {
 		defer close(cardsCh)
@@ -189,7 +172,7 @@ func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64,
 			}
 
 			matches := re.FindStringSubmatch(line)
-			if len(matches) != 5 {
+			if len(matches) != 12 {
 				c.log.WithFields(logrus.Fields{"line": line}).Warn("service failed to parse line in insert cards")
 				cardsNotProcessed++
 				continue
@@ -201,12 +184,27 @@ func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64,
 				CollectorNumber: matches[3],
 			}
 
-			if err := card.ValidateCardFields(matches[4]); err != nil {
+			if err := card.ValidateCardFields(matches[4], matches[5], matches[6], matches[7]); err != nil {
 				cardsNotProcessed++
 				c.log.Warn(fmt.Errorf("service failed to insert one card in insert cards: %w", err))
 				continue
 			}
 
+			if err := card.ValidateAcquisitionFields(matches[8], matches[9], matches[10]); err != nil {
+				cardsNotProcessed++
+				c.log.Warn(fmt.Errorf("service failed to insert one card in insert cards: %w", err))
+				continue
+			}
+
+			if err := card.ValidateCollectionField(matches[11]); err != nil {
+				cardsNotProcessed++
+				c.log.Warn(fmt.Errorf("service failed to insert one card in insert cards: %w", err))
+				continue
+			}
+			if card.CollectionID == 0 {
+				card.CollectionID = collectionID
+			}
+
 			cards = append(cards, card)
 			if len(cards) == c.commitSize {
 				cardsCh <- cards
@@ -224,7 +222,7 @@ func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64,
 		defer close(finishCh)
 
 		for cards, ok := <-cardsCh; ok; cards, ok = <-cardsCh {
-			err := c.cardsRepository.InsertCards(ctx, cards)
+			err := c.cardsRepository.InsertCards(ctx, userID, cards)
 			if err != nil {
 				c.log.Warn(fmt.Errorf("service failed to insert cards: %w", err))
 				cardsNotProcessed += int64(len(cards))
@@ -243,39 +241,31 @@ func (c *service) InsertCards(ctx context.Context, file multipart.File) (int64,
 	return cardsProcessed, cardsNotProcessed
 }
 
-func (c *service) GetCardsPaginated(ctx context.Context, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+func (c *service) GetCardsPaginated(ctx context.Context, filters map[string]string, currency string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	userID := domain.UserFromContext(ctx).ID
+
 	offset := (page - 1) * limit
 
 	// Get total count
-	total, err := c.cardsRepository.GetCardsCount(ctx, filters)
+	total, err := c.cardsRepository.GetCardsCount(ctx, userID, filters)
 	if err != nil {
 		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get cards count: %w", err)
 	}
 
 	// Get paginated cards
-	cardsDomain, err := c.cardsRepository.GetCardsPaginated(ctx, filters, offset, limit)
+	cardsDomain, err := c.cardsRepository.GetCardsPaginated(ctx, userID, filters, offset, limit)
 	if err != nil {
 		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get cards paginated: %w", err)
 	}
 
+	rates, err := c.exchangeRates(ctx, currency)
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, err
+	}
+
 	cards := make([]dtos.ResponseCard, 0, len(cardsDomain))
 	for _, card := range cardsDomain {
-		var lastUpdate time.Time
-		if card.LastUpdate != nil {
-			lastUpdate = *card.LastUpdate
-		}
-
-		cards = append(cards, dtos.ResponseCard{
-			ID:              card.ID,
-			Name:            card.Name,
-			Set:             card.SetName,
-			CollectorNumber: card.CollectorNumber,
-			Foil:            card.Foil,
-			LastPrice:       card.LastPrice,
-			OldPrice:        card.OldPrice,
-			PriceChange:     card.PriceChange,
-			LastUpdate:      lastUpdate,
-		})
+		cards = append(cards, convertCard(toResponseCard(card), rates))
 	}
 
 	totalPages := int((total + int64(limit) - 1) / int64(limit)) // Ceiling division
@@ -286,42 +276,42 @@ func (c *service) GetCardsPaginated(ctx context.Context, filters map[string]stri
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
+		Currency:   valuationCurrency(currency),
 	}, nil
 }
 
-func (c *service) GetCardHistoryPaginated(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+// GetCardHistoryPaginated returns a page of the price history of a card at
+// resolution, newest first. Daily snapshots are only kept for the retention
+// window, so older points are served as the weekly or monthly aggregates they
+// were rolled up into, whatever the resolution asked for.
+func (c *service) GetCardHistoryPaginated(ctx context.Context, id, currency string, resolution domain.PriceResolution, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	card, err := c.cardsRepository.GetCardbyID(ctx, userID, id)
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get card history: %w", err)
+	}
+
 	offset := (page - 1) * limit
 
-	// Get total count
-	total, err := c.cardsRepository.GetCardHistoryCount(ctx, id)
+	total, err := c.cardsRepository.GetCardPriceHistoryCount(ctx, userID, id, resolution)
 	if err != nil {
 		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get card history count: %w", err)
 	}
 
-	// Get paginated card history
-	cardsDomain, err := c.cardsRepository.GetCardHistoryPaginated(ctx, id, offset, limit)
+	points, err := c.cardsRepository.GetCardPriceHistoryPaginated(ctx, userID, id, resolution, offset, limit)
 	if err != nil {
-		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get card history paginated: %w", err)
+		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get card history prices: %w", err)
 	}
 
-	cards := make([]dtos.ResponseCard, 0, len(cardsDomain))
-	for _, card := range cardsDomain {
-		var lastUpdate time.Time
-		if card.LastUpdate != nil {
-			lastUpdate = *card.LastUpdate
-		}
+	rates, err := c.exchangeRates(ctx, currency)
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, err
+	}
 
-		cards = append(cards, dtos.ResponseCard{
-			ID:              card.ID,
-			Name:            card.Name,
-			Set:             card.SetName,
-			CollectorNumber: card.CollectorNumber,
-			Foil:            card.Foil,
-			LastPrice:       card.LastPrice,
-			OldPrice:        card.OldPrice,
-			PriceChange:     card.PriceChange,
-			LastUpdate:      lastUpdate,
-		})
+	cards := make([]dtos.ResponseCard, 0, len(points))
+	for _, point := range points {
+		cards = append(cards, convertCard(toResponseHistoryCard(card, point), rates))
 	}
 
 	totalPages := int((total + int64(limit) - 1) / int64(limit)) // Ceiling division
@@ -332,19 +322,292 @@ func (c *service) GetCardHistoryPaginated(ctx context.Context, id string, page,
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
+		Currency:   valuationCurrency(currency),
 	}, nil
 }
 
-func (c *service) GetCollectionStats(ctx context.Context) (dtos.ResponseCollectionStats, error) {
-	stats, err := c.cardsRepository.GetCollectionStats(ctx)
+// GetCollectionStats returns the statistics of a collection. Its values are
+// those of the latest prices, so they are converted at the latest rate.
+func (c *service) GetCollectionStats(ctx context.Context, collectionID int64, currency string) (dtos.ResponseCollectionStats, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	stats, err := c.cardsRepository.GetCollectionStats(ctx, userID, collectionID)
 	if err != nil {
 		return dtos.ResponseCollectionStats{}, fmt.Errorf("service failed to get collection stats: %w", err)
 	}
 
+	rates, err := c.exchangeRates(ctx, currency)
+	if err != nil {
+		return dtos.ResponseCollectionStats{}, err
+	}
+
+	if rate, ok := rates.At(time.Time{}); ok {
+		stats.TotalValue = domain.ConvertBRL(stats.TotalValue, rate)
+		stats.TotalCostBasis = domain.ConvertBRL(stats.TotalCostBasis, rate)
+		stats.UnrealizedGain = domain.ConvertBRL(stats.UnrealizedGain, rate)
+	}
+
 	return dtos.ResponseCollectionStats{
-		TotalCards: stats.TotalCards,
-		FoilCards:  stats.FoilCards,
-		UniqueSets: stats.UniqueSets,
-		TotalValue: stats.TotalValue,
+		TotalCards:     stats.TotalCards,
+		FoilCards:      stats.FoilCards,
+		UniqueSets:     stats.UniqueSets,
+		TotalValue:     stats.TotalValue,
+		TotalCostBasis: stats.TotalCostBasis,
+		UnrealizedGain: stats.UnrealizedGain,
+		Currency:       valuationCurrency(currency),
+	}, nil
+}
+
+func (c *service) SellCard(ctx context.Context, saleRequest dtos.RequestSellCard) (dtos.ResponseSale, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	id, err := strconv.ParseInt(saleRequest.ID, 10, 64)
+	if err != nil {
+		return dtos.ResponseSale{}, fmt.Errorf("service failed to parse id in sell card: %w", err)
+	}
+
+	sale := domain.Sale{
+		CardID:    id,
+		Quantity:  defaultQuantity,
+		SalePrice: *saleRequest.SalePrice,
+		Fees:      saleRequest.Fees,
+		SaleDate:  time.Now().UTC().Truncate(24 * time.Hour),
+	}
+
+	if saleRequest.Quantity != nil {
+		sale.Quantity = *saleRequest.Quantity
+	}
+
+	if date, err := time.Parse(domain.DateLayout, saleRequest.SaleDate); err == nil {
+		sale.SaleDate = date
+	}
+
+	sale, err = c.cardsRepository.SellCard(ctx, userID, sale)
+	if err != nil {
+		return dtos.ResponseSale{}, fmt.Errorf("service failed to sell card: %w", err)
+	}
+
+	return dtos.ResponseSale{
+		ID:                sale.ID,
+		CardID:            sale.CardID,
+		Quantity:          sale.Quantity,
+		SalePrice:         sale.SalePrice,
+		Fees:              sale.Fees,
+		SaleDate:          sale.SaleDate.Format(domain.DateLayout),
+		CostBasis:         sale.CostBasis,
+		RealizedGain:      sale.RealizedGain(),
+		RemainingQuantity: sale.RemainingQuantity,
 	}, nil
 }
+
+// GetRealizedGains always returns the twelve months of the year, months
+// without sales are reported with zeroed values. Sales without a known cost
+// basis are left out of the gain and reported apart.
+func (c *service) GetRealizedGains(ctx context.Context, year int) (dtos.ResponseRealizedGains, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	gains, err := c.cardsRepository.GetRealizedGains(ctx, userID, year)
+	if err != nil {
+		return dtos.ResponseRealizedGains{}, fmt.Errorf("service failed to get realized gains: %w", err)
+	}
+
+	byMonth := make(map[time.Month]domain.MonthlyRealizedGain, len(gains))
+	for _, gain := range gains {
+		byMonth[gain.Month] = gain
+	}
+
+	response := dtos.ResponseRealizedGains{
+		Year:   year,
+		Months: make([]dtos.ResponseMonthlyRealizedGain, 0, 12),
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		gain := byMonth[month]
+
+		response.Months = append(response.Months, dtos.ResponseMonthlyRealizedGain{
+			Month:               fmt.Sprintf("%d-%02d", year, int(month)),
+			CopiesSold:          gain.CopiesSold,
+			GrossProceeds:       gain.GrossProceeds,
+			Fees:                gain.Fees,
+			CostBasis:           gain.CostBasis,
+			RealizedGain:        gain.RealizedGain(),
+			UnknownCostCopies:   gain.UnknownCostCopies,
+			UnknownCostProceeds: gain.UnknownCostProceeds,
+		})
+
+		response.TotalGrossProceeds += gain.GrossProceeds
+		response.TotalFees += gain.Fees
+		response.TotalCostBasis += gain.CostBasis
+		response.TotalRealizedGain += gain.RealizedGain()
+		response.TotalUnknownCostCopies += gain.UnknownCostCopies
+		response.TotalUnknownCostProceeds += gain.UnknownCostProceeds
+	}
+
+	return response, nil
+}
+
+// exchangeRates returns the rate history of the currency values are shown in,
+// nil for BRL, the currency they are stored in.
+func (c *service) exchangeRates(ctx context.Context, currency string) (domain.ExchangeRateHistory, error) {
+	if valuationCurrency(currency) == domain.CurrencyBRL {
+		return nil, nil
+	}
+
+	rates, err := c.cardsRepository.GetExchangeRates(ctx, currency)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get exchange rates: %w", err)
+	}
+
+	if len(rates) == 0 {
+		return nil, domain.ErrExchangeRateNotFound{}
+	}
+
+	return rates, nil
+}
+
+func valuationCurrency(currency string) string {
+	if len(currency) == 0 {
+		return domain.CurrencyBRL
+	}
+	return currency
+}
+
+// convertCard re-expresses the BRL values of a card at the rate in effect when
+// it was priced. The acquisition price is kept in its own currency.
+func convertCard(card dtos.ResponseCard, rates domain.ExchangeRateHistory) dtos.ResponseCard {
+	rate, ok := rates.At(card.LastUpdate)
+	if !ok {
+		return card
+	}
+
+	card.LastPrice = domain.ConvertBRL(card.LastPrice, rate)
+	card.OldPrice = domain.ConvertBRL(card.OldPrice, rate)
+	card.PriceChange = domain.ConvertBRL(card.PriceChange, rate)
+
+	if card.CostBasis != nil {
+		costBasis := domain.ConvertBRL(*card.CostBasis, rate)
+		card.CostBasis = &costBasis
+	}
+
+	if card.UnrealizedGain != nil {
+		unrealizedGain := domain.ConvertBRL(*card.UnrealizedGain, rate)
+		card.UnrealizedGain = &unrealizedGain
+	}
+
+	if card.Period != nil {
+		period := *card.Period
+		period.Open = domain.ConvertBRL(period.Open, rate)
+		period.Min = domain.ConvertBRL(period.Min, rate)
+		period.Max = domain.ConvertBRL(period.Max, rate)
+		card.Period = &period
+	}
+
+	return card
+}
+
+// toResponseHistoryCard shows card as priced at a point of its history. The
+// point closes at its last update, and weekly and monthly points carry the
+// rest of their period.
+func toResponseHistoryCard(card domain.Cards, point domain.PricePoint) dtos.ResponseCard {
+	closedAt := point.ClosedAt
+	card.LastPrice = point.Close
+	card.OldPrice = point.OldPrice
+	card.PriceChange = point.PriceChange()
+	card.LastUpdate = &closedAt
+
+	response := toResponseCard(card)
+
+	if point.Resolution != domain.PriceResolutionDaily {
+		response.Period = &dtos.ResponsePricePeriod{
+			Resolution: string(point.Resolution),
+			Start:      point.PeriodStart.Format(domain.DateLayout),
+			Open:       point.Open,
+			Min:        point.Min,
+			Max:        point.Max,
+			Samples:    point.Samples,
+		}
+	}
+
+	return response
+}
+
+func toAcquisition(request dtos.RequestAcquisition) domain.Acquisition {
+	acquisition := domain.Acquisition{
+		AcquisitionPrice: request.AcquisitionPrice,
+	}
+
+	if len(request.AcquisitionCurrency) != 0 {
+		acquisition.AcquisitionCurrency = domain.NormalizeCurrency(request.AcquisitionCurrency)
+	}
+
+	if date, err := time.Parse(domain.DateLayout, request.AcquisitionDate); err == nil {
+		acquisition.AcquisitionDate = &date
+	}
+
+	return acquisition
+}
+
+func toResponseAcquisition(card domain.Cards) dtos.ResponseAcquisition {
+	acquisition := dtos.ResponseAcquisition{
+		AcquisitionPrice: card.AcquisitionPrice,
+	}
+
+	if card.AcquisitionPrice != nil {
+		acquisition.AcquisitionCurrency = card.AcquisitionCurrency
+	}
+
+	if card.AcquisitionDate != nil {
+		acquisition.AcquisitionDate = card.AcquisitionDate.Format(domain.DateLayout)
+	}
+
+	return acquisition
+}
+
+func toResponseInsertCard(card domain.Cards) dtos.ResponseInsertCard {
+	return dtos.ResponseInsertCard{
+		ID:                  card.ID,
+		Name:                card.Name,
+		Set:                 card.SetName,
+		CollectorNumber:     card.CollectorNumber,
+		Finish:              string(card.Finish),
+		Condition:           card.Condition,
+		Language:            card.Language,
+		Quantity:            card.Quantity,
+		CollectionID:        card.CollectionID,
+		ResponseAcquisition: toResponseAcquisition(card),
+	}
+}
+
+func toResponseCard(card domain.Cards) dtos.ResponseCard {
+	var lastUpdate time.Time
+	if card.LastUpdate != nil {
+		lastUpdate = *card.LastUpdate
+	}
+
+	response := dtos.ResponseCard{
+		ID:                  card.ID,
+		Name:                card.Name,
+		Set:                 card.SetName,
+		CollectorNumber:     card.CollectorNumber,
+		Finish:              string(card.Finish),
+		Condition:           card.Condition,
+		Language:            card.Language,
+		Quantity:            card.Quantity,
+		CollectionID:        card.CollectionID,
+		LastPrice:           card.LastPrice,
+		OldPrice:            card.OldPrice,
+		PriceChange:         card.PriceChange,
+		LastUpdate:          lastUpdate,
+		ResponseAcquisition: toResponseAcquisition(card),
+	}
+
+	if costBasis, ok := card.CostBasis(); ok {
+		response.CostBasis = &costBasis
+	}
+
+	if unrealizedGain, ok := card.UnrealizedGain(); ok {
+		response.UnrealizedGain = &unrealizedGain
+	}
+
+	return response
+}
@@ -6,6 +6,7 @@ import (
 	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
 	"mtg-report/mocks"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +14,11 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+const testUserID int64 = 7
+
+// userCtx carries the authenticated user every repository call is scoped to.
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
 func TestNew(t *testing.T) {
 	repoMock := mocks.NewCardsRepositoryMock()
 	logMock := mocks.NewLogMock()
@@ -24,6 +30,8 @@ func TestNew(t *testing.T) {
 }
 
 func TestService_InsertCard(t *testing.T) {
+	acquisitionDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name      string
 		request   dtos.RequestInsertCard
@@ -37,30 +45,107 @@ func TestService_InsertCard(t *testing.T) {
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
 				expectedCard := domain.Cards{
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
+					Condition:       "NM",
+					Language:        "en",
+					Quantity:        1,
+					CollectionID:    0,
+					Acquisition:     domain.Acquisition{AcquisitionCurrency: "BRL"},
 				}
 				returnCard := domain.Cards{
 					ID:              1,
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
+					Condition:       "NM",
+					Language:        "en",
+					Quantity:        1,
 				}
-				repoMock.On("InsertCard", mock.Anything, expectedCard).Return(returnCard, nil)
+				repoMock.On("InsertCard", mock.Anything, testUserID, expectedCard).Return(returnCard, nil)
 			},
 			want: dtos.ResponseInsertCard{
 				ID:              1,
 				Name:            "Lightning Bolt",
 				Set:             "M21",
 				CollectorNumber: "123",
-				Foil:            true,
+				Finish:          "foil",
+				Condition:       "NM",
+				Language:        "en",
+				Quantity:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should insert card with explicit quantity",
+			request: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Condition:       "lp",
+				Language:        "PT",
+				Quantity:        int64Ptr(4),
+				RequestAcquisition: dtos.RequestAcquisition{
+					AcquisitionPrice:    float64Ptr(2.5),
+					AcquisitionCurrency: "usd",
+					AcquisitionDate:     "2026-01-15",
+				},
+			},
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				expectedCard := domain.Cards{
+					Name:            "Lightning Bolt",
+					SetName:         "M21",
+					CollectorNumber: "123",
+					Finish:          domain.FinishNonfoil,
+					Condition:       "LP",
+					Language:        "pt",
+					Quantity:        4,
+					CollectionID:    0,
+					Acquisition: domain.Acquisition{
+						AcquisitionPrice:    float64Ptr(2.5),
+						AcquisitionCurrency: "USD",
+						AcquisitionDate:     &acquisitionDate,
+					},
+				}
+				returnCard := domain.Cards{
+					ID:              1,
+					Name:            "Lightning Bolt",
+					SetName:         "M21",
+					CollectorNumber: "123",
+					Finish:          domain.FinishNonfoil,
+					Condition:       "LP",
+					Language:        "pt",
+					Quantity:        6,
+					Acquisition: domain.Acquisition{
+						AcquisitionPrice:    float64Ptr(2.5),
+						AcquisitionCurrency: "USD",
+						AcquisitionDate:     &acquisitionDate,
+					},
+				}
+				repoMock.On("InsertCard", mock.Anything, testUserID, expectedCard).Return(returnCard, nil)
+			},
+			want: dtos.ResponseInsertCard{
+				ID:              1,
+				Name:            "Lightning Bolt",
+				Set:             "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Condition:       "LP",
+				Language:        "pt",
+				Quantity:        6,
+				ResponseAcquisition: dtos.ResponseAcquisition{
+					AcquisitionPrice:    float64Ptr(2.5),
+					AcquisitionCurrency: "USD",
+					AcquisitionDate:     "2026-01-15",
+				},
 			},
 			wantErr: false,
 		},
@@ -70,16 +155,21 @@ func TestService_InsertCard(t *testing.T) {
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
 				expectedCard := domain.Cards{
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
+					Condition:       "NM",
+					Language:        "en",
+					Quantity:        1,
+					CollectionID:    0,
+					Acquisition:     domain.Acquisition{AcquisitionCurrency: "BRL"},
 				}
-				repoMock.On("InsertCard", mock.Anything, expectedCard).Return(domain.Cards{}, errors.New("repository error"))
+				repoMock.On("InsertCard", mock.Anything, testUserID, expectedCard).Return(domain.Cards{}, errors.New("repository error"))
 			},
 			want:    dtos.ResponseInsertCard{},
 			wantErr: true,
@@ -94,7 +184,7 @@ func TestService_InsertCard(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.InsertCard(context.Background(), tt.request)
+			got, err := service.InsertCard(userCtx, tt.request)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -128,7 +218,7 @@ func TestService_GetCardbyID(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   15.50,
 						OldPrice:    12.00,
@@ -136,14 +226,14 @@ func TestService_GetCardbyID(t *testing.T) {
 						LastUpdate:  &fixedTime,
 					},
 				}
-				repoMock.On("GetCardbyID", mock.Anything, "1").Return(returnCard, nil)
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(returnCard, nil)
 			},
 			want: dtos.ResponseCard{
 				ID:              1,
 				Name:            "Lightning Bolt",
 				Set:             "M21",
 				CollectorNumber: "123",
-				Foil:            true,
+				Finish:          "foil",
 				LastPrice:       15.50,
 				OldPrice:        12.00,
 				PriceChange:     3.50,
@@ -160,7 +250,7 @@ func TestService_GetCardbyID(t *testing.T) {
 					Name:            "Lightning Bolt",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 					CardsDetails: domain.CardsDetails{
 						LastPrice:   15.50,
 						OldPrice:    12.00,
@@ -168,14 +258,14 @@ func TestService_GetCardbyID(t *testing.T) {
 						LastUpdate:  nil,
 					},
 				}
-				repoMock.On("GetCardbyID", mock.Anything, "1").Return(returnCard, nil)
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(returnCard, nil)
 			},
 			want: dtos.ResponseCard{
 				ID:              1,
 				Name:            "Lightning Bolt",
 				Set:             "M21",
 				CollectorNumber: "123",
-				Foil:            true,
+				Finish:          "foil",
 				LastPrice:       15.50,
 				OldPrice:        12.00,
 				PriceChange:     3.50,
@@ -187,7 +277,7 @@ func TestService_GetCardbyID(t *testing.T) {
 			name: "should return error when repository fails",
 			id:   "1",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardbyID", mock.Anything, "1").Return(domain.Cards{}, errors.New("repository error"))
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(domain.Cards{}, errors.New("repository error"))
 			},
 			want:    dtos.ResponseCard{},
 			wantErr: true,
@@ -202,7 +292,7 @@ func TestService_GetCardbyID(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.GetCardbyID(context.Background(), tt.id)
+			got, err := service.GetCardbyID(userCtx, tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -237,7 +327,7 @@ func TestService_GetCards(t *testing.T) {
 						Name:            "Lightning Bolt",
 						SetName:         "M21",
 						CollectorNumber: "123",
-						Foil:            true,
+						Finish:          domain.FinishFoil,
 						CardsDetails: domain.CardsDetails{
 							LastPrice:   15.50,
 							OldPrice:    12.00,
@@ -250,7 +340,7 @@ func TestService_GetCards(t *testing.T) {
 						Name:            "Counterspell",
 						SetName:         "M21",
 						CollectorNumber: "456",
-						Foil:            false,
+						Finish:          domain.FinishNonfoil,
 						CardsDetails: domain.CardsDetails{
 							LastPrice:   8.00,
 							OldPrice:    10.00,
@@ -259,7 +349,7 @@ func TestService_GetCards(t *testing.T) {
 						},
 					},
 				}
-				repoMock.On("GetCards", mock.Anything, map[string]string{"set_name": "M21"}).Return(returnCards, nil)
+				repoMock.On("GetCards", mock.Anything, testUserID, map[string]string{"set_name": "M21"}).Return(returnCards, nil)
 			},
 			want: []dtos.ResponseCard{
 				{
@@ -267,7 +357,7 @@ func TestService_GetCards(t *testing.T) {
 					Name:            "Lightning Bolt",
 					Set:             "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       15.50,
 					OldPrice:        12.00,
 					PriceChange:     3.50,
@@ -278,7 +368,7 @@ func TestService_GetCards(t *testing.T) {
 					Name:            "Counterspell",
 					Set:             "M21",
 					CollectorNumber: "456",
-					Foil:            false,
+					Finish:          "nonfoil",
 					LastPrice:       8.00,
 					OldPrice:        10.00,
 					PriceChange:     -2.00,
@@ -291,7 +381,7 @@ func TestService_GetCards(t *testing.T) {
 			name:    "should return empty slice when no cards found",
 			filters: map[string]string{"set_name": "UNKNOWN"},
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCards", mock.Anything, map[string]string{"set_name": "UNKNOWN"}).Return([]domain.Cards{}, nil)
+				repoMock.On("GetCards", mock.Anything, testUserID, map[string]string{"set_name": "UNKNOWN"}).Return([]domain.Cards{}, nil)
 			},
 			want:    []dtos.ResponseCard{},
 			wantErr: false,
@@ -300,7 +390,7 @@ func TestService_GetCards(t *testing.T) {
 			name:    "should return error when repository fails",
 			filters: map[string]string{"set_name": "M21"},
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCards", mock.Anything, map[string]string{"set_name": "M21"}).Return(nil, errors.New("repository error"))
+				repoMock.On("GetCards", mock.Anything, testUserID, map[string]string{"set_name": "M21"}).Return(nil, errors.New("repository error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -315,7 +405,7 @@ func TestService_GetCards(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.GetCards(context.Background(), tt.filters)
+			got, err := service.GetCards(userCtx, tt.filters)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -354,16 +444,47 @@ func TestService_UpdateCard(t *testing.T) {
 					Name:            "Lightning Bolt Updated",
 					SetName:         "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          domain.FinishFoil,
 				}
-				repoMock.On("UpdateCard", mock.Anything, expectedUpdateCard).Return(returnCard, nil)
+				repoMock.On("UpdateCard", mock.Anything, testUserID, expectedUpdateCard).Return(returnCard, nil)
 			},
 			want: dtos.ResponseInsertCard{
 				ID:              1,
 				Name:            "Lightning Bolt Updated",
 				Set:             "M21",
 				CollectorNumber: "123",
-				Foil:            true,
+				Finish:          "foil",
+			},
+			wantErr: false,
+		},
+		{
+			name: "should update card quantity",
+			request: dtos.RequestUpdateCard{
+				ID:       "1",
+				Quantity: int64Ptr(3),
+			},
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				expectedUpdateCard := domain.UpdateCard{
+					ID:       1,
+					Quantity: 3,
+				}
+				returnCard := domain.Cards{
+					ID:              1,
+					Name:            "Lightning Bolt",
+					SetName:         "M21",
+					CollectorNumber: "123",
+					Finish:          domain.FinishFoil,
+					Quantity:        3,
+				}
+				repoMock.On("UpdateCard", mock.Anything, testUserID, expectedUpdateCard).Return(returnCard, nil)
+			},
+			want: dtos.ResponseInsertCard{
+				ID:              1,
+				Name:            "Lightning Bolt",
+				Set:             "M21",
+				CollectorNumber: "123",
+				Finish:          "foil",
+				Quantity:        3,
 			},
 			wantErr: false,
 		},
@@ -390,7 +511,7 @@ func TestService_UpdateCard(t *testing.T) {
 					ID:   1,
 					Name: "Lightning Bolt Updated",
 				}
-				repoMock.On("UpdateCard", mock.Anything, expectedUpdateCard).Return(domain.Cards{}, errors.New("repository error"))
+				repoMock.On("UpdateCard", mock.Anything, testUserID, expectedUpdateCard).Return(domain.Cards{}, errors.New("repository error"))
 			},
 			want:    dtos.ResponseInsertCard{},
 			wantErr: true,
@@ -405,7 +526,7 @@ func TestService_UpdateCard(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.UpdateCard(context.Background(), tt.request)
+			got, err := service.UpdateCard(userCtx, tt.request)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -435,7 +556,7 @@ func TestService_DeleteCard(t *testing.T) {
 			name: "should delete card successfully",
 			id:   "1",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("DeleteCard", mock.Anything, "1").Return(nil)
+				repoMock.On("DeleteCard", mock.Anything, testUserID, "1").Return(nil)
 			},
 			wantErr: false,
 		},
@@ -443,7 +564,7 @@ func TestService_DeleteCard(t *testing.T) {
 			name: "should return error when repository fails",
 			id:   "1",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("DeleteCard", mock.Anything, "1").Return(errors.New("repository error"))
+				repoMock.On("DeleteCard", mock.Anything, testUserID, "1").Return(errors.New("repository error"))
 			},
 			wantErr: true,
 		},
@@ -457,7 +578,7 @@ func TestService_DeleteCard(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			err := service.DeleteCard(context.Background(), tt.id)
+			err := service.DeleteCard(userCtx, tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -491,7 +612,7 @@ func TestService_GetCardHistory(t *testing.T) {
 						Name:            "Lightning Bolt",
 						SetName:         "M21",
 						CollectorNumber: "123",
-						Foil:            true,
+						Finish:          domain.FinishFoil,
 						CardsDetails: domain.CardsDetails{
 							LastPrice:   15.50,
 							OldPrice:    12.00,
@@ -504,7 +625,7 @@ func TestService_GetCardHistory(t *testing.T) {
 						Name:            "Lightning Bolt",
 						SetName:         "M21",
 						CollectorNumber: "123",
-						Foil:            true,
+						Finish:          domain.FinishFoil,
 						CardsDetails: domain.CardsDetails{
 							LastPrice:   12.00,
 							OldPrice:    10.00,
@@ -513,7 +634,7 @@ func TestService_GetCardHistory(t *testing.T) {
 						},
 					},
 				}
-				repoMock.On("GetCardHistory", mock.Anything, "1").Return(returnCards, nil)
+				repoMock.On("GetCardHistory", mock.Anything, testUserID, "1").Return(returnCards, nil)
 			},
 			want: []dtos.ResponseCard{
 				{
@@ -521,7 +642,7 @@ func TestService_GetCardHistory(t *testing.T) {
 					Name:            "Lightning Bolt",
 					Set:             "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       15.50,
 					OldPrice:        12.00,
 					PriceChange:     3.50,
@@ -532,7 +653,7 @@ func TestService_GetCardHistory(t *testing.T) {
 					Name:            "Lightning Bolt",
 					Set:             "M21",
 					CollectorNumber: "123",
-					Foil:            true,
+					Finish:          "foil",
 					LastPrice:       12.00,
 					OldPrice:        10.00,
 					PriceChange:     2.00,
@@ -545,7 +666,7 @@ func TestService_GetCardHistory(t *testing.T) {
 			name: "should return empty slice when no history found",
 			id:   "999",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardHistory", mock.Anything, "999").Return([]domain.Cards{}, nil)
+				repoMock.On("GetCardHistory", mock.Anything, testUserID, "999").Return([]domain.Cards{}, nil)
 			},
 			want:    []dtos.ResponseCard{},
 			wantErr: false,
@@ -554,7 +675,7 @@ func TestService_GetCardHistory(t *testing.T) {
 			name: "should return error when repository fails",
 			id:   "1",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardHistory", mock.Anything, "1").Return(nil, errors.New("repository error"))
+				repoMock.On("GetCardHistory", mock.Anything, testUserID, "1").Return(nil, errors.New("repository error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -569,7 +690,7 @@ func TestService_GetCardHistory(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.GetCardHistory(context.Background(), tt.id)
+			got, err := service.GetCardHistory(userCtx, tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -589,8 +710,25 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// Helper function to create float64 pointers
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+// Helper function to create int64 pointers
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func TestService_GetCardHistoryPaginated(t *testing.T) {
 	fixedTime := time.Date(2023, 12, 25, 10, 30, 0, 0, time.UTC)
+	card := domain.Cards{
+		ID:              1,
+		Name:            "Lightning Bolt",
+		SetName:         "M21",
+		CollectorNumber: "123",
+		Finish:          domain.FinishFoil,
+	}
 
 	tests := []struct {
 		name      string
@@ -607,23 +745,12 @@ func TestService_GetCardHistoryPaginated(t *testing.T) {
 			page:  1,
 			limit: 10,
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardHistoryCount", mock.Anything, "1").Return(int64(2), nil)
-				returnCards := []domain.Cards{
-					{
-						ID:              1,
-						Name:            "Lightning Bolt",
-						SetName:         "M21",
-						CollectorNumber: "123",
-						Foil:            true,
-						CardsDetails: domain.CardsDetails{
-							LastPrice:   15.50,
-							OldPrice:    12.00,
-							PriceChange: 3.50,
-							LastUpdate:  &fixedTime,
-						},
-					},
-				}
-				repoMock.On("GetCardHistoryPaginated", mock.Anything, "1", 0, 10).Return(returnCards, nil)
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(card, nil)
+				repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(2), nil)
+				repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionDaily, 0, 10).Return([]domain.PricePoint{
+					domain.Snapshot(15.50, 12.00, fixedTime),
+					domain.Snapshot(12.00, 10.00, fixedTime.AddDate(0, 0, -1)),
+				}, nil)
 			},
 			want: dtos.ResponsePaginatedCards{
 				Cards: []dtos.ResponseCard{
@@ -632,39 +759,84 @@ func TestService_GetCardHistoryPaginated(t *testing.T) {
 						Name:            "Lightning Bolt",
 						Set:             "M21",
 						CollectorNumber: "123",
-						Foil:            true,
+						Finish:          "foil",
 						LastPrice:       15.50,
 						OldPrice:        12.00,
 						PriceChange:     3.50,
 						LastUpdate:      fixedTime,
 					},
+					{
+						ID:              1,
+						Name:            "Lightning Bolt",
+						Set:             "M21",
+						CollectorNumber: "123",
+						Finish:          "foil",
+						LastPrice:       12.00,
+						OldPrice:        10.00,
+						PriceChange:     2.00,
+						LastUpdate:      fixedTime.AddDate(0, 0, -1),
+					},
 				},
 				Page:       1,
 				Limit:      10,
 				Total:      2,
 				TotalPages: 1,
+				Currency:   "BRL",
 			},
 			wantErr: false,
 		},
 		{
-			name:  "should return error when repository fails to get count",
+			name:  "should return an empty page past the history",
+			id:    "1",
+			page:  3,
+			limit: 1,
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(card, nil)
+				repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(2), nil)
+				repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionDaily, 2, 1).Return([]domain.PricePoint{}, nil)
+			},
+			want: dtos.ResponsePaginatedCards{
+				Cards:      []dtos.ResponseCard{},
+				Page:       3,
+				Limit:      1,
+				Total:      2,
+				TotalPages: 2,
+				Currency:   "BRL",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "should return error when the card is not found",
+			id:    "1",
+			page:  1,
+			limit: 10,
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(domain.Cards{}, domain.ErrCardNotFound{})
+			},
+			want:    dtos.ResponsePaginatedCards{},
+			wantErr: true,
+		},
+		{
+			name:  "should return error when repository fails to get the price history",
 			id:    "1",
 			page:  1,
 			limit: 10,
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardHistoryCount", mock.Anything, "1").Return(int64(0), errors.New("repository error"))
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(card, nil)
+				repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(2), nil)
+				repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionDaily, 0, 10).Return(nil, errors.New("repository error"))
 			},
 			want:    dtos.ResponsePaginatedCards{},
 			wantErr: true,
 		},
 		{
-			name:  "should return error when repository fails to get paginated history",
+			name:  "should return error when repository fails to count the price history",
 			id:    "1",
 			page:  1,
 			limit: 10,
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCardHistoryCount", mock.Anything, "1").Return(int64(2), nil)
-				repoMock.On("GetCardHistoryPaginated", mock.Anything, "1", 0, 10).Return(nil, errors.New("repository error"))
+				repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(card, nil)
+				repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(0), errors.New("repository error"))
 			},
 			want:    dtos.ResponsePaginatedCards{},
 			wantErr: true,
@@ -679,7 +851,7 @@ func TestService_GetCardHistoryPaginated(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.GetCardHistoryPaginated(context.Background(), tt.id, tt.page, tt.limit)
+			got, err := service.GetCardHistoryPaginated(userCtx, tt.id, "", domain.PriceResolutionDaily, tt.page, tt.limit)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -694,6 +866,66 @@ func TestService_GetCardHistoryPaginated(t *testing.T) {
 	}
 }
 
+func TestService_GetCardHistoryPaginated_Resolution(t *testing.T) {
+	monday := time.Date(2026, 1, 12, 18, 0, 0, 0, time.UTC)
+	monthly := domain.PricePoint{
+		Resolution:  domain.PriceResolutionMonthly,
+		PeriodStart: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		OldPrice:    5,
+		Open:        6,
+		Close:       7,
+		Min:         4,
+		Max:         8,
+		OpenedAt:    time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC),
+		ClosedAt:    time.Date(2025, 6, 30, 18, 0, 0, 0, time.UTC),
+		Samples:     29,
+	}
+
+	repoMock := mocks.NewCardsRepositoryMock()
+	repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(domain.Cards{ID: 1, Quantity: 1}, nil)
+	repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionWeekly).Return(int64(2), nil)
+	repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionWeekly, 0, 10).Return([]domain.PricePoint{
+		{
+			Resolution:  domain.PriceResolutionWeekly,
+			PeriodStart: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+			OldPrice:    8,
+			Open:        10,
+			Close:       9,
+			Min:         9,
+			Max:         14,
+			OpenedAt:    monday,
+			ClosedAt:    monday.AddDate(0, 0, 2),
+			Samples:     3,
+		},
+		monthly,
+	}, nil)
+
+	service := New(repoMock, 100, mocks.NewLogMock())
+	got, err := service.GetCardHistoryPaginated(userCtx, "1", "", domain.PriceResolutionWeekly, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got.Total)
+	assert.Equal(t, dtos.ResponseCard{
+		ID:          1,
+		Quantity:    1,
+		LastPrice:   9,
+		OldPrice:    8,
+		PriceChange: 1,
+		LastUpdate:  monday.AddDate(0, 0, 2),
+		Period:      &dtos.ResponsePricePeriod{Resolution: "weekly", Start: "2026-01-12", Open: 10, Min: 9, Max: 14, Samples: 3},
+	}, got.Cards[0])
+	assert.Equal(t, dtos.ResponseCard{
+		ID:          1,
+		Quantity:    1,
+		LastPrice:   7,
+		OldPrice:    5,
+		PriceChange: 2,
+		LastUpdate:  monthly.ClosedAt,
+		Period:      &dtos.ResponsePricePeriod{Resolution: "monthly", Start: "2025-06-01", Open: 6, Min: 4, Max: 8, Samples: 29},
+	}, got.Cards[1])
+	repoMock.AssertExpectations(t)
+}
+
 func TestService_GetCollectionStats(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -710,20 +942,21 @@ func TestService_GetCollectionStats(t *testing.T) {
 					UniqueSets: 10,
 					TotalValue: 1500.50,
 				}
-				repoMock.On("GetCollectionStats", mock.Anything).Return(stats, nil)
+				repoMock.On("GetCollectionStats", mock.Anything, testUserID, int64(0)).Return(stats, nil)
 			},
 			want: dtos.ResponseCollectionStats{
 				TotalCards: 100,
 				FoilCards:  25,
 				UniqueSets: 10,
 				TotalValue: 1500.50,
+				Currency:   "BRL",
 			},
 			wantErr: false,
 		},
 		{
 			name: "should return error when repository fails",
 			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
-				repoMock.On("GetCollectionStats", mock.Anything).Return(domain.CollectionStats{}, errors.New("repository error"))
+				repoMock.On("GetCollectionStats", mock.Anything, testUserID, int64(0)).Return(domain.CollectionStats{}, errors.New("repository error"))
 			},
 			want:    dtos.ResponseCollectionStats{},
 			wantErr: true,
@@ -738,7 +971,7 @@ func TestService_GetCollectionStats(t *testing.T) {
 			tt.setupMock(repoMock)
 
 			service := New(repoMock, 100, logMock)
-			got, err := service.GetCollectionStats(context.Background())
+			got, err := service.GetCollectionStats(userCtx, 0, "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -752,3 +985,316 @@ func TestService_GetCollectionStats(t *testing.T) {
 		})
 	}
 }
+
+func TestService_GetCardHistoryPaginated_Currency(t *testing.T) {
+	firstUpdate := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	secondUpdate := time.Date(2026, 1, 20, 18, 0, 0, 0, time.UTC)
+	costPrice := 40.0
+	costRate := 1.0
+
+	repoMock := mocks.NewCardsRepositoryMock()
+	repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(domain.Cards{ID: 1, Quantity: 1,
+		Acquisition: domain.Acquisition{AcquisitionPrice: &costPrice, AcquisitionCurrency: domain.CurrencyBRL, AcquisitionRate: &costRate}}, nil)
+	repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(2), nil)
+	repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionDaily, 0, 10).Return([]domain.PricePoint{
+		domain.Snapshot(60, 50, secondUpdate),
+		domain.Snapshot(50, 0, firstUpdate),
+	}, nil)
+	repoMock.On("GetExchangeRates", mock.Anything, domain.CurrencyUSD).Return(domain.ExchangeRateHistory{
+		{Date: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 5},
+		{Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), Rate: 4},
+	}, nil)
+
+	service := New(repoMock, 100, mocks.NewLogMock())
+	got, err := service.GetCardHistoryPaginated(userCtx, "1", domain.CurrencyUSD, domain.PriceResolutionDaily, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", got.Currency)
+	assert.Equal(t, 15.0, got.Cards[0].LastPrice)
+	assert.Equal(t, 12.5, got.Cards[0].OldPrice)
+	assert.Equal(t, 2.5, got.Cards[0].PriceChange)
+	assert.Equal(t, 10.0, *got.Cards[0].CostBasis)
+	assert.Equal(t, 5.0, *got.Cards[0].UnrealizedGain)
+	assert.Equal(t, 40.0, *got.Cards[0].AcquisitionPrice)
+	assert.Equal(t, 10.0, got.Cards[1].LastPrice)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_GetCardHistoryPaginated_CurrencyOfPeriod(t *testing.T) {
+	weekly := domain.PricePoint{
+		Resolution:  domain.PriceResolutionWeekly,
+		PeriodStart: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		OldPrice:    40,
+		Open:        50,
+		Close:       60,
+		Min:         30,
+		Max:         70,
+		OpenedAt:    time.Date(2026, 1, 12, 18, 0, 0, 0, time.UTC),
+		ClosedAt:    time.Date(2026, 1, 18, 18, 0, 0, 0, time.UTC),
+		Samples:     7,
+	}
+
+	repoMock := mocks.NewCardsRepositoryMock()
+	repoMock.On("GetCardbyID", mock.Anything, testUserID, "1").Return(domain.Cards{ID: 1, Quantity: 1}, nil)
+	repoMock.On("GetCardPriceHistoryCount", mock.Anything, testUserID, "1", domain.PriceResolutionDaily).Return(int64(1), nil)
+	repoMock.On("GetCardPriceHistoryPaginated", mock.Anything, testUserID, "1", domain.PriceResolutionDaily, 0, 10).Return([]domain.PricePoint{weekly}, nil)
+	repoMock.On("GetExchangeRates", mock.Anything, domain.CurrencyUSD).Return(domain.ExchangeRateHistory{
+		{Date: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 5},
+	}, nil)
+
+	service := New(repoMock, 100, mocks.NewLogMock())
+	got, err := service.GetCardHistoryPaginated(userCtx, "1", domain.CurrencyUSD, domain.PriceResolutionDaily, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, got.Cards[0].LastPrice)
+	assert.Equal(t, &dtos.ResponsePricePeriod{Resolution: "weekly", Start: "2026-01-12", Open: 10, Min: 6, Max: 14, Samples: 7}, got.Cards[0].Period)
+}
+
+func TestService_GetCollectionStats_Currency(t *testing.T) {
+	stats := domain.CollectionStats{TotalCards: 3, TotalValue: 120, TotalCostBasis: 60, UnrealizedGain: 60}
+
+	t.Run("should convert at the latest rate", func(t *testing.T) {
+		repoMock := mocks.NewCardsRepositoryMock()
+		repoMock.On("GetCollectionStats", mock.Anything, testUserID, int64(0)).Return(stats, nil)
+		repoMock.On("GetExchangeRates", mock.Anything, domain.CurrencyEUR).Return(domain.ExchangeRateHistory{
+			{Date: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Rate: 5},
+			{Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), Rate: 6},
+		}, nil)
+
+		service := New(repoMock, 100, mocks.NewLogMock())
+		got, err := service.GetCollectionStats(userCtx, 0, domain.CurrencyEUR)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponseCollectionStats{TotalCards: 3, TotalValue: 20, TotalCostBasis: 10, UnrealizedGain: 10, Currency: "EUR"}, got)
+	})
+
+	t.Run("should fail without stored rates", func(t *testing.T) {
+		repoMock := mocks.NewCardsRepositoryMock()
+		repoMock.On("GetCollectionStats", mock.Anything, testUserID, int64(0)).Return(stats, nil)
+		repoMock.On("GetExchangeRates", mock.Anything, domain.CurrencyEUR).Return(domain.ExchangeRateHistory(nil), nil)
+
+		service := New(repoMock, 100, mocks.NewLogMock())
+		_, err := service.GetCollectionStats(userCtx, 0, domain.CurrencyEUR)
+
+		assert.ErrorIs(t, err, domain.ErrExchangeRateNotFound{})
+	})
+}
+
+type fileMock struct {
+	*strings.Reader
+}
+
+func (f fileMock) Close() error {
+	return nil
+}
+
+func TestService_InsertCards(t *testing.T) {
+	acquisitionDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	repoMock := mocks.NewCardsRepositoryMock()
+	logMock := mocks.NewLogMock()
+	customMock := mocks.NewCustomMock()
+
+	file := fileMock{strings.NewReader(
+		"name: Lightning Bolt, set_name: lea, collector_number: 161, finish: nonfoil\n" +
+			"name: Counterspell, set_name: lea, collector_number: 54, finish: foil, condition: LP, language: pt, quantity: 4, acquisition_price: 12.5, acquisition_currency: USD, acquisition_date: 2026-01-15\n" +
+			"invalid line\n")}
+
+	expectedCards := []domain.Cards{
+		{Name: "Lightning Bolt", SetName: "lea", CollectorNumber: "161", Finish: domain.FinishNonfoil, Condition: "NM", Language: "en", Quantity: 1, CollectionID: 0,
+			Acquisition: domain.Acquisition{AcquisitionCurrency: "BRL"}},
+		{Name: "Counterspell", SetName: "lea", CollectorNumber: "54", Finish: domain.FinishFoil, Condition: "LP", Language: "pt", Quantity: 4, CollectionID: 0,
+			Acquisition: domain.Acquisition{AcquisitionPrice: float64Ptr(12.5), AcquisitionCurrency: "USD", AcquisitionDate: &acquisitionDate}},
+	}
+
+	repoMock.On("InsertCards", mock.Anything, testUserID, expectedCards).Return(nil)
+	logMock.On("WithFields", mock.Anything).Return(customMock)
+	customMock.On("Warn", mock.Anything).Once()
+
+	service := New(repoMock, 100, logMock)
+	processed, notProcessed := service.InsertCards(userCtx, file, 0)
+
+	assert.Equal(t, int64(2), processed)
+	assert.Equal(t, int64(1), notProcessed)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_InsertCards_IntoCollection(t *testing.T) {
+	repoMock := mocks.NewCardsRepositoryMock()
+	logMock := mocks.NewLogMock()
+
+	file := fileMock{strings.NewReader(
+		"name: Lightning Bolt, set_name: lea, collector_number: 161, finish: nonfoil\n" +
+			"name: Counterspell, set_name: lea, collector_number: 54, finish: foil, collection_id: 5\n")}
+
+	repoMock.On("InsertCards", mock.Anything, testUserID, mock.MatchedBy(func(cards []domain.Cards) bool {
+		return len(cards) == 2 && cards[0].CollectionID == 3 && cards[1].CollectionID == 5
+	})).Return(nil)
+
+	service := New(repoMock, 100, logMock)
+	processed, notProcessed := service.InsertCards(userCtx, file, 3)
+
+	assert.Equal(t, int64(2), processed)
+	assert.Equal(t, int64(0), notProcessed)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_InsertCards_Finishes(t *testing.T) {
+	repoMock := mocks.NewCardsRepositoryMock()
+	logMock := mocks.NewLogMock()
+
+	file := fileMock{strings.NewReader(
+		"name: Sol Ring, set_name: cmm, collector_number: 464, finish: etched\n" +
+			"name: Counterspell, set_name: lea, collector_number: 54, foil: true\n" +
+			"name: Lightning Bolt, set_name: lea, collector_number: 161, foil: false\n")}
+
+	repoMock.On("InsertCards", mock.Anything, testUserID, mock.MatchedBy(func(cards []domain.Cards) bool {
+		return len(cards) == 3 && cards[0].Finish == domain.FinishEtched &&
+			cards[1].Finish == domain.FinishFoil && cards[2].Finish == domain.FinishNonfoil
+	})).Return(nil)
+
+	service := New(repoMock, 100, logMock)
+	processed, notProcessed := service.InsertCards(userCtx, file, 0)
+
+	assert.Equal(t, int64(3), processed)
+	assert.Equal(t, int64(0), notProcessed)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_SellCard(t *testing.T) {
+	saleDate := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	costBasis := 6.0
+
+	tests := []struct {
+		name      string
+		request   dtos.RequestSellCard
+		setupMock func(repoMock *mocks.CardsRepositoryMock)
+		want      dtos.ResponseSale
+		wantErr   string
+	}{
+		{
+			name:      "should return error when id is invalid",
+			request:   dtos.RequestSellCard{ID: "abc", SalePrice: float64Ptr(10)},
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {},
+			wantErr:   "service failed to parse id in sell card",
+		},
+		{
+			name:    "should sell one copy by default",
+			request: dtos.RequestSellCard{ID: "1", SalePrice: float64Ptr(10), Fees: 1, SaleDate: "2026-03-10"},
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				sale := domain.Sale{CardID: 1, Quantity: 1, SalePrice: 10, Fees: 1, SaleDate: saleDate}
+				sold := sale
+				sold.ID = 7
+				sold.CostBasis = &costBasis
+				sold.RemainingQuantity = 2
+				repoMock.On("SellCard", mock.Anything, testUserID, sale).Return(sold, nil)
+			},
+			want: dtos.ResponseSale{
+				ID:                7,
+				CardID:            1,
+				Quantity:          1,
+				SalePrice:         10,
+				Fees:              1,
+				SaleDate:          "2026-03-10",
+				CostBasis:         &costBasis,
+				RealizedGain:      3,
+				RemainingQuantity: 2,
+			},
+		},
+		{
+			name:    "should return error when repository fails",
+			request: dtos.RequestSellCard{ID: "1", Quantity: int64Ptr(5), SalePrice: float64Ptr(10), SaleDate: "2026-03-10"},
+			setupMock: func(repoMock *mocks.CardsRepositoryMock) {
+				sale := domain.Sale{CardID: 1, Quantity: 5, SalePrice: 10, SaleDate: saleDate}
+				repoMock.On("SellCard", mock.Anything, testUserID, sale).Return(domain.Sale{}, domain.ErrNotEnoughCopies{})
+			},
+			wantErr: "service failed to sell card",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewCardsRepositoryMock()
+			logMock := mocks.NewLogMock()
+
+			tt.setupMock(repoMock)
+
+			service := New(repoMock, 100, logMock)
+			got, err := service.SellCard(userCtx, tt.request)
+
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetRealizedGains(t *testing.T) {
+	t.Run("should fill every month of the year", func(t *testing.T) {
+		repoMock := mocks.NewCardsRepositoryMock()
+		logMock := mocks.NewLogMock()
+
+		repoMock.On("GetRealizedGains", mock.Anything, testUserID, 2026).Return([]domain.MonthlyRealizedGain{
+			{Month: time.February, CopiesSold: 2, GrossProceeds: 30, Fees: 3, CostBasis: 20},
+			{Month: time.November, CopiesSold: 1, GrossProceeds: 10, CostBasis: 15},
+		}, nil)
+
+		service := New(repoMock, 100, logMock)
+		got, err := service.GetRealizedGains(userCtx, 2026)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2026, got.Year)
+		assert.Len(t, got.Months, 12)
+		assert.Equal(t, dtos.ResponseMonthlyRealizedGain{Month: "2026-01"}, got.Months[0])
+		assert.Equal(t, dtos.ResponseMonthlyRealizedGain{
+			Month: "2026-02", CopiesSold: 2, GrossProceeds: 30, Fees: 3, CostBasis: 20, RealizedGain: 7,
+		}, got.Months[1])
+		assert.Equal(t, -5.0, got.Months[10].RealizedGain)
+		assert.Equal(t, 40.0, got.TotalGrossProceeds)
+		assert.Equal(t, 3.0, got.TotalFees)
+		assert.Equal(t, 35.0, got.TotalCostBasis)
+		assert.Equal(t, 2.0, got.TotalRealizedGain)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should report sales without a known cost basis apart from the gain", func(t *testing.T) {
+		repoMock := mocks.NewCardsRepositoryMock()
+		logMock := mocks.NewLogMock()
+
+		repoMock.On("GetRealizedGains", mock.Anything, testUserID, 2026).Return([]domain.MonthlyRealizedGain{
+			{Month: time.March, CopiesSold: 3, GrossProceeds: 50, Fees: 5, CostBasis: 20, UnknownCostCopies: 1, UnknownCostProceeds: 18},
+		}, nil)
+
+		service := New(repoMock, 100, logMock)
+		got, err := service.GetRealizedGains(userCtx, 2026)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponseMonthlyRealizedGain{
+			Month: "2026-03", CopiesSold: 3, GrossProceeds: 50, Fees: 5, CostBasis: 20, RealizedGain: 7,
+			UnknownCostCopies: 1, UnknownCostProceeds: 18,
+		}, got.Months[2])
+		assert.Equal(t, 7.0, got.TotalRealizedGain)
+		assert.Equal(t, int64(1), got.TotalUnknownCostCopies)
+		assert.Equal(t, 18.0, got.TotalUnknownCostProceeds)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		repoMock := mocks.NewCardsRepositoryMock()
+		logMock := mocks.NewLogMock()
+
+		repoMock.On("GetRealizedGains", mock.Anything, testUserID, 2026).Return([]domain.MonthlyRealizedGain(nil), errors.New("repository error"))
+
+		service := New(repoMock, 100, logMock)
+		_, err := service.GetRealizedGains(userCtx, 2026)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "service failed to get realized gains")
+		repoMock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,202 @@
+package collectionservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"strconv"
+	"strings"
+)
+
+type service struct {
+	collectionsRepository ports.CollectionsRepository
+	log                   logrus.Logger
+}
+
+func New(cr ports.CollectionsRepository, log logrus.Logger) *service {
+	return &service{
+		collectionsRepository: cr,
+		log:                   log,
+	}
+}
+
+func (s *service) InsertCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	collection := domain.Collection{
+		Name: collectionRequest.Name,
+	}
+
+	if collectionRequest.Description != nil {
+		collection.Description = *collectionRequest.Description
+	}
+
+	collection, err := s.collectionsRepository.InsertCollection(ctx, userID, collection)
+	if err != nil {
+		return dtos.ResponseCollection{}, fmt.Errorf("service failed to insert collection: %w", err)
+	}
+
+	return toResponseCollection(collection), nil
+}
+
+func (s *service) GetCollections(ctx context.Context) ([]dtos.ResponseCollection, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	collectionsDomain, err := s.collectionsRepository.GetCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get collections: %w", err)
+	}
+
+	collections := make([]dtos.ResponseCollection, 0, len(collectionsDomain))
+	for _, collection := range collectionsDomain {
+		collections = append(collections, toResponseCollection(collection))
+	}
+
+	return collections, nil
+}
+
+func (s *service) GetCollectionByID(ctx context.Context, id string) (dtos.ResponseCollection, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	collection, err := s.collectionsRepository.GetCollectionByID(ctx, userID, id)
+	if err != nil {
+		return dtos.ResponseCollection{}, fmt.Errorf("service failed to get collection: %w", err)
+	}
+
+	return toResponseCollection(collection), nil
+}
+
+func (s *service) UpdateCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	id, err := strconv.ParseInt(collectionRequest.ID, 10, 64)
+	if err != nil {
+		return dtos.ResponseCollection{}, fmt.Errorf("service failed to parse id in update collection: %w", err)
+	}
+
+	collection, err := s.collectionsRepository.UpdateCollection(ctx, userID, domain.UpdateCollection{
+		ID:          id,
+		Name:        collectionRequest.Name,
+		Description: collectionRequest.Description,
+	})
+	if err != nil {
+		return dtos.ResponseCollection{}, fmt.Errorf("service failed to update collection: %w", err)
+	}
+
+	return toResponseCollection(collection), nil
+}
+
+func (s *service) DeleteCollection(ctx context.Context, id string) error {
+	userID := domain.UserFromContext(ctx).ID
+
+	err := s.collectionsRepository.DeleteCollection(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("service failed to delete collection: %w", err)
+	}
+
+	return nil
+}
+
+// GetRole returns the role of the user on the collection. The id 0 stands
+// for the user's default collection, which the user always owns.
+func (s *service) GetRole(ctx context.Context, collectionID int64) (domain.Role, error) {
+	if collectionID == 0 {
+		return domain.RoleOwner, nil
+	}
+
+	userID := domain.UserFromContext(ctx).ID
+
+	role, err := s.collectionsRepository.GetRole(ctx, userID, collectionID)
+	if err != nil {
+		return "", fmt.Errorf("service failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *service) GetCardRole(ctx context.Context, cardID string) (domain.Role, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	role, err := s.collectionsRepository.GetCardRole(ctx, userID, cardID)
+	if err != nil {
+		return "", fmt.Errorf("service failed to get card role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *service) GetMembers(ctx context.Context, collectionID string) ([]dtos.ResponseMember, error) {
+	id, err := strconv.ParseInt(collectionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to parse id in get members: %w", err)
+	}
+
+	membersDomain, err := s.collectionsRepository.GetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get members: %w", err)
+	}
+
+	members := make([]dtos.ResponseMember, 0, len(membersDomain))
+	for _, member := range membersDomain {
+		members = append(members, toResponseMember(member))
+	}
+
+	return members, nil
+}
+
+func (s *service) InsertMember(ctx context.Context, memberRequest dtos.RequestMember) (dtos.ResponseMember, error) {
+	id, err := strconv.ParseInt(memberRequest.CollectionID, 10, 64)
+	if err != nil {
+		return dtos.ResponseMember{}, fmt.Errorf("service failed to parse id in insert member: %w", err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(memberRequest.Email))
+
+	member, err := s.collectionsRepository.InsertMember(ctx, id, email, domain.Role(memberRequest.Role))
+	if err != nil {
+		return dtos.ResponseMember{}, fmt.Errorf("service failed to insert member: %w", err)
+	}
+
+	return toResponseMember(member), nil
+}
+
+func (s *service) DeleteMember(ctx context.Context, collectionID, userID string) error {
+	id, err := strconv.ParseInt(collectionID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("service failed to parse id in delete member: %w", err)
+	}
+
+	memberID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("service failed to parse user id in delete member: %w", err)
+	}
+
+	if err := s.collectionsRepository.DeleteMember(ctx, id, memberID); err != nil {
+		return fmt.Errorf("service failed to delete member: %w", err)
+	}
+
+	return nil
+}
+
+func toResponseMember(member domain.Member) dtos.ResponseMember {
+	return dtos.ResponseMember{
+		UserID: member.UserID,
+		Name:   member.Name,
+		Email:  member.Email,
+		Role:   string(member.Role),
+	}
+}
+
+func toResponseCollection(collection domain.Collection) dtos.ResponseCollection {
+	return dtos.ResponseCollection{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		IsDefault:   collection.IsDefault,
+		TotalCards:  collection.TotalCards,
+		Role:        string(collection.Role),
+	}
+}
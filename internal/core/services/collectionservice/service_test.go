@@ -0,0 +1,258 @@
+package collectionservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+// userCtx carries the authenticated user every repository call is scoped to.
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestNew(t *testing.T) {
+	repoMock := mocks.NewCollectionsRepositoryMock()
+	logMock := mocks.NewLogMock()
+
+	service := New(repoMock, logMock)
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   dtos.RequestCollection
+		setupMock func(repoMock *mocks.CollectionsRepositoryMock)
+		want      dtos.ResponseCollection
+		wantErr   bool
+	}{
+		{
+			name:    "should insert collection successfully",
+			request: dtos.RequestCollection{Name: "Trade Binder", Description: strPtr("cards for trade")},
+			setupMock: func(repoMock *mocks.CollectionsRepositoryMock) {
+				repoMock.On("InsertCollection", mock.Anything, testUserID, domain.Collection{Name: "Trade Binder", Description: "cards for trade"}).
+					Return(domain.Collection{ID: 2, Name: "Trade Binder", Description: "cards for trade"}, nil)
+			},
+			want: dtos.ResponseCollection{ID: 2, Name: "Trade Binder", Description: "cards for trade"},
+		},
+		{
+			name:    "should return error when repository fails",
+			request: dtos.RequestCollection{Name: "Trade Binder"},
+			setupMock: func(repoMock *mocks.CollectionsRepositoryMock) {
+				repoMock.On("InsertCollection", mock.Anything, testUserID, domain.Collection{Name: "Trade Binder"}).
+					Return(domain.Collection{}, domain.ErrCollectionAlreadyExists{})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewCollectionsRepositoryMock()
+			tt.setupMock(repoMock)
+
+			service := New(repoMock, mocks.NewLogMock())
+			got, err := service.InsertCollection(userCtx, tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetCollections(t *testing.T) {
+	repoMock := mocks.NewCollectionsRepositoryMock()
+	repoMock.On("GetCollections", mock.Anything, testUserID).Return([]domain.Collection{
+		{ID: 1, Name: "Default", IsDefault: true, TotalCards: 10},
+		{ID: 2, Name: "Trade Binder", Description: "cards for trade", TotalCards: 3},
+	}, nil)
+
+	service := New(repoMock, mocks.NewLogMock())
+	got, err := service.GetCollections(userCtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dtos.ResponseCollection{
+		{ID: 1, Name: "Default", IsDefault: true, TotalCards: 10},
+		{ID: 2, Name: "Trade Binder", Description: "cards for trade", TotalCards: 3},
+	}, got)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_UpdateCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   dtos.RequestCollection
+		setupMock func(repoMock *mocks.CollectionsRepositoryMock)
+		want      dtos.ResponseCollection
+		wantErr   string
+	}{
+		{
+			name:      "should return error when id is invalid",
+			request:   dtos.RequestCollection{ID: "abc", Name: "Commander"},
+			setupMock: func(repoMock *mocks.CollectionsRepositoryMock) {},
+			wantErr:   "service failed to parse id in update collection",
+		},
+		{
+			name:    "should update collection successfully",
+			request: dtos.RequestCollection{ID: "2", Description: strPtr("decks")},
+			setupMock: func(repoMock *mocks.CollectionsRepositoryMock) {
+				repoMock.On("UpdateCollection", mock.Anything, testUserID, domain.UpdateCollection{ID: 2, Description: strPtr("decks")}).
+					Return(domain.Collection{ID: 2, Name: "Commander", Description: "decks"}, nil)
+			},
+			want: dtos.ResponseCollection{ID: 2, Name: "Commander", Description: "decks"},
+		},
+		{
+			name:    "should return error when repository fails",
+			request: dtos.RequestCollection{ID: "2", Name: "Commander"},
+			setupMock: func(repoMock *mocks.CollectionsRepositoryMock) {
+				repoMock.On("UpdateCollection", mock.Anything, testUserID, domain.UpdateCollection{ID: 2, Name: "Commander"}).
+					Return(domain.Collection{}, errors.New("repository error"))
+			},
+			wantErr: "service failed to update collection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock := mocks.NewCollectionsRepositoryMock()
+			tt.setupMock(repoMock)
+
+			service := New(repoMock, mocks.NewLogMock())
+			got, err := service.UpdateCollection(userCtx, tt.request)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			repoMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_DeleteCollection(t *testing.T) {
+	t.Run("should refuse to delete the default collection", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("DeleteCollection", mock.Anything, testUserID, "1").Return(domain.ErrDefaultCollection{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		err := service.DeleteCollection(userCtx, "1")
+
+		assert.ErrorIs(t, err, domain.ErrDefaultCollection{})
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("DeleteCollection", mock.Anything, testUserID, "2").Return(domain.ErrCollectionNotEmpty{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		err := service.DeleteCollection(userCtx, "2")
+
+		assert.ErrorIs(t, err, domain.ErrCollectionNotEmpty{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_GetRole(t *testing.T) {
+	t.Run("should own the default collection without asking the repository", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+
+		service := New(repoMock, mocks.NewLogMock())
+		role, err := service.GetRole(userCtx, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleOwner, role)
+		repoMock.AssertNotCalled(t, "GetRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return the role of the user on the collection", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("GetRole", mock.Anything, testUserID, int64(3)).Return(domain.RoleReader, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		role, err := service.GetRole(userCtx, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleReader, role)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("GetRole", mock.Anything, testUserID, int64(3)).Return(domain.Role(""), domain.ErrCollectionNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetRole(userCtx, 3)
+
+		assert.ErrorIs(t, err, domain.ErrCollectionNotFound{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_InsertMember(t *testing.T) {
+	t.Run("should invite the user by lowercased email", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("InsertMember", mock.Anything, int64(2), "chandra@example.com", domain.RoleEditor).
+			Return(domain.Member{UserID: 8, Name: "Chandra", Email: "chandra@example.com", Role: domain.RoleEditor}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.InsertMember(userCtx, dtos.RequestMember{CollectionID: "2", Email: " Chandra@Example.com", Role: "editor"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponseMember{UserID: 8, Name: "Chandra", Email: "chandra@example.com", Role: "editor"}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("InsertMember", mock.Anything, int64(2), "nobody@example.com", domain.RoleReader).
+			Return(domain.Member{}, domain.ErrUserNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.InsertMember(userCtx, dtos.RequestMember{CollectionID: "2", Email: "nobody@example.com", Role: "reader"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_DeleteMember(t *testing.T) {
+	t.Run("should refuse to remove the owner", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+		repoMock.On("DeleteMember", mock.Anything, int64(2), testUserID).Return(domain.ErrCollectionOwner{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		err := service.DeleteMember(userCtx, "2", "7")
+
+		assert.ErrorIs(t, err, domain.ErrCollectionOwner{})
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should fail on an invalid user id", func(t *testing.T) {
+		repoMock := mocks.NewCollectionsRepositoryMock()
+
+		service := New(repoMock, mocks.NewLogMock())
+		err := service.DeleteMember(userCtx, "2", "x")
+
+		assert.ErrorContains(t, err, "service failed to parse user id in delete member")
+	})
+}
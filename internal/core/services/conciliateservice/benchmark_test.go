@@ -0,0 +1,69 @@
+package conciliateservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeCardGateway answers every card after a fixed latency, standing in for a
+// round trip to Scryfall.
+type fakeCardGateway struct {
+	latency time.Duration
+}
+
+func (g fakeCardGateway) GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error) {
+	select {
+	case <-time.After(g.latency):
+	case <-ctx.Done():
+		return domain.Price{}, ctx.Err()
+	}
+
+	return domain.Price{Value: 1, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil
+}
+
+func BenchmarkConciliateCards(b *testing.B) {
+	const (
+		totalCards = 200
+		commitSize = 50
+	)
+
+	cards := make([]domain.Cards, totalCards)
+	for i := range cards {
+		cards[i] = domain.Cards{ID: int64(i + 1)}
+	}
+
+	rates := map[string]float64{domain.CurrencyBRL: 1, domain.CurrencyUSD: 5}
+
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+			mockLogger := mocks.NewLogMock()
+
+			for offset := 0; offset < totalCards; offset = offset + commitSize {
+				mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(offset), commitSize).Return(cards[offset:offset+commitSize], nil)
+			}
+			mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(totalCards), commitSize).Return([]domain.Cards{}, nil)
+			mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.Anything).Return(nil)
+			mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+			mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+			mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+			mockLogger.On("Info", mock.Anything)
+
+			service := New(mockConciliateRepo, fakeCardGateway{latency: time.Millisecond}, nil, nil, 0, nil, nil, nil, commitSize, workers, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if cardsUpdated := service.conciliateCards(context.Background(), &domain.ConciliationRun{}, rates); cardsUpdated != totalCards {
+					b.Fatalf("expected %d cards updated, got %d", totalCards, cardsUpdated)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package conciliateservice
+
+import (
+	"mtg-report/internal/core/domain"
+	"sync"
+)
+
+// progress follows a run while the workers price its cards out of order. Cards
+// are handed out a page at a time in id order, and the checkpoint only moves
+// past a page once each of its cards was written or recorded as failed, so a
+// resumed run never skips a card that was still in flight.
+type progress struct {
+	mu         sync.Mutex
+	pages      []page
+	lastCardID int64
+	processed  int64
+	failed     int64
+	failures   []domain.ConciliationFailure
+}
+
+type page struct {
+	lastID  int64
+	pending int
+}
+
+// newProgress starts from the checkpoint and counters of the run, which are
+// not zero when it is being resumed.
+func newProgress(run domain.ConciliationRun) *progress {
+	return &progress{
+		lastCardID: run.LastCardID,
+		processed:  run.Processed,
+		failed:     run.Failed,
+	}
+}
+
+// addPage registers a page of pending cards ending at lastID, as they are
+// handed to the workers.
+func (p *progress) addPage(lastID int64, pending int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pages = append(p.pages, page{lastID: lastID, pending: pending})
+	p.advance()
+}
+
+// done marks a card as priced and written.
+func (p *progress) done(cardID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.processed++
+	p.finish(cardID)
+}
+
+// fail marks a card as handled without a price, keeping the failure until the
+// next checkpoint.
+func (p *progress) fail(failure domain.ConciliationFailure) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failed++
+	p.failures = append(p.failures, failure)
+	p.finish(failure.CardID)
+}
+
+// checkpoint copies the cursor and counters into the run and returns the
+// failures recorded since the last checkpoint.
+func (p *progress) checkpoint(run *domain.ConciliationRun) []domain.ConciliationFailure {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	run.LastCardID = p.lastCardID
+	run.Processed = p.processed
+	run.Failed = p.failed
+
+	failures := p.failures
+	p.failures = nil
+
+	return failures
+}
+
+func (p *progress) finish(cardID int64) {
+	for i := range p.pages {
+		if cardID <= p.pages[i].lastID {
+			p.pages[i].pending--
+			break
+		}
+	}
+	p.advance()
+}
+
+func (p *progress) advance() {
+	for len(p.pages) > 0 && p.pages[0].pending == 0 {
+		p.lastCardID = p.pages[0].lastID
+		p.pages = p.pages[1:]
+	}
+}
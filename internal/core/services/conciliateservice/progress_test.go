@@ -0,0 +1,36 @@
+package conciliateservice
+
+import (
+	"testing"
+
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress_CheckpointWaitsForEveryCardOfThePage(t *testing.T) {
+	progress := newProgress(domain.ConciliationRun{LastCardID: 10, Processed: 10})
+	progress.addPage(13, 3)
+	progress.addPage(16, 2)
+
+	var run domain.ConciliationRun
+
+	// the second page is done first, but the first one still has cards in flight.
+	progress.done(15)
+	progress.fail(domain.ConciliationFailure{CardID: 16, Reason: domain.FailurePriceUnavailable})
+	progress.done(11)
+	failures := progress.checkpoint(&run)
+
+	assert.Equal(t, int64(10), run.LastCardID)
+	assert.Equal(t, int64(12), run.Processed)
+	assert.Equal(t, int64(1), run.Failed)
+	assert.Len(t, failures, 1)
+
+	progress.done(12)
+	progress.done(13)
+	failures = progress.checkpoint(&run)
+
+	assert.Equal(t, int64(16), run.LastCardID)
+	assert.Equal(t, int64(14), run.Processed)
+	assert.Empty(t, failures)
+}
@@ -5,126 +5,729 @@ import (
 	"errors"
 	"fmt"
 	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
 	"mtg-report/internal/core/ports"
 	"mtg-report/internal/sources/logger/logrus"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	exchangeDefault      float64 = 4.80
-	maxRequestsPerSecond         = 10
+	// saveRunTimeout bounds each save of a run, which does not use the context
+	// of the job.
+	saveRunTimeout = 10 * time.Second
+	// maxErrorLength is the size of the error columns of conciliation runs and
+	// failures.
+	maxErrorLength = 1024
 )
 
 type service struct {
 	ConciliateRepository ports.ConciliateRepository
 	cardGateway          ports.CardGateway
 	exchangegateway      ports.ExchangeGateway
+	secondaryExchange    ports.ExchangeGateway
+	maxRateAge           time.Duration
+	retries              ports.RetryCounter
+	email                ports.Email
+	webhooks             ports.WebhookDispatcher
 	commitSize           int
+	workers              int
+	staleness            domain.StalenessPolicy
+	sanity               domain.SanityRules
+	conditionMultipliers map[string]float64
 	log                  logrus.Logger
 }
 
-func New(cr ports.ConciliateRepository, cg ports.CardGateway, eg ports.ExchangeGateway, commitSize int, log logrus.Logger) *service {
+func New(cr ports.ConciliateRepository, cg ports.CardGateway, eg ports.ExchangeGateway, secondary ports.ExchangeGateway, maxRateAge time.Duration, retries ports.RetryCounter, email ports.Email, wd ports.WebhookDispatcher, commitSize int, workers int, staleness domain.StalenessPolicy, sanity domain.SanityRules, conditionMultipliers map[string]float64, log logrus.Logger) *service {
+	if workers < 1 {
+		workers = 1
+	}
+
 	return &service{
 		ConciliateRepository: cr,
 		cardGateway:          cg,
 		exchangegateway:      eg,
+		secondaryExchange:    secondary,
+		maxRateAge:           maxRateAge,
+		retries:              retries,
+		email:                email,
+		webhooks:             wd,
 		commitSize:           commitSize,
+		workers:              workers,
+		staleness:            staleness,
+		sanity:               sanity,
+		conditionMultipliers: conditionMultipliers,
 		log:                  log,
 	}
 }
 
 func (c *service) Conciliate(ctx context.Context) (int64, error) {
-	var cardsUpdated int64
+	retriesBefore := c.retryCount()
+
+	run, err := c.startRun(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("service failed to start conciliation run: %w", err)
+	}
+	// a resumed run keeps counting from the retries of its earlier invocations.
+	previousRetries := run.Retries
+
+	exchange, rateSource, err := c.getExchangeRates(ctx)
+	if err != nil {
+		err = fmt.Errorf("service failed to get exchange rates: %w", err)
+		c.failRun(run, err)
+		return 0, err
+	}
+	c.log.Info(fmt.Sprintf("exchange rates of %s taken from the %s source", exchange.Date.Format(time.DateOnly), rateSource))
+
+	rates, err := c.exchangeRates(exchange)
+	if err != nil {
+		c.failRun(run, err)
+		return 0, err
+	}
+	usd := rates[domain.CurrencyUSD]
+	run.ExchangeRate = &usd
+	run.ExchangeRateSource = rateSource
+
+	cardsUpdated := c.conciliateCards(ctx, &run, rates)
+
+	if ctx.Err() != nil {
+		run.Retries = previousRetries + c.retryCount() - retriesBefore
+		c.saveRun(run, nil)
+		c.log.Warn(fmt.Sprintf("conciliation run %d interrupted after card %d, the next run of the day resumes it", run.ID, run.LastCardID))
+		return cardsUpdated, fmt.Errorf("service conciliation interrupted: %w", ctx.Err())
+	}
+
+	wishlistUpdated := c.conciliateWishlist(ctx, rates)
+	c.log.Info(fmt.Sprintf("%d wishlist items updated", wishlistUpdated))
+
+	alertsTriggered := c.notifyAlerts(ctx, run.StartedAt)
+	c.log.Info(fmt.Sprintf("%d price alerts triggered", alertsTriggered))
+
+	c.notifyPriceChanges(ctx, run.StartedAt)
+
+	run.Retries = previousRetries + c.retryCount() - retriesBefore
+	c.log.Info(fmt.Sprintf("%d requests retried", run.Retries))
+
+	c.finishRun(ctx, &run)
+
+	err = c.webhooks.Broadcast(ctx, domain.EventConciliationFinished, dtos.WebhookConciliationFinished{
+		RunID:              run.ID,
+		StartedAt:          run.StartedAt,
+		FinishedAt:         *run.FinishedAt,
+		CardsUpdated:       run.Processed,
+		CardsFailed:        run.Failed,
+		AlertsTriggered:    alertsTriggered,
+		ExchangeRateSource: string(rateSource),
+		ExchangeRateDate:   exchange.Date,
+		Retries:            run.Retries,
+	})
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to broadcast conciliation finished: %w", err))
+	}
+
+	return cardsUpdated, nil
+}
+
+// startRun resumes the unfinished run of the day, if there is one. A run left
+// unfinished on an earlier day is marked as failed instead, since its prices
+// were taken at another exchange rate.
+func (c *service) startRun(ctx context.Context, now time.Time) (domain.ConciliationRun, error) {
+	run, err := c.ConciliateRepository.GetUnfinishedConciliationRun(ctx)
+	switch {
+	case errors.Is(err, domain.ErrConciliationRunNotFound{}):
+	case err != nil:
+		return domain.ConciliationRun{}, fmt.Errorf("service failed to get unfinished conciliation run: %w", err)
+	case run.Resumable(now):
+		c.log.Info(fmt.Sprintf("resuming conciliation run %d after card %d", run.ID, run.LastCardID))
+		return run, nil
+	default:
+		c.log.Warn(fmt.Sprintf("conciliation run %d was not resumed on the day it started", run.ID))
+		c.failRun(run, errors.New("run interrupted and not resumed on the day it started"))
+	}
 
-	exchangeValue, err := c.exchangegateway.GetUSD(ctx)
+	run = domain.ConciliationRun{
+		StartedAt: now,
+		Status:    domain.ConciliationRunning,
+	}
+
+	run.Skipped, err = c.ConciliateRepository.CountFreshCards(ctx, c.staleness.Cutoffs(now))
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to count fresh cards: %w", err))
+	}
+
+	run.ID, err = c.ConciliateRepository.InsertConciliationRun(ctx, run)
+	if err != nil {
+		return domain.ConciliationRun{}, fmt.Errorf("service failed to insert conciliation run: %w", err)
+	}
+
+	return run, nil
+}
+
+// finishRun marks the run as finished, summing up why its cards failed.
+func (c *service) finishRun(ctx context.Context, run *domain.ConciliationRun) {
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.ConciliationFinished
+
+	if run.Failed > 0 {
+		reasons, err := c.ConciliateRepository.GetConciliationFailureReasons(ctx, run.ID)
+		if err != nil {
+			c.log.Warn(fmt.Errorf("service failed to get conciliation failure reasons: %w", err))
+		}
+		run.ErrorSummary = domain.SummarizeFailures(reasons)
+	}
+
+	c.saveRun(*run, nil)
+}
+
+func (c *service) failRun(run domain.ConciliationRun, err error) {
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.ConciliationFailed
+	run.ErrorSummary = truncate(err.Error(), maxErrorLength)
+
+	c.saveRun(run, nil)
+}
+
+// saveRun stores the run along with its new failures. It does not use the
+// context of the job, so the checkpoint of a run that timed out is still
+// saved.
+func (c *service) saveRun(run domain.ConciliationRun, failures []domain.ConciliationFailure) {
+	ctx, cancel := context.WithTimeout(context.Background(), saveRunTimeout)
+	defer cancel()
+
+	err := c.ConciliateRepository.InsertConciliationFailures(ctx, failures)
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to insert conciliation failures: %w", err))
+	}
+
+	err = c.ConciliateRepository.UpdateConciliationRun(ctx, run)
 	if err != nil {
-		c.log.Error(fmt.Errorf("service failed to get usd exchange: %w", err))
-		exchangeValue = exchangeDefault
+		c.log.Error(fmt.Errorf("service failed to update conciliation run: %w", err))
 	}
+}
 
-	cardCh := make(chan []domain.CardsDetails, 0)
-	finishCh := make(chan struct{})
+// conciliateCards prices the cards with a pool of workers. A producer pages
+// through the cards into a bounded channel, the workers price them, and their
+// results are inserted in batches of commitSize as they arrive. Requests to
+// the price sources are rate limited by the gateways, so the workers overlap
+// their latency without going past the limit. The run is checkpointed after
+// every batch.
+func (c *service) conciliateCards(ctx context.Context, run *domain.ConciliationRun, rates map[string]float64) int64 {
+	cardCh := make(chan domain.Cards, c.workers)
+	detailsCh := make(chan domain.CardsDetails, c.workers)
+	progress := newProgress(*run)
 
-	offset := 0
+	go c.produceCards(ctx, *run, progress, cardCh)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.priceCards(ctx, run.ID, progress, cardCh, detailsCh, rates)
+		}()
+	}
 
 	go func() {
-		defer close(cardCh)
-		for {
-			cards, err := c.ConciliateRepository.GetCardsForUpdate(ctx, offset, c.commitSize)
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					c.log.Error(fmt.Errorf("service failed to get cards for update due context timeout: %w", err))
-					break
-				}
-				c.log.Error(fmt.Errorf("service failed to get cards for update: %w", err))
-			}
+		wg.Wait()
+		close(detailsCh)
+	}()
 
-			if len(cards) == 0 {
-				break
-			}
+	var cardsUpdated int64
+	batch := make([]domain.CardsDetails, 0, c.commitSize)
+	for details := range detailsCh {
+		batch = append(batch, details)
+		if len(batch) == c.commitSize {
+			cardsUpdated = cardsUpdated + c.writeBatch(ctx, run, progress, batch)
+			batch = make([]domain.CardsDetails, 0, c.commitSize)
+		}
+	}
+	cardsUpdated = cardsUpdated + c.writeBatch(ctx, run, progress, batch)
 
-			cardsDetails := make([]domain.CardsDetails, 0, len(cards))
-			ticker := time.NewTicker(time.Second / maxRequestsPerSecond)
-			defer ticker.Stop()
-
-			for i, card := range cards {
-				price, err := c.cardGateway.GetCardPrice(ctx, card)
-				<-ticker.C
-				if err != nil {
-					if errors.Is(err, context.DeadlineExceeded) {
-						c.logError(card, fmt.Errorf("service failed to get card price due context timeout: %w", err))
-						break
-					}
-					c.logError(card, fmt.Errorf("service failed to get card price: %w", err))
-					continue
-				}
+	return cardsUpdated
+}
 
-				cards[i].CardsDetails.CardID = card.ID
-				cards[i].OldPrice = card.LastPrice
-				cards[i].LastPrice = price * exchangeValue
-				cards[i].PriceChange = cards[i].LastPrice - cards[i].OldPrice
+// produceCards hands out the stale cards after the checkpoint of the run.
+// Staleness is resolved against the start of the run, so the cards priced by
+// an earlier invocation of a resumed run are left out along with the fresh
+// ones.
+func (c *service) produceCards(ctx context.Context, run domain.ConciliationRun, progress *progress, cardCh chan<- domain.Cards) {
+	defer close(cardCh)
 
-				lastUpdate := time.Now()
-				cards[i].CardsDetails.LastUpdate = &lastUpdate
-			}
+	cutoffs := c.staleness.Cutoffs(run.StartedAt)
 
-			for _, card := range cards {
-				if card.LastUpdate != nil {
-					cardsDetails = append(cardsDetails, card.CardsDetails)
-				}
+	for afterID := run.LastCardID; ; {
+		cards, err := c.ConciliateRepository.GetCardsForUpdate(ctx, cutoffs, afterID, c.commitSize)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				c.log.Error(fmt.Errorf("service failed to get cards for update due context timeout: %w", err))
+				return
 			}
+			c.log.Error(fmt.Errorf("service failed to get cards for update: %w", err))
+		}
 
-			cardCh <- cardsDetails
-			offset = offset + c.commitSize
+		if len(cards) == 0 {
+			return
 		}
-	}()
+		afterID = cards[len(cards)-1].ID
+		progress.addPage(afterID, len(cards))
 
-	go func() {
-		defer close(finishCh)
-		for cards, ok := <-cardCh; ok; cards, ok = <-cardCh {
-			c.log.Info("inserting cards...")
-			if len(cards) == 0 {
-				c.log.Info("no cards to insert")
-				continue
+		for _, card := range cards {
+			select {
+			case cardCh <- card:
+			case <-ctx.Done():
+				return
 			}
+		}
+	}
+}
+
+func (c *service) priceCards(ctx context.Context, runID int64, progress *progress, cardCh <-chan domain.Cards, detailsCh chan<- domain.CardsDetails, rates map[string]float64) {
+	for card := range cardCh {
+		details, reason, err := c.priceCard(ctx, card, rates)
+		if err == nil {
+			reason, err = c.checkPrice(ctx, runID, details)
+		}
+		if err != nil {
+			c.logError(card, err)
+			c.recordFailure(ctx, progress, runID, card.ID, reason, err)
+			continue
+		}
+
+		select {
+		case detailsCh <- details:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *service) priceCard(ctx context.Context, card domain.Cards, rates map[string]float64) (domain.CardsDetails, domain.ConciliationFailureReason, error) {
+	price, err := c.cardGateway.GetCardPrice(ctx, card)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return domain.CardsDetails{}, domain.FailureTimeout, fmt.Errorf("service failed to get card price due context timeout: %w", err)
+		}
+		return domain.CardsDetails{}, domain.FailurePriceUnavailable, fmt.Errorf("service failed to get card price: %w", err)
+	}
+
+	rate, ok := rates[price.Currency]
+	if !ok {
+		return domain.CardsDetails{}, domain.FailureNoExchangeRate, fmt.Errorf("service failed to convert card price: no exchange rate for %s", price.Currency)
+	}
+
+	lastUpdate := time.Now()
+
+	details := card.CardsDetails
+	details.CardID = card.ID
+	details.OldPrice = card.LastPrice
+	details.LastPrice = price.Value * rate * c.conditionMultiplier(card.Condition)
+	details.PriceChange = details.LastPrice - details.OldPrice
+	details.ExchangeRate = rates[domain.CurrencyUSD]
+	details.PriceSource = price.Source
+	details.LastUpdate = &lastUpdate
+	details.RawPrices = rawPrices(card.ID, lastUpdate, price, rates)
+
+	return details, "", nil
+}
+
+// checkPrice quarantines a price that breaks the sanity rules instead of
+// letting it into the history, failing the card with the quarantined reason
+// so it keeps its old price until the price is reviewed. The raw quotes are
+// kept as evidence for the review.
+func (c *service) checkPrice(ctx context.Context, runID int64, details domain.CardsDetails) (domain.ConciliationFailureReason, error) {
+	rule, broken := c.sanity.Check(details.OldPrice, details.LastPrice)
+	if !broken {
+		return "", nil
+	}
+
+	err := c.ConciliateRepository.InsertQuarantinedPrice(ctx, domain.QuarantinedPrice{
+		RunID:        runID,
+		CardsDetails: details,
+		Rule:         rule,
+		Status:       domain.QuarantinePending,
+	})
+	if err != nil {
+		return domain.FailureInsert, fmt.Errorf("service failed to insert quarantined price: %w", err)
+	}
+
+	err = c.ConciliateRepository.InsertRawPrices(ctx, details.RawPrices)
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to insert raw prices: %w", err))
+	}
+
+	return domain.FailureQuarantined, fmt.Errorf("price of %.2f quarantined by the %s rule, the old price was %.2f", details.LastPrice, rule, details.OldPrice)
+}
+
+// recordFailure keeps a card that could not be priced in the failures of the
+// run. Cards that failed because the job itself ran out of time are left
+// pending instead, so the run prices them when it is resumed.
+func (c *service) recordFailure(ctx context.Context, progress *progress, runID, cardID int64, reason domain.ConciliationFailureReason, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	progress.fail(domain.ConciliationFailure{
+		RunID:    runID,
+		CardID:   cardID,
+		Reason:   reason,
+		Error:    truncate(err.Error(), maxErrorLength),
+		FailedAt: time.Now(),
+	})
+}
+
+// writeBatch inserts a batch of prices and checkpoints the run, returning how
+// many cards were updated.
+func (c *service) writeBatch(ctx context.Context, run *domain.ConciliationRun, progress *progress, batch []domain.CardsDetails) int64 {
+	err := c.insertCardDetails(ctx, batch)
+	for _, details := range batch {
+		if err != nil {
+			c.recordFailure(ctx, progress, run.ID, details.CardID, domain.FailureInsert, err)
+			continue
+		}
+		progress.done(details.CardID)
+	}
 
-			err = c.ConciliateRepository.InsertCardDetails(ctx, cards)
+	c.saveRun(*run, progress.checkpoint(run))
+
+	if err != nil {
+		return 0
+	}
+	return int64(len(batch))
+}
+
+// insertCardDetails writes a batch of prices with their raw quotes.
+func (c *service) insertCardDetails(ctx context.Context, cards []domain.CardsDetails) error {
+	c.log.Info("inserting cards...")
+	if len(cards) == 0 {
+		c.log.Info("no cards to insert")
+		return nil
+	}
+
+	err := c.ConciliateRepository.InsertCardDetails(ctx, cards)
+	if err != nil {
+		err = fmt.Errorf("service failed to insert card details: %w", err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.log.Error(err)
+			return err
+		}
+		c.log.Warn(err)
+		return err
+	}
+	c.log.Info("cards inserted!")
+
+	var raw []domain.RawPrice
+	for _, card := range cards {
+		raw = append(raw, card.RawPrices...)
+	}
+
+	err = c.ConciliateRepository.InsertRawPrices(ctx, raw)
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to insert raw prices: %w", err))
+	}
+
+	return nil
+}
+
+// rawPrices stamps the quotes of a price with the card, the time of the
+// snapshot and the rate of their currency, marking the quote that was used.
+func rawPrices(cardID int64, quotedAt time.Time, price domain.Price, rates map[string]float64) []domain.RawPrice {
+	raw := make([]domain.RawPrice, 0, len(price.Raw))
+	for _, quote := range price.Raw {
+		quote.CardID = cardID
+		quote.QuotedAt = quotedAt
+		quote.Used = quote.Field == price.Field
+		if rate, ok := rates[quote.Currency]; ok {
+			quote.ExchangeRate = &rate
+		}
+		raw = append(raw, quote)
+	}
+
+	return raw
+}
+
+func (c *service) retryCount() int64 {
+	if c.retries == nil {
+		return 0
+	}
+	return c.retries.Retries()
+}
+
+// getExchangeRates walks the fallback chain of exchange rates: the primary
+// provider, then the last stored rates while younger than maxRateAge, then the
+// secondary provider. Rates fetched from a provider are stored for the day.
+// When every source fails the run is aborted rather than valued at a made up
+// rate.
+func (c *service) getExchangeRates(ctx context.Context) (domain.ExchangeRates, domain.ExchangeRateSource, error) {
+	exchange, err := c.exchangegateway.GetRates(ctx)
+	if err == nil {
+		c.insertExchangeRates(ctx, exchange)
+		return exchange, domain.ExchangeRateSourcePrimary, nil
+	}
+	c.log.Error(fmt.Errorf("service failed to get exchange rates from primary provider: %w", err))
+
+	stored, err := c.ConciliateRepository.GetLatestExchangeRates(ctx)
+	switch {
+	case err != nil:
+		c.log.Error(fmt.Errorf("service failed to get stored exchange rates: %w", err))
+	case time.Since(stored.Date) > c.maxRateAge:
+		c.log.Warn(fmt.Sprintf("stored exchange rates of %s are older than %s", stored.Date.Format(time.DateOnly), c.maxRateAge))
+	default:
+		return stored, domain.ExchangeRateSourceStored, nil
+	}
+
+	if c.secondaryExchange != nil {
+		exchange, err = c.secondaryExchange.GetRates(ctx)
+		if err == nil {
+			c.insertExchangeRates(ctx, exchange)
+			return exchange, domain.ExchangeRateSourceSecondary, nil
+		}
+		c.log.Error(fmt.Errorf("service failed to get exchange rates from secondary provider: %w", err))
+	}
+
+	return domain.ExchangeRates{}, "", domain.ErrExchangeRateUnavailable{}
+}
+
+func (c *service) insertExchangeRates(ctx context.Context, exchange domain.ExchangeRates) {
+	err := c.ConciliateRepository.InsertExchangeRates(ctx, exchange)
+	if err != nil {
+		c.log.Error(fmt.Errorf("service failed to insert exchange rates: %w", err))
+	}
+}
+
+// exchangeRates returns the value in BRL of one unit of each currency a price
+// source can quote in. USD is required, while EUR is left out when its rate is
+// unavailable, so cards priced in EUR are skipped instead of stored with a
+// wrong price.
+func (c *service) exchangeRates(exchange domain.ExchangeRates) (map[string]float64, error) {
+	usd, ok := exchange.BRL(domain.CurrencyUSD)
+	if !ok {
+		return nil, errors.New("service failed to get usd exchange")
+	}
+
+	rates := map[string]float64{
+		domain.CurrencyBRL: 1,
+		domain.CurrencyUSD: usd,
+		domain.CurrencyTIX: usd,
+	}
+
+	eur, ok := exchange.BRL(domain.CurrencyEUR)
+	if !ok {
+		c.log.Error(errors.New("service failed to get eur exchange"))
+		return rates, nil
+	}
+	rates[domain.CurrencyEUR] = eur
+
+	return rates, nil
+}
+
+// conciliateWishlist refreshes the price of every wishlist item. Items are
+// priced as near mint copies, so no condition multiplier is applied.
+func (c *service) conciliateWishlist(ctx context.Context, rates map[string]float64) int64 {
+	var itemsUpdated int64
+
+	for afterID := int64(0); ; {
+		items, err := c.ConciliateRepository.GetWishlistForUpdate(ctx, afterID, c.commitSize)
+		if err != nil {
+			c.log.Error(fmt.Errorf("service failed to get wishlist for update: %w", err))
+			break
+		}
+
+		if len(items) == 0 {
+			break
+		}
+		afterID = items[len(items)-1].ID
+
+		updated := make([]domain.WishlistItem, 0, len(items))
+		for _, item := range items {
+			price, err := c.cardGateway.GetCardPrice(ctx, domain.Cards{
+				Name:            item.Name,
+				SetName:         item.SetName,
+				CollectorNumber: item.CollectorNumber,
+				Finish:          item.Finish,
+			})
 			if err != nil {
 				if errors.Is(err, context.DeadlineExceeded) {
-					c.log.Error(fmt.Errorf("service failed to insert card details: %w", err))
+					c.logWishlistError(item, fmt.Errorf("service failed to get wishlist item price due context timeout: %w", err))
 					break
 				}
-				c.log.Warn(fmt.Errorf("service failed to insert card details: %w", err))
+				c.logWishlistError(item, fmt.Errorf("service failed to get wishlist item price: %w", err))
 				continue
 			}
-			cardsUpdated = cardsUpdated + int64(len(cards))
-			c.log.Info("cards inserted!")
+
+			rate, ok := rates[price.Currency]
+			if !ok {
+				c.logWishlistError(item, fmt.Errorf("service failed to convert wishlist item price: no exchange rate for %s", price.Currency))
+				continue
+			}
+
+			lastPrice := price.Value * rate
+			lastUpdate := time.Now()
+			item.LastPrice = &lastPrice
+			item.LastUpdate = &lastUpdate
+			updated = append(updated, item)
 		}
-	}()
 
-	<-finishCh
+		err = c.ConciliateRepository.UpdateWishlistPrices(ctx, updated)
+		if err != nil {
+			c.log.Warn(fmt.Errorf("service failed to update wishlist prices: %w", err))
+			continue
+		}
+		itemsUpdated = itemsUpdated + int64(len(updated))
+	}
 
-	return cardsUpdated, nil
+	return itemsUpdated
+}
+
+// notifyAlerts evaluates the alerts against the prices inserted since
+// startedAt, keeps the ones that fire in the alert history and emails each
+// user their own.
+func (c *service) notifyAlerts(ctx context.Context, startedAt time.Time) int {
+	// cards_details.last_update only keeps whole seconds.
+	candidates, err := c.ConciliateRepository.GetAlertCandidates(ctx, startedAt.Truncate(time.Second))
+	if err != nil {
+		c.log.Error(fmt.Errorf("service failed to get alert candidates: %w", err))
+		return 0
+	}
+
+	triggeredAt := time.Now()
+
+	var triggered []domain.AlertEvent
+	for _, event := range candidates {
+		if event.Alert.Triggered(event.OldPrice, event.NewPrice) {
+			event.TriggeredAt = triggeredAt
+			triggered = append(triggered, event)
+		}
+	}
+
+	if len(triggered) == 0 {
+		return 0
+	}
+
+	err = c.ConciliateRepository.InsertAlertEvents(ctx, triggered)
+	if err != nil {
+		c.log.Warn(fmt.Errorf("service failed to insert alert events: %w", err))
+	}
+
+	// candidates come ordered by user, so the events of a user are together.
+	for start := 0; start < len(triggered); {
+		end := start
+		for end < len(triggered) && triggered[end].Alert.UserID == triggered[start].Alert.UserID {
+			end++
+		}
+
+		err = c.email.SendAlerts(triggered[start].Email, c.formatAlertsTable(triggered[start:end]))
+		if err != nil {
+			c.log.WithFields(logrus.Fields{"user_id": triggered[start].Alert.UserID}).
+				Warn(fmt.Errorf("service failed to send alerts: %w", err))
+		}
+
+		start = end
+	}
+
+	return len(triggered)
+}
+
+// notifyPriceChanges sends each user subscribed to price changes the cards
+// repriced since startedAt, in a single event.
+func (c *service) notifyPriceChanges(ctx context.Context, startedAt time.Time) {
+	changes, err := c.ConciliateRepository.GetPriceChanges(ctx, startedAt.Truncate(time.Second))
+	if err != nil {
+		c.log.Error(fmt.Errorf("service failed to get price changes: %w", err))
+		return
+	}
+
+	// changes come ordered by user, so the cards of a user are together.
+	for start := 0; start < len(changes); {
+		end := start
+		data := dtos.WebhookCardPriceChanged{}
+		for end < len(changes) && changes[end].UserID == changes[start].UserID {
+			change := changes[end]
+			data.Cards = append(data.Cards, dtos.WebhookPriceChange{
+				CardID:          change.CardID,
+				Name:            change.Name,
+				Set:             change.SetName,
+				CollectorNumber: change.CollectorNumber,
+				Finish:          string(change.Finish),
+				OldPrice:        change.OldPrice,
+				NewPrice:        change.NewPrice,
+			})
+			end++
+		}
+
+		err = c.webhooks.Notify(ctx, changes[start].UserID, domain.EventCardPriceChanged, data)
+		if err != nil {
+			c.log.WithFields(logrus.Fields{"user_id": changes[start].UserID}).
+				Warn(fmt.Errorf("service failed to notify price changes: %w", err))
+		}
+
+		start = end
+	}
+}
+
+func (c *service) formatAlertsTable(events []domain.AlertEvent) string {
+	var builder strings.Builder
+
+	builder.WriteString("<table style='border-collapse: collapse;'>")
+
+	header := "<tr>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Name</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Set Name</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Alert</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Old Price</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>New Price</th>" +
+		"</tr>"
+	builder.WriteString(header)
+
+	rowFormat := "<tr>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%.2f</td>" +
+		"<td style='border: 1px solid black; padding: 10px; color: %s;'>%.2f</td>" +
+		"</tr>"
+
+	for _, event := range events {
+		color := "green"
+		if event.NewPrice < event.OldPrice {
+			color = "red"
+		}
+
+		builder.WriteString(fmt.Sprintf(rowFormat,
+			event.CardName, event.CardSetName, describeAlert(event.Alert), event.OldPrice, color, event.NewPrice))
+	}
+
+	builder.WriteString("</table>")
+
+	return builder.String()
+}
+
+// describeAlert tells what an alert watches for, such as "up 10.00%" or
+// "down to R$100.00".
+func describeAlert(alert domain.Alert) string {
+	if alert.Kind == domain.AlertPercentage {
+		return fmt.Sprintf("%s %.2f%%", alert.Direction, alert.Threshold)
+	}
+	return fmt.Sprintf("%s to R$%.2f", alert.Direction, alert.Threshold)
+}
+
+// conditionMultiplier returns the factor applied over the market price, which
+// always refers to a near mint copy. Unknown conditions are not discounted.
+func (c *service) conditionMultiplier(condition string) float64 {
+	multiplier, ok := c.conditionMultipliers[condition]
+	if !ok {
+		return 1
+	}
+	return multiplier
+}
+
+func truncate(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s
 }
 
 func (c *service) logError(card domain.Cards, err error) {
@@ -133,6 +736,17 @@ func (c *service) logError(card domain.Cards, err error) {
 		"card_name":        card.Name,
 		"set_name":         card.SetName,
 		"collector_number": card.CollectorNumber,
-		"foil":             card.Foil,
+		"finish":           card.Finish,
+		"condition":        card.Condition,
+	}).Warn(err)
+}
+
+func (c *service) logWishlistError(item domain.WishlistItem, err error) {
+	c.log.WithFields(logrus.Fields{
+		"wishlist_id":      item.ID,
+		"card_name":        item.Name,
+		"set_name":         item.SetName,
+		"collector_number": item.CollectorNumber,
+		"finish":           item.Finish,
 	}).Warn(err)
 }
@@ -3,29 +3,65 @@ package conciliateservice
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
 	"mtg-report/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// testRates are worth 5 BRL for one USD and 6 BRL for one EUR.
+var testRates = domain.ExchangeRates{
+	Base:  domain.CurrencyEUR,
+	Rates: map[string]float64{domain.CurrencyEUR: 1, domain.CurrencyUSD: 1.2, domain.CurrencyBRL: 6},
+}
+
+// expectNewRun mocks a conciliation started with no unfinished run to resume.
+func expectNewRun(mockConciliateRepo *mocks.ConciliateRepositoryMock) {
+	mockConciliateRepo.On("GetUnfinishedConciliationRun", mock.Anything).Return(domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{})
+	mockConciliateRepo.On("CountFreshCards", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockConciliateRepo.On("InsertConciliationRun", mock.Anything, mock.Anything).Return(int64(1), nil)
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
 func TestNew(t *testing.T) {
 	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
 	mockCardGateway := mocks.NewCardGatewayMock()
 	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
+	mockSecondaryExchange := mocks.NewExchangeGatewayMock()
+	mockRetries := mocks.NewRetryCounterMock()
 	commitSize := 10
+	conditionMultipliers := map[string]float64{"NM": 1, "LP": 0.9}
+	staleness := domain.StalenessPolicy{MaxAge: 24 * time.Hour, HighValue: domain.StalenessTier{Price: 100}}
+	sanity := domain.SanityRules{MaxChange: 4, DropToZero: true}
 
-	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, commitSize, mockLogger)
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, mockSecondaryExchange, 72*time.Hour, mockRetries, mockEmail, mockWebhooks, commitSize, 4, staleness, sanity, conditionMultipliers, mockLogger)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, mockConciliateRepo, service.ConciliateRepository)
 	assert.Equal(t, mockCardGateway, service.cardGateway)
 	assert.Equal(t, mockExchangeGateway, service.exchangegateway)
+	assert.Equal(t, mockSecondaryExchange, service.secondaryExchange)
+	assert.Equal(t, 72*time.Hour, service.maxRateAge)
+	assert.Equal(t, mockRetries, service.retries)
+	assert.Equal(t, mockEmail, service.email)
+	assert.Equal(t, mockWebhooks, service.webhooks)
 	assert.Equal(t, commitSize, service.commitSize)
+	assert.Equal(t, 4, service.workers)
+	assert.Equal(t, staleness, service.staleness)
+	assert.Equal(t, sanity, service.sanity)
+	assert.Equal(t, conditionMultipliers, service.conditionMultipliers)
 	assert.Equal(t, mockLogger, service.log)
 }
 
@@ -33,15 +69,23 @@ func TestConciliate_NoCardsToUpdate(t *testing.T) {
 	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
 	mockCardGateway := mocks.NewCardGatewayMock()
 	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, 10, mockLogger)
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
 
 	// Mock exchange rate
-	mockExchangeGateway.On("GetUSD", mock.Anything).Return(5.0, nil)
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
 
 	// Mock no cards to update
-	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, 0, 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
 
 	// Mock logger calls
 	mockLogger.On("Info", mock.Anything).Maybe()
@@ -54,49 +98,942 @@ func TestConciliate_NoCardsToUpdate(t *testing.T) {
 	mockConciliateRepo.AssertExpectations(t)
 }
 
-func TestConciliate_ExchangeGatewayError(t *testing.T) {
+func TestConciliate_ReportsRetries(t *testing.T) {
 	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
 	mockCardGateway := mocks.NewCardGatewayMock()
 	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockRetries := mocks.NewRetryCounterMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, 10, mockLogger)
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, mockRetries, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
 
-	// Mock exchange rate error - should use default value
-	mockExchangeGateway.On("GetUSD", mock.Anything).Return(0.0, fmt.Errorf("exchange error"))
+	mockRetries.On("Retries").Return(int64(3)).Once()
+	mockRetries.On("Retries").Return(int64(7)).Once()
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(payload dtos.WebhookConciliationFinished) bool {
+		return payload.Retries == 4
+	})).Return(nil)
+	mockLogger.On("Info", []interface{}{"4 requests retried"}).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
 
-	// Mock no cards to update
-	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, 0, 10).Return([]domain.Cards{}, nil)
+	_, err := service.Conciliate(context.Background())
 
-	// Mock logger calls for error
+	assert.NoError(t, err)
+	mockRetries.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_StoredExchangeRatesFallback(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockSecondaryExchange := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, mockSecondaryExchange, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	stored := testRates
+	stored.Date = time.Now().Add(-24 * time.Hour)
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(domain.ExchangeRates{}, fmt.Errorf("exchange error"))
+	mockConciliateRepo.On("GetLatestExchangeRates", mock.Anything).Return(stored, nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(payload dtos.WebhookConciliationFinished) bool {
+		return payload.ExchangeRateSource == "stored" && payload.ExchangeRateDate.Equal(stored.Date)
+	})).Return(nil)
 	mockLogger.On("Error", mock.Anything).Once()
 	mockLogger.On("Info", mock.Anything).Maybe()
 
-	cardsUpdated, err := service.Conciliate(context.Background())
+	_, err := service.Conciliate(context.Background())
 
-	// Should still work with default exchange rate
 	assert.NoError(t, err)
+	mockConciliateRepo.AssertNotCalled(t, "InsertExchangeRates", mock.Anything, mock.Anything)
+	mockSecondaryExchange.AssertNotCalled(t, "GetRates", mock.Anything)
+	mockConciliateRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_SecondaryExchangeFallback(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockSecondaryExchange := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, mockSecondaryExchange, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	stored := testRates
+	stored.Date = time.Now().Add(-96 * time.Hour)
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(domain.ExchangeRates{}, fmt.Errorf("exchange error"))
+	mockConciliateRepo.On("GetLatestExchangeRates", mock.Anything).Return(stored, nil)
+	mockSecondaryExchange.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(payload dtos.WebhookConciliationFinished) bool {
+		return payload.ExchangeRateSource == "secondary"
+	})).Return(nil)
+	mockLogger.On("Error", mock.Anything).Once()
+	mockLogger.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	mockSecondaryExchange.AssertExpectations(t)
+	mockConciliateRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_NoExchangeRateAvailable(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockSecondaryExchange := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, mockSecondaryExchange, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(domain.ExchangeRates{}, fmt.Errorf("exchange error"))
+	mockConciliateRepo.On("GetLatestExchangeRates", mock.Anything).Return(domain.ExchangeRates{}, domain.ErrExchangeRateNotFound{})
+	mockSecondaryExchange.On("GetRates", mock.Anything).Return(domain.ExchangeRates{}, fmt.Errorf("exchange error"))
+	mockLogger.On("Error", mock.Anything).Times(3)
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrExchangeRateUnavailable{})
 	assert.Equal(t, int64(0), cardsUpdated)
-	mockExchangeGateway.AssertExpectations(t)
+	mockConciliateRepo.AssertNotCalled(t, "GetCardsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.ID == 1 && run.Status == domain.ConciliationFailed && run.FinishedAt != nil &&
+			strings.Contains(run.ErrorSummary, domain.ErrExchangeRateUnavailable{}.Error())
+	}))
+	mockWebhooks.AssertNotCalled(t, "Broadcast", mock.Anything, mock.Anything, mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_NoSecondaryExchange(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	stored := testRates
+	stored.Date = time.Now().Add(-96 * time.Hour)
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(domain.ExchangeRates{}, fmt.Errorf("exchange error"))
+	mockConciliateRepo.On("GetLatestExchangeRates", mock.Anything).Return(stored, nil)
+	mockLogger.On("Error", mock.Anything).Once()
+	mockLogger.On("Warn", mock.Anything).Once()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrExchangeRateUnavailable{})
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_InsertExchangeRatesError(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(fmt.Errorf("database error"))
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("Error", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	mockConciliateRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConciliate_AppliesConditionMultiplier(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, map[string]float64{"LP": 0.9}, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	card := domain.Cards{
+		ID:              1,
+		Name:            "Lightning Bolt",
+		SetName:         "Alpha",
+		CollectorNumber: "161",
+		Condition:       "LP",
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{card}, nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(1), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, card).Return(domain.Price{Value: 10, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.MatchedBy(func(details []domain.CardsDetails) bool {
+		return len(details) == 1 && details[0].CardID == 1 && details[0].LastPrice == 45.0 &&
+			details[0].ExchangeRate == 5.0 && details[0].PriceSource == domain.SourceScryfallUSD
+	})).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, []domain.RawPrice(nil)).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cardsUpdated)
+	mockConciliateRepo.AssertExpectations(t)
+	mockCardGateway.AssertExpectations(t)
+}
+
+func TestConciliate_RepricesOnlyStaleCards(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	staleness := domain.StalenessPolicy{
+		MaxAge:    24 * time.Hour,
+		HighValue: domain.StalenessTier{Price: 100},
+		Bulk:      domain.StalenessTier{Price: 1, MaxAge: 7 * 24 * time.Hour},
+	}
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, staleness, domain.SanityRules{}, nil, mockLogger)
+
+	var cutoffs domain.StalenessCutoffs
+	matchesPolicy := mock.MatchedBy(func(c domain.StalenessCutoffs) bool {
+		cutoffs = c
+		return c.HighValuePrice == 100 && c.BulkPrice == 1 &&
+			c.Default.Equal(c.HighValue.Add(-24*time.Hour)) && c.Bulk.Equal(c.HighValue.Add(-7*24*time.Hour))
+	})
+
+	mockConciliateRepo.On("GetUnfinishedConciliationRun", mock.Anything).Return(domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{})
+	mockConciliateRepo.On("CountFreshCards", mock.Anything, matchesPolicy).Return(int64(40), nil)
+	mockConciliateRepo.On("InsertConciliationRun", mock.Anything, mock.Anything).Return(int64(1), nil)
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, matchesPolicy, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	// high value cards are repriced on every run.
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.Status == domain.ConciliationFinished && run.Skipped == 40 && cutoffs.HighValue.Equal(run.StartedAt.Truncate(time.Second))
+	}))
+	mockConciliateRepo.AssertExpectations(t)
+}
+
+// changingCards keeps the cards in memory and lets a test change them between
+// the pages read by a run.
+type changingCards struct {
+	*mocks.ConciliateRepositoryMock
+	mu     sync.Mutex
+	cards  []domain.Cards
+	onPage func(c *changingCards)
+}
+
+func (c *changingCards) GetCardsForUpdate(ctx context.Context, cutoffs domain.StalenessCutoffs, afterID int64, limit int) ([]domain.Cards, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var page []domain.Cards
+	for _, card := range c.cards {
+		if card.ID > afterID && len(page) < limit {
+			page = append(page, card)
+		}
+	}
+
+	if c.onPage != nil {
+		c.onPage(c)
+		c.onPage = nil
+	}
+
+	return page, nil
+}
+
+func TestConciliateCards_NoCardSkippedWhenCardsChangeDuringRun(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockLogger := mocks.NewLogMock()
+
+	repo := &changingCards{ConciliateRepositoryMock: mockConciliateRepo}
+	for id := int64(1); id <= 6; id++ {
+		repo.cards = append(repo.cards, domain.Cards{ID: id})
+	}
+	// after the first page, card 2 is sold out and card 7 is added. Paging by
+	// offset would then skip card 4.
+	repo.onPage = func(c *changingCards) {
+		c.cards = append(c.cards[:1], c.cards[2:]...)
+		c.cards = append(c.cards, domain.Cards{ID: 7})
+	}
+
+	service := New(repo, mockCardGateway, nil, nil, 0, nil, nil, nil, 3, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+
+	var mu sync.Mutex
+	var priced []int64
+	mockCardGateway.On("GetCardPrice", mock.Anything, mock.Anything).Return(domain.Price{Value: 1, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, details := range args.Get(1).([]domain.CardsDetails) {
+			priced = append(priced, details.CardID)
+		}
+	}).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	run := domain.ConciliationRun{ID: 1}
+	cardsUpdated := service.conciliateCards(context.Background(), &run, map[string]float64{domain.CurrencyBRL: 1, domain.CurrencyUSD: 5})
+
+	sort.Slice(priced, func(i, j int) bool { return priced[i] < priced[j] })
+	assert.Equal(t, int64(7), cardsUpdated)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, priced)
+	assert.Equal(t, int64(7), run.LastCardID)
+}
+
+func TestConciliate_WorkerPoolInsertsInBatches(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 4, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	var cards []domain.Cards
+	for i := 1; i <= 25; i++ {
+		cards = append(cards, domain.Cards{ID: int64(i), SetName: "mh2", CollectorNumber: fmt.Sprint(i)})
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return(cards[:10], nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(10), 10).Return(cards[10:20], nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(20), 10).Return(cards[20:], nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(25), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, mock.Anything).Return(domain.Price{Value: 1, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+
+	insertedIDs := make(map[int64]bool)
+	var batchSizes []int
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		details := args.Get(1).([]domain.CardsDetails)
+		batchSizes = append(batchSizes, len(details))
+		for _, detail := range details {
+			insertedIDs[detail.CardID] = true
+		}
+	}).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25), cardsUpdated)
+	assert.Equal(t, []int{10, 10, 5}, batchSizes)
+	assert.Len(t, insertedIDs, 25)
+	mockConciliateRepo.AssertExpectations(t)
+}
+
+func TestConciliate_StopsWhenContextIsDone(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 2, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cards := make([]domain.Cards, 10)
+	for i := range cards {
+		cards[i] = domain.Cards{ID: int64(i + 1)}
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, mock.Anything, 10).Return(cards, nil)
+	mockCardGateway.On("GetCardPrice", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cancel()
+	}).Return(domain.Price{}, context.Canceled)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, context.Canceled)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, context.Canceled)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, context.Canceled)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything).Maybe()
+	mockCustom := mocks.NewCustomMock()
+	mockCustom.On("Warn", mock.Anything).Maybe()
+	mockLogger.On("WithFields", mock.Anything).Return(mockCustom).Maybe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cardsUpdated, err := service.Conciliate(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(0), cardsUpdated)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("conciliate did not stop after the context was canceled")
+	}
+	mockConciliateRepo.AssertNotCalled(t, "InsertCardDetails", mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertNotCalled(t, "GetWishlistForUpdate", mock.Anything, mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.Status == domain.ConciliationRunning && run.LastCardID == 0 && run.Failed == 0
+	}))
+	mockWebhooks.AssertNotCalled(t, "Broadcast", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConciliate_ResumesUnfinishedRunOfTheDay(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+
+	startedAt := time.Now()
+	run := domain.ConciliationRun{ID: 7, StartedAt: startedAt, Status: domain.ConciliationRunning, LastCardID: 10, Processed: 10, Skipped: 3}
+
+	pending := domain.Cards{ID: 12, Name: "Black Lotus"}
+
+	mockConciliateRepo.On("GetUnfinishedConciliationRun", mock.Anything).Return(run, nil)
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	// cards priced by the earlier invocation are fresh against the start of the run.
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, domain.StalenessPolicy{}.Cutoffs(startedAt), int64(10), 10).Return([]domain.Cards{pending}, nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(12), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, pending).Return(domain.Price{Value: 1, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.MatchedBy(func(details []domain.CardsDetails) bool {
+		return len(details) == 1 && details[0].CardID == 12
+	})).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, startedAt.Truncate(time.Second)).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, startedAt.Truncate(time.Second)).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(data dtos.WebhookConciliationFinished) bool {
+		return data.RunID == 7 && data.CardsUpdated == 11 && data.StartedAt.Equal(startedAt)
+	})).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cardsUpdated)
+	mockConciliateRepo.AssertNotCalled(t, "InsertConciliationRun", mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertNotCalled(t, "CountFreshCards", mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.ID == 7 && run.Status == domain.ConciliationFinished && run.LastCardID == 12 &&
+			run.Processed == 11 && run.Skipped == 3 && run.Failed == 0
+	}))
 	mockConciliateRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestConciliate_FailsUnfinishedRunOfAnotherDay(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+
+	stale := domain.ConciliationRun{ID: 6, StartedAt: time.Now().Add(-48 * time.Hour), Status: domain.ConciliationRunning, LastCardID: 40}
+
+	mockConciliateRepo.On("GetUnfinishedConciliationRun", mock.Anything).Return(stale, nil)
+	mockConciliateRepo.On("CountFreshCards", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockConciliateRepo.On("InsertConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.Status == domain.ConciliationRunning && run.LastCardID == 0
+	})).Return(int64(8), nil)
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(data dtos.WebhookConciliationFinished) bool {
+		return data.RunID == 8
+	})).Return(nil)
+	mockLogger.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.ID == 6 && run.Status == domain.ConciliationFailed && run.FinishedAt != nil && run.LastCardID == 40
+	}))
+	mockConciliateRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
 
+func TestConciliate_ConvertsEachSourceCurrency(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	mockCustom := mocks.NewCustomMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	eurCard := domain.Cards{ID: 1, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", CollectionID: 2}
+	tixCard := domain.Cards{ID: 2, Name: "Black Lotus", SetName: "Alpha", CollectorNumber: "232", CollectionID: 3}
+
+	usdOnly := domain.ExchangeRates{Base: domain.CurrencyUSD, Rates: map[string]float64{domain.CurrencyUSD: 1, domain.CurrencyBRL: 5}}
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(usdOnly, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, usdOnly).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{eurCard, tixCard}, nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(2), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, eurCard).
+		Return(domain.Price{Value: 9, Currency: domain.CurrencyEUR, Source: domain.SourceScryfallEUR}, nil)
+	mockCardGateway.On("GetCardPrice", mock.Anything, tixCard).
+		Return(domain.Price{Value: 2, Currency: domain.CurrencyTIX, Source: domain.SourceMTGOTix, Field: "tix", Raw: []domain.RawPrice{
+			{Field: "eur", Currency: domain.CurrencyEUR, Value: 1.8},
+			{Field: "tix", Currency: domain.CurrencyTIX, Value: 2},
+		}}, nil)
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.MatchedBy(func(details []domain.CardsDetails) bool {
+		return len(details) == 1 && details[0].CardID == 2 && details[0].LastPrice == 10.0 &&
+			details[0].PriceSource == domain.SourceMTGOTix
+	})).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.MatchedBy(func(raw []domain.RawPrice) bool {
+		return len(raw) == 2 &&
+			raw[0].CardID == 2 && raw[0].Field == "eur" && raw[0].ExchangeRate == nil && !raw[0].Used &&
+			raw[1].CardID == 2 && raw[1].Field == "tix" && *raw[1].ExchangeRate == 5.0 && raw[1].Used &&
+			raw[0].QuotedAt.Equal(raw[1].QuotedAt) && !raw[1].QuotedAt.IsZero()
+	})).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockConciliateRepo.On("GetConciliationFailureReasons", mock.Anything, int64(1)).
+		Return(map[domain.ConciliationFailureReason]int64{domain.FailureNoExchangeRate: 1}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(data dtos.WebhookConciliationFinished) bool {
+		return data.RunID == 1 && data.CardsUpdated == 1 && data.CardsFailed == 1
+	})).Return(nil)
+	mockLogger.On("Error", mock.Anything).Once()
+	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockCustom).Once()
+	mockCustom.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cardsUpdated)
+	mockConciliateRepo.AssertCalled(t, "InsertConciliationFailures", mock.Anything, mock.MatchedBy(func(failures []domain.ConciliationFailure) bool {
+		return len(failures) == 1 && failures[0].RunID == 1 && failures[0].CardID == 1 && failures[0].Reason == domain.FailureNoExchangeRate
+	}))
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.Status == domain.ConciliationFinished && run.Processed == 1 && run.Failed == 1 &&
+			run.LastCardID == 2 && run.ErrorSummary == "no_exchange_rate: 1"
+	}))
+	mockConciliateRepo.AssertExpectations(t)
+	mockCustom.AssertExpectations(t)
+}
+
+func TestConciliate_QuarantinesSuspiciousPrices(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	mockCustom := mocks.NewCustomMock()
+
+	sanity := domain.SanityRules{MaxChange: 4, DropToZero: true}
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, sanity, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	jumped := domain.Cards{ID: 1, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", CardsDetails: domain.CardsDetails{LastPrice: 10}}
+	steady := domain.Cards{ID: 2, Name: "Counterspell", SetName: "Alpha", CollectorNumber: "55", CardsDetails: domain.CardsDetails{LastPrice: 10}}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{jumped, steady}, nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(2), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, jumped).Return(domain.Price{Value: 100, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockCardGateway.On("GetCardPrice", mock.Anything, steady).Return(domain.Price{Value: 3, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("InsertQuarantinedPrice", mock.Anything, mock.MatchedBy(func(price domain.QuarantinedPrice) bool {
+		return price.RunID == 1 && price.CardsDetails.CardID == 1 && price.CardsDetails.LastPrice == 500.0 &&
+			price.CardsDetails.OldPrice == 10.0 && price.Rule == domain.QuarantineMaxChange && price.Status == domain.QuarantinePending
+	})).Return(nil)
+	mockConciliateRepo.On("InsertCardDetails", mock.Anything, mock.MatchedBy(func(details []domain.CardsDetails) bool {
+		return len(details) == 1 && details[0].CardID == 2 && details[0].LastPrice == 15.0
+	})).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockConciliateRepo.On("GetConciliationFailureReasons", mock.Anything, int64(1)).
+		Return(map[domain.ConciliationFailureReason]int64{domain.FailureQuarantined: 1}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockCustom).Once()
+	mockCustom.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cardsUpdated)
+	mockConciliateRepo.AssertCalled(t, "InsertConciliationFailures", mock.Anything, mock.MatchedBy(func(failures []domain.ConciliationFailure) bool {
+		return len(failures) == 1 && failures[0].CardID == 1 && failures[0].Reason == domain.FailureQuarantined &&
+			failures[0].Error == "price of 500.00 quarantined by the max_change rule, the old price was 10.00"
+	}))
+	mockConciliateRepo.AssertCalled(t, "UpdateConciliationRun", mock.Anything, mock.MatchedBy(func(run domain.ConciliationRun) bool {
+		return run.Status == domain.ConciliationFinished && run.Processed == 1 && run.Failed == 1 && run.ErrorSummary == "quarantined: 1"
+	}))
+	mockConciliateRepo.AssertExpectations(t)
+	mockCustom.AssertExpectations(t)
+}
+
+func TestConciliate_QuarantinesPricedCardsDroppingToZero(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	mockCustom := mocks.NewCustomMock()
+
+	sanity := domain.SanityRules{MaxChange: 4, DropToZero: true}
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, sanity, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	// The gateway prices a card that lost its quote at zero.
+	delisted := domain.Cards{ID: 1, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", CardsDetails: domain.CardsDetails{LastPrice: 10}}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{delisted}, nil).Once()
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(1), 10).Return([]domain.Cards{}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, delisted).Return(domain.Price{Value: 0, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("InsertQuarantinedPrice", mock.Anything, mock.MatchedBy(func(price domain.QuarantinedPrice) bool {
+		return price.CardsDetails.CardID == 1 && price.CardsDetails.LastPrice == 0 && price.CardsDetails.OldPrice == 10.0 &&
+			price.Rule == domain.QuarantineDropToZero && price.Status == domain.QuarantinePending
+	})).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockConciliateRepo.On("GetConciliationFailureReasons", mock.Anything, int64(1)).
+		Return(map[domain.ConciliationFailureReason]int64{domain.FailureQuarantined: 1}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockCustom).Once()
+	mockCustom.On("Warn", mock.Anything).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), cardsUpdated)
+	mockConciliateRepo.AssertCalled(t, "InsertConciliationFailures", mock.Anything, mock.MatchedBy(func(failures []domain.ConciliationFailure) bool {
+		return len(failures) == 1 && failures[0].Reason == domain.FailureQuarantined &&
+			failures[0].Error == "price of 0.00 quarantined by the drop_to_zero rule, the old price was 10.00"
+	}))
+	mockConciliateRepo.AssertNotCalled(t, "InsertCardDetails", mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertExpectations(t)
+}
+
+func TestConciliate_RequarantinesLatestQuoteOnEveryRun(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	mockCustom := mocks.NewCustomMock()
+
+	sanity := domain.SanityRules{MaxChange: 4}
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, sanity, nil, mockLogger)
+
+	// The quarantined card keeps its old price, so both runs find it again.
+	jumped := domain.Cards{ID: 1, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", CardsDetails: domain.CardsDetails{LastPrice: 10}}
+
+	mockConciliateRepo.On("GetUnfinishedConciliationRun", mock.Anything).Return(domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{})
+	mockConciliateRepo.On("CountFreshCards", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockConciliateRepo.On("InsertConciliationRun", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	mockConciliateRepo.On("InsertConciliationRun", mock.Anything, mock.Anything).Return(int64(2), nil).Once()
+	mockConciliateRepo.On("InsertConciliationFailures", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("UpdateConciliationRun", mock.Anything, mock.Anything).Return(nil)
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{jumped}, nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(1), 10).Return([]domain.Cards{}, nil)
+	mockCardGateway.On("GetCardPrice", mock.Anything, jumped).Return(domain.Price{Value: 100, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil).Once()
+	mockCardGateway.On("GetCardPrice", mock.Anything, jumped).Return(domain.Price{Value: 120, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil).Once()
+	mockConciliateRepo.On("InsertQuarantinedPrice", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("InsertRawPrices", mock.Anything, mock.Anything).Return(nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockConciliateRepo.On("GetConciliationFailureReasons", mock.Anything, mock.Anything).
+		Return(map[domain.ConciliationFailureReason]int64{domain.FailureQuarantined: 1}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockCustom)
+	mockCustom.On("Warn", mock.Anything)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	for i := 0; i < 2; i++ {
+		cardsUpdated, err := service.Conciliate(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), cardsUpdated)
+	}
+
+	var quarantined []domain.QuarantinedPrice
+	for _, call := range mockConciliateRepo.Calls {
+		if call.Method == "InsertQuarantinedPrice" {
+			quarantined = append(quarantined, call.Arguments.Get(1).(domain.QuarantinedPrice))
+		}
+	}
+	// Each run quarantines its own quote, which supersedes the pending one of
+	// the run before, and the old price of the card is never replaced.
+	assert.Len(t, quarantined, 2)
+	assert.Equal(t, int64(1), quarantined[0].RunID)
+	assert.Equal(t, 500.0, quarantined[0].CardsDetails.LastPrice)
+	assert.Equal(t, int64(2), quarantined[1].RunID)
+	assert.Equal(t, 600.0, quarantined[1].CardsDetails.LastPrice)
+	assert.Equal(t, 10.0, quarantined[1].CardsDetails.OldPrice)
+	mockConciliateRepo.AssertNotCalled(t, "InsertCardDetails", mock.Anything, mock.Anything)
+	mockConciliateRepo.AssertExpectations(t)
+	mockCardGateway.AssertExpectations(t)
+}
+
+func TestConciliate_UpdatesWishlistPrices(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, map[string]float64{"LP": 0.9}, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	item := domain.WishlistItem{
+		ID:              3,
+		UserID:          7,
+		Name:            "Lightning Bolt",
+		SetName:         "Alpha",
+		CollectorNumber: "161",
+		MaxPrice:        60,
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{item}, nil).Once()
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(3), 10).Return([]domain.WishlistItem{}, nil).Once()
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockCardGateway.On("GetCardPrice", mock.Anything, domain.Cards{
+		Name:            "Lightning Bolt",
+		SetName:         "Alpha",
+		CollectorNumber: "161",
+	}).Return(domain.Price{Value: 10, Currency: domain.CurrencyUSD, Source: domain.SourceScryfallUSD}, nil)
+	mockConciliateRepo.On("UpdateWishlistPrices", mock.Anything, mock.MatchedBy(func(items []domain.WishlistItem) bool {
+		return len(items) == 1 && items[0].ID == 3 && *items[0].LastPrice == 50.0 && items[0].LastUpdate != nil
+	})).Return(nil)
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	cardsUpdated, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), cardsUpdated)
+	mockConciliateRepo.AssertExpectations(t)
+	mockCardGateway.AssertExpectations(t)
+}
+
+func TestConciliate_NotifiesTriggeredAlerts(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	cardAlert := domain.Alert{ID: 1, UserID: 7, Kind: domain.AlertPercentage, Direction: domain.AlertUp, Threshold: 10}
+	setAlert := domain.Alert{ID: 2, UserID: 8, SetName: "Alpha", Kind: domain.AlertAbsolute, Direction: domain.AlertDown, Threshold: 100}
+
+	candidates := []domain.AlertEvent{
+		{Alert: cardAlert, Email: "jace@example.com", CardID: 1, CardName: "Lightning Bolt", CardSetName: "Alpha", OldPrice: 100, NewPrice: 115},
+		{Alert: setAlert, Email: "liliana@example.com", CardID: 2, CardName: "Black Lotus", CardSetName: "Alpha", OldPrice: 120, NewPrice: 130},
+		{Alert: setAlert, Email: "liliana@example.com", CardID: 3, CardName: "Dark Ritual", CardSetName: "Alpha", OldPrice: 110, NewPrice: 90},
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return(candidates, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.PriceChange{}, nil)
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.Anything).Return(nil)
+	mockConciliateRepo.On("InsertAlertEvents", mock.Anything, mock.MatchedBy(func(events []domain.AlertEvent) bool {
+		return len(events) == 2 && events[0].CardID == 1 && events[1].CardID == 3 && !events[0].TriggeredAt.IsZero()
+	})).Return(nil)
+	mockEmail.On("SendAlerts", "jace@example.com", mock.MatchedBy(func(table string) bool {
+		return strings.Contains(table, "Lightning Bolt") && strings.Contains(table, "up 10.00%")
+	})).Return(nil).Once()
+	mockEmail.On("SendAlerts", "liliana@example.com", mock.MatchedBy(func(table string) bool {
+		return strings.Contains(table, "Dark Ritual") && !strings.Contains(table, "Black Lotus")
+	})).Return(nil).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	mockConciliateRepo.AssertExpectations(t)
+	mockEmail.AssertExpectations(t)
+}
+
+func TestConciliate_NotifiesPriceChangesPerUser(t *testing.T) {
+	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
+	mockCardGateway := mocks.NewCardGatewayMock()
+	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
+	expectNewRun(mockConciliateRepo)
+
+	changes := []domain.PriceChange{
+		{UserID: 7, CardID: 1, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", OldPrice: 100, NewPrice: 115},
+		{UserID: 7, CardID: 2, Name: "Black Lotus", SetName: "Alpha", CollectorNumber: "232", OldPrice: 120, NewPrice: 130},
+		{UserID: 8, CardID: 3, Name: "Dark Ritual", SetName: "Alpha", CollectorNumber: "98", Finish: domain.FinishFoil, OldPrice: 110, NewPrice: 90},
+	}
+
+	mockExchangeGateway.On("GetRates", mock.Anything).Return(testRates, nil)
+	mockConciliateRepo.On("InsertExchangeRates", mock.Anything, testRates).Return(nil)
+	mockConciliateRepo.On("GetCardsForUpdate", mock.Anything, mock.Anything, int64(0), 10).Return([]domain.Cards{}, nil)
+	mockConciliateRepo.On("GetWishlistForUpdate", mock.Anything, int64(0), 10).Return([]domain.WishlistItem{}, nil)
+	mockConciliateRepo.On("GetAlertCandidates", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.AlertEvent{}, nil)
+	mockConciliateRepo.On("GetPriceChanges", mock.Anything, mock.AnythingOfType("time.Time")).Return(changes, nil)
+	mockWebhooks.On("Notify", mock.Anything, int64(7), domain.EventCardPriceChanged, dtos.WebhookCardPriceChanged{
+		Cards: []dtos.WebhookPriceChange{
+			{CardID: 1, Name: "Lightning Bolt", Set: "Alpha", CollectorNumber: "161", OldPrice: 100, NewPrice: 115},
+			{CardID: 2, Name: "Black Lotus", Set: "Alpha", CollectorNumber: "232", OldPrice: 120, NewPrice: 130},
+		},
+	}).Return(nil).Once()
+	mockWebhooks.On("Notify", mock.Anything, int64(8), domain.EventCardPriceChanged, dtos.WebhookCardPriceChanged{
+		Cards: []dtos.WebhookPriceChange{
+			{CardID: 3, Name: "Dark Ritual", Set: "Alpha", CollectorNumber: "98", Finish: "foil", OldPrice: 110, NewPrice: 90},
+		},
+	}).Return(nil).Once()
+	mockWebhooks.On("Broadcast", mock.Anything, domain.EventConciliationFinished, mock.MatchedBy(func(data dtos.WebhookConciliationFinished) bool {
+		return data.CardsUpdated == 0 && !data.FinishedAt.Before(data.StartedAt)
+	})).Return(nil).Once()
+	mockLogger.On("Info", mock.Anything).Maybe()
+
+	_, err := service.Conciliate(context.Background())
+
+	assert.NoError(t, err)
+	mockConciliateRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+}
+
 func TestLogError(t *testing.T) {
 	mockConciliateRepo := mocks.NewConciliateRepositoryMock()
 	mockCardGateway := mocks.NewCardGatewayMock()
 	mockExchangeGateway := mocks.NewExchangeGatewayMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 	mockCustom := mocks.NewCustomMock()
 
-	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, 10, mockLogger)
+	service := New(mockConciliateRepo, mockCardGateway, mockExchangeGateway, nil, 72*time.Hour, nil, mockEmail, mockWebhooks, 10, 1, domain.StalenessPolicy{}, domain.SanityRules{}, nil, mockLogger)
 
 	card := domain.Cards{
 		ID:              1,
 		Name:            "Lightning Bolt",
 		SetName:         "Alpha",
 		CollectorNumber: "161",
-		Foil:            false,
+		Finish:          domain.FinishNonfoil,
 	}
 
 	testError := fmt.Errorf("test error")
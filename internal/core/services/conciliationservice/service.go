@@ -0,0 +1,111 @@
+package conciliationservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+)
+
+type service struct {
+	conciliationsRepository ports.ConciliationsRepository
+	log                     logrus.Logger
+}
+
+func New(cr ports.ConciliationsRepository, log logrus.Logger) *service {
+	return &service{
+		conciliationsRepository: cr,
+		log:                     log,
+	}
+}
+
+// GetConciliations lists the runs of the conciliate job, newest first.
+func (s *service) GetConciliations(ctx context.Context, page, limit int) (dtos.ResponsePaginatedConciliations, error) {
+	offset := (page - 1) * limit
+
+	total, err := s.conciliationsRepository.GetConciliationRunsCount(ctx)
+	if err != nil {
+		return dtos.ResponsePaginatedConciliations{}, fmt.Errorf("service failed to get conciliation runs count: %w", err)
+	}
+
+	runs, err := s.conciliationsRepository.GetConciliationRuns(ctx, offset, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedConciliations{}, fmt.Errorf("service failed to get conciliation runs: %w", err)
+	}
+
+	conciliations := make([]dtos.ResponseConciliation, 0, len(runs))
+	for _, run := range runs {
+		conciliations = append(conciliations, dtos.ResponseConciliation{
+			ID:                 run.ID,
+			StartedAt:          run.StartedAt,
+			FinishedAt:         run.FinishedAt,
+			Status:             string(run.Status),
+			ExchangeRate:       run.ExchangeRate,
+			ExchangeRateSource: string(run.ExchangeRateSource),
+			Processed:          run.Processed,
+			Failed:             run.Failed,
+			Skipped:            run.Skipped,
+			Retries:            run.Retries,
+			ErrorSummary:       run.ErrorSummary,
+		})
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return dtos.ResponsePaginatedConciliations{
+		Conciliations: conciliations,
+		Page:          page,
+		Limit:         limit,
+		Total:         total,
+		TotalPages:    totalPages,
+	}, nil
+}
+
+// GetConciliationFailures lists the cards a run failed to price, limited to
+// the collections the user is a member of.
+func (s *service) GetConciliationFailures(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedConciliationFailures, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	run, err := s.conciliationsRepository.GetConciliationRunByID(ctx, id)
+	if err != nil {
+		return dtos.ResponsePaginatedConciliationFailures{}, fmt.Errorf("service failed to get conciliation run: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.conciliationsRepository.GetConciliationFailuresCount(ctx, userID, run.ID)
+	if err != nil {
+		return dtos.ResponsePaginatedConciliationFailures{}, fmt.Errorf("service failed to get conciliation failures count: %w", err)
+	}
+
+	failuresDomain, err := s.conciliationsRepository.GetConciliationFailures(ctx, userID, run.ID, offset, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedConciliationFailures{}, fmt.Errorf("service failed to get conciliation failures: %w", err)
+	}
+
+	failures := make([]dtos.ResponseConciliationFailure, 0, len(failuresDomain))
+	for _, failure := range failuresDomain {
+		failures = append(failures, dtos.ResponseConciliationFailure{
+			ID:              failure.ID,
+			CardID:          failure.CardID,
+			Name:            failure.Name,
+			SetName:         failure.SetName,
+			CollectorNumber: failure.CollectorNumber,
+			Reason:          string(failure.Reason),
+			Error:           failure.Error,
+			FailedAt:        failure.FailedAt,
+		})
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return dtos.ResponsePaginatedConciliationFailures{
+		Failures:   failures,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
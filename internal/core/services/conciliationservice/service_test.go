@@ -0,0 +1,108 @@
+package conciliationservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewConciliationsRepositoryMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_GetConciliations(t *testing.T) {
+	t.Run("should paginate the runs", func(t *testing.T) {
+		startedAt := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+		finishedAt := startedAt.Add(time.Hour)
+		rate := 5.0
+
+		repoMock := mocks.NewConciliationsRepositoryMock()
+		repoMock.On("GetConciliationRunsCount", mock.Anything).Return(int64(21), nil)
+		repoMock.On("GetConciliationRuns", mock.Anything, 20, 10).Return([]domain.ConciliationRun{
+			{ID: 3, StartedAt: startedAt, FinishedAt: &finishedAt, Status: domain.ConciliationFinished, ExchangeRate: &rate,
+				ExchangeRateSource: domain.ExchangeRateSourcePrimary, LastCardID: 90, Processed: 88, Failed: 2, Retries: 1,
+				ErrorSummary: "timeout: 2"},
+		}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetConciliations(userCtx, 3, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponsePaginatedConciliations{
+			Conciliations: []dtos.ResponseConciliation{
+				{ID: 3, StartedAt: startedAt, FinishedAt: &finishedAt, Status: "finished", ExchangeRate: &rate,
+					ExchangeRateSource: "primary", Processed: 88, Failed: 2, Retries: 1, ErrorSummary: "timeout: 2"},
+			},
+			Page:       3,
+			Limit:      10,
+			Total:      21,
+			TotalPages: 3,
+		}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewConciliationsRepositoryMock()
+		repoMock.On("GetConciliationRunsCount", mock.Anything).Return(int64(0), errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetConciliations(userCtx, 1, 10)
+
+		assert.ErrorContains(t, err, "service failed to get conciliation runs count")
+		repoMock.AssertNotCalled(t, "GetConciliationRuns", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_GetConciliationFailures(t *testing.T) {
+	t.Run("should paginate the failures of the user cards", func(t *testing.T) {
+		failedAt := time.Date(2024, 5, 2, 3, 10, 0, 0, time.UTC)
+
+		repoMock := mocks.NewConciliationsRepositoryMock()
+		repoMock.On("GetConciliationRunByID", mock.Anything, "3").Return(domain.ConciliationRun{ID: 3}, nil)
+		repoMock.On("GetConciliationFailuresCount", mock.Anything, testUserID, int64(3)).Return(int64(1), nil)
+		repoMock.On("GetConciliationFailures", mock.Anything, testUserID, int64(3), 0, 10).Return([]domain.ConciliationFailure{
+			{ID: 5, RunID: 3, CardID: 12, Name: "Black Lotus", SetName: "lea", CollectorNumber: "232",
+				Reason: domain.FailurePriceUnavailable, Error: "card not found", FailedAt: failedAt},
+		}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetConciliationFailures(userCtx, "3", 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponsePaginatedConciliationFailures{
+			Failures: []dtos.ResponseConciliationFailure{
+				{ID: 5, CardID: 12, Name: "Black Lotus", SetName: "lea", CollectorNumber: "232", Reason: "price_unavailable",
+					Error: "card not found", FailedAt: failedAt},
+			},
+			Page:       1,
+			Limit:      10,
+			Total:      1,
+			TotalPages: 1,
+		}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should fail when the run does not exist", func(t *testing.T) {
+		repoMock := mocks.NewConciliationsRepositoryMock()
+		repoMock.On("GetConciliationRunByID", mock.Anything, "3").Return(domain.ConciliationRun{}, domain.ErrConciliationRunNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetConciliationFailures(userCtx, "3", 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrConciliationRunNotFound{})
+		repoMock.AssertNotCalled(t, "GetConciliationFailures", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
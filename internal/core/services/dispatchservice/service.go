@@ -0,0 +1,148 @@
+package dispatchservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"time"
+)
+
+// maxErrorLength is the size of webhook_deliveries.error.
+const maxErrorLength = 255
+
+type service struct {
+	webhooksRepository ports.WebhooksRepository
+	webhookGateway     ports.WebhookGateway
+	maxAttempts        int
+	backoff            time.Duration
+	log                logrus.Logger
+}
+
+// New returns a dispatcher that tries each delivery up to maxAttempts times,
+// waiting backoff before the first retry and doubling it for each new one.
+func New(wr ports.WebhooksRepository, wg ports.WebhookGateway, maxAttempts int, backoff time.Duration, log logrus.Logger) *service {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &service{
+		webhooksRepository: wr,
+		webhookGateway:     wg,
+		maxAttempts:        maxAttempts,
+		backoff:            backoff,
+		log:                log,
+	}
+}
+
+func (s *service) Notify(ctx context.Context, userID int64, event domain.WebhookEvent, data interface{}) error {
+	return s.dispatch(ctx, userID, event, data)
+}
+
+func (s *service) Broadcast(ctx context.Context, event domain.WebhookEvent, data interface{}) error {
+	return s.dispatch(ctx, 0, event, data)
+}
+
+// dispatch delivers the event to every subscribed webhook. Webhooks that keep
+// failing are only logged, they do not stop the others.
+func (s *service) dispatch(ctx context.Context, userID int64, event domain.WebhookEvent, data interface{}) error {
+	webhooks, err := s.webhooksRepository.GetSubscribedWebhooks(ctx, event, userID)
+	if err != nil {
+		return fmt.Errorf("service failed to get subscribed webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		err := s.deliver(ctx, webhook, event, data)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{"webhook_id": webhook.ID, "event": event}).
+				Warn(fmt.Errorf("service failed to deliver webhook: %w", err))
+		}
+	}
+
+	return nil
+}
+
+func (s *service) deliver(ctx context.Context, webhook domain.Webhook, event domain.WebhookEvent, data interface{}) error {
+	deliveryID, err := domain.NewWebhookDeliveryID()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(dtos.WebhookPayload{
+		ID:        deliveryID,
+		Event:     string(event),
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	headers := map[string]string{
+		"Content-Type":    "application/json",
+		"User-Agent":      "mtg-report-webhooks",
+		"X-MTG-Event":     string(event),
+		"X-MTG-Delivery":  deliveryID,
+		"X-MTG-Signature": "sha256=" + domain.SignWebhookPayload(webhook.Secret, body),
+	}
+
+	wait := s.backoff
+	for attempt := 1; ; attempt++ {
+		statusCode, err := s.webhookGateway.Post(ctx, webhook.URL, headers, body)
+		if err == nil && (statusCode < 200 || statusCode > 299) {
+			err = fmt.Errorf("unexpected status %d", statusCode)
+		}
+
+		s.recordDelivery(ctx, domain.WebhookDelivery{
+			WebhookID:  webhook.ID,
+			DeliveryID: deliveryID,
+			Event:      event,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Error:      errorMessage(err),
+			Success:    err == nil,
+			CreatedAt:  time.Now(),
+		})
+
+		if err == nil {
+			return nil
+		}
+
+		if attempt == s.maxAttempts {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+	}
+}
+
+func (s *service) recordDelivery(ctx context.Context, delivery domain.WebhookDelivery) {
+	err := s.webhooksRepository.InsertWebhookDelivery(ctx, delivery)
+	if err != nil {
+		s.log.WithFields(logrus.Fields{"webhook_id": delivery.WebhookID, "attempt": delivery.Attempt}).
+			Warn(fmt.Errorf("service failed to insert webhook delivery: %w", err))
+	}
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+
+	return message
+}
@@ -0,0 +1,135 @@
+package dispatchservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var webhook = domain.Webhook{ID: 4, UserID: 7, URL: "https://hooks.test.com", Secret: "secret"}
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewWebhooksRepositoryMock(), mocks.NewWebhookGatewayMock(), 0, 0, mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+	assert.Equal(t, 1, service.maxAttempts)
+}
+
+func TestService_Notify(t *testing.T) {
+	t.Run("should post a signed payload to the webhooks of the user", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		gatewayMock := mocks.NewWebhookGatewayMock()
+
+		repoMock.On("GetSubscribedWebhooks", mock.Anything, domain.EventReportGenerated, int64(7)).Return([]domain.Webhook{webhook}, nil)
+		gatewayMock.On("Post", mock.Anything, "https://hooks.test.com", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				headers := args.Get(2).(map[string]string)
+				body := args.Get(3).([]byte)
+
+				assert.Equal(t, "sha256="+domain.SignWebhookPayload("secret", body), headers["X-MTG-Signature"])
+				assert.Equal(t, "report.generated", headers["X-MTG-Event"])
+
+				var payload dtos.WebhookPayload
+				assert.NoError(t, json.Unmarshal(body, &payload))
+				assert.Equal(t, headers["X-MTG-Delivery"], payload.ID)
+				assert.Equal(t, "report.generated", payload.Event)
+			}).
+			Return(http.StatusOK, nil).Once()
+		repoMock.On("InsertWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+			return delivery.WebhookID == 4 && delivery.Attempt == 1 && delivery.Success && delivery.StatusCode == http.StatusOK
+		})).Return(nil).Once()
+
+		service := New(repoMock, gatewayMock, 3, 0, mocks.NewLogMock())
+		err := service.Notify(context.Background(), 7, domain.EventReportGenerated, dtos.WebhookReportGenerated{Cards: 2})
+
+		assert.NoError(t, err)
+		repoMock.AssertExpectations(t)
+		gatewayMock.AssertExpectations(t)
+	})
+
+	t.Run("should retry failed deliveries and record every attempt", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		gatewayMock := mocks.NewWebhookGatewayMock()
+		logMock := mocks.NewLogMock()
+		customMock := mocks.NewCustomMock()
+
+		var deliveries []domain.WebhookDelivery
+
+		repoMock.On("GetSubscribedWebhooks", mock.Anything, domain.EventReportGenerated, int64(7)).Return([]domain.Webhook{webhook}, nil)
+		gatewayMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("connection refused")).Once()
+		gatewayMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusBadGateway, nil).Once()
+		gatewayMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusBadGateway, nil).Once()
+		repoMock.On("InsertWebhookDelivery", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { deliveries = append(deliveries, args.Get(1).(domain.WebhookDelivery)) }).
+			Return(nil)
+		logMock.On("WithFields", mock.Anything).Return(customMock).Once()
+		customMock.On("Warn", mock.Anything).Once()
+
+		service := New(repoMock, gatewayMock, 3, 0, logMock)
+		err := service.Notify(context.Background(), 7, domain.EventReportGenerated, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 3)
+		assert.Equal(t, "connection refused", deliveries[0].Error)
+		assert.Equal(t, "unexpected status 502", deliveries[2].Error)
+		assert.Equal(t, 3, deliveries[2].Attempt)
+		assert.Equal(t, deliveries[0].DeliveryID, deliveries[2].DeliveryID)
+		assert.False(t, deliveries[2].Success)
+		gatewayMock.AssertExpectations(t)
+		customMock.AssertExpectations(t)
+	})
+
+	t.Run("should stop retrying when the context is done", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		gatewayMock := mocks.NewWebhookGatewayMock()
+		logMock := mocks.NewLogMock()
+		customMock := mocks.NewCustomMock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		repoMock.On("GetSubscribedWebhooks", mock.Anything, domain.EventReportGenerated, int64(7)).Return([]domain.Webhook{webhook}, nil)
+		gatewayMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.StatusInternalServerError, nil).Once()
+		repoMock.On("InsertWebhookDelivery", mock.Anything, mock.Anything).Return(nil).Once()
+		logMock.On("WithFields", mock.Anything).Return(customMock).Once()
+		customMock.On("Warn", mock.Anything).Once()
+
+		service := New(repoMock, gatewayMock, 5, time.Hour, logMock)
+		err := service.Notify(ctx, 7, domain.EventReportGenerated, nil)
+
+		assert.NoError(t, err)
+		repoMock.AssertExpectations(t)
+		gatewayMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		repoMock.On("GetSubscribedWebhooks", mock.Anything, domain.EventReportGenerated, int64(7)).
+			Return([]domain.Webhook(nil), errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewWebhookGatewayMock(), 3, 0, mocks.NewLogMock())
+		err := service.Notify(context.Background(), 7, domain.EventReportGenerated, nil)
+
+		assert.ErrorContains(t, err, "service failed to get subscribed webhooks")
+	})
+}
+
+func TestService_Broadcast(t *testing.T) {
+	repoMock := mocks.NewWebhooksRepositoryMock()
+	repoMock.On("GetSubscribedWebhooks", mock.Anything, domain.EventConciliationFinished, int64(0)).Return([]domain.Webhook{}, nil)
+
+	service := New(repoMock, mocks.NewWebhookGatewayMock(), 3, 0, mocks.NewLogMock())
+	err := service.Broadcast(context.Background(), domain.EventConciliationFinished, nil)
+
+	assert.NoError(t, err)
+	repoMock.AssertExpectations(t)
+}
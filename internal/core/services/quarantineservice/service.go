@@ -0,0 +1,142 @@
+package quarantineservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"time"
+)
+
+type service struct {
+	quarantineRepository ports.QuarantineRepository
+	log                  logrus.Logger
+}
+
+func New(qr ports.QuarantineRepository, log logrus.Logger) *service {
+	return &service{
+		quarantineRepository: qr,
+		log:                  log,
+	}
+}
+
+// GetQuarantinedPrices lists the quarantined prices of a status, limited to
+// the collections the user is a member of.
+func (s *service) GetQuarantinedPrices(ctx context.Context, status domain.QuarantineStatus, page, limit int) (dtos.ResponsePaginatedQuarantinedPrices, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	offset := (page - 1) * limit
+
+	total, err := s.quarantineRepository.GetQuarantinedPricesCount(ctx, userID, status)
+	if err != nil {
+		return dtos.ResponsePaginatedQuarantinedPrices{}, fmt.Errorf("service failed to get quarantined prices count: %w", err)
+	}
+
+	pricesDomain, err := s.quarantineRepository.GetQuarantinedPrices(ctx, userID, status, offset, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedQuarantinedPrices{}, fmt.Errorf("service failed to get quarantined prices: %w", err)
+	}
+
+	prices := make([]dtos.ResponseQuarantinedPrice, 0, len(pricesDomain))
+	for _, price := range pricesDomain {
+		prices = append(prices, toResponseQuarantinedPrice(price))
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return dtos.ResponsePaginatedQuarantinedPrices{
+		Prices:     prices,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// AcceptQuarantinedPrice lets a quarantined price into the history of its
+// card, as the price it was quoted at.
+func (s *service) AcceptQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	price, err := s.getPendingPrice(ctx, userID, id)
+	if err != nil {
+		return dtos.ResponseQuarantinedPrice{}, err
+	}
+
+	reviewedAt := time.Now()
+
+	err = s.quarantineRepository.AcceptQuarantinedPrice(ctx, userID, price, reviewedAt)
+	if err != nil {
+		return dtos.ResponseQuarantinedPrice{}, fmt.Errorf("service failed to accept quarantined price: %w", err)
+	}
+
+	price.Status = domain.QuarantineAccepted
+	price.ReviewedAt = &reviewedAt
+	price.ReviewedBy = &userID
+
+	return toResponseQuarantinedPrice(price), nil
+}
+
+// RejectQuarantinedPrice discards a quarantined price, so its card keeps the
+// price it had before.
+func (s *service) RejectQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	price, err := s.getPendingPrice(ctx, userID, id)
+	if err != nil {
+		return dtos.ResponseQuarantinedPrice{}, err
+	}
+
+	reviewedAt := time.Now()
+
+	err = s.quarantineRepository.RejectQuarantinedPrice(ctx, userID, price, reviewedAt)
+	if err != nil {
+		return dtos.ResponseQuarantinedPrice{}, fmt.Errorf("service failed to reject quarantined price: %w", err)
+	}
+
+	price.Status = domain.QuarantineRejected
+	price.ReviewedAt = &reviewedAt
+	price.ReviewedBy = &userID
+
+	return toResponseQuarantinedPrice(price), nil
+}
+
+// getPendingPrice returns a price the user may review: it must still be
+// pending and the user an editor of the collection of its card.
+func (s *service) getPendingPrice(ctx context.Context, userID int64, id string) (domain.QuarantinedPrice, error) {
+	price, err := s.quarantineRepository.GetQuarantinedPriceByID(ctx, userID, id)
+	if err != nil {
+		return domain.QuarantinedPrice{}, fmt.Errorf("service failed to get quarantined price: %w", err)
+	}
+
+	if !price.Role.Includes(domain.RoleEditor) {
+		return domain.QuarantinedPrice{}, domain.ErrForbidden{}
+	}
+
+	if price.Status != domain.QuarantinePending {
+		return domain.QuarantinedPrice{}, domain.ErrQuarantinedPriceReviewed{}
+	}
+
+	return price, nil
+}
+
+func toResponseQuarantinedPrice(price domain.QuarantinedPrice) dtos.ResponseQuarantinedPrice {
+	return dtos.ResponseQuarantinedPrice{
+		ID:              price.ID,
+		RunID:           price.RunID,
+		CardID:          price.CardsDetails.CardID,
+		Name:            price.Name,
+		SetName:         price.SetName,
+		CollectorNumber: price.CollectorNumber,
+		LastPrice:       price.CardsDetails.LastPrice,
+		OldPrice:        price.CardsDetails.OldPrice,
+		PriceChange:     price.CardsDetails.PriceChange,
+		PriceSource:     string(price.CardsDetails.PriceSource),
+		QuotedAt:        price.CardsDetails.LastUpdate,
+		Rule:            string(price.Rule),
+		Status:          string(price.Status),
+		ReviewedAt:      price.ReviewedAt,
+	}
+}
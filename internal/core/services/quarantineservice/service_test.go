@@ -0,0 +1,168 @@
+package quarantineservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+var quotedAt = time.Date(2024, 5, 2, 3, 10, 0, 0, time.UTC)
+
+func pendingPrice(role domain.Role) domain.QuarantinedPrice {
+	return domain.QuarantinedPrice{
+		ID:    5,
+		RunID: 3,
+		CardsDetails: domain.CardsDetails{
+			CardID:      12,
+			LastPrice:   500,
+			OldPrice:    10,
+			PriceChange: 490,
+			PriceSource: domain.SourceScryfallUSD,
+			LastUpdate:  &quotedAt,
+		},
+		Name:            "Black Lotus",
+		SetName:         "lea",
+		CollectorNumber: "232",
+		Rule:            domain.QuarantineMaxChange,
+		Status:          domain.QuarantinePending,
+		Role:            role,
+	}
+}
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewQuarantineRepositoryMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_GetQuarantinedPrices(t *testing.T) {
+	t.Run("should paginate the prices of the user cards", func(t *testing.T) {
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPricesCount", mock.Anything, testUserID, domain.QuarantinePending).Return(int64(11), nil)
+		repoMock.On("GetQuarantinedPrices", mock.Anything, testUserID, domain.QuarantinePending, 10, 10).
+			Return([]domain.QuarantinedPrice{pendingPrice("")}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetQuarantinedPrices(userCtx, domain.QuarantinePending, 2, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponsePaginatedQuarantinedPrices{
+			Prices: []dtos.ResponseQuarantinedPrice{
+				{ID: 5, RunID: 3, CardID: 12, Name: "Black Lotus", SetName: "lea", CollectorNumber: "232", LastPrice: 500,
+					OldPrice: 10, PriceChange: 490, PriceSource: "scryfall_usd", QuotedAt: &quotedAt, Rule: "max_change", Status: "pending"},
+			},
+			Page:       2,
+			Limit:      10,
+			Total:      11,
+			TotalPages: 2,
+		}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPricesCount", mock.Anything, testUserID, domain.QuarantinePending).Return(int64(0), errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.GetQuarantinedPrices(userCtx, domain.QuarantinePending, 1, 10)
+
+		assert.ErrorContains(t, err, "service failed to get quarantined prices count")
+		repoMock.AssertNotCalled(t, "GetQuarantinedPrices", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_AcceptQuarantinedPrice(t *testing.T) {
+	t.Run("should accept a pending price", func(t *testing.T) {
+		price := pendingPrice(domain.RoleEditor)
+
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").Return(price, nil)
+		repoMock.On("AcceptQuarantinedPrice", mock.Anything, testUserID, price, mock.AnythingOfType("time.Time")).Return(nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.AcceptQuarantinedPrice(userCtx, "5")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "accepted", got.Status)
+		assert.NotNil(t, got.ReviewedAt)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should forbid readers", func(t *testing.T) {
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").Return(pendingPrice(domain.RoleReader), nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.AcceptQuarantinedPrice(userCtx, "5")
+
+		assert.ErrorIs(t, err, domain.ErrForbidden{})
+		repoMock.AssertNotCalled(t, "AcceptQuarantinedPrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not review a price twice", func(t *testing.T) {
+		price := pendingPrice(domain.RoleOwner)
+		price.Status = domain.QuarantineRejected
+
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").Return(price, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.AcceptQuarantinedPrice(userCtx, "5")
+
+		assert.ErrorIs(t, err, domain.ErrQuarantinedPriceReviewed{})
+		repoMock.AssertNotCalled(t, "AcceptQuarantinedPrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should pass not found through", func(t *testing.T) {
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").
+			Return(domain.QuarantinedPrice{}, domain.ErrQuarantinedPriceNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.AcceptQuarantinedPrice(userCtx, "5")
+
+		assert.ErrorIs(t, err, domain.ErrQuarantinedPriceNotFound{})
+	})
+}
+
+func TestService_RejectQuarantinedPrice(t *testing.T) {
+	t.Run("should reject a pending price", func(t *testing.T) {
+		price := pendingPrice(domain.RoleEditor)
+
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").Return(price, nil)
+		repoMock.On("RejectQuarantinedPrice", mock.Anything, testUserID, price, mock.AnythingOfType("time.Time")).Return(nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.RejectQuarantinedPrice(userCtx, "5")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "rejected", got.Status)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		price := pendingPrice(domain.RoleEditor)
+
+		repoMock := mocks.NewQuarantineRepositoryMock()
+		repoMock.On("GetQuarantinedPriceByID", mock.Anything, testUserID, "5").Return(price, nil)
+		repoMock.On("RejectQuarantinedPrice", mock.Anything, testUserID, price, mock.AnythingOfType("time.Time")).
+			Return(errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.RejectQuarantinedPrice(userCtx, "5")
+
+		assert.ErrorContains(t, err, "service failed to reject quarantined price")
+	})
+}
@@ -2,8 +2,10 @@ package reportservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
 	"mtg-report/internal/core/ports"
 	"mtg-report/internal/sources/logger/logrus"
 	"strings"
@@ -13,42 +15,94 @@ import (
 type service struct {
 	ReportRepository ports.ReportRepository
 	Email            ports.Email
+	webhooks         ports.WebhookDispatcher
+	collectionID     int64
 	log              logrus.Logger
 }
 
-func New(rr ports.ReportRepository, email ports.Email, log logrus.Logger) *service {
+func New(rr ports.ReportRepository, email ports.Email, wd ports.WebhookDispatcher, collectionID int64,
+	log logrus.Logger) *service {
 	return &service{
 		ReportRepository: rr,
 		Email:            email,
+		webhooks:         wd,
+		collectionID:     collectionID,
 		log:              log,
 	}
 }
 
+// ProcessAndSend sends each user the report of their own cards. A failure for
+// one user does not stop the reports of the others.
 func (s *service) ProcessAndSend(ctx context.Context) error {
-	err := s.ReportRepository.InsertTotalPrice(ctx)
+	users, err := s.ReportRepository.GetReportUsers(ctx, s.collectionID)
+	if err != nil {
+		return fmt.Errorf("service failed to get report users in process and send: %w", err)
+	}
+
+	var errs []error
+	for _, user := range users {
+		err := s.processAndSendUser(ctx, user)
+		if errors.Is(err, domain.ErrCardNotFound{}) {
+			s.log.WithFields(logrus.Fields{"user_id": user.ID}).Info("user has no cards to report")
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service failed to report user %d: %w", user.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) processAndSendUser(ctx context.Context, user domain.User) error {
+	err := s.ReportRepository.InsertTotalPrice(ctx, user.ID, s.collectionID)
 	if err != nil {
 		return fmt.Errorf("service failed to insert total price in process and send: %w", err)
 	}
 
-	cards, err := s.ReportRepository.GetCardsReport(ctx)
+	cards, err := s.ReportRepository.GetCardsReport(ctx, user.ID, s.collectionID)
 	if err != nil {
 		return fmt.Errorf("service failed to get cards reports in process and send: %w", err)
 	}
 
 	cardsTable := s.formatCardsTable(cards)
 
-	cardsPrice, err := s.ReportRepository.GetTotalPrice(ctx)
+	cardsPrice, err := s.ReportRepository.GetTotalPrice(ctx, user.ID, s.collectionID)
 	if err != nil {
 		return fmt.Errorf("service failed to get total price in process and send: %w", err)
 	}
 
-	cardsPriceFormatted := s.formatCardsPrice(cardsPrice)
+	unrealizedGain, err := s.ReportRepository.GetUnrealizedGain(ctx, user.ID, s.collectionID)
+	if err != nil {
+		return fmt.Errorf("service failed to get unrealized gain in process and send: %w", err)
+	}
+
+	cardsPriceFormatted := s.formatCardsPrice(cardsPrice) + "<br/>" + s.formatUnrealizedGain(unrealizedGain)
+
+	wishlist, err := s.ReportRepository.GetAffordableWishlist(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("service failed to get affordable wishlist in process and send: %w", err)
+	}
+
+	wishlistTable := s.formatWishlistTable(wishlist)
 
-	err = s.Email.SendEmail(cardsTable, cardsPriceFormatted)
+	err = s.Email.SendEmail(user.Email, cardsTable, cardsPriceFormatted, wishlistTable)
 	if err != nil {
 		return fmt.Errorf("service failed to send email in process and send: %w", err)
 	}
 
+	err = s.webhooks.Notify(ctx, user.ID, domain.EventReportGenerated, dtos.WebhookReportGenerated{
+		CollectionID:   s.collectionID,
+		Cards:          len(cards),
+		TotalPrice:     cardsPrice.NewPrice,
+		PriceChange:    cardsPrice.PriceChange,
+		UnrealizedGain: unrealizedGain.UnrealizedGain,
+	})
+	if err != nil {
+		s.log.WithFields(logrus.Fields{"user_id": user.ID}).
+			Warn(fmt.Errorf("service failed to notify report generated: %w", err))
+	}
+
 	return nil
 }
 
@@ -60,7 +114,10 @@ func (s *service) formatCardsTable(cards []domain.Cards) string {
 		"<th style='border: 1px solid black; padding: 10px;'>Name</th>" +
 		"<th style='border: 1px solid black; padding: 10px;'>Set Name</th>" +
 		"<th style='border: 1px solid black; padding: 10px;'>Collector Number</th>" +
-		"<th style='border: 1px solid black; padding: 10px;'>Foil</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Finish</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Condition</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Language</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Quantity</th>" +
 		"<th style='border: 1px solid black; padding: 10px;'>Old Price</th>" +
 		"<th style='border: 1px solid black; padding: 10px;'>Last Price</th>" +
 		"<th style='border: 1px solid black; padding: 10px;'>Price Change</th>" +
@@ -74,6 +131,9 @@ func (s *service) formatCardsTable(cards []domain.Cards) string {
 		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
 		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
 		"<td style='border: 1px solid black; padding: 10px;'>%v</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%d</td>" +
 		"<td style='border: 1px solid black; padding: 10px;'>%.2f</td>" +
 		"<td style='border: 1px solid black; padding: 10px;'>%.2f</td>" +
 		"<td style='border: 1px solid black; padding: 10px; color: %s;'>%.2f</td>" +
@@ -95,7 +155,7 @@ func (s *service) formatCardsTable(cards []domain.Cards) string {
 
 		row := fmt.Sprintf(rowFormat,
 			card.ID, card.Name, card.SetName, card.CollectorNumber,
-			card.Foil, card.OldPrice, card.LastPrice, color, card.PriceChange,
+			card.Finish, card.Condition, card.Language, card.Quantity, card.OldPrice, card.LastPrice, color, card.PriceChange,
 			lastUpdate.Format(time.RFC1123))
 		builder.WriteString(row)
 	}
@@ -105,6 +165,52 @@ func (s *service) formatCardsTable(cards []domain.Cards) string {
 	return builder.String()
 }
 
+// formatWishlistTable lists the wishlist items that can be bought at or below
+// their target price. It is empty when there are none.
+func (s *service) formatWishlistTable(items []domain.WishlistItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("<h2>Wishlist items at or below target</h2>")
+	builder.WriteString("<table style='border-collapse: collapse;'>")
+
+	header := "<tr>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Name</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Set Name</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Collector Number</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Finish</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Target Price</th>" +
+		"<th style='border: 1px solid black; padding: 10px;'>Last Price</th>" +
+		"</tr>"
+	builder.WriteString(header)
+
+	rowFormat := "<tr>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%s</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%v</td>" +
+		"<td style='border: 1px solid black; padding: 10px;'>%.2f</td>" +
+		"<td style='border: 1px solid black; padding: 10px; color: green;'>%.2f</td>" +
+		"</tr>"
+
+	for _, item := range items {
+		var lastPrice float64
+		if item.LastPrice != nil {
+			lastPrice = *item.LastPrice
+		}
+
+		builder.WriteString(fmt.Sprintf(rowFormat,
+			item.Name, item.SetName, item.CollectorNumber, item.Finish, item.MaxPrice, lastPrice))
+	}
+
+	builder.WriteString("</table>")
+
+	return builder.String()
+}
+
 func (s *service) formatCardsPrice(price domain.CardsPrice) string {
 	var builder strings.Builder
 
@@ -122,3 +228,21 @@ func (s *service) formatCardsPrice(price domain.CardsPrice) string {
 
 	return builder.String()
 }
+
+func (s *service) formatUnrealizedGain(gain domain.UnrealizedGain) string {
+	var builder strings.Builder
+
+	var color string
+	if gain.UnrealizedGain > 0 {
+		color = "<span style='color: green;'>gain</span>"
+	} else if gain.UnrealizedGain < 0 {
+		color = "<span style='color: red;'>loss</span>"
+	} else {
+		color = "<span style='color: black;'>break-even</span>"
+	}
+
+	builder.WriteString(fmt.Sprintf("The cards you paid <strong>R$%.2f</strong> for are now worth <strong>R$%.2f</strong>. That's an unrealized %s of <strong>R$%.2f</strong>.",
+		gain.CostBasis, gain.MarketValue, color, gain.UnrealizedGain))
+
+	return builder.String()
+}
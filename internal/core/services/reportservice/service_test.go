@@ -3,35 +3,42 @@ package reportservice
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
 	"mtg-report/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+var reportUser = domain.User{ID: 7, Name: "Jace", Email: "jace@example.com"}
+
 func TestNew(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, mockRepo, service.ReportRepository)
 	assert.Equal(t, mockEmail, service.Email)
+	assert.Equal(t, mockWebhooks, service.webhooks)
 	assert.Equal(t, mockLogger, service.log)
 }
 
 func TestProcessAndSend_Success(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	now := time.Now()
 	expectedCards := []domain.Cards{
@@ -40,7 +47,7 @@ func TestProcessAndSend_Success(t *testing.T) {
 			Name:            "Lightning Bolt",
 			SetName:         "Alpha",
 			CollectorNumber: "161",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
 			CardsDetails: domain.CardsDetails{
 				LastPrice:   10.50,
 				OldPrice:    9.00,
@@ -57,10 +64,70 @@ func TestProcessAndSend_Success(t *testing.T) {
 		LastUpdate:  &now,
 	}
 
-	mockRepo.On("InsertTotalPrice", mock.Anything).Return(nil)
-	mockRepo.On("GetCardsReport", mock.Anything).Return(expectedCards, nil)
-	mockRepo.On("GetTotalPrice", mock.Anything).Return(expectedPrice, nil)
-	mockEmail.On("SendEmail", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return(expectedCards, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(expectedPrice, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem{}, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"), "").Return(nil)
+	mockWebhooks.On("Notify", mock.Anything, reportUser.ID, domain.EventReportGenerated, dtos.WebhookReportGenerated{
+		Cards:       1,
+		TotalPrice:  110.50,
+		PriceChange: 10.50,
+	}).Return(nil)
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEmail.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestProcessAndSend_WebhookErrorDoesNotFail(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	customMock := mocks.NewCustomMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem{}, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"), "").Return(nil)
+	mockWebhooks.On("Notify", mock.Anything, reportUser.ID, domain.EventReportGenerated, mock.Anything).Return(fmt.Errorf("store error"))
+	mockLogger.On("WithFields", mock.Anything).Return(customMock)
+	customMock.On("Warn", mock.Anything).Once()
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.NoError(t, err)
+	mockWebhooks.AssertExpectations(t)
+	customMock.AssertExpectations(t)
+}
+
+func TestProcessAndSend_ScopedToCollection(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 3, mockLogger)
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(3)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(3)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(3)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(3)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(3)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem{}, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"), "").Return(nil)
+	mockWebhooks.On("Notify", mock.Anything, reportUser.ID, domain.EventReportGenerated, mock.Anything).Return(nil)
 
 	err := service.ProcessAndSend(context.Background())
 
@@ -72,11 +139,13 @@ func TestProcessAndSend_Success(t *testing.T) {
 func TestProcessAndSend_InsertTotalPriceError(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
-	mockRepo.On("InsertTotalPrice", mock.Anything).Return(fmt.Errorf("database error"))
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(fmt.Errorf("database error"))
 
 	err := service.ProcessAndSend(context.Background())
 
@@ -88,12 +157,14 @@ func TestProcessAndSend_InsertTotalPriceError(t *testing.T) {
 func TestProcessAndSend_GetCardsReportError(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
-	mockRepo.On("InsertTotalPrice", mock.Anything).Return(nil)
-	mockRepo.On("GetCardsReport", mock.Anything).Return([]domain.Cards(nil), fmt.Errorf("query error"))
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards(nil), fmt.Errorf("query error"))
 
 	err := service.ProcessAndSend(context.Background())
 
@@ -105,15 +176,17 @@ func TestProcessAndSend_GetCardsReportError(t *testing.T) {
 func TestProcessAndSend_GetTotalPriceError(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	expectedCards := []domain.Cards{}
 
-	mockRepo.On("InsertTotalPrice", mock.Anything).Return(nil)
-	mockRepo.On("GetCardsReport", mock.Anything).Return(expectedCards, nil)
-	mockRepo.On("GetTotalPrice", mock.Anything).Return(domain.CardsPrice{}, fmt.Errorf("query error"))
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return(expectedCards, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, fmt.Errorf("query error"))
 
 	err := service.ProcessAndSend(context.Background())
 
@@ -122,20 +195,45 @@ func TestProcessAndSend_GetTotalPriceError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestProcessAndSend_GetUnrealizedGainError(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, fmt.Errorf("query error"))
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service failed to get unrealized gain in process and send")
+	mockRepo.AssertExpectations(t)
+}
+
 func TestProcessAndSend_SendEmailError(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	expectedCards := []domain.Cards{}
 	expectedPrice := domain.CardsPrice{}
 
-	mockRepo.On("InsertTotalPrice", mock.Anything).Return(nil)
-	mockRepo.On("GetCardsReport", mock.Anything).Return(expectedCards, nil)
-	mockRepo.On("GetTotalPrice", mock.Anything).Return(expectedPrice, nil)
-	mockEmail.On("SendEmail", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(fmt.Errorf("email error"))
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return(expectedCards, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(expectedPrice, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem{}, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"), "").Return(fmt.Errorf("email error"))
 
 	err := service.ProcessAndSend(context.Background())
 
@@ -145,12 +243,116 @@ func TestProcessAndSend_SendEmailError(t *testing.T) {
 	mockEmail.AssertExpectations(t)
 }
 
+func TestProcessAndSend_IncludesAffordableWishlist(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	lastPrice := 45.0
+	wishlist := []domain.WishlistItem{
+		{ID: 3, UserID: reportUser.ID, Name: "Lightning Bolt", SetName: "Alpha", CollectorNumber: "161", MaxPrice: 50, LastPrice: &lastPrice},
+	}
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return(wishlist, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"),
+		mock.MatchedBy(func(table string) bool {
+			return strings.Contains(table, "Lightning Bolt") && strings.Contains(table, "45.00")
+		})).Return(nil)
+	mockWebhooks.On("Notify", mock.Anything, reportUser.ID, domain.EventReportGenerated, mock.Anything).Return(nil)
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEmail.AssertExpectations(t)
+}
+
+func TestProcessAndSend_GetAffordableWishlistError(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem(nil), fmt.Errorf("query error"))
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service failed to get affordable wishlist in process and send")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessAndSend_GetReportUsersError(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User(nil), fmt.Errorf("query error"))
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service failed to get report users in process and send")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessAndSend_SkipsUsersWithoutCards(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+	customMock := mocks.NewCustomMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	otherUser := domain.User{ID: 8, Name: "Chandra", Email: "chandra@example.com"}
+
+	mockRepo.On("GetReportUsers", mock.Anything, int64(0)).Return([]domain.User{otherUser, reportUser}, nil)
+	mockRepo.On("InsertTotalPrice", mock.Anything, otherUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, otherUser.ID, int64(0)).Return([]domain.Cards(nil), domain.ErrCardNotFound{})
+	mockLogger.On("WithFields", mock.Anything).Return(customMock)
+	customMock.On("Info", mock.Anything).Once()
+	mockRepo.On("InsertTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(nil)
+	mockRepo.On("GetCardsReport", mock.Anything, reportUser.ID, int64(0)).Return([]domain.Cards{}, nil)
+	mockRepo.On("GetTotalPrice", mock.Anything, reportUser.ID, int64(0)).Return(domain.CardsPrice{}, nil)
+	mockRepo.On("GetUnrealizedGain", mock.Anything, reportUser.ID, int64(0)).Return(domain.UnrealizedGain{}, nil)
+	mockRepo.On("GetAffordableWishlist", mock.Anything, reportUser.ID).Return([]domain.WishlistItem{}, nil)
+	mockEmail.On("SendEmail", reportUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string"), "").Return(nil)
+	mockWebhooks.On("Notify", mock.Anything, reportUser.ID, domain.EventReportGenerated, mock.Anything).Return(nil)
+
+	err := service.ProcessAndSend(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEmail.AssertExpectations(t)
+	customMock.AssertExpectations(t)
+}
+
 func TestFormatCardsTable(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	now := time.Now()
 	cards := []domain.Cards{
@@ -159,7 +361,7 @@ func TestFormatCardsTable(t *testing.T) {
 			Name:            "Lightning Bolt",
 			SetName:         "Alpha",
 			CollectorNumber: "161",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
 			CardsDetails: domain.CardsDetails{
 				LastPrice:   10.50,
 				OldPrice:    9.00,
@@ -172,7 +374,7 @@ func TestFormatCardsTable(t *testing.T) {
 			Name:            "Counterspell",
 			SetName:         "Alpha",
 			CollectorNumber: "50",
-			Foil:            true,
+			Finish:          domain.FinishFoil,
 			CardsDetails: domain.CardsDetails{
 				LastPrice:   4.00,
 				OldPrice:    5.25,
@@ -185,7 +387,7 @@ func TestFormatCardsTable(t *testing.T) {
 			Name:            "Black Lotus",
 			SetName:         "Alpha",
 			CollectorNumber: "232",
-			Foil:            false,
+			Finish:          domain.FinishNonfoil,
 			CardsDetails: domain.CardsDetails{
 				LastPrice:   5000.00,
 				OldPrice:    5000.00,
@@ -216,12 +418,31 @@ func TestFormatCardsTable(t *testing.T) {
 	assert.Contains(t, result, "</table>")
 }
 
+func TestFormatWishlistTable(t *testing.T) {
+	service := New(mocks.NewReportRepositoryMock(), mocks.NewEmailMock(), mocks.NewWebhookDispatcherMock(), 0, mocks.NewLogMock())
+
+	assert.Empty(t, service.formatWishlistTable(nil))
+
+	lastPrice := 4.5
+	result := service.formatWishlistTable([]domain.WishlistItem{
+		{Name: "Counterspell", SetName: "Beta", CollectorNumber: "54", Finish: domain.FinishFoil, MaxPrice: 5, LastPrice: &lastPrice},
+	})
+
+	assert.Contains(t, result, "Wishlist items at or below target")
+	assert.Contains(t, result, "Counterspell")
+	assert.Contains(t, result, "Beta")
+	assert.Contains(t, result, "5.00")
+	assert.Contains(t, result, "4.50")
+	assert.Contains(t, result, "</table>")
+}
+
 func TestFormatCardsPrice(t *testing.T) {
 	mockRepo := mocks.NewReportRepositoryMock()
 	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
 	mockLogger := mocks.NewLogMock()
 
-	service := New(mockRepo, mockEmail, mockLogger)
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
 
 	tests := []struct {
 		name     string
@@ -268,3 +489,45 @@ func TestFormatCardsPrice(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatUnrealizedGain(t *testing.T) {
+	mockRepo := mocks.NewReportRepositoryMock()
+	mockEmail := mocks.NewEmailMock()
+	mockWebhooks := mocks.NewWebhookDispatcherMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, mockEmail, mockWebhooks, 0, mockLogger)
+
+	tests := []struct {
+		name     string
+		gain     domain.UnrealizedGain
+		expected string
+	}{
+		{
+			name:     "Gain",
+			gain:     domain.UnrealizedGain{CostBasis: 100.00, MarketValue: 120.00, UnrealizedGain: 20.00},
+			expected: "gain",
+		},
+		{
+			name:     "Loss",
+			gain:     domain.UnrealizedGain{CostBasis: 100.00, MarketValue: 80.00, UnrealizedGain: -20.00},
+			expected: "loss",
+		},
+		{
+			name:     "Break-even",
+			gain:     domain.UnrealizedGain{CostBasis: 100.00, MarketValue: 100.00},
+			expected: "break-even",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.formatUnrealizedGain(tt.gain)
+
+			assert.Contains(t, result, tt.expected)
+			assert.Contains(t, result, fmt.Sprintf("R$%.2f", tt.gain.CostBasis))
+			assert.Contains(t, result, fmt.Sprintf("R$%.2f", tt.gain.MarketValue))
+			assert.Contains(t, result, fmt.Sprintf("R$%.2f", tt.gain.UnrealizedGain))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package retentionservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"time"
+)
+
+type service struct {
+	RetentionRepository ports.RetentionRepository
+	policy              domain.RetentionPolicy
+	log                 logrus.Logger
+}
+
+func New(rr ports.RetentionRepository, policy domain.RetentionPolicy, log logrus.Logger) *service {
+	return &service{
+		RetentionRepository: rr,
+		policy:              policy,
+		log:                 log,
+	}
+}
+
+// RollUp downsamples the price history: snapshots older than the daily
+// window become weekly aggregates, and weekly aggregates older than the
+// weekly window become monthly ones. The daily roll up goes first so its
+// weeks are rolled up into months in the same run.
+func (s *service) RollUp(ctx context.Context) error {
+	cutoffs := s.policy.Cutoffs(time.Now().UTC())
+
+	snapshots, err := s.RetentionRepository.RollUpDailyPrices(ctx, cutoffs.Daily)
+	if err != nil {
+		return fmt.Errorf("service failed to roll up daily prices: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"before": cutoffs.Daily.Format(domain.DateLayout), "snapshots": snapshots}).Info("daily prices rolled up")
+
+	weeks, err := s.RetentionRepository.RollUpWeeklyPrices(ctx, cutoffs.Weekly)
+	if err != nil {
+		return fmt.Errorf("service failed to roll up weekly prices: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{"before": cutoffs.Weekly.Format(domain.DateLayout), "weeks": weeks}).Info("weekly prices rolled up")
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package retentionservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"mtg-report/internal/core/domain"
+	"mtg-report/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var policy = domain.RetentionPolicy{DailyWindow: 90 * 24 * time.Hour, WeeklyWindow: 365 * 24 * time.Hour}
+
+func TestNew(t *testing.T) {
+	mockRepo := mocks.NewRetentionRepositoryMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, policy, mockLogger)
+
+	assert.NotNil(t, service)
+	assert.Equal(t, mockRepo, service.RetentionRepository)
+	assert.Equal(t, policy, service.policy)
+	assert.Equal(t, mockLogger, service.log)
+}
+
+func TestRollUp_Success(t *testing.T) {
+	mockRepo := mocks.NewRetentionRepositoryMock()
+	mockLogger := mocks.NewLogMock()
+	customMock := mocks.NewCustomMock()
+
+	service := New(mockRepo, policy, mockLogger)
+
+	cutoffs := policy.Cutoffs(time.Now().UTC())
+
+	mockRepo.On("RollUpDailyPrices", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Weekday() == time.Monday && !before.After(cutoffs.Daily)
+	})).Return(int64(120), nil)
+	mockRepo.On("RollUpWeeklyPrices", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Day() == 1 && !before.After(cutoffs.Weekly)
+	})).Return(int64(8), nil)
+	mockLogger.On("WithFields", mock.Anything).Return(customMock)
+	customMock.On("Info", mock.Anything).Twice()
+
+	err := service.RollUp(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	customMock.AssertExpectations(t)
+}
+
+func TestRollUp_DailyError(t *testing.T) {
+	mockRepo := mocks.NewRetentionRepositoryMock()
+	mockLogger := mocks.NewLogMock()
+
+	service := New(mockRepo, policy, mockLogger)
+
+	mockRepo.On("RollUpDailyPrices", mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("database error"))
+
+	err := service.RollUp(context.Background())
+
+	assert.ErrorContains(t, err, "service failed to roll up daily prices")
+	mockRepo.AssertNotCalled(t, "RollUpWeeklyPrices", mock.Anything, mock.Anything)
+}
+
+func TestRollUp_WeeklyError(t *testing.T) {
+	mockRepo := mocks.NewRetentionRepositoryMock()
+	mockLogger := mocks.NewLogMock()
+	customMock := mocks.NewCustomMock()
+
+	service := New(mockRepo, policy, mockLogger)
+
+	mockRepo.On("RollUpDailyPrices", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockRepo.On("RollUpWeeklyPrices", mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("database error"))
+	mockLogger.On("WithFields", mock.Anything).Return(customMock)
+	customMock.On("Info", mock.Anything).Once()
+
+	err := service.RollUp(context.Background())
+
+	assert.ErrorContains(t, err, "service failed to roll up weekly prices")
+	mockRepo.AssertExpectations(t)
+}
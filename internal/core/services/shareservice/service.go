@@ -0,0 +1,167 @@
+package shareservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"strconv"
+	"time"
+)
+
+type service struct {
+	sharesRepository ports.SharesRepository
+	cardService      ports.CardService
+	log              logrus.Logger
+}
+
+func New(sr ports.SharesRepository, cs ports.CardService, log logrus.Logger) *service {
+	return &service{
+		sharesRepository: sr,
+		cardService:      cs,
+		log:              log,
+	}
+}
+
+// InsertShare mints a share link for the collection. The token is only
+// returned here, the repository keeps its hash.
+func (s *service) InsertShare(ctx context.Context, shareRequest dtos.RequestShare) (dtos.ResponseShare, error) {
+	id, err := strconv.ParseInt(shareRequest.CollectionID, 10, 64)
+	if err != nil {
+		return dtos.ResponseShare{}, fmt.Errorf("service failed to parse id in insert share: %w", err)
+	}
+
+	token, err := domain.NewShareToken()
+	if err != nil {
+		return dtos.ResponseShare{}, fmt.Errorf("service failed to insert share: %w", err)
+	}
+
+	share, err := s.sharesRepository.InsertShare(ctx, domain.Share{
+		CollectionID:  id,
+		HideCostBasis: shareRequest.HideCostBasis,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}, domain.HashShareToken(token))
+	if err != nil {
+		return dtos.ResponseShare{}, fmt.Errorf("service failed to insert share: %w", err)
+	}
+
+	response := toResponseShare(share)
+	response.Token = token
+	response.URL = "/shared/" + token
+
+	return response, nil
+}
+
+func (s *service) GetShares(ctx context.Context, collectionID string) ([]dtos.ResponseShare, error) {
+	id, err := strconv.ParseInt(collectionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to parse id in get shares: %w", err)
+	}
+
+	sharesDomain, err := s.sharesRepository.GetShares(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get shares: %w", err)
+	}
+
+	shares := make([]dtos.ResponseShare, 0, len(sharesDomain))
+	for _, share := range sharesDomain {
+		shares = append(shares, toResponseShare(share))
+	}
+
+	return shares, nil
+}
+
+func (s *service) DeleteShare(ctx context.Context, collectionID, shareID string) error {
+	id, err := strconv.ParseInt(collectionID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("service failed to parse id in delete share: %w", err)
+	}
+
+	share, err := strconv.ParseInt(shareID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("service failed to parse share id in delete share: %w", err)
+	}
+
+	if err := s.sharesRepository.DeleteShare(ctx, id, share); err != nil {
+		return fmt.Errorf("service failed to delete share: %w", err)
+	}
+
+	return nil
+}
+
+// GetSharedCards lists the cards of the shared collection as its owner sees
+// them, without the acquisition fields when the share hides the cost basis.
+func (s *service) GetSharedCards(ctx context.Context, token string, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	share, err := s.sharesRepository.GetShareByToken(ctx, domain.HashShareToken(token))
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get share: %w", err)
+	}
+
+	cards, err := s.getCards(ctx, share, filters, page, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, fmt.Errorf("service failed to get shared cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// GetSharedCollection returns a page of the shared collection with its name
+// and total value.
+func (s *service) GetSharedCollection(ctx context.Context, token string, page, limit int) (dtos.ResponseSharedCollection, error) {
+	share, err := s.sharesRepository.GetShareByToken(ctx, domain.HashShareToken(token))
+	if err != nil {
+		return dtos.ResponseSharedCollection{}, fmt.Errorf("service failed to get share: %w", err)
+	}
+
+	cards, err := s.getCards(ctx, share, map[string]string{}, page, limit)
+	if err != nil {
+		return dtos.ResponseSharedCollection{}, fmt.Errorf("service failed to get shared collection: %w", err)
+	}
+
+	stats, err := s.cardService.GetCollectionStats(ownerContext(ctx, share), share.CollectionID, domain.CurrencyBRL)
+	if err != nil {
+		return dtos.ResponseSharedCollection{}, fmt.Errorf("service failed to get shared collection: %w", err)
+	}
+
+	return dtos.ResponseSharedCollection{
+		Name:       share.CollectionName,
+		TotalValue: stats.TotalValue,
+		Cards:      cards,
+	}, nil
+}
+
+func (s *service) getCards(ctx context.Context, share domain.Share, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	filters["collection_id"] = strconv.FormatInt(share.CollectionID, 10)
+
+	cards, err := s.cardService.GetCardsPaginated(ownerContext(ctx, share), filters, domain.CurrencyBRL, page, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedCards{}, err
+	}
+
+	if share.HideCostBasis {
+		for i := range cards.Cards {
+			cards.Cards[i].ResponseAcquisition = dtos.ResponseAcquisition{}
+			cards.Cards[i].CostBasis = nil
+			cards.Cards[i].UnrealizedGain = nil
+		}
+	}
+
+	return cards, nil
+}
+
+// ownerContext reads the shared collection on behalf of its owner, who always
+// sees all of its cards.
+func ownerContext(ctx context.Context, share domain.Share) context.Context {
+	return domain.WithUser(ctx, domain.User{ID: share.OwnerID})
+}
+
+func toResponseShare(share domain.Share) dtos.ResponseShare {
+	return dtos.ResponseShare{
+		ID:            share.ID,
+		CollectionID:  share.CollectionID,
+		HideCostBasis: share.HideCostBasis,
+		CreatedAt:     share.CreatedAt,
+	}
+}
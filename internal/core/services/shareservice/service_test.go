@@ -0,0 +1,162 @@
+package shareservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testToken = "abababababababababababababababababababababababababababababababab"
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+// ownerOf matches a context carrying the given owner as its user.
+func ownerOf(ownerID int64) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.UserFromContext(ctx).ID == ownerID
+	})
+}
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewSharesRepositoryMock(), mocks.NewCardServiceMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertShare(t *testing.T) {
+	t.Run("should store the hash of the returned token", func(t *testing.T) {
+		repoMock := mocks.NewSharesRepositoryMock()
+
+		var hash string
+		repoMock.On("InsertShare", mock.Anything, mock.MatchedBy(func(share domain.Share) bool {
+			return share.CollectionID == 2 && share.HideCostBasis
+		}), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { hash = args.String(2) }).
+			Return(domain.Share{ID: 3, CollectionID: 2, HideCostBasis: true}, nil)
+
+		service := New(repoMock, mocks.NewCardServiceMock(), mocks.NewLogMock())
+		got, err := service.InsertShare(context.Background(), dtos.RequestShare{CollectionID: "2", HideCostBasis: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), got.ID)
+		assert.Len(t, got.Token, 64)
+		assert.Equal(t, "/shared/"+got.Token, got.URL)
+		assert.Equal(t, domain.HashShareToken(got.Token), hash)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewSharesRepositoryMock()
+		repoMock.On("InsertShare", mock.Anything, mock.Anything, mock.Anything).Return(domain.Share{}, errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewCardServiceMock(), mocks.NewLogMock())
+		_, err := service.InsertShare(context.Background(), dtos.RequestShare{CollectionID: "2"})
+
+		assert.ErrorContains(t, err, "service failed to insert share")
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_DeleteShare(t *testing.T) {
+	repoMock := mocks.NewSharesRepositoryMock()
+	repoMock.On("DeleteShare", mock.Anything, int64(2), int64(3)).Return(domain.ErrShareNotFound{})
+
+	service := New(repoMock, mocks.NewCardServiceMock(), mocks.NewLogMock())
+	err := service.DeleteShare(context.Background(), "2", "3")
+
+	assert.ErrorIs(t, err, domain.ErrShareNotFound{})
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_GetSharedCards(t *testing.T) {
+	cards := func() dtos.ResponsePaginatedCards {
+		return dtos.ResponsePaginatedCards{
+			Cards: []dtos.ResponseCard{{
+				ID:                  1,
+				Name:                "Lightning Bolt",
+				LastPrice:           10,
+				ResponseAcquisition: dtos.ResponseAcquisition{AcquisitionPrice: float64Ptr(4), AcquisitionCurrency: "BRL"},
+				CostBasis:           float64Ptr(4),
+				UnrealizedGain:      float64Ptr(6),
+			}},
+			Page: 1, Limit: 20, Total: 1, TotalPages: 1,
+		}
+	}
+
+	t.Run("should list the cards of the collection as its owner", func(t *testing.T) {
+		repoMock := mocks.NewSharesRepositoryMock()
+		cardMock := mocks.NewCardServiceMock()
+
+		repoMock.On("GetShareByToken", mock.Anything, domain.HashShareToken(testToken)).
+			Return(domain.Share{ID: 3, CollectionID: 2, OwnerID: 7}, nil)
+		cardMock.On("GetCardsPaginated", ownerOf(7), map[string]string{"name": "Bolt", "collection_id": "2"}, domain.CurrencyBRL, 1, 20).
+			Return(cards(), nil)
+
+		service := New(repoMock, cardMock, mocks.NewLogMock())
+		got, err := service.GetSharedCards(context.Background(), testToken, map[string]string{"name": "Bolt"}, 1, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cards(), got)
+		repoMock.AssertExpectations(t)
+		cardMock.AssertExpectations(t)
+	})
+
+	t.Run("should hide the cost basis when the share asks to", func(t *testing.T) {
+		repoMock := mocks.NewSharesRepositoryMock()
+		cardMock := mocks.NewCardServiceMock()
+
+		repoMock.On("GetShareByToken", mock.Anything, mock.Anything).
+			Return(domain.Share{ID: 3, CollectionID: 2, OwnerID: 7, HideCostBasis: true}, nil)
+		cardMock.On("GetCardsPaginated", ownerOf(7), mock.Anything, domain.CurrencyBRL, 1, 20).Return(cards(), nil)
+
+		service := New(repoMock, cardMock, mocks.NewLogMock())
+		got, err := service.GetSharedCards(context.Background(), testToken, map[string]string{}, 1, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(10), got.Cards[0].LastPrice)
+		assert.Equal(t, dtos.ResponseAcquisition{}, got.Cards[0].ResponseAcquisition)
+		assert.Nil(t, got.Cards[0].CostBasis)
+		assert.Nil(t, got.Cards[0].UnrealizedGain)
+	})
+
+	t.Run("should not read cards of an unknown token", func(t *testing.T) {
+		repoMock := mocks.NewSharesRepositoryMock()
+		cardMock := mocks.NewCardServiceMock()
+
+		repoMock.On("GetShareByToken", mock.Anything, mock.Anything).Return(domain.Share{}, domain.ErrShareNotFound{})
+
+		service := New(repoMock, cardMock, mocks.NewLogMock())
+		_, err := service.GetSharedCards(context.Background(), testToken, map[string]string{}, 1, 20)
+
+		assert.ErrorIs(t, err, domain.ErrShareNotFound{})
+		cardMock.AssertNotCalled(t, "GetCardsPaginated", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_GetSharedCollection(t *testing.T) {
+	repoMock := mocks.NewSharesRepositoryMock()
+	cardMock := mocks.NewCardServiceMock()
+
+	repoMock.On("GetShareByToken", mock.Anything, mock.Anything).
+		Return(domain.Share{ID: 3, CollectionID: 2, CollectionName: "Trade Binder", OwnerID: 7}, nil)
+	cardMock.On("GetCardsPaginated", ownerOf(7), map[string]string{"collection_id": "2"}, domain.CurrencyBRL, 1, 100).
+		Return(dtos.ResponsePaginatedCards{Page: 1, Limit: 100}, nil)
+	cardMock.On("GetCollectionStats", ownerOf(7), int64(2), domain.CurrencyBRL).Return(dtos.ResponseCollectionStats{TotalValue: 42.5}, nil)
+
+	service := New(repoMock, cardMock, mocks.NewLogMock())
+	got, err := service.GetSharedCollection(context.Background(), testToken, 1, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Trade Binder", got.Name)
+	assert.Equal(t, 42.5, got.TotalValue)
+	repoMock.AssertExpectations(t)
+	cardMock.AssertExpectations(t)
+}
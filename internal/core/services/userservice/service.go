@@ -0,0 +1,89 @@
+package userservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"strings"
+)
+
+type service struct {
+	usersRepository ports.UsersRepository
+	log             logrus.Logger
+}
+
+func New(ur ports.UsersRepository, log logrus.Logger) *service {
+	return &service{
+		usersRepository: ur,
+		log:             log,
+	}
+}
+
+// InsertUser creates a user and returns its API key. The key is not stored,
+// so this is the only time it is shown.
+func (s *service) InsertUser(ctx context.Context, userRequest dtos.RequestUser) (dtos.ResponseUser, error) {
+	apiKey, err := domain.NewAPIKey()
+	if err != nil {
+		return dtos.ResponseUser{}, fmt.Errorf("service failed to create api key in insert user: %w", err)
+	}
+
+	user := domain.User{
+		Name:  userRequest.Name,
+		Email: strings.ToLower(userRequest.Email),
+	}
+
+	user, err = s.usersRepository.InsertUser(ctx, user, domain.HashAPIKey(apiKey))
+	if err != nil {
+		return dtos.ResponseUser{}, fmt.Errorf("service failed to insert user: %w", err)
+	}
+
+	response := toResponseUser(user)
+	response.APIKey = apiKey
+
+	return response, nil
+}
+
+func (s *service) Authenticate(ctx context.Context, apiKey string) (domain.User, error) {
+	if len(apiKey) == 0 {
+		return domain.User{}, domain.ErrUnauthorized{}
+	}
+
+	user, err := s.usersRepository.GetUserByAPIKey(ctx, domain.HashAPIKey(apiKey))
+	if err != nil {
+		return domain.User{}, fmt.Errorf("service failed to authenticate: %w", err)
+	}
+
+	return user, nil
+}
+
+// RotateAPIKey replaces the API key of the authenticated user, the previous
+// key stops working at once.
+func (s *service) RotateAPIKey(ctx context.Context) (dtos.ResponseUser, error) {
+	user := domain.UserFromContext(ctx)
+
+	apiKey, err := domain.NewAPIKey()
+	if err != nil {
+		return dtos.ResponseUser{}, fmt.Errorf("service failed to create api key in rotate api key: %w", err)
+	}
+
+	err = s.usersRepository.UpdateAPIKey(ctx, user.ID, domain.HashAPIKey(apiKey))
+	if err != nil {
+		return dtos.ResponseUser{}, fmt.Errorf("service failed to rotate api key: %w", err)
+	}
+
+	response := toResponseUser(user)
+	response.APIKey = apiKey
+
+	return response, nil
+}
+
+func toResponseUser(user domain.User) dtos.ResponseUser {
+	return dtos.ResponseUser{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+	}
+}
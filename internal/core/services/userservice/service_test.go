@@ -0,0 +1,122 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew(t *testing.T) {
+	repoMock := mocks.NewUsersRepositoryMock()
+	logMock := mocks.NewLogMock()
+
+	service := New(repoMock, logMock)
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertUser(t *testing.T) {
+	t.Run("should store the hash of the returned api key", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+
+		var hash string
+		repoMock.On("InsertUser", mock.Anything, domain.User{Name: "Jace", Email: "jace@example.com"}, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { hash = args.String(2) }).
+			Return(domain.User{ID: 2, Name: "Jace", Email: "jace@example.com"}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.InsertUser(context.Background(), dtos.RequestUser{Name: "Jace", Email: "Jace@Example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), got.ID)
+		assert.Equal(t, "jace@example.com", got.Email)
+		assert.Len(t, got.APIKey, 64)
+		assert.Equal(t, domain.HashAPIKey(got.APIKey), hash)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+		repoMock.On("InsertUser", mock.Anything, mock.Anything, mock.Anything).Return(domain.User{}, domain.ErrUserAlreadyExists{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.InsertUser(context.Background(), dtos.RequestUser{Name: "Jace", Email: "jace@example.com"})
+
+		assert.ErrorIs(t, err, domain.ErrUserAlreadyExists{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Run("should refuse an empty api key", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.Authenticate(context.Background(), "")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized{})
+		repoMock.AssertNotCalled(t, "GetUserByAPIKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should look the user up by the hash of the api key", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+		repoMock.On("GetUserByAPIKey", mock.Anything, domain.HashAPIKey("secret")).Return(domain.User{ID: 2, Name: "Jace"}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.Authenticate(context.Background(), "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.User{ID: 2, Name: "Jace"}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+		repoMock.On("GetUserByAPIKey", mock.Anything, mock.Anything).Return(domain.User{}, domain.ErrUnauthorized{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.Authenticate(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_RotateAPIKey(t *testing.T) {
+	user := domain.User{ID: 2, Name: "Jace", Email: "jace@example.com"}
+	ctx := domain.WithUser(context.Background(), user)
+
+	t.Run("should replace the api key of the user", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+
+		var hash string
+		repoMock.On("UpdateAPIKey", mock.Anything, int64(2), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { hash = args.String(2) }).
+			Return(nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.RotateAPIKey(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jace@example.com", got.Email)
+		assert.Equal(t, domain.HashAPIKey(got.APIKey), hash)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewUsersRepositoryMock()
+		repoMock.On("UpdateAPIKey", mock.Anything, int64(2), mock.Anything).Return(errors.New("repository error"))
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.RotateAPIKey(ctx)
+
+		assert.ErrorContains(t, err, "service failed to rotate api key")
+		repoMock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,152 @@
+package webhookservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"time"
+)
+
+type service struct {
+	webhooksRepository ports.WebhooksRepository
+	urlGuard           ports.URLGuard
+	log                logrus.Logger
+}
+
+func New(wr ports.WebhooksRepository, ug ports.URLGuard, log logrus.Logger) *service {
+	return &service{
+		webhooksRepository: wr,
+		urlGuard:           ug,
+		log:                log,
+	}
+}
+
+// InsertWebhook creates the webhook with a new signing secret. The secret is
+// only returned here. URLs resolving to loopback, private or link-local
+// addresses are refused.
+func (s *service) InsertWebhook(ctx context.Context, webhookRequest dtos.RequestWebhook) (dtos.ResponseWebhook, error) {
+	err := s.urlGuard.CheckURL(ctx, webhookRequest.URL)
+	if err != nil {
+		return dtos.ResponseWebhook{}, fmt.Errorf("service failed to check webhook url: %w: %w", domain.ErrWebhookURLNotAllowed{}, err)
+	}
+
+	secret, err := domain.NewWebhookSecret()
+	if err != nil {
+		return dtos.ResponseWebhook{}, fmt.Errorf("service failed to insert webhook: %w", err)
+	}
+
+	webhook := domain.Webhook{
+		UserID:    domain.UserFromContext(ctx).ID,
+		URL:       webhookRequest.URL,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+
+	seen := make(map[domain.WebhookEvent]bool)
+	for _, name := range webhookRequest.Events {
+		event := domain.WebhookEvent(name)
+		if !seen[event] {
+			seen[event] = true
+			webhook.Events = append(webhook.Events, event)
+		}
+	}
+
+	webhook, err = s.webhooksRepository.InsertWebhook(ctx, webhook)
+	if err != nil {
+		return dtos.ResponseWebhook{}, fmt.Errorf("service failed to insert webhook: %w", err)
+	}
+
+	response := toResponseWebhook(webhook)
+	response.Secret = webhook.Secret
+
+	return response, nil
+}
+
+func (s *service) GetWebhooks(ctx context.Context) ([]dtos.ResponseWebhook, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	webhooksDomain, err := s.webhooksRepository.GetWebhooks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get webhooks: %w", err)
+	}
+
+	webhooks := make([]dtos.ResponseWebhook, 0, len(webhooksDomain))
+	for _, webhook := range webhooksDomain {
+		webhooks = append(webhooks, toResponseWebhook(webhook))
+	}
+
+	return webhooks, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id string) error {
+	userID := domain.UserFromContext(ctx).ID
+
+	err := s.webhooksRepository.DeleteWebhook(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("service failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetWebhookDeliveries(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedWebhookDeliveries, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	webhook, err := s.webhooksRepository.GetWebhookByID(ctx, userID, id)
+	if err != nil {
+		return dtos.ResponsePaginatedWebhookDeliveries{}, fmt.Errorf("service failed to get webhook: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.webhooksRepository.GetWebhookDeliveriesCount(ctx, webhook.ID)
+	if err != nil {
+		return dtos.ResponsePaginatedWebhookDeliveries{}, fmt.Errorf("service failed to get webhook deliveries count: %w", err)
+	}
+
+	deliveriesDomain, err := s.webhooksRepository.GetWebhookDeliveries(ctx, webhook.ID, offset, limit)
+	if err != nil {
+		return dtos.ResponsePaginatedWebhookDeliveries{}, fmt.Errorf("service failed to get webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]dtos.ResponseWebhookDelivery, 0, len(deliveriesDomain))
+	for _, delivery := range deliveriesDomain {
+		deliveries = append(deliveries, dtos.ResponseWebhookDelivery{
+			ID:         delivery.ID,
+			DeliveryID: delivery.DeliveryID,
+			Event:      string(delivery.Event),
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			Success:    delivery.Success,
+			CreatedAt:  delivery.CreatedAt,
+		})
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return dtos.ResponsePaginatedWebhookDeliveries{
+		Deliveries: deliveries,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func toResponseWebhook(webhook domain.Webhook) dtos.ResponseWebhook {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	return dtos.ResponseWebhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package webhookservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewWebhooksRepositoryMock(), mocks.NewURLGuardMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertWebhook(t *testing.T) {
+	t.Run("should insert the webhook with a new secret and return it once", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		guardMock := mocks.NewURLGuardMock()
+		guardMock.On("CheckURL", mock.Anything, "https://hooks.test.com").Return(nil)
+
+		repoMock.On("InsertWebhook", mock.Anything, mock.MatchedBy(func(webhook domain.Webhook) bool {
+			return webhook.UserID == testUserID && webhook.URL == "https://hooks.test.com" && len(webhook.Secret) == 64 &&
+				assert.ObjectsAreEqual([]domain.WebhookEvent{domain.EventReportGenerated, domain.EventCardPriceChanged}, webhook.Events)
+		})).Return(domain.Webhook{ID: 4, UserID: testUserID, URL: "https://hooks.test.com", Secret: "secret",
+			Events: []domain.WebhookEvent{domain.EventReportGenerated, domain.EventCardPriceChanged}}, nil)
+
+		service := New(repoMock, guardMock, mocks.NewLogMock())
+		got, err := service.InsertWebhook(userCtx, dtos.RequestWebhook{
+			URL:    "https://hooks.test.com",
+			Events: []string{"report.generated", "card.price_changed", "report.generated"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), got.ID)
+		assert.Equal(t, "secret", got.Secret)
+		assert.Equal(t, []string{"report.generated", "card.price_changed"}, got.Events)
+		repoMock.AssertExpectations(t)
+		guardMock.AssertExpectations(t)
+	})
+
+	t.Run("should refuse urls the guard rejects", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		guardMock := mocks.NewURLGuardMock()
+		guardMock.On("CheckURL", mock.Anything, "http://169.254.169.254/latest").Return(errors.New("blocked"))
+
+		service := New(repoMock, guardMock, mocks.NewLogMock())
+		_, err := service.InsertWebhook(userCtx, dtos.RequestWebhook{URL: "http://169.254.169.254/latest", Events: []string{"report.generated"}})
+
+		assert.ErrorIs(t, err, domain.ErrWebhookURLNotAllowed{})
+		repoMock.AssertNotCalled(t, "InsertWebhook", mock.Anything, mock.Anything)
+		guardMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		repoMock.On("InsertWebhook", mock.Anything, mock.Anything).Return(domain.Webhook{}, errors.New("repository error"))
+		guardMock := mocks.NewURLGuardMock()
+		guardMock.On("CheckURL", mock.Anything, "https://hooks.test.com").Return(nil)
+
+		service := New(repoMock, guardMock, mocks.NewLogMock())
+		_, err := service.InsertWebhook(userCtx, dtos.RequestWebhook{URL: "https://hooks.test.com"})
+
+		assert.ErrorContains(t, err, "service failed to insert webhook")
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_GetWebhooks(t *testing.T) {
+	repoMock := mocks.NewWebhooksRepositoryMock()
+	repoMock.On("GetWebhooks", mock.Anything, testUserID).Return([]domain.Webhook{
+		{ID: 4, URL: "https://hooks.test.com", Secret: "secret", Events: []domain.WebhookEvent{domain.EventConciliationFinished}},
+	}, nil)
+
+	service := New(repoMock, mocks.NewURLGuardMock(), mocks.NewLogMock())
+	got, err := service.GetWebhooks(userCtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dtos.ResponseWebhook{{ID: 4, URL: "https://hooks.test.com", Events: []string{"conciliation.finished"}}}, got)
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_DeleteWebhook(t *testing.T) {
+	repoMock := mocks.NewWebhooksRepositoryMock()
+	repoMock.On("DeleteWebhook", mock.Anything, testUserID, "4").Return(domain.ErrWebhookNotFound{})
+
+	service := New(repoMock, mocks.NewURLGuardMock(), mocks.NewLogMock())
+	err := service.DeleteWebhook(userCtx, "4")
+
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound{})
+	repoMock.AssertExpectations(t)
+}
+
+func TestService_GetWebhookDeliveries(t *testing.T) {
+	t.Run("should paginate the deliveries of the webhook", func(t *testing.T) {
+		createdAt := time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)
+
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		repoMock.On("GetWebhookByID", mock.Anything, testUserID, "4").Return(domain.Webhook{ID: 4}, nil)
+		repoMock.On("GetWebhookDeliveriesCount", mock.Anything, int64(4)).Return(int64(11), nil)
+		repoMock.On("GetWebhookDeliveries", mock.Anything, int64(4), 10, 10).Return([]domain.WebhookDelivery{
+			{ID: 9, WebhookID: 4, DeliveryID: "abc", Event: domain.EventReportGenerated, Attempt: 1, StatusCode: 200,
+				Success: true, CreatedAt: createdAt},
+		}, nil)
+
+		service := New(repoMock, mocks.NewURLGuardMock(), mocks.NewLogMock())
+		got, err := service.GetWebhookDeliveries(userCtx, "4", 2, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponsePaginatedWebhookDeliveries{
+			Deliveries: []dtos.ResponseWebhookDelivery{
+				{ID: 9, DeliveryID: "abc", Event: "report.generated", Attempt: 1, StatusCode: 200, Success: true, CreatedAt: createdAt},
+			},
+			Page:       2,
+			Limit:      10,
+			Total:      11,
+			TotalPages: 2,
+		}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should not list the deliveries of webhooks of other users", func(t *testing.T) {
+		repoMock := mocks.NewWebhooksRepositoryMock()
+		repoMock.On("GetWebhookByID", mock.Anything, testUserID, "4").Return(domain.Webhook{}, domain.ErrWebhookNotFound{})
+
+		service := New(repoMock, mocks.NewURLGuardMock(), mocks.NewLogMock())
+		_, err := service.GetWebhookDeliveries(userCtx, "4", 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound{})
+		repoMock.AssertNotCalled(t, "GetWebhookDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,97 @@
+package wishlistservice
+
+import (
+	"context"
+	"fmt"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/internal/core/ports"
+	"mtg-report/internal/sources/logger/logrus"
+	"strconv"
+)
+
+type service struct {
+	wishlistRepository ports.WishlistRepository
+	log                logrus.Logger
+}
+
+func New(wr ports.WishlistRepository, log logrus.Logger) *service {
+	return &service{
+		wishlistRepository: wr,
+		log:                log,
+	}
+}
+
+func (s *service) InsertWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	item, err := s.wishlistRepository.InsertWishlistItem(ctx, userID, domain.WishlistItem{
+		Name:            itemRequest.Name,
+		SetName:         itemRequest.SetName,
+		CollectorNumber: itemRequest.CollectorNumber,
+		Finish:          domain.NormalizeFinish(itemRequest.Finish),
+		MaxPrice:        *itemRequest.MaxPrice,
+	})
+	if err != nil {
+		return dtos.ResponseWishlistItem{}, fmt.Errorf("service failed to insert wishlist item: %w", err)
+	}
+
+	return toResponseWishlistItem(item), nil
+}
+
+func (s *service) GetWishlist(ctx context.Context) ([]dtos.ResponseWishlistItem, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	itemsDomain, err := s.wishlistRepository.GetWishlist(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service failed to get wishlist: %w", err)
+	}
+
+	items := make([]dtos.ResponseWishlistItem, 0, len(itemsDomain))
+	for _, item := range itemsDomain {
+		items = append(items, toResponseWishlistItem(item))
+	}
+
+	return items, nil
+}
+
+func (s *service) UpdateWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error) {
+	userID := domain.UserFromContext(ctx).ID
+
+	id, err := strconv.ParseInt(itemRequest.ID, 10, 64)
+	if err != nil {
+		return dtos.ResponseWishlistItem{}, fmt.Errorf("service failed to parse id in update wishlist item: %w", err)
+	}
+
+	item, err := s.wishlistRepository.UpdateWishlistItem(ctx, userID, id, *itemRequest.MaxPrice)
+	if err != nil {
+		return dtos.ResponseWishlistItem{}, fmt.Errorf("service failed to update wishlist item: %w", err)
+	}
+
+	return toResponseWishlistItem(item), nil
+}
+
+func (s *service) DeleteWishlistItem(ctx context.Context, id string) error {
+	userID := domain.UserFromContext(ctx).ID
+
+	err := s.wishlistRepository.DeleteWishlistItem(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("service failed to delete wishlist item: %w", err)
+	}
+
+	return nil
+}
+
+func toResponseWishlistItem(item domain.WishlistItem) dtos.ResponseWishlistItem {
+	return dtos.ResponseWishlistItem{
+		ID:              item.ID,
+		Name:            item.Name,
+		Set:             item.SetName,
+		CollectorNumber: item.CollectorNumber,
+		Finish:          string(item.Finish),
+		MaxPrice:        item.MaxPrice,
+		LastPrice:       item.LastPrice,
+		LastUpdate:      item.LastUpdate,
+		Affordable:      item.Affordable(),
+	}
+}
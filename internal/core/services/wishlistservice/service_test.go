@@ -0,0 +1,145 @@
+package wishlistservice
+
+import (
+	"context"
+	"errors"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+	"mtg-report/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID int64 = 7
+
+var userCtx = domain.WithUser(context.Background(), domain.User{ID: testUserID})
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestNew(t *testing.T) {
+	service := New(mocks.NewWishlistRepositoryMock(), mocks.NewLogMock())
+
+	assert.NotNil(t, service)
+}
+
+func TestService_InsertWishlistItem(t *testing.T) {
+	request := dtos.RequestWishlistItem{
+		Name:            "Lightning Bolt",
+		SetName:         "Alpha",
+		CollectorNumber: "161",
+		Finish:          "foil",
+		MaxPrice:        float64Ptr(50),
+	}
+
+	t.Run("should insert the item for the user", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("InsertWishlistItem", mock.Anything, testUserID, domain.WishlistItem{
+			Name:            "Lightning Bolt",
+			SetName:         "Alpha",
+			CollectorNumber: "161",
+			Finish:          domain.FinishFoil,
+			MaxPrice:        50,
+		}).Return(domain.WishlistItem{ID: 3, UserID: testUserID, Name: "Lightning Bolt", SetName: "Alpha",
+			CollectorNumber: "161", Finish: domain.FinishFoil, MaxPrice: 50}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.InsertWishlistItem(userCtx, request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.ResponseWishlistItem{ID: 3, Name: "Lightning Bolt", Set: "Alpha", CollectorNumber: "161",
+			Finish: "foil", MaxPrice: 50}, got)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("InsertWishlistItem", mock.Anything, testUserID, mock.Anything).
+			Return(domain.WishlistItem{}, domain.ErrWishlistItemAlreadyExists{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.InsertWishlistItem(userCtx, request)
+
+		assert.ErrorIs(t, err, domain.ErrWishlistItemAlreadyExists{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_GetWishlist(t *testing.T) {
+	t.Run("should flag the items at or below the target price", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("GetWishlist", mock.Anything, testUserID).Return([]domain.WishlistItem{
+			{ID: 3, Name: "Lightning Bolt", MaxPrice: 50, LastPrice: float64Ptr(45)},
+			{ID: 4, Name: "Counterspell", MaxPrice: 5, LastPrice: float64Ptr(7)},
+			{ID: 5, Name: "Black Lotus", MaxPrice: 100},
+		}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetWishlist(userCtx)
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.True(t, got[0].Affordable)
+		assert.False(t, got[1].Affordable)
+		assert.False(t, got[2].Affordable)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should return an empty list when there are no items", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("GetWishlist", mock.Anything, testUserID).Return([]domain.WishlistItem(nil), nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.GetWishlist(userCtx)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+}
+
+func TestService_UpdateWishlistItem(t *testing.T) {
+	t.Run("should update the target price", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("UpdateWishlistItem", mock.Anything, testUserID, int64(3), 40.0).
+			Return(domain.WishlistItem{ID: 3, MaxPrice: 40, LastPrice: float64Ptr(45)}, nil)
+
+		service := New(repoMock, mocks.NewLogMock())
+		got, err := service.UpdateWishlistItem(userCtx, dtos.RequestWishlistItem{ID: "3", MaxPrice: float64Ptr(40)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 40.0, got.MaxPrice)
+		assert.False(t, got.Affordable)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		repoMock := mocks.NewWishlistRepositoryMock()
+		repoMock.On("UpdateWishlistItem", mock.Anything, testUserID, int64(3), 40.0).
+			Return(domain.WishlistItem{}, domain.ErrWishlistItemNotFound{})
+
+		service := New(repoMock, mocks.NewLogMock())
+		_, err := service.UpdateWishlistItem(userCtx, dtos.RequestWishlistItem{ID: "3", MaxPrice: float64Ptr(40)})
+
+		assert.ErrorIs(t, err, domain.ErrWishlistItemNotFound{})
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestService_DeleteWishlistItem(t *testing.T) {
+	repoMock := mocks.NewWishlistRepositoryMock()
+	repoMock.On("DeleteWishlistItem", mock.Anything, testUserID, "3").Return(errors.New("repository error"))
+
+	service := New(repoMock, mocks.NewLogMock())
+	err := service.DeleteWishlistItem(userCtx, "3")
+
+	assert.ErrorContains(t, err, "service failed to delete wishlist item")
+	repoMock.AssertExpectations(t)
+}
@@ -1,9 +1,16 @@
 package validate
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
+	"net/mail"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type validator struct{}
@@ -25,12 +32,42 @@ func (v *validator) Card(card dtos.RequestInsertCard) error {
 		return errors.New("set_name is required")
 	}
 
-	if card.Foil == nil {
-		return errors.New("foil is required")
+	if err := finish(card.Finish); err != nil {
+		return err
 	}
 
-	if *card.Foil != true && *card.Foil != false {
-		return errors.New("foil must be true or false")
+	if len(card.Condition) != 0 && !domain.ValidCondition(domain.NormalizeCondition(card.Condition)) {
+		return errors.New("condition must be one of NM, LP, MP, HP or DMG")
+	}
+
+	if len(card.Language) != 0 && !domain.ValidLanguage(domain.NormalizeLanguage(card.Language)) {
+		return errors.New("language is not supported")
+	}
+
+	if card.Quantity != nil && *card.Quantity < 1 {
+		return errors.New("quantity must be greater than 0")
+	}
+
+	if card.CollectionID != nil && *card.CollectionID < 1 {
+		return errors.New("collection_id must be greater than 0")
+	}
+
+	return v.acquisition(card.RequestAcquisition)
+}
+
+func (v *validator) acquisition(acquisition dtos.RequestAcquisition) error {
+	if acquisition.AcquisitionPrice != nil && *acquisition.AcquisitionPrice < 0 {
+		return errors.New("acquisition_price must not be negative")
+	}
+
+	if len(acquisition.AcquisitionCurrency) != 0 && !domain.ValidCurrency(domain.NormalizeCurrency(acquisition.AcquisitionCurrency)) {
+		return errors.New("acquisition_currency must be BRL or USD")
+	}
+
+	if len(acquisition.AcquisitionDate) != 0 {
+		if _, err := time.Parse(domain.DateLayout, acquisition.AcquisitionDate); err != nil {
+			return errors.New("acquisition_date must be in YYYY-MM-DD format")
+		}
 	}
 
 	return nil
@@ -57,15 +94,107 @@ func (v *validator) CardID(parts []string) (string, error) {
 	return id, nil
 }
 
-func (v *validator) CardName(card dtos.RequestUpdateCard) error {
-	if card.Name == "" {
-		return errors.New("name is required")
+func (v *validator) UpdateCard(card dtos.RequestUpdateCard) error {
+	if card.Name == "" && card.Quantity == nil && card.CollectionID == nil && card.AcquisitionPrice == nil &&
+		card.AcquisitionCurrency == "" && card.AcquisitionDate == "" {
+		return errors.New("name, quantity, collection_id or acquisition fields are required")
+	}
+
+	if card.Quantity != nil && *card.Quantity < 1 {
+		return errors.New("quantity must be greater than 0")
+	}
+
+	if card.CollectionID != nil && *card.CollectionID < 1 {
+		return errors.New("collection_id must be greater than 0")
+	}
+
+	return v.acquisition(card.RequestAcquisition)
+}
+
+func (v *validator) Sale(sale dtos.RequestSellCard) error {
+	if sale.SalePrice == nil {
+		return errors.New("sale_price is required")
+	}
+
+	if *sale.SalePrice < 0 {
+		return errors.New("sale_price must not be negative")
+	}
+
+	if sale.Quantity != nil && *sale.Quantity < 1 {
+		return errors.New("quantity must be greater than 0")
+	}
+
+	if sale.Fees < 0 {
+		return errors.New("fees must not be negative")
+	}
+
+	if len(sale.SaleDate) != 0 {
+		if _, err := time.Parse(domain.DateLayout, sale.SaleDate); err != nil {
+			return errors.New("sale_date must be in YYYY-MM-DD format")
+		}
 	}
 
 	return nil
 }
 
-func (v *validator) Filters(setName, name, collector_number string) map[string]string {
+// Currency returns the currency values are shown in, BRL when none is given.
+func (v *validator) Currency(currency string) (string, error) {
+	currency = domain.NormalizeCurrency(currency)
+	if !domain.ValidValuationCurrency(currency) {
+		return "", errors.New("currency must be one of BRL, USD or EUR")
+	}
+
+	return currency, nil
+}
+
+// Resolution returns the resolution the price history is shown at, daily
+// when none is given.
+func (v *validator) Resolution(resolution string) (domain.PriceResolution, error) {
+	if len(resolution) == 0 {
+		return domain.PriceResolutionDaily, nil
+	}
+
+	priceResolution := domain.PriceResolution(strings.ToLower(resolution))
+	if !domain.ValidPriceResolution(priceResolution) {
+		return "", errors.New("resolution must be one of daily, weekly or monthly")
+	}
+
+	return priceResolution, nil
+}
+
+// QuarantineStatus returns the status quarantined prices are listed by,
+// pending when none is given.
+func (v *validator) QuarantineStatus(status string) (domain.QuarantineStatus, error) {
+	if len(status) == 0 {
+		return domain.QuarantinePending, nil
+	}
+
+	quarantineStatus := domain.QuarantineStatus(strings.ToLower(status))
+	if !domain.ValidQuarantineStatus(quarantineStatus) {
+		return "", errors.New("status must be one of pending, accepted, rejected or superseded")
+	}
+
+	return quarantineStatus, nil
+}
+
+func (v *validator) Year(yearStr string) (int, error) {
+	if yearStr == "" {
+		return time.Now().Year(), nil
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return 0, errors.New("invalid year parameter")
+	}
+
+	if year < 1993 || year > 9999 {
+		return 0, errors.New("year must be between 1993 and 9999")
+	}
+
+	return year, nil
+}
+
+func (v *validator) Filters(setName, name, collector_number, collection string) map[string]string {
 	filters := make(map[string]string)
 
 	if len(setName) != 0 {
@@ -80,9 +209,218 @@ func (v *validator) Filters(setName, name, collector_number string) map[string]s
 		filters["collector_number"] = collector_number
 	}
 
+	if len(collection) != 0 {
+		filters["collection_id"] = collection
+	}
+
 	return filters
 }
 
+func (v *validator) CollectionID(collection string) (int64, error) {
+	if collection == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(collection, 10, 64)
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid collection parameter")
+	}
+
+	return id, nil
+}
+
+func (v *validator) Collection(collection dtos.RequestCollection) error {
+	if collection.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(collection.Name) > 255 {
+		return errors.New("name must have at most 255 characters")
+	}
+
+	return nil
+}
+
+func (v *validator) UpdateCollection(collection dtos.RequestCollection) error {
+	if collection.Name == "" && collection.Description == nil {
+		return errors.New("name or description is required")
+	}
+
+	if len(collection.Name) > 255 {
+		return errors.New("name must have at most 255 characters")
+	}
+
+	return nil
+}
+
+func (v *validator) User(user dtos.RequestUser) error {
+	if user.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(user.Name) > 255 {
+		return errors.New("name must have at most 255 characters")
+	}
+
+	if user.Email == "" {
+		return errors.New("email is required")
+	}
+
+	address, err := mail.ParseAddress(user.Email)
+	if err != nil || address.Address != user.Email || len(user.Email) > 255 {
+		return errors.New("email is invalid")
+	}
+
+	return nil
+}
+
+func (v *validator) Member(member dtos.RequestMember) error {
+	if member.Email == "" {
+		return errors.New("email is required")
+	}
+
+	if !domain.ValidMemberRole(domain.Role(member.Role)) {
+		return errors.New("role must be reader or editor")
+	}
+
+	return nil
+}
+
+// MemberID returns the collection and user ids of a
+// /collection/{id}/members/{user_id} url.
+func (v *validator) MemberID(parts []string) (string, string, error) {
+	return subresourceID(parts, "members")
+}
+
+func (v *validator) ShareID(parts []string) (string, string, error) {
+	return subresourceID(parts, "shares")
+}
+
+// subresourceID returns the collection and item ids of a
+// /collection/{id}/{resource}/{item_id} url.
+func subresourceID(parts []string, resource string) (string, string, error) {
+	if len(parts) != 5 || parts[3] != resource {
+		return "", "", errors.New("invalid url")
+	}
+
+	for _, id := range []string{parts[2], parts[4]} {
+		if len(id) == 0 {
+			return "", "", errors.New("id is required")
+		}
+
+		if _, err := strconv.Atoi(id); err != nil {
+			return "", "", errors.New("invalid id")
+		}
+	}
+
+	return parts[2], parts[4], nil
+}
+
+// ShareToken returns the token of a /shared/{token} or /shared/{token}/cards
+// url.
+func (v *validator) ShareToken(parts []string) (string, error) {
+	if len(parts) < 3 || len(parts) > 4 || (len(parts) == 4 && parts[3] != "cards") {
+		return "", errors.New("invalid url")
+	}
+
+	if _, err := hex.DecodeString(parts[2]); err != nil || len(parts[2]) != 64 {
+		return "", errors.New("invalid share token")
+	}
+
+	return parts[2], nil
+}
+
+func (v *validator) WishlistItem(item dtos.RequestWishlistItem) error {
+	if item.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if item.CollectorNumber == "" {
+		return errors.New("collector_number is required")
+	}
+
+	if item.SetName == "" {
+		return errors.New("set_name is required")
+	}
+
+	if err := finish(item.Finish); err != nil {
+		return err
+	}
+
+	return v.maxPrice(item.MaxPrice)
+}
+
+func (v *validator) UpdateWishlistItem(item dtos.RequestWishlistItem) error {
+	return v.maxPrice(item.MaxPrice)
+}
+
+func (v *validator) maxPrice(maxPrice *float64) error {
+	if maxPrice == nil {
+		return errors.New("max_price is required")
+	}
+
+	if *maxPrice < 0 {
+		return errors.New("max_price must not be negative")
+	}
+
+	return nil
+}
+
+func (v *validator) Alert(alert dtos.RequestAlert) error {
+	if (alert.CardID == nil) == (alert.SetName == "") {
+		return errors.New("either card_id or set_name is required")
+	}
+
+	if alert.CardID != nil && *alert.CardID < 1 {
+		return errors.New("card_id must be greater than 0")
+	}
+
+	if !domain.ValidAlertKind(domain.AlertKind(strings.ToLower(alert.Kind))) {
+		return errors.New("kind must be percentage or absolute")
+	}
+
+	if !domain.ValidAlertDirection(domain.AlertDirection(strings.ToLower(alert.Direction))) {
+		return errors.New("direction must be up or down")
+	}
+
+	if alert.Threshold == nil {
+		return errors.New("threshold is required")
+	}
+
+	if *alert.Threshold <= 0 {
+		return errors.New("threshold must be greater than 0")
+	}
+
+	return nil
+}
+
+func (v *validator) Webhook(webhook dtos.RequestWebhook) error {
+	if webhook.URL == "" {
+		return errors.New("url is required")
+	}
+
+	if len(webhook.URL) > 2048 {
+		return errors.New("url must have at most 2048 characters")
+	}
+
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+
+	if len(webhook.Events) == 0 {
+		return errors.New("events is required")
+	}
+
+	for _, event := range webhook.Events {
+		if !domain.ValidWebhookEvent(domain.WebhookEvent(event)) {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+
+	return nil
+}
+
 func (v *validator) Pagination(pageStr, limitStr string) (int, int, error) {
 	page := 1
 	limit := 20 // default limit
@@ -111,3 +449,15 @@ func (v *validator) Pagination(pageStr, limitStr string) (int, int, error) {
 
 	return page, limit, nil
 }
+
+func finish(finish string) error {
+	if finish == "" {
+		return errors.New("finish is required")
+	}
+
+	if !domain.ValidFinish(domain.NormalizeFinish(finish)) {
+		return errors.New("finish must be one of nonfoil, foil, etched, gilded, textured or surge")
+	}
+
+	return nil
+}
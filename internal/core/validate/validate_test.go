@@ -1,8 +1,11 @@
 package validate
 
 import (
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,7 +30,7 @@ func TestValidator_Card(t *testing.T) {
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			wantErr: false,
 		},
@@ -37,7 +40,7 @@ func TestValidator_Card(t *testing.T) {
 				Name:            "",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			wantErr: true,
 			errMsg:  "name is required",
@@ -48,7 +51,7 @@ func TestValidator_Card(t *testing.T) {
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			wantErr: true,
 			errMsg:  "collector_number is required",
@@ -59,32 +62,91 @@ func TestValidator_Card(t *testing.T) {
 				Name:            "Lightning Bolt",
 				SetName:         "",
 				CollectorNumber: "123",
-				Foil:            boolPtr(true),
+				Finish:          "foil",
 			},
 			wantErr: true,
 			errMsg:  "set_name is required",
 		},
 		{
-			name: "should return error when foil is nil",
+			name: "should return error when finish is empty",
 			card: dtos.RequestInsertCard{
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            nil,
+				Finish:          "",
 			},
 			wantErr: true,
-			errMsg:  "foil is required",
+			errMsg:  "finish is required",
 		},
 		{
-			name: "should return nil when foil is false",
+			name: "should return error when finish is unknown",
 			card: dtos.RequestInsertCard{
 				Name:            "Lightning Bolt",
 				SetName:         "M21",
 				CollectorNumber: "123",
-				Foil:            boolPtr(false),
+				Finish:          "shiny",
+			},
+			wantErr: true,
+			errMsg:  "finish must be one of nonfoil, foil, etched, gilded, textured or surge",
+		},
+		{
+			name: "should return nil when finish is nonfoil",
+			card: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return error when condition is invalid",
+			card: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Condition:       "GOOD",
+			},
+			wantErr: true,
+			errMsg:  "condition must be one of NM, LP, MP, HP or DMG",
+		},
+		{
+			name: "should return error when language is invalid",
+			card: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Language:        "xx",
+			},
+			wantErr: true,
+			errMsg:  "language is not supported",
+		},
+		{
+			name: "should return nil when condition and language are valid",
+			card: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Condition:       "lp",
+				Language:        "pt",
 			},
 			wantErr: false,
 		},
+		{
+			name: "should return error when quantity is not positive",
+			card: dtos.RequestInsertCard{
+				Name:            "Lightning Bolt",
+				SetName:         "M21",
+				CollectorNumber: "123",
+				Finish:          "nonfoil",
+				Quantity:        int64Ptr(0),
+			},
+			wantErr: true,
+			errMsg:  "quantity must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,7 +230,7 @@ func TestValidator_CardID(t *testing.T) {
 	}
 }
 
-func TestValidator_CardName(t *testing.T) {
+func TestValidator_UpdateCard(t *testing.T) {
 	validator := New()
 
 	tests := []struct {
@@ -185,28 +247,495 @@ func TestValidator_CardName(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "should return error when name is empty",
+			name: "should return nil when only quantity is set",
+			card: dtos.RequestUpdateCard{
+				Quantity: int64Ptr(4),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return error when name and quantity are empty",
 			card: dtos.RequestUpdateCard{
 				Name: "",
 			},
 			wantErr: true,
-			errMsg:  "name is required",
+			errMsg:  "name, quantity, collection_id or acquisition fields are required",
+		},
+		{
+			name: "should return error when quantity is zero",
+			card: dtos.RequestUpdateCard{
+				Quantity: int64Ptr(0),
+			},
+			wantErr: true,
+			errMsg:  "quantity must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.UpdateCard(tt.card)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errMsg, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Sale(t *testing.T) {
+	validator := New()
+	price := 10.0
+	negative := -1.0
+
+	tests := []struct {
+		name    string
+		sale    dtos.RequestSellCard
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "should return nil when only sale price is set",
+			sale:    dtos.RequestSellCard{SalePrice: &price},
+			wantErr: false,
+		},
+		{
+			name:    "should return nil when all fields are valid",
+			sale:    dtos.RequestSellCard{SalePrice: &price, Quantity: int64Ptr(2), Fees: 1.5, SaleDate: "2026-03-10"},
+			wantErr: false,
+		},
+		{
+			name:    "should return error when sale price is missing",
+			sale:    dtos.RequestSellCard{},
+			wantErr: true,
+			errMsg:  "sale_price is required",
+		},
+		{
+			name:    "should return error when sale price is negative",
+			sale:    dtos.RequestSellCard{SalePrice: &negative},
+			wantErr: true,
+			errMsg:  "sale_price must not be negative",
+		},
+		{
+			name:    "should return error when quantity is zero",
+			sale:    dtos.RequestSellCard{SalePrice: &price, Quantity: int64Ptr(0)},
+			wantErr: true,
+			errMsg:  "quantity must be greater than 0",
+		},
+		{
+			name:    "should return error when fees are negative",
+			sale:    dtos.RequestSellCard{SalePrice: &price, Fees: -1},
+			wantErr: true,
+			errMsg:  "fees must not be negative",
+		},
+		{
+			name:    "should return error when sale date is invalid",
+			sale:    dtos.RequestSellCard{SalePrice: &price, SaleDate: "10/03/2026"},
+			wantErr: true,
+			errMsg:  "sale_date must be in YYYY-MM-DD format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Sale(tt.sale)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errMsg, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Year(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name    string
+		yearStr string
+		want    int
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "should default to the current year",
+			yearStr: "",
+			want:    time.Now().Year(),
+		},
+		{
+			name:    "should parse a valid year",
+			yearStr: "2026",
+			want:    2026,
+		},
+		{
+			name:    "should return error when year is not a number",
+			yearStr: "abc",
+			wantErr: true,
+			errMsg:  "invalid year parameter",
+		},
+		{
+			name:    "should return error when year is out of range",
+			yearStr: "1200",
+			wantErr: true,
+			errMsg:  "year must be between 1993 and 9999",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validator.CardName(tt.card)
+			got, err := validator.Year(tt.yearStr)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errMsg, err.Error())
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
 			}
 		})
 	}
 }
 
+func TestValidator_Currency(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name     string
+		currency string
+		want     string
+		wantErr  string
+	}{
+		{name: "should default to BRL", currency: "", want: "BRL"},
+		{name: "should normalize the currency", currency: "eur", want: "EUR"},
+		{name: "should return error when currency is not supported", currency: "JPY", wantErr: "currency must be one of BRL, USD or EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.Currency(tt.currency)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidator_Resolution(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name       string
+		resolution string
+		want       domain.PriceResolution
+		wantErr    string
+	}{
+		{name: "should default to daily", resolution: "", want: domain.PriceResolutionDaily},
+		{name: "should normalize the resolution", resolution: "Weekly", want: domain.PriceResolutionWeekly},
+		{name: "should accept monthly", resolution: "monthly", want: domain.PriceResolutionMonthly},
+		{name: "should return error when resolution is not supported", resolution: "yearly", wantErr: "resolution must be one of daily, weekly or monthly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.Resolution(tt.resolution)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidator_QuarantineStatus(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name    string
+		status  string
+		want    domain.QuarantineStatus
+		wantErr string
+	}{
+		{name: "should default to pending", status: "", want: domain.QuarantinePending},
+		{name: "should normalize the status", status: "Accepted", want: domain.QuarantineAccepted},
+		{name: "should accept rejected", status: "rejected", want: domain.QuarantineRejected},
+		{name: "should accept superseded", status: "superseded", want: domain.QuarantineSuperseded},
+		{name: "should return error when status is not supported", status: "deleted", wantErr: "status must be one of pending, accepted, rejected or superseded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.QuarantineStatus(tt.status)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidator_CollectionID(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name       string
+		collection string
+		want       int64
+		wantErr    bool
+	}{
+		{name: "should return zero when collection is empty", collection: "", want: 0},
+		{name: "should parse a valid collection", collection: "3", want: 3},
+		{name: "should return error when collection is not a number", collection: "abc", wantErr: true},
+		{name: "should return error when collection is not positive", collection: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.CollectionID(tt.collection)
+			if tt.wantErr {
+				assert.EqualError(t, err, "invalid collection parameter")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidator_Collection(t *testing.T) {
+	validator := New()
+
+	assert.NoError(t, validator.Collection(dtos.RequestCollection{Name: "Trade Binder"}))
+	assert.EqualError(t, validator.Collection(dtos.RequestCollection{}), "name is required")
+
+	description := "decks"
+	assert.NoError(t, validator.UpdateCollection(dtos.RequestCollection{Description: &description}))
+	assert.EqualError(t, validator.UpdateCollection(dtos.RequestCollection{}), "name or description is required")
+}
+
+func TestValidator_User(t *testing.T) {
+	validator := New()
+
+	tests := []struct {
+		name    string
+		user    dtos.RequestUser
+		wantErr string
+	}{
+		{name: "should accept a valid user", user: dtos.RequestUser{Name: "Jace", Email: "jace@example.com"}},
+		{name: "should return error when name is empty", user: dtos.RequestUser{Email: "jace@example.com"}, wantErr: "name is required"},
+		{name: "should return error when email is empty", user: dtos.RequestUser{Name: "Jace"}, wantErr: "email is required"},
+		{name: "should return error when email is invalid", user: dtos.RequestUser{Name: "Jace", Email: "jace"}, wantErr: "email is invalid"},
+		{name: "should return error when email has a display name", user: dtos.RequestUser{Name: "Jace", Email: "Jace <jace@example.com>"}, wantErr: "email is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.User(tt.user)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Member(t *testing.T) {
+	validator := New()
+
+	assert.NoError(t, validator.Member(dtos.RequestMember{Email: "jace@example.com", Role: "reader"}))
+	assert.NoError(t, validator.Member(dtos.RequestMember{Email: "jace@example.com", Role: "editor"}))
+	assert.EqualError(t, validator.Member(dtos.RequestMember{Role: "editor"}), "email is required")
+	assert.EqualError(t, validator.Member(dtos.RequestMember{Email: "jace@example.com", Role: "owner"}), "role must be reader or editor")
+}
+
+func TestValidator_MemberID(t *testing.T) {
+	validator := New()
+
+	collectionID, userID, err := validator.MemberID([]string{"", "collection", "2", "members", "5"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", collectionID)
+	assert.Equal(t, "5", userID)
+
+	_, _, err = validator.MemberID([]string{"", "collection", "2", "members"})
+	assert.EqualError(t, err, "invalid url")
+
+	_, _, err = validator.MemberID([]string{"", "collection", "2", "members", "abc"})
+	assert.EqualError(t, err, "invalid id")
+
+	_, _, err = validator.MemberID([]string{"", "collection", "2", "shares", "5"})
+	assert.EqualError(t, err, "invalid url")
+}
+
+func TestValidator_ShareID(t *testing.T) {
+	validator := New()
+
+	collectionID, shareID, err := validator.ShareID([]string{"", "collection", "2", "shares", "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", collectionID)
+	assert.Equal(t, "3", shareID)
+
+	_, _, err = validator.ShareID([]string{"", "collection", "2", "shares", ""})
+	assert.EqualError(t, err, "id is required")
+}
+
+func TestValidator_ShareToken(t *testing.T) {
+	validator := New()
+	token := strings.Repeat("ab", 32)
+
+	got, err := validator.ShareToken([]string{"", "shared", token})
+	assert.NoError(t, err)
+	assert.Equal(t, token, got)
+
+	got, err = validator.ShareToken([]string{"", "shared", token, "cards"})
+	assert.NoError(t, err)
+	assert.Equal(t, token, got)
+
+	_, err = validator.ShareToken([]string{"", "shared", token, "stats"})
+	assert.EqualError(t, err, "invalid url")
+
+	_, err = validator.ShareToken([]string{"", "shared", "short"})
+	assert.EqualError(t, err, "invalid share token")
+
+	_, err = validator.ShareToken([]string{"", "shared", strings.Repeat("zz", 32)})
+	assert.EqualError(t, err, "invalid share token")
+}
+
+func TestValidator_WishlistItem(t *testing.T) {
+	validator := New()
+
+	valid := func() dtos.RequestWishlistItem {
+		maxPrice := 50.0
+		return dtos.RequestWishlistItem{
+			Name:            "Lightning Bolt",
+			SetName:         "Alpha",
+			CollectorNumber: "161",
+			Finish:          "nonfoil",
+			MaxPrice:        &maxPrice,
+		}
+	}
+
+	assert.NoError(t, validator.WishlistItem(valid()))
+
+	item := valid()
+	item.Name = ""
+	assert.EqualError(t, validator.WishlistItem(item), "name is required")
+
+	item = valid()
+	item.Finish = ""
+	assert.EqualError(t, validator.WishlistItem(item), "finish is required")
+
+	item = valid()
+	item.MaxPrice = nil
+	assert.EqualError(t, validator.WishlistItem(item), "max_price is required")
+
+	negative := -1.0
+	item = valid()
+	item.MaxPrice = &negative
+	assert.EqualError(t, validator.WishlistItem(item), "max_price must not be negative")
+}
+
+func TestValidator_UpdateWishlistItem(t *testing.T) {
+	validator := New()
+
+	maxPrice := 40.0
+	assert.NoError(t, validator.UpdateWishlistItem(dtos.RequestWishlistItem{MaxPrice: &maxPrice}))
+	assert.EqualError(t, validator.UpdateWishlistItem(dtos.RequestWishlistItem{}), "max_price is required")
+}
+
+func TestValidator_Alert(t *testing.T) {
+	validator := New()
+
+	valid := func() dtos.RequestAlert {
+		cardID := int64(3)
+		threshold := 10.0
+		return dtos.RequestAlert{
+			CardID:    &cardID,
+			Kind:      "Percentage",
+			Direction: "up",
+			Threshold: &threshold,
+		}
+	}
+
+	assert.NoError(t, validator.Alert(valid()))
+
+	alert := valid()
+	alert.CardID = nil
+	alert.SetName = "Alpha"
+	assert.NoError(t, validator.Alert(alert))
+
+	alert = valid()
+	alert.CardID = nil
+	assert.EqualError(t, validator.Alert(alert), "either card_id or set_name is required")
+
+	alert = valid()
+	alert.SetName = "Alpha"
+	assert.EqualError(t, validator.Alert(alert), "either card_id or set_name is required")
+
+	zero := int64(0)
+	alert = valid()
+	alert.CardID = &zero
+	assert.EqualError(t, validator.Alert(alert), "card_id must be greater than 0")
+
+	alert = valid()
+	alert.Kind = "ratio"
+	assert.EqualError(t, validator.Alert(alert), "kind must be percentage or absolute")
+
+	alert = valid()
+	alert.Direction = "sideways"
+	assert.EqualError(t, validator.Alert(alert), "direction must be up or down")
+
+	alert = valid()
+	alert.Threshold = nil
+	assert.EqualError(t, validator.Alert(alert), "threshold is required")
+
+	negative := -5.0
+	alert = valid()
+	alert.Threshold = &negative
+	assert.EqualError(t, validator.Alert(alert), "threshold must be greater than 0")
+}
+
+func TestValidator_Webhook(t *testing.T) {
+	validator := New()
+
+	valid := func() dtos.RequestWebhook {
+		return dtos.RequestWebhook{
+			URL:    "https://hooks.example.com/mtg",
+			Events: []string{"conciliation.finished", "card.price_changed"},
+		}
+	}
+
+	assert.NoError(t, validator.Webhook(valid()))
+
+	webhook := valid()
+	webhook.URL = ""
+	assert.EqualError(t, validator.Webhook(webhook), "url is required")
+
+	webhook = valid()
+	webhook.URL = "ftp://hooks.example.com"
+	assert.EqualError(t, validator.Webhook(webhook), "url must be an absolute http or https url")
+
+	webhook = valid()
+	webhook.URL = "/mtg"
+	assert.EqualError(t, validator.Webhook(webhook), "url must be an absolute http or https url")
+
+	webhook = valid()
+	webhook.Events = nil
+	assert.EqualError(t, validator.Webhook(webhook), "events is required")
+
+	webhook = valid()
+	webhook.Events = []string{"card.deleted"}
+	assert.EqualError(t, validator.Webhook(webhook), `unknown event "card.deleted"`)
+}
+
 func TestValidator_Filters(t *testing.T) {
 	validator := New()
 
@@ -215,6 +744,7 @@ func TestValidator_Filters(t *testing.T) {
 		setName         string
 		cardName        string
 		collectorNumber string
+		collection      string
 		expectedFilters map[string]string
 	}{
 		{
@@ -256,6 +786,11 @@ func TestValidator_Filters(t *testing.T) {
 				"collector_number": "123",
 			},
 		},
+		{
+			name:            "should return collection_id filter when only collection is provided",
+			collection:      "2",
+			expectedFilters: map[string]string{"collection_id": "2"},
+		},
 		{
 			name:            "should return partial filters when some parameters are provided",
 			setName:         "M21",
@@ -270,7 +805,7 @@ func TestValidator_Filters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filters := validator.Filters(tt.setName, tt.cardName, tt.collectorNumber)
+			filters := validator.Filters(tt.setName, tt.cardName, tt.collectorNumber, tt.collection)
 			assert.Equal(t, tt.expectedFilters, filters)
 		})
 	}
@@ -280,3 +815,8 @@ func TestValidator_Filters(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// Helper function to create int64 pointers
+func int64Ptr(i int64) *int64 {
+	return &i
+}
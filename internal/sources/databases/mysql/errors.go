@@ -0,0 +1,23 @@
+package mysql
+
+import (
+	"errors"
+
+	driver "github.com/go-sql-driver/mysql"
+)
+
+const (
+	ErrDuplicateEntry  uint16 = 1062
+	ErrRowIsReferenced uint16 = 1451
+	ErrNoReferencedRow uint16 = 1452
+)
+
+// IsError reports whether err is a MySQL error with the given number.
+func IsError(err error, number uint16) bool {
+	var mysqlErr *driver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == number
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWait_Burst(t *testing.T) {
+	limiter := New(1, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestWait_Rate(t *testing.T) {
+	limiter := New(100, 1)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	// the first call takes the token in the bucket, the other three wait 10ms
+	// each for theirs
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+}
+
+func TestWait_ContextDone(t *testing.T) {
+	limiter := New(1, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.InDelta(t, 0, limiter.tokens, 0.1)
+}
+
+func TestWait_Unlimited(t *testing.T) {
+	limiter := New(0, 1)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
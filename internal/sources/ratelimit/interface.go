@@ -0,0 +1,7 @@
+package ratelimit
+
+import "context"
+
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
@@ -20,10 +20,33 @@ func New() *web {
 	}
 }
 
+// NewWithTimeout returns a client for slow transfers, such as large
+// downloads, that may take longer than the default timeout.
+func NewWithTimeout(timeout time.Duration) *web {
+	return &web{
+		http: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
 func (c *web) NewRequestWithContext(ctx context.Context, method, url string, body io.Reader) (Request, error) {
 	return http.NewRequestWithContext(ctx, method, url, body)
 }
 
+func (c *web) NewRequestWithHeaders(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
+
 type HTTPResponse struct {
 	Resp *http.Response
 }
@@ -36,6 +59,10 @@ func (r *HTTPResponse) StatusCode() int {
 	return r.Resp.StatusCode
 }
 
+func (r *HTTPResponse) Header(key string) string {
+	return r.Resp.Header.Get(key)
+}
+
 func (c *web) Do(req Request) (Response, error) {
 	request, ok := req.(*http.Request)
 	if !ok {
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned for urls that are not http or https, or whose
+// host resolves to an address of the server's own network, so user supplied
+// urls cannot be used to reach it.
+var ErrBlockedAddress = errors.New("url must be http or https and resolve to public addresses only")
+
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type guard struct {
+	resolver Resolver
+}
+
+func NewGuard(resolver Resolver) *guard {
+	return &guard{
+		resolver: resolver,
+	}
+}
+
+// CheckURL resolves the host of rawURL and fails with ErrBlockedAddress when
+// any of its addresses is not public.
+func (g *guard) CheckURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrBlockedAddress
+	}
+
+	addrs, err := g.resolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", parsed.Hostname(), err)
+	}
+
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return ErrBlockedAddress
+		}
+	}
+
+	return nil
+}
+
+// NewGuarded returns a client for user supplied urls. Addresses are checked
+// when dialing, after resolution, so redirects and hosts that resolve
+// differently than when they were registered cannot reach private addresses
+// either.
+func NewGuarded() *web {
+	dialer := &net.Dialer{
+		Timeout: time.Second * 30,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return ErrBlockedAddress
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &web{
+		http: &http.Client{
+			Timeout:   time.Second * 30,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrBlockedAddress
+				}
+				if len(via) >= 10 {
+					return errors.New("stopped after 10 redirects")
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// publicIP reports whether ip is routable on the internet, that is not
+// loopback, private, link-local, multicast or unspecified.
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestGuard_CheckURL(t *testing.T) {
+	resolver := staticResolver{
+		"hooks.test.com":    {"93.184.216.34"},
+		"localhost":         {"127.0.0.1", "::1"},
+		"intranet.test.com": {"93.184.216.34", "10.0.0.5"},
+		"metadata.test.com": {"169.254.169.254"},
+		"ula.test.com":      {"fd00::1"},
+		"192.168.1.10":      {"192.168.1.10"},
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "should accept a host resolving to public addresses", url: "https://hooks.test.com/mtg"},
+		{name: "should refuse other schemes", url: "ftp://hooks.test.com/mtg", wantErr: true},
+		{name: "should refuse loopback addresses", url: "http://localhost:8080", wantErr: true},
+		{name: "should refuse a host with any private address", url: "https://intranet.test.com", wantErr: true},
+		{name: "should refuse link-local addresses", url: "http://metadata.test.com/latest", wantErr: true},
+		{name: "should refuse private ipv6 addresses", url: "http://ula.test.com", wantErr: true},
+		{name: "should refuse private ip literals", url: "http://192.168.1.10/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewGuard(resolver).CheckURL(context.Background(), tt.url)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrBlockedAddress)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuard_CheckURL_ResolveError(t *testing.T) {
+	err := NewGuard(staticResolver{}).CheckURL(context.Background(), "https://unknown.test.com")
+
+	assert.ErrorContains(t, err, "failed to resolve unknown.test.com")
+}
+
+func TestGuarded_RefusesLoopbackAtSendTime(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	client := NewGuarded()
+
+	req, _ := client.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	_, err := client.Do(req)
+
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+	assert.False(t, called)
+}
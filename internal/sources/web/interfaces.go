@@ -7,6 +7,7 @@ import (
 
 type HTTP interface {
 	NewRequestWithContext(ctx context.Context, method, url string, body io.Reader) (Request, error)
+	NewRequestWithHeaders(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (Request, error)
 	Do(req Request) (Response, error)
 }
 
@@ -15,4 +16,5 @@ type Request interface{}
 type Response interface {
 	Body() io.ReadCloser
 	StatusCode() int
+	Header(key string) string
 }
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"context"
+	"io"
+	"mtg-report/internal/sources/ratelimit"
+	"net/http"
+)
+
+type limitedHTTP struct {
+	http    HTTP
+	limiter ratelimit.Limiter
+}
+
+// NewLimited wraps client so that every request waits on limiter before it is
+// sent. Wrapped by NewRetry, retries wait on it as well, so they count against
+// the same budget as the first attempts.
+func NewLimited(client HTTP, limiter ratelimit.Limiter) *limitedHTTP {
+	return &limitedHTTP{
+		http:    client,
+		limiter: limiter,
+	}
+}
+
+func (l *limitedHTTP) NewRequestWithContext(ctx context.Context, method, url string, body io.Reader) (Request, error) {
+	return l.http.NewRequestWithContext(ctx, method, url, body)
+}
+
+func (l *limitedHTTP) NewRequestWithHeaders(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (Request, error) {
+	return l.http.NewRequestWithHeaders(ctx, method, url, body, headers)
+}
+
+func (l *limitedHTTP) Do(req Request) (Response, error) {
+	ctx := context.Background()
+	if request, ok := req.(*http.Request); ok {
+		ctx = request.Context()
+	}
+
+	if err := l.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return l.http.Do(req)
+}
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingLimiter struct {
+	waits atomic.Int32
+	err   error
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return l.err
+}
+
+func TestLimited_RetriesWaitOnTheLimiter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	client := NewRetry(NewLimited(New(), limiter), testPolicy, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int32(3), limiter.waits.Load())
+}
+
+func TestLimited_DoesNotSendWhenWaitFails(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewLimited(New(), &countingLimiter{err: context.DeadlineExceeded})
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	_, err := client.Do(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestRetryCounters(t *testing.T) {
+	first := NewRetry(New(), testPolicy, testLogger)
+	second := NewRetry(New(), testPolicy, testLogger)
+	first.retries.Add(2)
+	second.retries.Add(3)
+
+	assert.Equal(t, int64(5), RetryCounters{first, second}.Retries())
+}
@@ -0,0 +1,166 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"mtg-report/internal/sources/logger/logrus"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy bounds the retries of a request: at most MaxAttempts attempts,
+// waiting BaseDelay doubled after every attempt, up to MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+type retryHTTP struct {
+	http    HTTP
+	policy  RetryPolicy
+	log     logrus.Logger
+	retries atomic.Int64
+	jitter  func() float64
+}
+
+// NewRetry wraps client so that requests failing with a network error, a 429
+// or a 5xx are retried with exponential backoff and jitter. A Retry-After
+// header longer than the backoff is waited instead, up to MaxDelay.
+func NewRetry(client HTTP, policy RetryPolicy, log logrus.Logger) *retryHTTP {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	return &retryHTTP{
+		http:   client,
+		policy: policy,
+		log:    log,
+		jitter: rand.Float64,
+	}
+}
+
+func (r *retryHTTP) NewRequestWithContext(ctx context.Context, method, url string, body io.Reader) (Request, error) {
+	return r.http.NewRequestWithContext(ctx, method, url, body)
+}
+
+func (r *retryHTTP) NewRequestWithHeaders(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (Request, error) {
+	return r.http.NewRequestWithHeaders(ctx, method, url, body, headers)
+}
+
+func (r *retryHTTP) Do(req Request) (Response, error) {
+	ctx := context.Background()
+	request, isHTTP := req.(*http.Request)
+	if isHTTP {
+		ctx = request.Context()
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := r.http.Do(req)
+
+		retryable, reason := r.retryable(ctx, resp, err)
+		if !retryable || attempt == r.policy.MaxAttempts || !rewindable(request) {
+			return resp, err
+		}
+
+		delay := r.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header("Retry-After")); ok && retryAfter > delay {
+				delay = retryAfter
+				if r.policy.MaxDelay > 0 && delay > r.policy.MaxDelay {
+					delay = r.policy.MaxDelay
+				}
+			}
+			resp.Body().Close()
+		}
+
+		r.retries.Add(1)
+		r.log.Warn(fmt.Sprintf("retrying request after %s in %s, attempt %d of %d", reason, delay, attempt+1, r.policy.MaxAttempts))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		if request != nil && request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// Retries returns how many requests were retried since the client was created.
+func (r *retryHTTP) Retries() int64 {
+	return r.retries.Load()
+}
+
+// RetryCounters adds up the retries of several clients.
+type RetryCounters []interface{ Retries() int64 }
+
+func (rc RetryCounters) Retries() int64 {
+	var retries int64
+	for _, counter := range rc {
+		retries += counter.Retries()
+	}
+
+	return retries
+}
+
+func (r *retryHTTP) retryable(ctx context.Context, resp Response, err error) (bool, string) {
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false, ""
+		}
+		return true, fmt.Sprintf("error %q", err)
+	}
+
+	if resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() >= http.StatusInternalServerError {
+		return true, fmt.Sprintf("http status %d", resp.StatusCode())
+	}
+
+	return false, ""
+}
+
+// backoff returns the wait before the attempt after the given one, between
+// half and all of the exponential delay.
+func (r *retryHTTP) backoff(attempt int) time.Duration {
+	delay := r.policy.BaseDelay << (attempt - 1)
+	if r.policy.MaxDelay > 0 && (delay > r.policy.MaxDelay || delay <= 0) {
+		delay = r.policy.MaxDelay
+	}
+
+	return delay/2 + time.Duration(r.jitter()*float64(delay/2))
+}
+
+// rewindable reports whether the request can be sent again: requests without
+// a body, or whose body can be read anew.
+func rewindable(request *http.Request) bool {
+	return request == nil || request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// parseRetryAfter reads a Retry-After header, given in seconds or as a date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,200 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mtg-report/internal/sources/logger/logrus"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger = logrus.New("error")
+
+var testPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+func TestRetry_RetriesTransientStatus(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	req, err := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	body, _ := io.ReadAll(resp.Body())
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(2), client.Retries())
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode())
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(2), client.Retries())
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, int64(0), client.Retries())
+}
+
+func TestRetry_HonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetry_CapsRetryAfterAtMaxDelay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_ReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	req, _ := client.NewRequestWithContext(context.Background(), "POST", server.URL, strings.NewReader("payload"))
+	resp, err := client.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestRetry_StopsWhenContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewRetry(New(), testPolicy, testLogger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	_, err := client.Do(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBackoff(t *testing.T) {
+	client := NewRetry(New(), RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}, testLogger)
+	client.jitter = func() float64 { return 1 }
+
+	assert.Equal(t, 100*time.Millisecond, client.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, client.backoff(2))
+	assert.Equal(t, 300*time.Millisecond, client.backoff(3))
+
+	client.jitter = func() float64 { return 0 }
+
+	assert.Equal(t, 50*time.Millisecond, client.backoff(1))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, delay, float64(2*time.Second))
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,4 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards`
+    ADD COLUMN `quantity` int unsigned NOT NULL DEFAULT 1 AFTER `foil`;
@@ -0,0 +1,7 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards`
+    ADD COLUMN `card_condition` varchar(3) NOT NULL DEFAULT 'NM' AFTER `foil`,
+    ADD COLUMN `language` varchar(3) NOT NULL DEFAULT 'en' AFTER `card_condition`,
+    DROP INDEX `unique_idx`,
+    ADD UNIQUE INDEX `unique_idx` (`set_name`, `collector_number`, `foil`, `card_condition`, `language`);
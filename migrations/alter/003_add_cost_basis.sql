@@ -0,0 +1,9 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards`
+    ADD COLUMN `acquisition_price` decimal(10,2) NULL AFTER `quantity`,
+    ADD COLUMN `acquisition_currency` varchar(3) NOT NULL DEFAULT 'BRL' AFTER `acquisition_price`,
+    ADD COLUMN `acquisition_date` date NULL AFTER `acquisition_currency`;
+
+ALTER TABLE `cards_details`
+    ADD COLUMN `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0 AFTER `price_change`;
@@ -0,0 +1,18 @@
+USE MTGREPORTS;
+
+CREATE TABLE `sales` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `card_id` int unsigned NULL,
+    `quantity` int unsigned NOT NULL,
+    `sale_price` decimal(10,2) NOT NULL,
+    `fees` decimal(10,2) NOT NULL DEFAULT 0,
+    `sale_date` date NOT NULL,
+    `cost_basis` decimal(10,2) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_sales_sale_date` (`sale_date`),
+    CONSTRAINT `fk_sales_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,25 @@
+USE MTGREPORTS;
+
+CREATE TABLE `collections` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `description` varchar(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_collection_name` (`name`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+INSERT INTO collections (id, name) VALUES (1, 'Default');
+
+ALTER TABLE `cards`
+    ADD COLUMN `collection_id` int unsigned NOT NULL DEFAULT 1 AFTER `quantity`,
+    DROP INDEX `unique_idx`,
+    ADD UNIQUE INDEX `unique_idx` (`set_name`, `collector_number`, `foil`, `card_condition`, `language`, `collection_id`),
+    ADD CONSTRAINT `fk_cards_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE RESTRICT
+        ON UPDATE CASCADE;
+
+ALTER TABLE `prices`
+    ADD COLUMN `collection_id` int unsigned NOT NULL DEFAULT 0 AFTER `id`,
+    ADD INDEX `idx_prices_collection_id_last_update` (`collection_id`, `last_update`);
@@ -0,0 +1,57 @@
+USE MTGREPORTS;
+
+-- Existing data is handed to a first user. Set its email below, the API key is
+-- printed by the last statement and is not stored anywhere else.
+SET @admin_email = 'admin@example.com';
+SET @api_key = SHA2(RANDOM_BYTES(32), 256);
+
+CREATE TABLE `users` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `email` varchar(255) NOT NULL,
+    `api_key_hash` char(64) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_user_email` (`email`),
+    UNIQUE INDEX `unique_user_api_key_hash` (`api_key_hash`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+INSERT INTO users (id, name, email, api_key_hash) VALUES (1, 'Admin', @admin_email, SHA2(@api_key, 256));
+
+ALTER TABLE `collections`
+    ADD COLUMN `user_id` int unsigned NOT NULL DEFAULT 1 AFTER `id`,
+    ADD COLUMN `is_default` tinyint NOT NULL DEFAULT 0 AFTER `description`,
+    DROP INDEX `unique_collection_name`,
+    ADD UNIQUE INDEX `unique_collection_name` (`user_id`, `name`),
+    ADD CONSTRAINT `fk_collections_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE;
+
+ALTER TABLE `collections` ALTER COLUMN `user_id` DROP DEFAULT;
+
+UPDATE collections SET is_default = 1 WHERE id = 1;
+
+ALTER TABLE `cards` ALTER COLUMN `collection_id` DROP DEFAULT;
+
+ALTER TABLE `sales`
+    ADD COLUMN `user_id` int unsigned NOT NULL DEFAULT 1 AFTER `id`,
+    DROP INDEX `idx_sales_sale_date`,
+    ADD INDEX `idx_sales_user_id_sale_date` (`user_id`, `sale_date`),
+    ADD CONSTRAINT `fk_sales_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE;
+
+ALTER TABLE `sales` ALTER COLUMN `user_id` DROP DEFAULT;
+
+ALTER TABLE `prices`
+    ADD COLUMN `user_id` int unsigned NOT NULL DEFAULT 1 AFTER `id`,
+    DROP INDEX `idx_prices_collection_id_last_update`,
+    ADD INDEX `idx_prices_user_id_collection_id_last_update` (`user_id`, `collection_id`, `last_update`);
+
+ALTER TABLE `prices` ALTER COLUMN `user_id` DROP DEFAULT;
+
+SELECT @api_key AS api_key;
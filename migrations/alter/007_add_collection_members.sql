@@ -0,0 +1,23 @@
+USE MTGREPORTS;
+
+CREATE TABLE `collection_members` (
+    `collection_id` int unsigned NOT NULL,
+    `user_id` int unsigned NOT NULL,
+    `role` varchar(10) NOT NULL,
+    PRIMARY KEY (`collection_id`, `user_id`),
+    INDEX `idx_collection_members_user_id` (`user_id`),
+    CONSTRAINT `fk_collection_members_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_collection_members_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) DEFAULT CHARSET = latin1;
+
+-- every existing collection is owned by the user that created it.
+INSERT INTO collection_members (collection_id, user_id, role)
+SELECT id, user_id, 'owner' FROM collections;
@@ -0,0 +1,17 @@
+USE MTGREPORTS;
+
+CREATE TABLE `collection_shares` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `collection_id` int unsigned NOT NULL,
+    `token_hash` char(64) NOT NULL,
+    `hide_cost_basis` tinyint NOT NULL DEFAULT 0,
+    `created_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_collection_share_token_hash` (`token_hash`),
+    INDEX `idx_collection_shares_collection_id` (`collection_id`),
+    CONSTRAINT `fk_collection_shares_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
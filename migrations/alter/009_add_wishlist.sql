@@ -0,0 +1,20 @@
+USE MTGREPORTS;
+
+CREATE TABLE `wishlist` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `set_name` varchar(255) NOT NULL,
+    `collector_number` varchar(255) NOT NULL,
+    `foil` tinyint NOT NULL,
+    `max_price` decimal(10,2) NOT NULL,
+    `last_price` decimal(10,2) NULL,
+    `last_update` datetime NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_wishlist_user_card` (`user_id`, `set_name`, `collector_number`, `foil`),
+    CONSTRAINT `fk_wishlist_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,56 @@
+USE MTGREPORTS;
+
+CREATE TABLE `alerts` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `card_id` int unsigned NULL,
+    `set_name` varchar(255) NOT NULL DEFAULT '',
+    `kind` varchar(10) NOT NULL,
+    `direction` varchar(4) NOT NULL,
+    `threshold` decimal(10,2) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `idx_alerts_user_id` (`user_id`),
+    CONSTRAINT `fk_alerts_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alerts_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `alert_events` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `alert_id` int unsigned NULL,
+    `user_id` int unsigned NOT NULL,
+    `card_id` int unsigned NULL,
+    `card_name` varchar(255) NOT NULL,
+    `set_name` varchar(255) NOT NULL,
+    `kind` varchar(10) NOT NULL,
+    `direction` varchar(4) NOT NULL,
+    `threshold` decimal(10,2) NOT NULL,
+    `old_price` decimal(10,2) NOT NULL,
+    `new_price` decimal(10,2) NOT NULL,
+    `triggered_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_alert_events_user_id_triggered_at` (`user_id`, `triggered_at`),
+    CONSTRAINT `fk_alert_events_alert_id`
+        FOREIGN KEY (`alert_id`)
+        REFERENCES `alerts` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alert_events_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alert_events_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,36 @@
+USE MTGREPORTS;
+
+CREATE TABLE `webhooks` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `url` varchar(2048) NOT NULL,
+    `secret` char(64) NOT NULL,
+    `events` varchar(255) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `idx_webhooks_user_id` (`user_id`),
+    CONSTRAINT `fk_webhooks_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `webhook_deliveries` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `webhook_id` int unsigned NOT NULL,
+    `delivery_id` char(64) NOT NULL,
+    `event` varchar(50) NOT NULL,
+    `attempt` int unsigned NOT NULL,
+    `status_code` int NOT NULL DEFAULT 0,
+    `error` varchar(255) NOT NULL DEFAULT '',
+    `success` tinyint NOT NULL,
+    `created_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_webhook_deliveries_webhook_id_created_at` (`webhook_id`, `created_at`),
+    CONSTRAINT `fk_webhook_deliveries_webhook_id`
+        FOREIGN KEY (`webhook_id`)
+        REFERENCES `webhooks` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
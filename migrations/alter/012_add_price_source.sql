@@ -0,0 +1,4 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards_details`
+    ADD COLUMN `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd' AFTER `exchange_rate`;
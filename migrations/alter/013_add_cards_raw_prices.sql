@@ -0,0 +1,19 @@
+USE MTGREPORTS;
+
+CREATE TABLE `cards_raw_prices` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `card_id` int unsigned NOT NULL,
+    `quoted_at` datetime NOT NULL,
+    `field` varchar(20) NOT NULL,
+    `currency` varchar(3) NOT NULL,
+    `price` decimal(12,4) NOT NULL,
+    `exchange_rate` decimal(10,4) NULL,
+    `used` tinyint NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_cards_raw_prices_card_id_quoted_at` (`card_id`, `quoted_at`),
+    CONSTRAINT `fk_cards_raw_prices_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
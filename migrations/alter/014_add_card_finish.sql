@@ -0,0 +1,21 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards`
+    ADD COLUMN `finish` varchar(10) NOT NULL DEFAULT 'nonfoil' AFTER `foil`;
+
+UPDATE `cards` SET `finish` = IF(`foil`, 'foil', 'nonfoil');
+
+ALTER TABLE `cards`
+    DROP INDEX `unique_idx`,
+    ADD UNIQUE INDEX `unique_idx` (`set_name`, `collector_number`, `finish`, `card_condition`, `language`, `collection_id`),
+    DROP COLUMN `foil`;
+
+ALTER TABLE `wishlist`
+    ADD COLUMN `finish` varchar(10) NOT NULL DEFAULT 'nonfoil' AFTER `foil`;
+
+UPDATE `wishlist` SET `finish` = IF(`foil`, 'foil', 'nonfoil');
+
+ALTER TABLE `wishlist`
+    DROP INDEX `unique_wishlist_user_card`,
+    ADD UNIQUE INDEX `unique_wishlist_user_card` (`user_id`, `set_name`, `collector_number`, `finish`),
+    DROP COLUMN `foil`;
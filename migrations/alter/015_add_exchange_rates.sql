@@ -0,0 +1,11 @@
+USE MTGREPORTS;
+
+CREATE TABLE `exchange_rates` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `rate_date` date NOT NULL,
+    `base_code` varchar(3) NOT NULL,
+    `currency` varchar(3) NOT NULL,
+    `rate` decimal(18,8) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_exchange_rates_date_currency` (`rate_date`, `currency`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,39 @@
+USE MTGREPORTS;
+
+CREATE TABLE `conciliation_runs` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `started_at` datetime NOT NULL,
+    `finished_at` datetime NULL,
+    `status` varchar(20) NOT NULL,
+    `exchange_rate` decimal(10,4) NULL,
+    `exchange_rate_source` varchar(20) NOT NULL DEFAULT '',
+    `last_card_id` int unsigned NOT NULL DEFAULT 0,
+    `processed` int unsigned NOT NULL DEFAULT 0,
+    `failed` int unsigned NOT NULL DEFAULT 0,
+    `skipped` int unsigned NOT NULL DEFAULT 0,
+    `retries` int unsigned NOT NULL DEFAULT 0,
+    `error_summary` varchar(1024) NOT NULL DEFAULT '',
+    PRIMARY KEY (`id`),
+    INDEX `idx_conciliation_runs_status_started_at` (`status`, `started_at`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `conciliation_failures` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `run_id` int unsigned NOT NULL,
+    `card_id` int unsigned NOT NULL,
+    `reason` varchar(30) NOT NULL,
+    `error` varchar(1024) NOT NULL DEFAULT '',
+    `failed_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_conciliation_failures_run_id` (`run_id`),
+    CONSTRAINT `fk_conciliation_failures_run_id`
+        FOREIGN KEY (`run_id`)
+        REFERENCES `conciliation_runs` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_conciliation_failures_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,17 @@
+USE MTGREPORTS;
+
+CREATE TABLE `cards_current_price` (
+    `card_id` int unsigned NOT NULL,
+    `last_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `price_change` decimal(10,2) NOT NULL DEFAULT 0,
+    `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0,
+    `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd',
+    `last_update` datetime NOT NULL,
+    PRIMARY KEY (`card_id`),
+    CONSTRAINT `fk_cards_current_price_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) DEFAULT CHARSET = latin1;
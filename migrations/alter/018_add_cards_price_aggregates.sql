@@ -0,0 +1,23 @@
+USE MTGREPORTS;
+
+CREATE TABLE `cards_price_aggregates` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `card_id` int unsigned NOT NULL,
+    `resolution` varchar(10) NOT NULL,
+    `period_start` date NOT NULL,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `open_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `close_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `min_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `max_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `opened_at` datetime NOT NULL,
+    `closed_at` datetime NOT NULL,
+    `samples` int unsigned NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_cards_price_aggregates_period` (`card_id`, `resolution`, `period_start`),
+    CONSTRAINT `fk_cards_price_aggregates_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,34 @@
+USE MTGREPORTS;
+
+CREATE TABLE `cards_quarantined_prices` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `run_id` int unsigned NOT NULL,
+    `card_id` int unsigned NOT NULL,
+    `last_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `price_change` decimal(10,2) NOT NULL DEFAULT 0,
+    `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0,
+    `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd',
+    `quoted_at` datetime NOT NULL,
+    `sanity_rule` varchar(20) NOT NULL,
+    `status` varchar(10) NOT NULL DEFAULT 'pending',
+    `reviewed_at` datetime NULL,
+    `reviewed_by` int unsigned NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_cards_quarantined_prices_status` (`status`, `id`),
+    CONSTRAINT `fk_cards_quarantined_prices_run_id`
+        FOREIGN KEY (`run_id`)
+        REFERENCES `conciliation_runs` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_cards_quarantined_prices_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_cards_quarantined_prices_reviewed_by`
+        FOREIGN KEY (`reviewed_by`)
+        REFERENCES `users` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
@@ -0,0 +1,20 @@
+USE MTGREPORTS;
+
+ALTER TABLE `cards`
+    ADD COLUMN `acquisition_rate` decimal(18,8) NULL AFTER `acquisition_currency`;
+
+UPDATE `cards`
+SET
+    `acquisition_rate` = CASE `acquisition_currency` WHEN 'BRL' THEN 1 ELSE (
+        SELECT
+            brl.rate / cur.rate
+        FROM
+            exchange_rates brl
+        JOIN
+            exchange_rates cur ON cur.rate_date = brl.rate_date AND cur.currency = cards.acquisition_currency
+        WHERE
+            brl.currency = 'BRL' AND cur.rate > 0
+        ORDER BY
+            brl.rate_date <= COALESCE(cards.acquisition_date, CURRENT_DATE) DESC,
+            ABS(DATEDIFF(brl.rate_date, COALESCE(cards.acquisition_date, CURRENT_DATE)))
+        LIMIT 1) END;
@@ -1,19 +1,109 @@
 CREATE SCHEMA MTGREPORTS;
 USE MTGREPORTS;
 
+DROP TABLE IF EXISTS cards_quarantined_prices;
+DROP TABLE IF EXISTS conciliation_failures;
+DROP TABLE IF EXISTS conciliation_runs;
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS cards_raw_prices;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS alert_events;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS wishlist;
+DROP TABLE IF EXISTS sales;
+DROP TABLE IF EXISTS cards_price_aggregates;
+DROP TABLE IF EXISTS cards_current_price;
 DROP TABLE IF EXISTS cards_details;
 DROP TABLE IF EXISTS cards;
+DROP TABLE IF EXISTS collection_shares;
+DROP TABLE IF EXISTS collection_members;
+DROP TABLE IF EXISTS collections;
 DROP TABLE IF EXISTS prices;
+DROP TABLE IF EXISTS users;
+
+CREATE TABLE `users` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `email` varchar(255) NOT NULL,
+    `api_key_hash` char(64) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_user_email` (`email`),
+    UNIQUE INDEX `unique_user_api_key_hash` (`api_key_hash`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `collections` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `description` varchar(255) NOT NULL DEFAULT '',
+    `is_default` tinyint NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_collection_name` (`user_id`, `name`),
+    CONSTRAINT `fk_collections_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `collection_members` (
+    `collection_id` int unsigned NOT NULL,
+    `user_id` int unsigned NOT NULL,
+    `role` varchar(10) NOT NULL,
+    PRIMARY KEY (`collection_id`, `user_id`),
+    INDEX `idx_collection_members_user_id` (`user_id`),
+    CONSTRAINT `fk_collection_members_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_collection_members_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) DEFAULT CHARSET = latin1;
+
+CREATE TABLE `collection_shares` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `collection_id` int unsigned NOT NULL,
+    `token_hash` char(64) NOT NULL,
+    `hide_cost_basis` tinyint NOT NULL DEFAULT 0,
+    `created_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_collection_share_token_hash` (`token_hash`),
+    INDEX `idx_collection_shares_collection_id` (`collection_id`),
+    CONSTRAINT `fk_collection_shares_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
 CREATE TABLE `cards` (
     `id` int unsigned NOT NULL AUTO_INCREMENT,
     `name` varchar(255) NOT NULL,
     `set_name` varchar(255) NOT NULL,
     `collector_number` varchar(255) NOT NULL,
-    `foil` tinyint NOT NULL,
+    `finish` varchar(10) NOT NULL DEFAULT 'nonfoil',
+    `card_condition` varchar(3) NOT NULL DEFAULT 'NM',
+    `language` varchar(3) NOT NULL DEFAULT 'en',
+    `quantity` int unsigned NOT NULL DEFAULT 1,
+    `collection_id` int unsigned NOT NULL,
+    `acquisition_price` decimal(10,2) NULL,
+    `acquisition_currency` varchar(3) NOT NULL DEFAULT 'BRL',
+    `acquisition_rate` decimal(18,8) NULL,
+    `acquisition_date` date NULL,
     PRIMARY KEY (`id`),
     INDEX `idx_cards_name` (`name`),
-    UNIQUE INDEX `unique_idx` (`set_name`, `collector_number`, `foil`)
+    UNIQUE INDEX `unique_idx` (`set_name`, `collector_number`, `finish`, `card_condition`, `language`, `collection_id`),
+    CONSTRAINT `fk_cards_collection_id`
+        FOREIGN KEY (`collection_id`)
+        REFERENCES `collections` (`id`)
+        ON DELETE RESTRICT
+        ON UPDATE CASCADE
 ) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
 CREATE TABLE `cards_details` (
@@ -22,6 +112,8 @@ CREATE TABLE `cards_details` (
     `last_price` decimal(10,2) NOT NULL DEFAULT 0,
     `old_price` decimal(10,2) NOT NULL DEFAULT 0,
     `price_change` decimal(10,2) NOT NULL DEFAULT 0,
+    `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0,
+    `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd',
     `last_update` datetime NOT NULL,
     PRIMARY KEY (`id`),
     INDEX `idx_card_details_card_id_last_update` (`card_id`, `last_update`),
@@ -32,15 +124,284 @@ CREATE TABLE `cards_details` (
         ON UPDATE CASCADE
 ) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
+CREATE TABLE `cards_current_price` (
+    `card_id` int unsigned NOT NULL,
+    `last_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `price_change` decimal(10,2) NOT NULL DEFAULT 0,
+    `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0,
+    `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd',
+    `last_update` datetime NOT NULL,
+    PRIMARY KEY (`card_id`),
+    CONSTRAINT `fk_cards_current_price_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) DEFAULT CHARSET = latin1;
+
+CREATE TABLE `cards_price_aggregates` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `card_id` int unsigned NOT NULL,
+    `resolution` varchar(10) NOT NULL,
+    `period_start` date NOT NULL,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `open_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `close_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `min_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `max_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `opened_at` datetime NOT NULL,
+    `closed_at` datetime NOT NULL,
+    `samples` int unsigned NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_cards_price_aggregates_period` (`card_id`, `resolution`, `period_start`),
+    CONSTRAINT `fk_cards_price_aggregates_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `sales` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `card_id` int unsigned NULL,
+    `quantity` int unsigned NOT NULL,
+    `sale_price` decimal(10,2) NOT NULL,
+    `fees` decimal(10,2) NOT NULL DEFAULT 0,
+    `sale_date` date NOT NULL,
+    `cost_basis` decimal(10,2) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_sales_user_id_sale_date` (`user_id`, `sale_date`),
+    CONSTRAINT `fk_sales_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_sales_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
 CREATE TABLE `prices` (
     `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `collection_id` int unsigned NOT NULL DEFAULT 0,
     `old_price` decimal(10,2) NOT NULL DEFAULT 0,
     `new_price` decimal(10,2) NOT NULL DEFAULT 0,
     `price_change` decimal(10,2) NOT NULL DEFAULT 0,
     `last_update` datetime NOT NULL,
     PRIMARY KEY (`id`),
-    INDEX `idx_last_update` (`last_update`)
+    INDEX `idx_last_update` (`last_update`),
+    INDEX `idx_prices_user_id_collection_id_last_update` (`user_id`, `collection_id`, `last_update`)
 ) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
+CREATE TABLE `wishlist` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `set_name` varchar(255) NOT NULL,
+    `collector_number` varchar(255) NOT NULL,
+    `finish` varchar(10) NOT NULL DEFAULT 'nonfoil',
+    `max_price` decimal(10,2) NOT NULL,
+    `last_price` decimal(10,2) NULL,
+    `last_update` datetime NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_wishlist_user_card` (`user_id`, `set_name`, `collector_number`, `finish`),
+    CONSTRAINT `fk_wishlist_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
+CREATE TABLE `alerts` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `card_id` int unsigned NULL,
+    `set_name` varchar(255) NOT NULL DEFAULT '',
+    `kind` varchar(10) NOT NULL,
+    `direction` varchar(4) NOT NULL,
+    `threshold` decimal(10,2) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `idx_alerts_user_id` (`user_id`),
+    CONSTRAINT `fk_alerts_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alerts_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `alert_events` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `alert_id` int unsigned NULL,
+    `user_id` int unsigned NOT NULL,
+    `card_id` int unsigned NULL,
+    `card_name` varchar(255) NOT NULL,
+    `set_name` varchar(255) NOT NULL,
+    `kind` varchar(10) NOT NULL,
+    `direction` varchar(4) NOT NULL,
+    `threshold` decimal(10,2) NOT NULL,
+    `old_price` decimal(10,2) NOT NULL,
+    `new_price` decimal(10,2) NOT NULL,
+    `triggered_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_alert_events_user_id_triggered_at` (`user_id`, `triggered_at`),
+    CONSTRAINT `fk_alert_events_alert_id`
+        FOREIGN KEY (`alert_id`)
+        REFERENCES `alerts` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alert_events_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_alert_events_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `webhooks` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `user_id` int unsigned NOT NULL,
+    `url` varchar(2048) NOT NULL,
+    `secret` char(64) NOT NULL,
+    `events` varchar(255) NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `idx_webhooks_user_id` (`user_id`),
+    CONSTRAINT `fk_webhooks_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `webhook_deliveries` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `webhook_id` int unsigned NOT NULL,
+    `delivery_id` char(64) NOT NULL,
+    `event` varchar(50) NOT NULL,
+    `attempt` int unsigned NOT NULL,
+    `status_code` int NOT NULL DEFAULT 0,
+    `error` varchar(255) NOT NULL DEFAULT '',
+    `success` tinyint NOT NULL,
+    `created_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_webhook_deliveries_webhook_id_created_at` (`webhook_id`, `created_at`),
+    CONSTRAINT `fk_webhook_deliveries_webhook_id`
+        FOREIGN KEY (`webhook_id`)
+        REFERENCES `webhooks` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
 
+CREATE TABLE `cards_raw_prices` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `card_id` int unsigned NOT NULL,
+    `quoted_at` datetime NOT NULL,
+    `field` varchar(20) NOT NULL,
+    `currency` varchar(3) NOT NULL,
+    `price` decimal(12,4) NOT NULL,
+    `exchange_rate` decimal(10,4) NULL,
+    `used` tinyint NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_cards_raw_prices_card_id_quoted_at` (`card_id`, `quoted_at`),
+    CONSTRAINT `fk_cards_raw_prices_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `exchange_rates` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `rate_date` date NOT NULL,
+    `base_code` varchar(3) NOT NULL,
+    `currency` varchar(3) NOT NULL,
+    `rate` decimal(18,8) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `unique_exchange_rates_date_currency` (`rate_date`, `currency`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `conciliation_runs` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `started_at` datetime NOT NULL,
+    `finished_at` datetime NULL,
+    `status` varchar(20) NOT NULL,
+    `exchange_rate` decimal(10,4) NULL,
+    `exchange_rate_source` varchar(20) NOT NULL DEFAULT '',
+    `last_card_id` int unsigned NOT NULL DEFAULT 0,
+    `processed` int unsigned NOT NULL DEFAULT 0,
+    `failed` int unsigned NOT NULL DEFAULT 0,
+    `skipped` int unsigned NOT NULL DEFAULT 0,
+    `retries` int unsigned NOT NULL DEFAULT 0,
+    `error_summary` varchar(1024) NOT NULL DEFAULT '',
+    PRIMARY KEY (`id`),
+    INDEX `idx_conciliation_runs_status_started_at` (`status`, `started_at`)
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `conciliation_failures` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `run_id` int unsigned NOT NULL,
+    `card_id` int unsigned NOT NULL,
+    `reason` varchar(30) NOT NULL,
+    `error` varchar(1024) NOT NULL DEFAULT '',
+    `failed_at` datetime NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_conciliation_failures_run_id` (`run_id`),
+    CONSTRAINT `fk_conciliation_failures_run_id`
+        FOREIGN KEY (`run_id`)
+        REFERENCES `conciliation_runs` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_conciliation_failures_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
+
+CREATE TABLE `cards_quarantined_prices` (
+    `id` int unsigned NOT NULL AUTO_INCREMENT,
+    `run_id` int unsigned NOT NULL,
+    `card_id` int unsigned NOT NULL,
+    `last_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `old_price` decimal(10,2) NOT NULL DEFAULT 0,
+    `price_change` decimal(10,2) NOT NULL DEFAULT 0,
+    `exchange_rate` decimal(10,4) NOT NULL DEFAULT 0,
+    `price_source` varchar(20) NOT NULL DEFAULT 'scryfall_usd',
+    `quoted_at` datetime NOT NULL,
+    `sanity_rule` varchar(20) NOT NULL,
+    `status` varchar(10) NOT NULL DEFAULT 'pending',
+    `reviewed_at` datetime NULL,
+    `reviewed_by` int unsigned NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_cards_quarantined_prices_status` (`status`, `id`),
+    CONSTRAINT `fk_cards_quarantined_prices_run_id`
+        FOREIGN KEY (`run_id`)
+        REFERENCES `conciliation_runs` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_cards_quarantined_prices_card_id`
+        FOREIGN KEY (`card_id`)
+        REFERENCES `cards` (`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_cards_quarantined_prices_reviewed_by`
+        FOREIGN KEY (`reviewed_by`)
+        REFERENCES `users` (`id`)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+) AUTO_INCREMENT = 1 DEFAULT CHARSET = latin1;
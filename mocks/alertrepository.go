@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AlertsRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAlertsRepositoryMock() *AlertsRepositoryMock {
+	return &AlertsRepositoryMock{}
+}
+
+func (a *AlertsRepositoryMock) InsertAlert(ctx context.Context, userID int64, alert domain.Alert) (domain.Alert, error) {
+	args := a.Called(ctx, userID, alert)
+	return args.Get(0).(domain.Alert), args.Error(1)
+}
+
+func (a *AlertsRepositoryMock) GetAlerts(ctx context.Context, userID int64) ([]domain.Alert, error) {
+	args := a.Called(ctx, userID)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+func (a *AlertsRepositoryMock) DeleteAlert(ctx context.Context, userID int64, id string) error {
+	args := a.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (a *AlertsRepositoryMock) GetAlertEvents(ctx context.Context, userID int64, offset, limit int) ([]domain.AlertEvent, error) {
+	args := a.Called(ctx, userID, offset, limit)
+	return args.Get(0).([]domain.AlertEvent), args.Error(1)
+}
+
+func (a *AlertsRepositoryMock) GetAlertEventsCount(ctx context.Context, userID int64) (int64, error) {
+	args := a.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
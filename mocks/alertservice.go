@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AlertServiceMock struct {
+	mock.Mock
+}
+
+func NewAlertServiceMock() *AlertServiceMock {
+	return &AlertServiceMock{}
+}
+
+func (a *AlertServiceMock) InsertAlert(ctx context.Context, alertRequest dtos.RequestAlert) (dtos.ResponseAlert, error) {
+	args := a.Called(ctx, alertRequest)
+	return args.Get(0).(dtos.ResponseAlert), args.Error(1)
+}
+
+func (a *AlertServiceMock) GetAlerts(ctx context.Context) ([]dtos.ResponseAlert, error) {
+	args := a.Called(ctx)
+	return args.Get(0).([]dtos.ResponseAlert), args.Error(1)
+}
+
+func (a *AlertServiceMock) DeleteAlert(ctx context.Context, id string) error {
+	args := a.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (a *AlertServiceMock) GetAlertHistory(ctx context.Context, page, limit int) (dtos.ResponsePaginatedAlertEvents, error) {
+	args := a.Called(ctx, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedAlertEvents), args.Error(1)
+}
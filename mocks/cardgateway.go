@@ -15,7 +15,7 @@ func NewCardGatewayMock() *CardGatewayMock {
 	return &CardGatewayMock{}
 }
 
-func (m *CardGatewayMock) GetCardPrice(ctx context.Context, card domain.Cards) (float64, error) {
+func (m *CardGatewayMock) GetCardPrice(ctx context.Context, card domain.Cards) (domain.Price, error) {
 	args := m.Called(ctx, card)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(domain.Price), args.Error(1)
 }
@@ -15,83 +15,98 @@ func NewCardsRepositoryMock() *CardsRepositoryMock {
 	return &CardsRepositoryMock{}
 }
 
-func (c *CardsRepositoryMock) InsertCard(ctx context.Context, card domain.Cards) (domain.Cards, error) {
-	args := c.Called(ctx, card)
+func (c *CardsRepositoryMock) InsertCard(ctx context.Context, userID int64, card domain.Cards) (domain.Cards, error) {
+	args := c.Called(ctx, userID, card)
 	if args.Get(0) == nil {
 		return domain.Cards{}, args.Error(1)
 	}
 	return args.Get(0).(domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) InsertCards(ctx context.Context, cards []domain.Cards) error {
-	args := c.Called(ctx, cards)
+func (c *CardsRepositoryMock) InsertCards(ctx context.Context, userID int64, cards []domain.Cards) error {
+	args := c.Called(ctx, userID, cards)
 	return args.Error(0)
 }
 
-func (c *CardsRepositoryMock) GetCardbyID(ctx context.Context, id string) (domain.Cards, error) {
-	args := c.Called(ctx, id)
+func (c *CardsRepositoryMock) GetCardbyID(ctx context.Context, userID int64, id string) (domain.Cards, error) {
+	args := c.Called(ctx, userID, id)
 	if args.Get(0) == nil {
 		return domain.Cards{}, args.Error(1)
 	}
 	return args.Get(0).(domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCards(ctx context.Context, filters map[string]string) ([]domain.Cards, error) {
-	args := c.Called(ctx, filters)
+func (c *CardsRepositoryMock) GetCards(ctx context.Context, userID int64, filters map[string]string) ([]domain.Cards, error) {
+	args := c.Called(ctx, userID, filters)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) DeleteCard(ctx context.Context, id string) error {
-	args := c.Called(ctx, id)
+func (c *CardsRepositoryMock) DeleteCard(ctx context.Context, userID int64, id string) error {
+	args := c.Called(ctx, userID, id)
 	return args.Error(0)
 }
 
-func (c *CardsRepositoryMock) GetCardHistory(ctx context.Context, id string) ([]domain.Cards, error) {
-	args := c.Called(ctx, id)
+func (c *CardsRepositoryMock) GetCardHistory(ctx context.Context, userID int64, id string) ([]domain.Cards, error) {
+	args := c.Called(ctx, userID, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) UpdateCard(ctx context.Context, card domain.UpdateCard) (domain.Cards, error) {
-	args := c.Called(ctx, card)
+func (c *CardsRepositoryMock) UpdateCard(ctx context.Context, userID int64, card domain.UpdateCard) (domain.Cards, error) {
+	args := c.Called(ctx, userID, card)
 	if args.Get(0) == nil {
 		return domain.Cards{}, args.Error(1)
 	}
 	return args.Get(0).(domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCardsPaginated(ctx context.Context, filters map[string]string, offset, limit int) ([]domain.Cards, error) {
-	args := c.Called(ctx, filters, offset, limit)
+func (c *CardsRepositoryMock) GetCardsPaginated(ctx context.Context, userID int64, filters map[string]string, offset, limit int) ([]domain.Cards, error) {
+	args := c.Called(ctx, userID, filters, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Cards), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCardsCount(ctx context.Context, filters map[string]string) (int64, error) {
-	args := c.Called(ctx, filters)
+func (c *CardsRepositoryMock) GetCardsCount(ctx context.Context, userID int64, filters map[string]string) (int64, error) {
+	args := c.Called(ctx, userID, filters)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCardHistoryPaginated(ctx context.Context, id string, offset, limit int) ([]domain.Cards, error) {
-	args := c.Called(ctx, id, offset, limit)
+func (c *CardsRepositoryMock) GetCardPriceHistoryPaginated(ctx context.Context, userID int64, id string, resolution domain.PriceResolution, offset, limit int) ([]domain.PricePoint, error) {
+	args := c.Called(ctx, userID, id, resolution, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]domain.Cards), args.Error(1)
+	return args.Get(0).([]domain.PricePoint), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCardHistoryCount(ctx context.Context, id string) (int64, error) {
-	args := c.Called(ctx, id)
+func (c *CardsRepositoryMock) GetCardPriceHistoryCount(ctx context.Context, userID int64, id string, resolution domain.PriceResolution) (int64, error) {
+	args := c.Called(ctx, userID, id, resolution)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (c *CardsRepositoryMock) GetCollectionStats(ctx context.Context) (domain.CollectionStats, error) {
-	args := c.Called(ctx)
+func (c *CardsRepositoryMock) GetCollectionStats(ctx context.Context, userID, collectionID int64) (domain.CollectionStats, error) {
+	args := c.Called(ctx, userID, collectionID)
 	return args.Get(0).(domain.CollectionStats), args.Error(1)
 }
+
+func (c *CardsRepositoryMock) SellCard(ctx context.Context, userID int64, sale domain.Sale) (domain.Sale, error) {
+	args := c.Called(ctx, userID, sale)
+	return args.Get(0).(domain.Sale), args.Error(1)
+}
+
+func (c *CardsRepositoryMock) GetRealizedGains(ctx context.Context, userID int64, year int) ([]domain.MonthlyRealizedGain, error) {
+	args := c.Called(ctx, userID, year)
+	return args.Get(0).([]domain.MonthlyRealizedGain), args.Error(1)
+}
+
+func (c *CardsRepositoryMock) GetExchangeRates(ctx context.Context, currency string) (domain.ExchangeRateHistory, error) {
+	args := c.Called(ctx, currency)
+	return args.Get(0).(domain.ExchangeRateHistory), args.Error(1)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"mime/multipart"
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
 
 	"github.com/stretchr/testify/mock"
@@ -21,8 +22,8 @@ func (c *CardServiceMock) InsertCard(ctx context.Context, card dtos.RequestInser
 	return args.Get(0).(dtos.ResponseInsertCard), args.Error(1)
 }
 
-func (c *CardServiceMock) InsertCards(ctx context.Context, file multipart.File) (int64, int64) {
-	args := c.Called(ctx, file)
+func (c *CardServiceMock) InsertCards(ctx context.Context, file multipart.File, collectionID int64) (int64, int64) {
+	args := c.Called(ctx, file, collectionID)
 	return args.Get(0).(int64), args.Get(1).(int64)
 }
 
@@ -51,17 +52,27 @@ func (c *CardServiceMock) UpdateCard(ctx context.Context, cardRequest dtos.Reque
 	return args.Get(0).(dtos.ResponseInsertCard), args.Error(1)
 }
 
-func (c *CardServiceMock) GetCardsPaginated(ctx context.Context, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error) {
-	args := c.Called(ctx, filters, page, limit)
+func (c *CardServiceMock) GetCardsPaginated(ctx context.Context, filters map[string]string, currency string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	args := c.Called(ctx, filters, currency, page, limit)
 	return args.Get(0).(dtos.ResponsePaginatedCards), args.Error(1)
 }
 
-func (c *CardServiceMock) GetCardHistoryPaginated(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedCards, error) {
-	args := c.Called(ctx, id, page, limit)
+func (c *CardServiceMock) GetCardHistoryPaginated(ctx context.Context, id, currency string, resolution domain.PriceResolution, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	args := c.Called(ctx, id, currency, resolution, page, limit)
 	return args.Get(0).(dtos.ResponsePaginatedCards), args.Error(1)
 }
 
-func (c *CardServiceMock) GetCollectionStats(ctx context.Context) (dtos.ResponseCollectionStats, error) {
-	args := c.Called(ctx)
+func (c *CardServiceMock) GetCollectionStats(ctx context.Context, collectionID int64, currency string) (dtos.ResponseCollectionStats, error) {
+	args := c.Called(ctx, collectionID, currency)
 	return args.Get(0).(dtos.ResponseCollectionStats), args.Error(1)
 }
+
+func (c *CardServiceMock) SellCard(ctx context.Context, saleRequest dtos.RequestSellCard) (dtos.ResponseSale, error) {
+	args := c.Called(ctx, saleRequest)
+	return args.Get(0).(dtos.ResponseSale), args.Error(1)
+}
+
+func (c *CardServiceMock) GetRealizedGains(ctx context.Context, year int) (dtos.ResponseRealizedGains, error) {
+	args := c.Called(ctx, year)
+	return args.Get(0).(dtos.ResponseRealizedGains), args.Error(1)
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CollectionsRepositoryMock struct {
+	mock.Mock
+}
+
+func NewCollectionsRepositoryMock() *CollectionsRepositoryMock {
+	return &CollectionsRepositoryMock{}
+}
+
+func (c *CollectionsRepositoryMock) InsertCollection(ctx context.Context, userID int64, collection domain.Collection) (domain.Collection, error) {
+	args := c.Called(ctx, userID, collection)
+	return args.Get(0).(domain.Collection), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) GetCollections(ctx context.Context, userID int64) ([]domain.Collection, error) {
+	args := c.Called(ctx, userID)
+	return args.Get(0).([]domain.Collection), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) GetCollectionByID(ctx context.Context, userID int64, id string) (domain.Collection, error) {
+	args := c.Called(ctx, userID, id)
+	return args.Get(0).(domain.Collection), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) UpdateCollection(ctx context.Context, userID int64, collection domain.UpdateCollection) (domain.Collection, error) {
+	args := c.Called(ctx, userID, collection)
+	return args.Get(0).(domain.Collection), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) DeleteCollection(ctx context.Context, userID int64, id string) error {
+	args := c.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (c *CollectionsRepositoryMock) GetRole(ctx context.Context, userID, collectionID int64) (domain.Role, error) {
+	args := c.Called(ctx, userID, collectionID)
+	return args.Get(0).(domain.Role), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) GetCardRole(ctx context.Context, userID int64, cardID string) (domain.Role, error) {
+	args := c.Called(ctx, userID, cardID)
+	return args.Get(0).(domain.Role), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) GetMembers(ctx context.Context, collectionID int64) ([]domain.Member, error) {
+	args := c.Called(ctx, collectionID)
+	return args.Get(0).([]domain.Member), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) InsertMember(ctx context.Context, collectionID int64, email string, role domain.Role) (domain.Member, error) {
+	args := c.Called(ctx, collectionID, email, role)
+	return args.Get(0).(domain.Member), args.Error(1)
+}
+
+func (c *CollectionsRepositoryMock) DeleteMember(ctx context.Context, collectionID, memberID int64) error {
+	args := c.Called(ctx, collectionID, memberID)
+	return args.Error(0)
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CollectionServiceMock struct {
+	mock.Mock
+}
+
+func NewCollectionServiceMock() *CollectionServiceMock {
+	return &CollectionServiceMock{}
+}
+
+func (c *CollectionServiceMock) InsertCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error) {
+	args := c.Called(ctx, collectionRequest)
+	return args.Get(0).(dtos.ResponseCollection), args.Error(1)
+}
+
+func (c *CollectionServiceMock) GetCollections(ctx context.Context) ([]dtos.ResponseCollection, error) {
+	args := c.Called(ctx)
+	return args.Get(0).([]dtos.ResponseCollection), args.Error(1)
+}
+
+func (c *CollectionServiceMock) GetCollectionByID(ctx context.Context, id string) (dtos.ResponseCollection, error) {
+	args := c.Called(ctx, id)
+	return args.Get(0).(dtos.ResponseCollection), args.Error(1)
+}
+
+func (c *CollectionServiceMock) UpdateCollection(ctx context.Context, collectionRequest dtos.RequestCollection) (dtos.ResponseCollection, error) {
+	args := c.Called(ctx, collectionRequest)
+	return args.Get(0).(dtos.ResponseCollection), args.Error(1)
+}
+
+func (c *CollectionServiceMock) DeleteCollection(ctx context.Context, id string) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CollectionServiceMock) GetRole(ctx context.Context, collectionID int64) (domain.Role, error) {
+	args := c.Called(ctx, collectionID)
+	return args.Get(0).(domain.Role), args.Error(1)
+}
+
+func (c *CollectionServiceMock) GetCardRole(ctx context.Context, cardID string) (domain.Role, error) {
+	args := c.Called(ctx, cardID)
+	return args.Get(0).(domain.Role), args.Error(1)
+}
+
+func (c *CollectionServiceMock) GetMembers(ctx context.Context, collectionID string) ([]dtos.ResponseMember, error) {
+	args := c.Called(ctx, collectionID)
+	return args.Get(0).([]dtos.ResponseMember), args.Error(1)
+}
+
+func (c *CollectionServiceMock) InsertMember(ctx context.Context, memberRequest dtos.RequestMember) (dtos.ResponseMember, error) {
+	args := c.Called(ctx, memberRequest)
+	return args.Get(0).(dtos.ResponseMember), args.Error(1)
+}
+
+func (c *CollectionServiceMock) DeleteMember(ctx context.Context, collectionID, userID string) error {
+	args := c.Called(ctx, collectionID, userID)
+	return args.Error(0)
+}
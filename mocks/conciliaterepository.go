@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"mtg-report/internal/core/domain"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -20,7 +21,87 @@ func (m *ConciliateRepositoryMock) InsertCardDetails(ctx context.Context, cardsD
 	return args.Error(0)
 }
 
-func (m *ConciliateRepositoryMock) GetCardsForUpdate(ctx context.Context, offset, limit int) ([]domain.Cards, error) {
-	args := m.Called(ctx, offset, limit)
+func (m *ConciliateRepositoryMock) InsertRawPrices(ctx context.Context, rawPrices []domain.RawPrice) error {
+	args := m.Called(ctx, rawPrices)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) InsertExchangeRates(ctx context.Context, rates domain.ExchangeRates) error {
+	args := m.Called(ctx, rates)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) GetLatestExchangeRates(ctx context.Context) (domain.ExchangeRates, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.ExchangeRates), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) GetCardsForUpdate(ctx context.Context, cutoffs domain.StalenessCutoffs, afterID int64, limit int) ([]domain.Cards, error) {
+	args := m.Called(ctx, cutoffs, afterID, limit)
+	return args.Get(0).([]domain.Cards), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) CountFreshCards(ctx context.Context, cutoffs domain.StalenessCutoffs) (int64, error) {
+	args := m.Called(ctx, cutoffs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) GetPricedCards(ctx context.Context) ([]domain.Cards, error) {
+	args := m.Called(ctx)
 	return args.Get(0).([]domain.Cards), args.Error(1)
 }
+
+func (m *ConciliateRepositoryMock) GetWishlistForUpdate(ctx context.Context, afterID int64, limit int) ([]domain.WishlistItem, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).([]domain.WishlistItem), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) UpdateWishlistPrices(ctx context.Context, items []domain.WishlistItem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) GetAlertCandidates(ctx context.Context, since time.Time) ([]domain.AlertEvent, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]domain.AlertEvent), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) InsertAlertEvents(ctx context.Context, events []domain.AlertEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) GetPriceChanges(ctx context.Context, since time.Time) ([]domain.PriceChange, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]domain.PriceChange), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) InsertConciliationRun(ctx context.Context, run domain.ConciliationRun) (int64, error) {
+	args := m.Called(ctx, run)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) UpdateConciliationRun(ctx context.Context, run domain.ConciliationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) GetUnfinishedConciliationRun(ctx context.Context) (domain.ConciliationRun, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.ConciliationRun), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) InsertConciliationFailures(ctx context.Context, failures []domain.ConciliationFailure) error {
+	args := m.Called(ctx, failures)
+	return args.Error(0)
+}
+
+func (m *ConciliateRepositoryMock) GetConciliationFailureReasons(ctx context.Context, runID int64) (map[domain.ConciliationFailureReason]int64, error) {
+	args := m.Called(ctx, runID)
+	return args.Get(0).(map[domain.ConciliationFailureReason]int64), args.Error(1)
+}
+
+func (m *ConciliateRepositoryMock) InsertQuarantinedPrice(ctx context.Context, price domain.QuarantinedPrice) error {
+	args := m.Called(ctx, price)
+	return args.Error(0)
+}
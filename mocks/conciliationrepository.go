@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ConciliationsRepositoryMock struct {
+	mock.Mock
+}
+
+func NewConciliationsRepositoryMock() *ConciliationsRepositoryMock {
+	return &ConciliationsRepositoryMock{}
+}
+
+func (m *ConciliationsRepositoryMock) GetConciliationRuns(ctx context.Context, offset, limit int) ([]domain.ConciliationRun, error) {
+	args := m.Called(ctx, offset, limit)
+	return args.Get(0).([]domain.ConciliationRun), args.Error(1)
+}
+
+func (m *ConciliationsRepositoryMock) GetConciliationRunsCount(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ConciliationsRepositoryMock) GetConciliationRunByID(ctx context.Context, id string) (domain.ConciliationRun, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ConciliationRun), args.Error(1)
+}
+
+func (m *ConciliationsRepositoryMock) GetConciliationFailures(ctx context.Context, userID, runID int64, offset, limit int) ([]domain.ConciliationFailure, error) {
+	args := m.Called(ctx, userID, runID, offset, limit)
+	return args.Get(0).([]domain.ConciliationFailure), args.Error(1)
+}
+
+func (m *ConciliationsRepositoryMock) GetConciliationFailuresCount(ctx context.Context, userID, runID int64) (int64, error) {
+	args := m.Called(ctx, userID, runID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ConciliationServiceMock struct {
+	mock.Mock
+}
+
+func NewConciliationServiceMock() *ConciliationServiceMock {
+	return &ConciliationServiceMock{}
+}
+
+func (m *ConciliationServiceMock) GetConciliations(ctx context.Context, page, limit int) (dtos.ResponsePaginatedConciliations, error) {
+	args := m.Called(ctx, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedConciliations), args.Error(1)
+}
+
+func (m *ConciliationServiceMock) GetConciliationFailures(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedConciliationFailures, error) {
+	args := m.Called(ctx, id, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedConciliationFailures), args.Error(1)
+}
@@ -12,7 +12,12 @@ func NewEmailMock() *EmailMock {
 	return &EmailMock{}
 }
 
-func (m *EmailMock) SendEmail(cardsTable, cardsPriceFormatted string) error {
-	args := m.Called(cardsTable, cardsPriceFormatted)
+func (m *EmailMock) SendEmail(to, cardsTable, cardsPriceFormatted, wishlistTable string) error {
+	args := m.Called(to, cardsTable, cardsPriceFormatted, wishlistTable)
+	return args.Error(0)
+}
+
+func (m *EmailMock) SendAlerts(to, alertsTable string) error {
+	args := m.Called(to, alertsTable)
 	return args.Error(0)
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"mtg-report/internal/core/domain"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -14,7 +15,7 @@ func NewExchangeGatewayMock() *ExchangeGatewayMock {
 	return &ExchangeGatewayMock{}
 }
 
-func (m *ExchangeGatewayMock) GetUSD(ctx context.Context) (float64, error) {
+func (m *ExchangeGatewayMock) GetRates(ctx context.Context) (domain.ExchangeRates, error) {
 	args := m.Called(ctx)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(domain.ExchangeRates), args.Error(1)
 }
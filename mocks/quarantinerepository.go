@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuarantineRepositoryMock struct {
+	mock.Mock
+}
+
+func NewQuarantineRepositoryMock() *QuarantineRepositoryMock {
+	return &QuarantineRepositoryMock{}
+}
+
+func (m *QuarantineRepositoryMock) GetQuarantinedPrices(ctx context.Context, userID int64, status domain.QuarantineStatus, offset, limit int) ([]domain.QuarantinedPrice, error) {
+	args := m.Called(ctx, userID, status, offset, limit)
+	return args.Get(0).([]domain.QuarantinedPrice), args.Error(1)
+}
+
+func (m *QuarantineRepositoryMock) GetQuarantinedPricesCount(ctx context.Context, userID int64, status domain.QuarantineStatus) (int64, error) {
+	args := m.Called(ctx, userID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *QuarantineRepositoryMock) GetQuarantinedPriceByID(ctx context.Context, userID int64, id string) (domain.QuarantinedPrice, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(domain.QuarantinedPrice), args.Error(1)
+}
+
+func (m *QuarantineRepositoryMock) AcceptQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error {
+	args := m.Called(ctx, userID, price, reviewedAt)
+	return args.Error(0)
+}
+
+func (m *QuarantineRepositoryMock) RejectQuarantinedPrice(ctx context.Context, userID int64, price domain.QuarantinedPrice, reviewedAt time.Time) error {
+	args := m.Called(ctx, userID, price, reviewedAt)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuarantineServiceMock struct {
+	mock.Mock
+}
+
+func NewQuarantineServiceMock() *QuarantineServiceMock {
+	return &QuarantineServiceMock{}
+}
+
+func (m *QuarantineServiceMock) GetQuarantinedPrices(ctx context.Context, status domain.QuarantineStatus, page, limit int) (dtos.ResponsePaginatedQuarantinedPrices, error) {
+	args := m.Called(ctx, status, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedQuarantinedPrices), args.Error(1)
+}
+
+func (m *QuarantineServiceMock) AcceptQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dtos.ResponseQuarantinedPrice), args.Error(1)
+}
+
+func (m *QuarantineServiceMock) RejectQuarantinedPrice(ctx context.Context, id string) (dtos.ResponseQuarantinedPrice, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dtos.ResponseQuarantinedPrice), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LimiterMock struct {
+	mock.Mock
+}
+
+func NewLimiterMock() *LimiterMock {
+	return &LimiterMock{}
+}
+
+func (m *LimiterMock) Wait(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
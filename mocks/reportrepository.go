@@ -15,17 +15,32 @@ func NewReportRepositoryMock() *ReportRepositoryMock {
 	return &ReportRepositoryMock{}
 }
 
-func (m *ReportRepositoryMock) InsertTotalPrice(ctx context.Context) error {
-	args := m.Called(ctx)
+func (m *ReportRepositoryMock) GetReportUsers(ctx context.Context, collectionID int64) ([]domain.User, error) {
+	args := m.Called(ctx, collectionID)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) InsertTotalPrice(ctx context.Context, userID, collectionID int64) error {
+	args := m.Called(ctx, userID, collectionID)
 	return args.Error(0)
 }
 
-func (m *ReportRepositoryMock) GetCardsReport(ctx context.Context) ([]domain.Cards, error) {
-	args := m.Called(ctx)
+func (m *ReportRepositoryMock) GetCardsReport(ctx context.Context, userID, collectionID int64) ([]domain.Cards, error) {
+	args := m.Called(ctx, userID, collectionID)
 	return args.Get(0).([]domain.Cards), args.Error(1)
 }
 
-func (m *ReportRepositoryMock) GetTotalPrice(ctx context.Context) (domain.CardsPrice, error) {
-	args := m.Called(ctx)
+func (m *ReportRepositoryMock) GetTotalPrice(ctx context.Context, userID, collectionID int64) (domain.CardsPrice, error) {
+	args := m.Called(ctx, userID, collectionID)
 	return args.Get(0).(domain.CardsPrice), args.Error(1)
 }
+
+func (m *ReportRepositoryMock) GetUnrealizedGain(ctx context.Context, userID, collectionID int64) (domain.UnrealizedGain, error) {
+	args := m.Called(ctx, userID, collectionID)
+	return args.Get(0).(domain.UnrealizedGain), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) GetAffordableWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.WishlistItem), args.Error(1)
+}
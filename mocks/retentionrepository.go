@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RetentionRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRetentionRepositoryMock() *RetentionRepositoryMock {
+	return &RetentionRepositoryMock{}
+}
+
+func (m *RetentionRepositoryMock) RollUpDailyPrices(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RetentionRepositoryMock) RollUpWeeklyPrices(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RetentionServiceMock struct {
+	mock.Mock
+}
+
+func NewRetentionServiceMock() *RetentionServiceMock {
+	return &RetentionServiceMock{}
+}
+
+func (m *RetentionServiceMock) RollUp(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import "github.com/stretchr/testify/mock"
+
+type RetryCounterMock struct {
+	mock.Mock
+}
+
+func NewRetryCounterMock() *RetryCounterMock {
+	return &RetryCounterMock{}
+}
+
+func (m *RetryCounterMock) Retries() int64 {
+	args := m.Called()
+	return args.Get(0).(int64)
+}
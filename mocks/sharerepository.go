@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SharesRepositoryMock struct {
+	mock.Mock
+}
+
+func NewSharesRepositoryMock() *SharesRepositoryMock {
+	return &SharesRepositoryMock{}
+}
+
+func (s *SharesRepositoryMock) InsertShare(ctx context.Context, share domain.Share, tokenHash string) (domain.Share, error) {
+	args := s.Called(ctx, share, tokenHash)
+	return args.Get(0).(domain.Share), args.Error(1)
+}
+
+func (s *SharesRepositoryMock) GetShares(ctx context.Context, collectionID int64) ([]domain.Share, error) {
+	args := s.Called(ctx, collectionID)
+	return args.Get(0).([]domain.Share), args.Error(1)
+}
+
+func (s *SharesRepositoryMock) DeleteShare(ctx context.Context, collectionID, shareID int64) error {
+	args := s.Called(ctx, collectionID, shareID)
+	return args.Error(0)
+}
+
+func (s *SharesRepositoryMock) GetShareByToken(ctx context.Context, tokenHash string) (domain.Share, error) {
+	args := s.Called(ctx, tokenHash)
+	return args.Get(0).(domain.Share), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ShareServiceMock struct {
+	mock.Mock
+}
+
+func NewShareServiceMock() *ShareServiceMock {
+	return &ShareServiceMock{}
+}
+
+func (s *ShareServiceMock) InsertShare(ctx context.Context, shareRequest dtos.RequestShare) (dtos.ResponseShare, error) {
+	args := s.Called(ctx, shareRequest)
+	return args.Get(0).(dtos.ResponseShare), args.Error(1)
+}
+
+func (s *ShareServiceMock) GetShares(ctx context.Context, collectionID string) ([]dtos.ResponseShare, error) {
+	args := s.Called(ctx, collectionID)
+	return args.Get(0).([]dtos.ResponseShare), args.Error(1)
+}
+
+func (s *ShareServiceMock) DeleteShare(ctx context.Context, collectionID, shareID string) error {
+	args := s.Called(ctx, collectionID, shareID)
+	return args.Error(0)
+}
+
+func (s *ShareServiceMock) GetSharedCards(ctx context.Context, token string, filters map[string]string, page, limit int) (dtos.ResponsePaginatedCards, error) {
+	args := s.Called(ctx, token, filters, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedCards), args.Error(1)
+}
+
+func (s *ShareServiceMock) GetSharedCollection(ctx context.Context, token string, page, limit int) (dtos.ResponseSharedCollection, error) {
+	args := s.Called(ctx, token, page, limit)
+	return args.Get(0).(dtos.ResponseSharedCollection), args.Error(1)
+}
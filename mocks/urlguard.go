@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type URLGuardMock struct {
+	mock.Mock
+}
+
+func NewURLGuardMock() *URLGuardMock {
+	return &URLGuardMock{}
+}
+
+func (m *URLGuardMock) CheckURL(ctx context.Context, url string) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UsersRepositoryMock struct {
+	mock.Mock
+}
+
+func NewUsersRepositoryMock() *UsersRepositoryMock {
+	return &UsersRepositoryMock{}
+}
+
+func (u *UsersRepositoryMock) InsertUser(ctx context.Context, user domain.User, apiKeyHash string) (domain.User, error) {
+	args := u.Called(ctx, user, apiKeyHash)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (u *UsersRepositoryMock) GetUserByAPIKey(ctx context.Context, apiKeyHash string) (domain.User, error) {
+	args := u.Called(ctx, apiKeyHash)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (u *UsersRepositoryMock) UpdateAPIKey(ctx context.Context, userID int64, apiKeyHash string) error {
+	args := u.Called(ctx, userID, apiKeyHash)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UserServiceMock struct {
+	mock.Mock
+}
+
+func NewUserServiceMock() *UserServiceMock {
+	return &UserServiceMock{}
+}
+
+func (u *UserServiceMock) InsertUser(ctx context.Context, userRequest dtos.RequestUser) (dtos.ResponseUser, error) {
+	args := u.Called(ctx, userRequest)
+	return args.Get(0).(dtos.ResponseUser), args.Error(1)
+}
+
+func (u *UserServiceMock) Authenticate(ctx context.Context, apiKey string) (domain.User, error) {
+	args := u.Called(ctx, apiKey)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (u *UserServiceMock) RotateAPIKey(ctx context.Context) (dtos.ResponseUser, error) {
+	args := u.Called(ctx)
+	return args.Get(0).(dtos.ResponseUser), args.Error(1)
+}
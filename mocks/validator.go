@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"mtg-report/internal/core/domain"
 	"mtg-report/internal/core/dtos"
 
 	"github.com/stretchr/testify/mock"
@@ -24,12 +25,12 @@ func (v *ValidateMock) CardID(parts []string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
-func (v *ValidateMock) Filters(setName, name, collector_number string) map[string]string {
-	args := v.Called(setName, name, collector_number)
+func (v *ValidateMock) Filters(setName, name, collector_number, collection string) map[string]string {
+	args := v.Called(setName, name, collector_number, collection)
 	return args.Get(0).(map[string]string)
 }
 
-func (v *ValidateMock) CardName(card dtos.RequestUpdateCard) error {
+func (v *ValidateMock) UpdateCard(card dtos.RequestUpdateCard) error {
 	args := v.Called(card)
 	return args.Error(0)
 }
@@ -38,3 +39,88 @@ func (v *ValidateMock) Pagination(pageStr, limitStr string) (int, int, error) {
 	args := v.Called(pageStr, limitStr)
 	return args.Int(0), args.Int(1), args.Error(2)
 }
+
+func (v *ValidateMock) Sale(sale dtos.RequestSellCard) error {
+	args := v.Called(sale)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) Year(yearStr string) (int, error) {
+	args := v.Called(yearStr)
+	return args.Int(0), args.Error(1)
+}
+
+func (v *ValidateMock) Currency(currency string) (string, error) {
+	args := v.Called(currency)
+	return args.String(0), args.Error(1)
+}
+
+func (v *ValidateMock) Resolution(resolution string) (domain.PriceResolution, error) {
+	args := v.Called(resolution)
+	return args.Get(0).(domain.PriceResolution), args.Error(1)
+}
+
+func (v *ValidateMock) QuarantineStatus(status string) (domain.QuarantineStatus, error) {
+	args := v.Called(status)
+	return args.Get(0).(domain.QuarantineStatus), args.Error(1)
+}
+
+func (v *ValidateMock) CollectionID(collection string) (int64, error) {
+	args := v.Called(collection)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (v *ValidateMock) Collection(collection dtos.RequestCollection) error {
+	args := v.Called(collection)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) UpdateCollection(collection dtos.RequestCollection) error {
+	args := v.Called(collection)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) User(user dtos.RequestUser) error {
+	args := v.Called(user)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) Member(member dtos.RequestMember) error {
+	args := v.Called(member)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) MemberID(parts []string) (string, string, error) {
+	args := v.Called(parts)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (v *ValidateMock) ShareID(parts []string) (string, string, error) {
+	args := v.Called(parts)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (v *ValidateMock) ShareToken(parts []string) (string, error) {
+	args := v.Called(parts)
+	return args.String(0), args.Error(1)
+}
+
+func (v *ValidateMock) WishlistItem(item dtos.RequestWishlistItem) error {
+	args := v.Called(item)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) UpdateWishlistItem(item dtos.RequestWishlistItem) error {
+	args := v.Called(item)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) Alert(alert dtos.RequestAlert) error {
+	args := v.Called(alert)
+	return args.Error(0)
+}
+
+func (v *ValidateMock) Webhook(webhook dtos.RequestWebhook) error {
+	args := v.Called(webhook)
+	return args.Error(0)
+}
@@ -24,6 +24,14 @@ func (h *httpMock) NewRequestWithContext(ctx context.Context, method string, url
 	return argsMock.Get(0).(web.Request), argsMock.Error(1)
 }
 
+func (h *httpMock) NewRequestWithHeaders(ctx context.Context, method string, url string, body io.Reader, headers map[string]string) (web.Request, error) {
+	argsMock := h.Called(ctx, method, url, body, headers)
+	if argsMock.Get(0) == nil {
+		return nil, argsMock.Error(1)
+	}
+	return argsMock.Get(0).(web.Request), argsMock.Error(1)
+}
+
 func (h *httpMock) Do(req web.Request) (web.Response, error) {
 	argsMock := h.Called(req)
 	if argsMock.Get(0) == nil {
@@ -50,6 +58,11 @@ func (r *responseMock) StatusCode() int {
 	return argsMock.Get(0).(int)
 }
 
+func (r *responseMock) Header(key string) string {
+	argsMock := r.Called(key)
+	return argsMock.String(0)
+}
+
 type requestMock struct {
 	mock.Mock
 }
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookDispatcherMock struct {
+	mock.Mock
+}
+
+func NewWebhookDispatcherMock() *WebhookDispatcherMock {
+	return &WebhookDispatcherMock{}
+}
+
+func (w *WebhookDispatcherMock) Notify(ctx context.Context, userID int64, event domain.WebhookEvent, data interface{}) error {
+	args := w.Called(ctx, userID, event, data)
+	return args.Error(0)
+}
+
+func (w *WebhookDispatcherMock) Broadcast(ctx context.Context, event domain.WebhookEvent, data interface{}) error {
+	args := w.Called(ctx, event, data)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookGatewayMock struct {
+	mock.Mock
+}
+
+func NewWebhookGatewayMock() *WebhookGatewayMock {
+	return &WebhookGatewayMock{}
+}
+
+func (w *WebhookGatewayMock) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	args := w.Called(ctx, url, headers, body)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhooksRepositoryMock struct {
+	mock.Mock
+}
+
+func NewWebhooksRepositoryMock() *WebhooksRepositoryMock {
+	return &WebhooksRepositoryMock{}
+}
+
+func (w *WebhooksRepositoryMock) InsertWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	args := w.Called(ctx, webhook)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (w *WebhooksRepositoryMock) GetWebhooks(ctx context.Context, userID int64) ([]domain.Webhook, error) {
+	args := w.Called(ctx, userID)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (w *WebhooksRepositoryMock) GetWebhookByID(ctx context.Context, userID int64, id string) (domain.Webhook, error) {
+	args := w.Called(ctx, userID, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (w *WebhooksRepositoryMock) DeleteWebhook(ctx context.Context, userID int64, id string) error {
+	args := w.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (w *WebhooksRepositoryMock) GetSubscribedWebhooks(ctx context.Context, event domain.WebhookEvent, userID int64) ([]domain.Webhook, error) {
+	args := w.Called(ctx, event, userID)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (w *WebhooksRepositoryMock) InsertWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	args := w.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (w *WebhooksRepositoryMock) GetWebhookDeliveries(ctx context.Context, webhookID int64, offset, limit int) ([]domain.WebhookDelivery, error) {
+	args := w.Called(ctx, webhookID, offset, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (w *WebhooksRepositoryMock) GetWebhookDeliveriesCount(ctx context.Context, webhookID int64) (int64, error) {
+	args := w.Called(ctx, webhookID)
+	return args.Get(0).(int64), args.Error(1)
+}
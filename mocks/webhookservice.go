@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookServiceMock struct {
+	mock.Mock
+}
+
+func NewWebhookServiceMock() *WebhookServiceMock {
+	return &WebhookServiceMock{}
+}
+
+func (w *WebhookServiceMock) InsertWebhook(ctx context.Context, webhookRequest dtos.RequestWebhook) (dtos.ResponseWebhook, error) {
+	args := w.Called(ctx, webhookRequest)
+	return args.Get(0).(dtos.ResponseWebhook), args.Error(1)
+}
+
+func (w *WebhookServiceMock) GetWebhooks(ctx context.Context) ([]dtos.ResponseWebhook, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]dtos.ResponseWebhook), args.Error(1)
+}
+
+func (w *WebhookServiceMock) DeleteWebhook(ctx context.Context, id string) error {
+	args := w.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (w *WebhookServiceMock) GetWebhookDeliveries(ctx context.Context, id string, page, limit int) (dtos.ResponsePaginatedWebhookDeliveries, error) {
+	args := w.Called(ctx, id, page, limit)
+	return args.Get(0).(dtos.ResponsePaginatedWebhookDeliveries), args.Error(1)
+}
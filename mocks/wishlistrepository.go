@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WishlistRepositoryMock struct {
+	mock.Mock
+}
+
+func NewWishlistRepositoryMock() *WishlistRepositoryMock {
+	return &WishlistRepositoryMock{}
+}
+
+func (w *WishlistRepositoryMock) InsertWishlistItem(ctx context.Context, userID int64, item domain.WishlistItem) (domain.WishlistItem, error) {
+	args := w.Called(ctx, userID, item)
+	return args.Get(0).(domain.WishlistItem), args.Error(1)
+}
+
+func (w *WishlistRepositoryMock) GetWishlist(ctx context.Context, userID int64) ([]domain.WishlistItem, error) {
+	args := w.Called(ctx, userID)
+	return args.Get(0).([]domain.WishlistItem), args.Error(1)
+}
+
+func (w *WishlistRepositoryMock) UpdateWishlistItem(ctx context.Context, userID, id int64, maxPrice float64) (domain.WishlistItem, error) {
+	args := w.Called(ctx, userID, id, maxPrice)
+	return args.Get(0).(domain.WishlistItem), args.Error(1)
+}
+
+func (w *WishlistRepositoryMock) DeleteWishlistItem(ctx context.Context, userID int64, id string) error {
+	args := w.Called(ctx, userID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"mtg-report/internal/core/dtos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WishlistServiceMock struct {
+	mock.Mock
+}
+
+func NewWishlistServiceMock() *WishlistServiceMock {
+	return &WishlistServiceMock{}
+}
+
+func (w *WishlistServiceMock) InsertWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error) {
+	args := w.Called(ctx, itemRequest)
+	return args.Get(0).(dtos.ResponseWishlistItem), args.Error(1)
+}
+
+func (w *WishlistServiceMock) GetWishlist(ctx context.Context) ([]dtos.ResponseWishlistItem, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]dtos.ResponseWishlistItem), args.Error(1)
+}
+
+func (w *WishlistServiceMock) UpdateWishlistItem(ctx context.Context, itemRequest dtos.RequestWishlistItem) (dtos.ResponseWishlistItem, error) {
+	args := w.Called(ctx, itemRequest)
+	return args.Get(0).(dtos.ResponseWishlistItem), args.Error(1)
+}
+
+func (w *WishlistServiceMock) DeleteWishlistItem(ctx context.Context, id string) error {
+	args := w.Called(ctx, id)
+	return args.Error(0)
+}
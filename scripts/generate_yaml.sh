@@ -22,10 +22,58 @@ conciliatejob:
     database: "MTGREPORTS"
     commitSize: 1000
   timeout: "1h"
+  workers: 4
+  staleness:
+    maxAge: "20h"
+    highValue:
+      price: 100
+      maxAge: "0s"
+    bulk:
+      price: 1
+      maxAge: "168h"
+  sanity:
+    maxChange: 4
+    ceiling: 0
+    dropToZero: true
+  rateLimit:
+    requestsPerSecond: 10
+    burst: 1
+  retry:
+    maxAttempts: 4
+    baseDelay: "500ms"
+    maxDelay: "30s"
   log:
     level: "debug"
   exchange:
     url: "https://v6.exchangerate-api.com/v6/your_key/latest/USD"
+    secondaryUrl: ""
+    maxRateAge: "72h"
+  conditions:
+    nm: 1.0
+    lp: 0.9
+    mp: 0.75
+    hp: 0.6
+    dmg: 0.4
+  prices:
+    source: "scryfall_usd"
+    collections: {}
+    cards: {}
+    list:
+      path: ""
+      currency: "USD"
+    mode: "api"
+    bulk:
+      url: "https://api.scryfall.com/bulk-data/default-cards"
+      path: ""
+      timeout: "10m"
+  email:
+    host: "smtp.your_host.com"
+    username: "your_user@email.com"
+    password: "your_password"
+    port: "587"
+  webhook:
+    maxAttempts: 3
+    backoff: "1s"
 
 reportjob:
   db:
@@ -35,14 +83,31 @@ reportjob:
     port: "3306"
     database: "MTGREPORTS"
   timeout: "1h"
+  collection: 0
   log:
     level: "debug"
   email:
     host: "smtp.your_host.com"
     username: "your_user@email.com"
     password: "your_password"
-    to: "your_destination@email.com"
     port: "587"
+  webhook:
+    maxAttempts: 3
+    backoff: "1s"
+
+retentionjob:
+  db:
+    user: "root"
+    password: "root"
+    host: "localhost or db (if it's running in a docker container)"
+    port: "3306"
+    database: "MTGREPORTS"
+  timeout: "1h"
+  log:
+    level: "debug"
+  retention:
+    dailyWindow: "2160h"
+    weeklyWindow: "8760h"
 EOL
 
 echo "config.yaml generated successfully."